@@ -3,15 +3,19 @@ package main
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	uberzap "go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/provisioner"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/config"
+	ngxruntime "github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/runtime"
 )
 
 const (
@@ -22,6 +26,8 @@ const (
 	gatewayCtrlNameFlag     = "gateway-ctlr-name"
 	gatewayCtrlNameUsageFmt = `The name of the Gateway controller. ` +
 		`The controller name must be of the form: DOMAIN/PATH. The controller's domain is '%s'`
+	logFormatFlag     = "log-format"
+	logFormatUsageFmt = `The format of the control plane logs. Supported values: %s.`
 )
 
 var (
@@ -34,6 +40,11 @@ var (
 	gatewayClassName = stringValidatingValue{
 		validator: validateResourceName,
 	}
+
+	logFormat = stringValidatingValue{
+		validator: validateLogFormat,
+		value:     logFormatJSON,
+	}
 )
 
 // stringValidatingValue is a string flag value with custom validation logic.
@@ -59,6 +70,93 @@ func (v *stringValidatingValue) Type() string {
 	return "string"
 }
 
+// stringSliceValidatingValue is a string slice flag value with custom validation logic for each element.
+// The elements are comma-separated, and the flag can be specified multiple times.
+// it implements the pflag.Value interface.
+type stringSliceValidatingValue struct {
+	validator func(v string) error
+	values    []string
+}
+
+func (v *stringSliceValidatingValue) String() string {
+	return strings.Join(v.values, ",")
+}
+
+func (v *stringSliceValidatingValue) Set(param string) error {
+	values := strings.Split(param, ",")
+
+	for _, value := range values {
+		if err := v.validator(value); err != nil {
+			return err
+		}
+	}
+
+	v.values = append(v.values, values...)
+	return nil
+}
+
+func (v *stringSliceValidatingValue) Type() string {
+	return "strings"
+}
+
+// intValidatingValue is an int flag value with custom validation logic.
+// it implements the pflag.Value interface.
+type intValidatingValue struct {
+	validator func(v int) error
+	value     int
+}
+
+func (v *intValidatingValue) String() string {
+	return strconv.Itoa(v.value)
+}
+
+func (v *intValidatingValue) Set(param string) error {
+	value, err := strconv.ParseInt(param, 10, 32)
+	if err != nil {
+		return fmt.Errorf("failed to parse int value: %w", err)
+	}
+
+	if err := v.validator(int(value)); err != nil {
+		return err
+	}
+
+	v.value = int(value)
+	return nil
+}
+
+func (v *intValidatingValue) Type() string {
+	return "int"
+}
+
+// durationValidatingValue is a duration flag value with custom validation logic.
+// it implements the pflag.Value interface.
+type durationValidatingValue struct {
+	validator func(v time.Duration) error
+	value     time.Duration
+}
+
+func (v *durationValidatingValue) String() string {
+	return v.value.String()
+}
+
+func (v *durationValidatingValue) Set(param string) error {
+	value, err := time.ParseDuration(param)
+	if err != nil {
+		return fmt.Errorf("failed to parse duration value: %w", err)
+	}
+
+	if err := v.validator(value); err != nil {
+		return err
+	}
+
+	v.value = value
+	return nil
+}
+
+func (v *durationValidatingValue) Type() string {
+	return "duration"
+}
+
 // namespacedNameValue is a string flag value that represents a namespaced name.
 // it implements the pflag.Value interface.
 type namespacedNameValue struct {
@@ -109,21 +207,79 @@ func createRootCommand() *cobra.Command {
 	)
 	utilruntime.Must(rootCmd.MarkPersistentFlagRequired(gatewayClassFlag))
 
+	rootCmd.PersistentFlags().Var(
+		&logFormat,
+		logFormatFlag,
+		fmt.Sprintf(logFormatUsageFmt, strings.Join(logFormats, ", ")),
+	)
+
 	return rootCmd
 }
 
 func createStaticModeCommand() *cobra.Command {
-	const gatewayFlag = "gateway"
+	const (
+		gatewayFlag                = "gateway"
+		enablePprofFlag            = "enable-pprof"
+		healthProbeBindAddressFlag = "health-probe-bind-address"
+		adminSecretFlag            = "admin-secret"
+		metricsBindAddressFlag     = "metrics-bind-address"
+		nginxConfigMapFlag         = "nginx-config-map"
+		enableSnippetsFlag         = "enable-snippets"
+	)
 
 	// flag values
 	gateway := namespacedNameValue{}
 	var updateGCStatus bool
+	var enablePprof bool
+	var enableSnippets bool
+	var statusDryRun bool
+	pprofPort := intValidatingValue{
+		validator: validatePort,
+		value:     6060,
+	}
+	healthProbeBindAddress := stringValidatingValue{
+		validator: validateBindAddress,
+	}
+	adminSecret := namespacedNameValue{}
+	nginxConfigMap := namespacedNameValue{}
+	adminBindAddress := stringValidatingValue{
+		validator: validateBindAddress,
+		value:     ":8082",
+	}
+	metricsBindAddress := stringValidatingValue{
+		validator: validateBindAddress,
+	}
+	nginxStatusPort := intValidatingValue{
+		validator: validatePort,
+		value:     8765,
+	}
+	nginxStatusScrapeInterval := durationValidatingValue{
+		validator: validatePositiveDuration,
+		value:     10 * time.Second,
+	}
+	reconcileRateLimitQPS := intValidatingValue{
+		validator: validatePositiveInt,
+		value:     10,
+	}
+	reconcileRateLimitBurst := intValidatingValue{
+		validator: validatePositiveInt,
+		value:     100,
+	}
+	maxConcurrentReconciles := intValidatingValue{
+		validator: validatePositiveInt,
+		value:     1,
+	}
+	nginxReloadTimeout := durationValidatingValue{
+		validator: validatePositiveDuration,
+		value:     5 * time.Second,
+	}
 
 	cmd := &cobra.Command{
 		Use:   "static-mode",
 		Short: "Configure NGINX in the scope of a single Gateway resource",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			logger := zap.New()
+			logLevel := uberzap.NewAtomicLevel()
+			logger := createLogger(logFormat.value, logLevel, os.Stderr)
 			logger.Info("Starting NGINX Kubernetes Gateway in static mode",
 				"version", version,
 				"commit", commit,
@@ -140,13 +296,40 @@ func createStaticModeCommand() *cobra.Command {
 				gwNsName = &gateway.value
 			}
 
+			var adminSecretNsName *types.NamespacedName
+			if cmd.Flags().Changed(adminSecretFlag) {
+				adminSecretNsName = &adminSecret.value
+			}
+
+			var nginxConfigMapNsName *types.NamespacedName
+			if cmd.Flags().Changed(nginxConfigMapFlag) {
+				nginxConfigMapNsName = &nginxConfigMap.value
+			}
+
 			conf := config.Config{
-				GatewayCtlrName:          gatewayCtlrName.value,
-				Logger:                   logger,
-				GatewayClassName:         gatewayClassName.value,
-				PodIP:                    podIP,
-				GatewayNsName:            gwNsName,
-				UpdateGatewayClassStatus: updateGCStatus,
+				GatewayCtlrName:           gatewayCtlrName.value,
+				Logger:                    logger,
+				GatewayClassName:          gatewayClassName.value,
+				PodIP:                     podIP,
+				NodeName:                  os.Getenv("NODE_NAME"),
+				GatewayNsName:             gwNsName,
+				UpdateGatewayClassStatus:  updateGCStatus,
+				PprofEnabled:              enablePprof,
+				PprofPort:                 pprofPort.value,
+				HealthProbeBindAddress:    healthProbeBindAddress.value,
+				LogLevel:                  logLevel,
+				NginxConfigMapNsName:      nginxConfigMapNsName,
+				AdminSecretNsName:         adminSecretNsName,
+				AdminBindAddress:          adminBindAddress.value,
+				MetricsBindAddress:        metricsBindAddress.value,
+				NginxStatusPort:           nginxStatusPort.value,
+				NginxStatusScrapeInterval: nginxStatusScrapeInterval.value,
+				ReconcileRateLimitQPS:     reconcileRateLimitQPS.value,
+				ReconcileRateLimitBurst:   reconcileRateLimitBurst.value,
+				MaxConcurrentReconciles:   maxConcurrentReconciles.value,
+				NginxReloadTimeout:        nginxReloadTimeout.value,
+				EnableSnippets:            enableSnippets,
+				StatusDryRun:              statusDryRun,
 			}
 
 			if err := static.StartManager(conf); err != nil {
@@ -174,27 +357,198 @@ func createStaticModeCommand() *cobra.Command {
 		"Update the status of the GatewayClass resource.",
 	)
 
+	cmd.Flags().BoolVar(
+		&enablePprof,
+		enablePprofFlag,
+		false,
+		"Enable the pprof endpoint, which serves runtime profiling data on 127.0.0.1 at /debug/pprof/. "+
+			"The endpoint must not be exposed outside the cluster. Use kubectl port-forward to access it.",
+	)
+
+	cmd.Flags().Var(
+		&pprofPort,
+		"pprof-port",
+		fmt.Sprintf("The port of the pprof endpoint. Ignored if --%s is false.", enablePprofFlag),
+	)
+
+	cmd.Flags().Var(
+		&healthProbeBindAddress,
+		healthProbeBindAddressFlag,
+		"The address the health probe endpoints (/healthz and /readyz) bind to. Must be of the form: [HOST]:PORT. "+
+			"If not specified, the health probe endpoints are disabled. "+
+			"The readiness probe succeeds once NGINX is configured for the first time.",
+	)
+
+	cmd.Flags().Var(
+		&nginxConfigMap,
+		nginxConfigMapFlag,
+		"The namespaced name of the ConfigMap with the NGINX configuration snippets. Must be of the form: "+
+			"NAMESPACE/NAME. The value of the 'http-snippet' key is added to the NGINX http context. "+
+			"A change to the ConfigMap reloads NGINX. If not specified, no snippets are added.",
+	)
+
+	cmd.Flags().BoolVar(
+		&enableSnippets,
+		enableSnippetsFlag,
+		false,
+		"Allow the gateway.nginx.org/server-snippet annotation of the Gateway resources and the "+
+			"gateway.nginx.org/location-snippet annotation of the HTTPRoute resources, which add NGINX directives "+
+			"verbatim to the NGINX configuration. The directives can read any file that NGINX can read, including "+
+			"the TLS private keys of all Gateways, so only enable the snippets if all users who can annotate "+
+			"the Gateway and HTTPRoute resources are trusted.",
+	)
+
+	cmd.Flags().BoolVar(
+		&statusDryRun,
+		"status-dry-run",
+		false,
+		"Log the status changes of the resources as JSON merge patches instead of writing them to the "+
+			"API server. NGINX is still configured.",
+	)
+
+	cmd.Flags().Var(
+		&adminSecret,
+		adminSecretFlag,
+		"The namespaced name of the Secret with the token of the admin server. Must be of the form: NAMESPACE/NAME. "+
+			"The token is the value of the 'token' key. Requests to the admin server must include the token in the "+
+			"'Authorization: Bearer <token>' header. If not specified, the admin server only listens on 127.0.0.1 "+
+			"and only serves /prestop. "+
+			"The admin server allows changing the log level at runtime with a PUT request to /log-level "+
+			`with the body {"level": "debug"}. A GET request to /prestop gracefully shuts down NGINX and returns `+
+			"after NGINX exits or after 30s. It is used by the pre-stop hook of the Pod, doesn't need the token, and "+
+			"only accepts requests from the loopback interface. A GET request to /snapshot "+
+			"returns the latest NGINX configuration, its hash, and the statuses of the resources in JSON. "+
+			"A POST request to /apply-snapshot with an NGINX configuration in JSON in the body applies the "+
+			"configuration to NGINX until the next change of the resources. The configuration is validated as "+
+			"the resources, so it can only include the snippets if the snippets are enabled. The exported "+
+			"configuration doesn't include the private keys: a key pair without the private key reuses the key of "+
+			"the key pair that NGINX runs with the same certificate.",
+	)
+
+	cmd.Flags().Var(
+		&adminBindAddress,
+		"admin-bind-address",
+		"The address the admin server binds to. Must be of the form: [HOST]:PORT. "+
+			fmt.Sprintf("If --%s is not specified, only the port is used, and the host is 127.0.0.1.", adminSecretFlag),
+	)
+
+	cmd.Flags().Var(
+		&metricsBindAddress,
+		metricsBindAddressFlag,
+		"The address the Prometheus metrics endpoint (/metrics) binds to. Must be of the form: [HOST]:PORT. "+
+			"If not specified, the metrics endpoint is disabled. "+
+			"The metrics include the NGINX connection and request metrics reported by the NGINX stub_status module.",
+	)
+
+	cmd.Flags().Var(
+		&nginxStatusPort,
+		"nginx-status-port",
+		"The port on 127.0.0.1 where NGINX serves the stub_status module output at /nginx_status. "+
+			fmt.Sprintf("Ignored if --%s is not specified.", metricsBindAddressFlag),
+	)
+
+	cmd.Flags().Var(
+		&nginxStatusScrapeInterval,
+		"nginx-status-scrape-interval",
+		"The interval between two scrapes of the NGINX stub_status module output. "+
+			fmt.Sprintf("Ignored if --%s is not specified.", metricsBindAddressFlag),
+	)
+
+	cmd.Flags().Var(
+		&reconcileRateLimitQPS,
+		"reconcile-rate-limit-qps",
+		"The maximum number of reconciles per second for every kind of resource. "+
+			"Limits the CPU usage when many resources change at once, for example, "+
+			"the EndpointSlices during a rolling update of a Deployment.",
+	)
+
+	cmd.Flags().Var(
+		&reconcileRateLimitBurst,
+		"reconcile-rate-limit-burst",
+		"The maximum number of reconciles for every kind of resource that can exceed the rate limit at once.",
+	)
+
+	cmd.Flags().Var(
+		&maxConcurrentReconciles,
+		"max-concurrent-reconciles",
+		"The maximum number of resources of every kind that can be reconciled concurrently.",
+	)
+
+	cmd.Flags().Var(
+		&nginxReloadTimeout,
+		"nginx-reload-timeout",
+		"The time to wait for NGINX to start new worker processes after a reload. "+
+			"If NGINX doesn't start them in time, the reload is considered failed. "+
+			"A reload, for which NGINX logs an error, fails without waiting.",
+	)
+
 	return cmd
 }
 
 func createProvisionerModeCommand() *cobra.Command {
-	return &cobra.Command{
+	// flag values
+	additionalGatewayClassNames := stringSliceValidatingValue{
+		validator: validateResourceName,
+	}
+
+	cmd := &cobra.Command{
 		Use:    "provisioner-mode",
 		Short:  "Provision a static-mode NGINX Gateway Deployment per Gateway resource",
 		Hidden: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			logger := zap.New()
+			logger := createLogger(logFormat.value, uberzap.NewAtomicLevel(), os.Stderr)
 			logger.Info("Starting NGINX Kubernetes Gateway Provisioner",
 				"version", version,
 				"commit", commit,
 				"date", date,
 			)
 
+			gcNames := append([]string{gatewayClassName.value}, additionalGatewayClassNames.values...)
+
 			return provisioner.StartManager(provisioner.Config{
-				Logger:           logger,
-				GatewayClassName: gatewayClassName.value,
-				GatewayCtlrName:  gatewayCtlrName.value,
+				Logger:            logger,
+				GatewayClassNames: gcNames,
+				GatewayCtlrName:   gatewayCtlrName.value,
 			})
 		},
 	}
+
+	cmd.Flags().Var(
+		&additionalGatewayClassNames,
+		"additional-gatewayclasses",
+		"A comma-separated list of the names of the GatewayClass resources to provision Deployments for "+
+			fmt.Sprintf("in addition to the one specified by --%s. ", gatewayClassFlag)+
+			"Every GatewayClass must reference the Gateway controller. "+
+			"The Gateways of an additional GatewayClass that doesn't exist are not provisioned.",
+	)
+
+	return cmd
+}
+
+func createAwaitNginxExitCommand() *cobra.Command {
+	// flag values
+	timeout := durationValidatingValue{
+		validator: validatePositiveDuration,
+		value:     35 * time.Second,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "await-nginx-exit",
+		Short: "Wait until NGINX exits. Used by the pre-stop hook of the nginx-gateway container",
+		Long: "Wait until the NGINX main process exits or the timeout expires. " +
+			"The pre-stop hook of the nginx-gateway container runs the command, so that the container keeps " +
+			"serving the /prestop endpoint of the admin server until NGINX finishes handling the open connections.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ngxruntime.WaitForExit(cmd.Context(), timeout.value)
+		},
+	}
+
+	cmd.Flags().Var(
+		&timeout,
+		"timeout",
+		"The maximum time to wait for NGINX to exit. "+
+			"The default is longer than the time the /prestop endpoint waits for NGINX to exit.",
+	)
+
+	return cmd
 }
@@ -47,6 +47,25 @@ func TestRootCmdFlagValidation(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid flags with log format",
+			args: []string{
+				"--gateway-ctlr-name=k8s-gateway.nginx.org/nginx-gateway",
+				"--gatewayclass=nginx",
+				"--log-format=text",
+			},
+			wantErr: false,
+		},
+		{
+			name: "log-format is invalid",
+			args: []string{
+				"--gateway-ctlr-name=k8s-gateway.nginx.org/nginx-gateway",
+				"--gatewayclass=nginx",
+				"--log-format=xml",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "xml" for "--log-format" flag: unsupported log format "xml"`,
+		},
 		{
 			name: "gateway-ctlr-name is not set",
 			args: []string{
@@ -117,6 +136,20 @@ func TestStaticModeCmdFlagValidation(t *testing.T) {
 			args: []string{
 				"--gateway=nginx-gateway/nginx",
 				"--update-gatewayclass-status=true",
+				"--enable-pprof",
+				"--pprof-port=6061",
+				"--health-probe-bind-address=:8082",
+				"--admin-secret=nginx-gateway/admin-token",
+				"--nginx-config-map=nginx-gateway/nginx-config",
+				"--admin-bind-address=:8083",
+				"--metrics-bind-address=:9113",
+				"--nginx-status-port=8766",
+				"--nginx-status-scrape-interval=30s",
+				"--reconcile-rate-limit-qps=20",
+				"--reconcile-rate-limit-burst=200",
+				"--max-concurrent-reconciles=5",
+				"--nginx-reload-timeout=30s",
+				"--status-dry-run",
 			},
 			wantErr: false,
 		},
@@ -158,6 +191,153 @@ func TestStaticModeCmdFlagValidation(t *testing.T) {
 			wantErr:           true,
 			expectedErrPrefix: `invalid argument "invalid" for "--update-gatewayclass-status" flag: strconv.ParseBool`,
 		},
+		{
+			name: "enable-pprof is invalid",
+			args: []string{
+				"--enable-pprof=invalid", // not a boolean
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "invalid" for "--enable-pprof" flag: strconv.ParseBool`,
+		},
+		{
+			name: "status-dry-run is invalid",
+			args: []string{
+				"--status-dry-run=invalid", // not a boolean
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "invalid" for "--status-dry-run" flag: strconv.ParseBool`,
+		},
+		{
+			name: "pprof-port is set to empty string",
+			args: []string{
+				"--pprof-port=",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "" for "--pprof-port" flag: failed to parse int value`,
+		},
+		{
+			name: "pprof-port is outside of the valid port range",
+			args: []string{
+				"--pprof-port=65536",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "65536" for "--pprof-port" flag: port outside of valid port range`,
+		},
+		{
+			name: "health-probe-bind-address is set to empty string",
+			args: []string{
+				"--health-probe-bind-address=",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "" for "--health-probe-bind-address" flag: invalid format`,
+		},
+		{
+			name: "health-probe-bind-address is invalid",
+			args: []string{
+				"--health-probe-bind-address=8081", // no colon
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "8081" for "--health-probe-bind-address" flag: invalid format`,
+		},
+		{
+			name: "admin-secret is set to empty string",
+			args: []string{
+				"--admin-secret=",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "" for "--admin-secret" flag: must be set`,
+		},
+		{
+			name: "admin-secret is invalid",
+			args: []string{
+				"--admin-secret=admin-token", // no namespace
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "admin-token" for "--admin-secret" flag: invalid format; ` +
+				"must be NAMESPACE/NAME",
+		},
+		{
+			name: "nginx-config-map is invalid",
+			args: []string{
+				"--nginx-config-map=nginx-config", // no namespace
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "nginx-config" for "--nginx-config-map" flag: invalid format; ` +
+				"must be NAMESPACE/NAME",
+		},
+		{
+			name: "admin-bind-address is invalid",
+			args: []string{
+				"--admin-bind-address=8082", // no colon
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "8082" for "--admin-bind-address" flag: invalid format`,
+		},
+		{
+			name: "metrics-bind-address is set to empty string",
+			args: []string{
+				"--metrics-bind-address=",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "" for "--metrics-bind-address" flag: invalid format`,
+		},
+		{
+			name: "nginx-status-port is outside of the valid port range",
+			args: []string{
+				"--nginx-status-port=0",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "0" for "--nginx-status-port" flag: port outside of valid port range`,
+		},
+		{
+			name: "nginx-status-scrape-interval is invalid",
+			args: []string{
+				"--nginx-status-scrape-interval=10", // no unit
+			},
+			wantErr: true,
+			expectedErrPrefix: `invalid argument "10" for "--nginx-status-scrape-interval" flag: ` +
+				"failed to parse duration value",
+		},
+		{
+			name: "nginx-status-scrape-interval is not positive",
+			args: []string{
+				"--nginx-status-scrape-interval=0s",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "0s" for "--nginx-status-scrape-interval" flag: must be positive`,
+		},
+		{
+			name: "reconcile-rate-limit-qps is not positive",
+			args: []string{
+				"--reconcile-rate-limit-qps=0",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "0" for "--reconcile-rate-limit-qps" flag: must be positive`,
+		},
+		{
+			name: "reconcile-rate-limit-burst is not an integer",
+			args: []string{
+				"--reconcile-rate-limit-burst=many",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "many" for "--reconcile-rate-limit-burst" flag: failed to parse int value`,
+		},
+		{
+			name: "max-concurrent-reconciles is not positive",
+			args: []string{
+				"--max-concurrent-reconciles=-1",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "-1" for "--max-concurrent-reconciles" flag: must be positive`,
+		},
+		{
+			name: "nginx-reload-timeout is not positive",
+			args: []string{
+				"--nginx-reload-timeout=0s",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "0s" for "--nginx-reload-timeout" flag: must be positive`,
+		},
 	}
 
 	for _, test := range tests {
@@ -167,3 +347,83 @@ func TestStaticModeCmdFlagValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestProvisionerModeCmdFlagValidation(t *testing.T) {
+	tests := []flagTestCase{
+		{
+			name: "valid flags",
+			args: []string{
+				"--additional-gatewayclasses=fast,slow",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid flags, specified multiple times",
+			args: []string{
+				"--additional-gatewayclasses=fast",
+				"--additional-gatewayclasses=slow",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "valid flags, not set",
+			args:    nil,
+			wantErr: false,
+		},
+		{
+			name: "additional-gatewayclasses is set to empty string",
+			args: []string{
+				"--additional-gatewayclasses=",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "" for "--additional-gatewayclasses" flag: must be set`,
+		},
+		{
+			name: "additional-gatewayclasses includes an invalid name",
+			args: []string{
+				"--additional-gatewayclasses=fast,@",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "fast,@" for "--additional-gatewayclasses" flag: invalid format`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := createProvisionerModeCommand()
+			testFlag(t, cmd, test)
+		})
+	}
+}
+
+func TestAwaitNginxExitCmdFlagValidation(t *testing.T) {
+	tests := []flagTestCase{
+		{
+			name: "valid flags",
+			args: []string{
+				"--timeout=20s",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "valid flags, not set",
+			args:    nil,
+			wantErr: false,
+		},
+		{
+			name: "timeout is not positive",
+			args: []string{
+				"--timeout=0s",
+			},
+			wantErr:           true,
+			expectedErrPrefix: `invalid argument "0s" for "--timeout" flag: must be positive`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := createAwaitNginxExitCommand()
+			testFlag(t, cmd, test)
+		})
+	}
+}
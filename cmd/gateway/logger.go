@@ -0,0 +1,30 @@
+package main
+
+import (
+	"io"
+
+	"github.com/go-logr/logr"
+	uberzap "go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+const (
+	logFormatJSON = "json"
+	logFormatText = "text"
+)
+
+var logFormats = []string{logFormatJSON, logFormatText}
+
+// createLogger creates a logger that writes the log messages to out in the format, which must be one of logFormats.
+// The logger only writes the messages enabled by the level, so changing the level at runtime changes what gets logged.
+func createLogger(format string, level uberzap.AtomicLevel, out io.Writer) logr.Logger {
+	opts := []zap.Opts{zap.WriteTo(out), zap.Level(level)}
+
+	if format == logFormatText {
+		opts = append(opts, zap.ConsoleEncoder())
+	} else {
+		opts = append(opts, zap.JSONEncoder())
+	}
+
+	return zap.New(opts...)
+}
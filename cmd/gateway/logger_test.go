@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	uberzap "go.uber.org/zap"
+)
+
+func TestCreateLogger(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		var buf bytes.Buffer
+		logger := createLogger(logFormatJSON, uberzap.NewAtomicLevel(), &buf)
+
+		logger.Info("test message", "resource_kind", "HTTPRoute", "namespace", "test", "resource_name", "route")
+
+		var entry map[string]interface{}
+		g.Expect(json.Unmarshal(buf.Bytes(), &entry)).To(Succeed())
+
+		g.Expect(entry).To(HaveKeyWithValue("msg", "test message"))
+		g.Expect(entry).To(HaveKeyWithValue("resource_kind", "HTTPRoute"))
+		g.Expect(entry).To(HaveKeyWithValue("namespace", "test"))
+		g.Expect(entry).To(HaveKeyWithValue("resource_name", "route"))
+	})
+
+	t.Run("text", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		var buf bytes.Buffer
+		logger := createLogger(logFormatText, uberzap.NewAtomicLevel(), &buf)
+
+		logger.Info("test message", "namespace", "test")
+
+		g.Expect(json.Valid(buf.Bytes())).To(BeFalse())
+		g.Expect(buf.String()).To(ContainSubstring("test message"))
+		g.Expect(buf.String()).To(ContainSubstring(`"namespace": "test"`))
+	})
+}
+
+func TestCreateLoggerLevel(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var buf bytes.Buffer
+	level := uberzap.NewAtomicLevel()
+	logger := createLogger(logFormatJSON, level, &buf)
+
+	logger.V(1).Info("debug message")
+	g.Expect(buf.String()).To(BeEmpty())
+
+	level.SetLevel(uberzap.DebugLevel)
+
+	logger.V(1).Info("debug message")
+	g.Expect(buf.String()).To(ContainSubstring("debug message"))
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"net"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -99,3 +101,55 @@ func validateIP(ip string) error {
 
 	return nil
 }
+
+func validatePort(port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("port outside of valid port range [1 - 65535]: %v", port)
+	}
+
+	return nil
+}
+
+func validateBindAddress(address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid format; must be [HOST]:PORT: %w", err)
+	}
+
+	if host != "" && net.ParseIP(host) == nil {
+		return fmt.Errorf("%q must be a valid IP address", host)
+	}
+
+	portNum, err := strconv.Atoi(port)
+	if err != nil {
+		return fmt.Errorf("%q must be a valid port", port)
+	}
+
+	return validatePort(portNum)
+}
+
+func validatePositiveDuration(duration time.Duration) error {
+	if duration <= 0 {
+		return fmt.Errorf("must be positive, got %s", duration)
+	}
+
+	return nil
+}
+
+func validatePositiveInt(value int) error {
+	if value <= 0 {
+		return fmt.Errorf("must be positive, got %d", value)
+	}
+
+	return nil
+}
+
+func validateLogFormat(format string) error {
+	for _, f := range logFormats {
+		if format == f {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported log format %q; must be one of: %s", format, strings.Join(logFormats, ", "))
+}
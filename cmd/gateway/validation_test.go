@@ -2,6 +2,7 @@ package main
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/types"
@@ -294,3 +295,208 @@ func TestValidateIP(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePort(t *testing.T) {
+	tests := []struct {
+		name   string
+		port   int
+		expErr bool
+	}{
+		{
+			name:   "port under minimum allowed value",
+			port:   0,
+			expErr: true,
+		},
+		{
+			name:   "port over maximum allowed value",
+			port:   65536,
+			expErr: true,
+		},
+		{
+			name:   "valid port",
+			port:   6060,
+			expErr: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			err := validatePort(tc.port)
+			if !tc.expErr {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(err).To(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidateBindAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		expErr  bool
+	}{
+		{
+			name:    "valid address without host",
+			address: ":8081",
+			expErr:  false,
+		},
+		{
+			name:    "valid address with host",
+			address: "127.0.0.1:8081",
+			expErr:  false,
+		},
+		{
+			name:    "missing port",
+			address: "127.0.0.1",
+			expErr:  true,
+		},
+		{
+			name:    "invalid host",
+			address: "localhost:8081",
+			expErr:  true,
+		},
+		{
+			name:    "invalid port",
+			address: ":http",
+			expErr:  true,
+		},
+		{
+			name:    "port outside of the valid port range",
+			address: ":65536",
+			expErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			err := validateBindAddress(tc.address)
+			if !tc.expErr {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(err).To(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidatePositiveDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration time.Duration
+		expErr   bool
+	}{
+		{
+			name:     "positive",
+			duration: 10 * time.Second,
+			expErr:   false,
+		},
+		{
+			name:     "zero",
+			duration: 0,
+			expErr:   true,
+		},
+		{
+			name:     "negative",
+			duration: -time.Second,
+			expErr:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			err := validatePositiveDuration(tc.duration)
+			if !tc.expErr {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(err).To(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidatePositiveInt(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  int
+		expErr bool
+	}{
+		{
+			name:   "positive",
+			value:  10,
+			expErr: false,
+		},
+		{
+			name:   "zero",
+			value:  0,
+			expErr: true,
+		},
+		{
+			name:   "negative",
+			value:  -1,
+			expErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			err := validatePositiveInt(tc.value)
+			if !tc.expErr {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(err).To(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidateLogFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		expErr bool
+	}{
+		{
+			name:   "json",
+			format: "json",
+			expErr: false,
+		},
+		{
+			name:   "text",
+			format: "text",
+			expErr: false,
+		},
+		{
+			name:   "empty",
+			format: "",
+			expErr: true,
+		},
+		{
+			name:   "unsupported",
+			format: "xml",
+			expErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			err := validateLogFormat(tc.format)
+			if !tc.expErr {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(err).To(HaveOccurred())
+			}
+		})
+	}
+}
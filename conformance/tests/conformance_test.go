@@ -18,6 +18,7 @@ limitations under the License.
 package tests
 
 import (
+	"flag"
 	"strings"
 	"testing"
 
@@ -32,6 +33,12 @@ import (
 	"sigs.k8s.io/gateway-api/conformance/utils/suite"
 )
 
+var reportOutput = flag.String(
+	"report-output",
+	"",
+	"The path of the file to write the conformance report to. If empty, the report is not written.",
+)
+
 func TestConformance(t *testing.T) {
 	g := NewGomegaWithT(t)
 	cfg, err := config.GetConfig()
@@ -61,7 +68,12 @@ func TestConformance(t *testing.T) {
 		EnableAllSupportedFeatures: *flags.EnableAllSupportedFeatures,
 	})
 	cSuite.Setup(t)
-	cSuite.Run(t, tests.ConformanceTests)
+
+	results := runAndRecord(t, cSuite, tests.ConformanceTests)
+
+	if *reportOutput != "" {
+		g.Expect(writeReport(*reportOutput, *flags.GatewayClassName, results)).To(Succeed())
+	}
 }
 
 // parseSupportedFeatures parses flag arguments and converts the string to
@@ -0,0 +1,158 @@
+//go:build conformance
+
+package tests
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"sigs.k8s.io/gateway-api/conformance/utils/suite"
+)
+
+type testResult string
+
+const (
+	testResultPassed  testResult = "Passed"
+	testResultFailed  testResult = "Failed"
+	testResultSkipped testResult = "Skipped"
+)
+
+// conformanceResult is the result of a single conformance test.
+type conformanceResult struct {
+	result testResult
+	test   suite.ConformanceTest
+}
+
+// resultRecorder records the results of the conformance tests. It is safe for concurrent use, because
+// some conformance tests run in parallel.
+type resultRecorder struct {
+	results []conformanceResult
+	lock    sync.Mutex
+}
+
+func (r *resultRecorder) record(test suite.ConformanceTest, result testResult) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.results = append(r.results, conformanceResult{test: test, result: result})
+}
+
+// runAndRecord runs the conformance tests the same way as suite.ConformanceTestSuite.Run does and records
+// their results.
+func runAndRecord(
+	t *testing.T,
+	cSuite *suite.ConformanceTestSuite,
+	conformanceTests []suite.ConformanceTest,
+) []conformanceResult {
+	recorder := &resultRecorder{}
+
+	// the parent subtest returns only after all its subtests, including the parallel ones, have completed.
+	t.Run("Tests", func(t *testing.T) {
+		for _, test := range conformanceTests {
+			test := test
+
+			t.Run(test.ShortName, func(t *testing.T) {
+				t.Cleanup(func() {
+					switch {
+					case t.Failed():
+						recorder.record(test, testResultFailed)
+					case t.Skipped(), cSuite.SkipTests.Has(test.ShortName):
+						recorder.record(test, testResultSkipped)
+					default:
+						recorder.record(test, testResultPassed)
+					}
+				})
+
+				test.Run(t, cSuite)
+			})
+		}
+	})
+
+	sort.Slice(recorder.results, func(i, j int) bool {
+		return recorder.results[i].test.ShortName < recorder.results[j].test.ShortName
+	})
+
+	return recorder.results
+}
+
+// writeReport writes the conformance report in the Markdown format to the file at path.
+func writeReport(path string, gatewayClassName string, results []conformanceResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create conformance report file %s: %w", path, err)
+	}
+
+	if err := generateReport(f, gatewayClassName, results); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write conformance report file %s: %w", path, err)
+	}
+
+	return f.Close()
+}
+
+func generateReport(w io.Writer, gatewayClassName string, results []conformanceResult) error {
+	var b strings.Builder
+
+	counts := make(map[testResult]int)
+	for _, r := range results {
+		counts[r.result]++
+	}
+
+	b.WriteString("# Gateway API Conformance\n\n")
+	b.WriteString("<!-- This file is generated by the conformance tests (make conformance). DO NOT EDIT. -->\n\n")
+	fmt.Fprintf(&b, "- Gateway API version: %s\n", gatewayAPIVersion())
+	fmt.Fprintf(&b, "- GatewayClass: %s\n", gatewayClassName)
+	fmt.Fprintf(
+		&b,
+		"- Results: %d passed, %d failed, %d skipped\n\n",
+		counts[testResultPassed],
+		counts[testResultFailed],
+		counts[testResultSkipped],
+	)
+
+	b.WriteString("| Test | Features | Result | Description |\n")
+	b.WriteString("| ---- | -------- | ------ | ----------- |\n")
+
+	for _, r := range results {
+		features := make([]string, 0, len(r.test.Features))
+		for _, f := range r.test.Features {
+			features = append(features, string(f))
+		}
+
+		fmt.Fprintf(
+			&b,
+			"| %s | %s | %s | %s |\n",
+			r.test.ShortName,
+			strings.Join(features, ", "),
+			r.result,
+			strings.ReplaceAll(r.test.Description, "|", `\|`),
+		)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// gatewayAPIVersion returns the version of the Gateway API module the conformance tests were built with.
+func gatewayAPIVersion() string {
+	const unknown = "unknown"
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return unknown
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path == "sigs.k8s.io/gateway-api" {
+			return dep.Version
+		}
+	}
+
+	return unknown
+}
@@ -1,6 +1,14 @@
 package embeddedfiles
 
-import _ "embed"
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
 
 // StaticModeDeploymentYAML contains the YAML manifest of the Deployment resource for the static mode.
 //
@@ -9,3 +17,78 @@ import _ "embed"
 //
 //go:embed deploy/manifests/deployment.yaml
 var StaticModeDeploymentYAML []byte
+
+// StaticModeRBACYAML contains the YAML manifest of the RBAC resources for the static mode.
+//
+//go:embed deploy/manifests/rbac.yaml
+var StaticModeRBACYAML []byte
+
+// staticModeDeploymentTemplate is the template of the YAML manifest of the Deployment resource for the static mode.
+// Rendered with the default values, it must be the same as StaticModeDeploymentYAML.
+//
+//go:embed deploy/templates/deployment.yaml.tmpl
+var staticModeDeploymentTemplate string
+
+var deploymentTemplate = template.Must(template.New("deployment").Parse(staticModeDeploymentTemplate))
+
+const (
+	defaultImage    = "ghcr.io/nginxinc/nginx-kubernetes-gateway"
+	defaultVersion  = "edge"
+	defaultReplicas = 1
+	// appLabel is the label that selects the Pods of the Deployment.
+	appLabel = "app"
+)
+
+// DeploymentTemplateValues holds the values for rendering the static mode Deployment.
+// The zero values are replaced with the values of the static mode Deployment manifest.
+type DeploymentTemplateValues struct {
+	// Labels are added to the labels of the Pods.
+	Labels map[string]string
+	// ResourceLimits are the resource limits of each container, where the key is the name of the resource, for
+	// example, cpu, and the value is the quantity, for example, 500m. If empty, the containers have no limits.
+	ResourceLimits map[string]string
+	// Image is the image of NKG without the tag.
+	Image string
+	// Version is the tag of the NKG image.
+	Version string
+	// Replicas is the number of the Pods.
+	Replicas int32
+}
+
+// RenderDeploymentYAML renders the YAML manifest of the static mode Deployment with the given values.
+func RenderDeploymentYAML(values DeploymentTemplateValues) ([]byte, error) {
+	return renderDeploymentYAML(deploymentTemplate, values)
+}
+
+func renderDeploymentYAML(tmpl *template.Template, values DeploymentTemplateValues) ([]byte, error) {
+	if values.Replicas < 0 {
+		return nil, errors.New("replicas must not be negative")
+	}
+
+	if _, exists := values.Labels[appLabel]; exists {
+		return nil, fmt.Errorf("label %q is reserved", appLabel)
+	}
+
+	for name, value := range values.ResourceLimits {
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return nil, fmt.Errorf("invalid limit of resource %q: %w", name, err)
+		}
+	}
+
+	if values.Image == "" {
+		values.Image = defaultImage
+	}
+	if values.Version == "" {
+		values.Version = defaultVersion
+	}
+	if values.Replicas == 0 {
+		values.Replicas = defaultReplicas
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, values); err != nil {
+		return nil, fmt.Errorf("failed to execute deployment template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
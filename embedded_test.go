@@ -0,0 +1,136 @@
+package embeddedfiles
+
+import (
+	"testing"
+	"text/template"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+func TestRenderDeploymentYAMLDefaultValues(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	result, err := RenderDeploymentYAML(DeploymentTemplateValues{})
+
+	g.Expect(err).ToNot(HaveOccurred())
+	// The template must be updated together with the manifest.
+	g.Expect(string(result)).To(Equal(string(StaticModeDeploymentYAML)))
+}
+
+func TestRenderDeploymentYAML(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	result, err := RenderDeploymentYAML(DeploymentTemplateValues{
+		Labels: map[string]string{
+			"team":                      "gateway",
+			"example.com/cost-center":   "1234",
+			"example.com/empty-allowed": "",
+		},
+		ResourceLimits: map[string]string{
+			"cpu":    "500m",
+			"memory": "256Mi",
+		},
+		Image:    "registry.example.com/nkg",
+		Version:  "1.0.0",
+		Replicas: 3,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	dep := &v1.Deployment{}
+	g.Expect(yaml.Unmarshal(result, dep)).To(Succeed())
+
+	g.Expect(dep.Spec.Replicas).To(HaveValue(Equal(int32(3))))
+	g.Expect(dep.Spec.Template.Labels).To(Equal(map[string]string{
+		"app":                       "nginx-gateway",
+		"team":                      "gateway",
+		"example.com/cost-center":   "1234",
+		"example.com/empty-allowed": "",
+	}))
+
+	expectedLimits := apiv1.ResourceList{
+		apiv1.ResourceCPU:    resource.MustParse("500m"),
+		apiv1.ResourceMemory: resource.MustParse("256Mi"),
+	}
+
+	containers := dep.Spec.Template.Spec.Containers
+	g.Expect(containers).To(HaveLen(2))
+
+	g.Expect(containers[0].Name).To(Equal("nginx-gateway"))
+	g.Expect(containers[0].Image).To(Equal("registry.example.com/nkg:1.0.0"))
+	g.Expect(containers[0].Resources.Limits).To(Equal(expectedLimits))
+
+	g.Expect(containers[1].Name).To(Equal("nginx"))
+	g.Expect(containers[1].Image).To(Equal("nginx:1.25"))
+	g.Expect(containers[1].Resources.Limits).To(Equal(expectedLimits))
+}
+
+func TestRenderDeploymentYAMLPartialValues(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	result, err := RenderDeploymentYAML(DeploymentTemplateValues{
+		Version: "1.0.0",
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	dep := &v1.Deployment{}
+	g.Expect(yaml.Unmarshal(result, dep)).To(Succeed())
+
+	g.Expect(dep.Spec.Replicas).To(HaveValue(Equal(int32(defaultReplicas))))
+	g.Expect(dep.Spec.Template.Labels).To(Equal(map[string]string{"app": "nginx-gateway"}))
+
+	containers := dep.Spec.Template.Spec.Containers
+	g.Expect(containers).To(HaveLen(2))
+	g.Expect(containers[0].Image).To(Equal(defaultImage + ":1.0.0"))
+	g.Expect(containers[0].Resources.Limits).To(BeEmpty())
+	g.Expect(containers[1].Resources.Limits).To(BeEmpty())
+}
+
+func TestRenderDeploymentYAMLInvalidValues(t *testing.T) {
+	tests := []struct {
+		msg         string
+		expectedErr string
+		values      DeploymentTemplateValues
+	}{
+		{
+			values:      DeploymentTemplateValues{Replicas: -1},
+			msg:         "negative replicas",
+			expectedErr: "replicas must not be negative",
+		},
+		{
+			values:      DeploymentTemplateValues{Labels: map[string]string{"app": "test"}},
+			msg:         "reserved label",
+			expectedErr: `label "app" is reserved`,
+		},
+		{
+			values:      DeploymentTemplateValues{ResourceLimits: map[string]string{"cpu": "a lot"}},
+			msg:         "invalid quantity",
+			expectedErr: `invalid limit of resource "cpu": quantities must match the regular expression`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			result, err := RenderDeploymentYAML(test.values)
+
+			g.Expect(err).To(MatchError(ContainSubstring(test.expectedErr)))
+			g.Expect(result).To(BeNil())
+		})
+	}
+}
+
+func TestRenderDeploymentYAMLTemplateError(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tmpl := template.Must(template.New("test").Parse("replicas: {{ .Unknown }}"))
+
+	result, err := renderDeploymentYAML(tmpl, DeploymentTemplateValues{})
+
+	g.Expect(err).To(MatchError(ContainSubstring("failed to execute deployment template")))
+	g.Expect(result).To(BeNil())
+}
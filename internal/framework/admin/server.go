@@ -0,0 +1,326 @@
+// Package admin implements the admin HTTP server of the control plane.
+//
+// The admin server serves the endpoints that change the behavior of the running control plane, such as its log level.
+// All requests must carry a bearer token, which the server compares against the token returned by a TokenGetter.
+// The only exception is the pre-stop endpoint, which is called by the pre-stop hook of the Pod and only accepts
+// requests from the loopback interface.
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// LogLevelPath is the path of the endpoint that changes the log level.
+//
+// A PUT request with the body {"level": "debug"} sets the level to debug. A GET request returns the current level.
+const LogLevelPath = "/log-level"
+
+// PreStopPath is the path of the endpoint for the pre-stop hook of the Pod.
+//
+// A GET request gracefully shuts down NGINX and returns after NGINX has finished handling the open connections
+// and exited. The request doesn't need the bearer token, but it must come from the loopback interface, so that
+// only the containers of the Pod can make it.
+const PreStopPath = "/prestop"
+
+// SnapshotPath is the path of the endpoint that exports the state of the control plane.
+//
+// A GET request returns the snapshot in JSON.
+const SnapshotPath = "/snapshot"
+
+// ApplySnapshotPath is the path of the endpoint that applies a snapshot to the data plane.
+//
+// A POST request with the snapshot in JSON in the body applies the snapshot.
+const ApplySnapshotPath = "/apply-snapshot"
+
+// maxSnapshotSize is the maximum size of the body of a request to the ApplySnapshotPath endpoint.
+const maxSnapshotSize = 10 << 20
+
+const (
+	bearerPrefix      = "Bearer "
+	readHeaderTimeout = 10 * time.Second
+)
+
+// TokenGetter returns the token that authenticates the requests to the admin server.
+type TokenGetter func(ctx context.Context) (string, error)
+
+// NginxQuitter gracefully shuts down NGINX. It blocks until NGINX exits.
+type NginxQuitter func(ctx context.Context) error
+
+// SnapshotGetter returns the latest snapshot of the state of the control plane. The snapshot must be encodable
+// to JSON. It returns false if there is no snapshot yet.
+type SnapshotGetter func() (interface{}, bool)
+
+// SnapshotApplier applies the snapshot in JSON to the data plane. It returns an error that wraps
+// ErrInvalidSnapshot if the snapshot is invalid.
+type SnapshotApplier func(ctx context.Context, snapshot []byte) error
+
+// ErrInvalidSnapshot is the error that a SnapshotApplier wraps when the snapshot is invalid.
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+// ServerConfig holds configuration parameters for the admin server.
+type ServerConfig struct {
+	// Logger is the logger of the admin server.
+	Logger logr.Logger
+	// GetToken returns the token that the requests must carry in the Authorization header.
+	GetToken TokenGetter
+	// QuitNginx shuts down NGINX for the PreStopPath endpoint. If nil, the endpoint is not served.
+	QuitNginx NginxQuitter
+	// GetSnapshot returns the snapshot for the SnapshotPath endpoint. If nil, the endpoint is not served.
+	GetSnapshot SnapshotGetter
+	// ApplySnapshot applies the snapshots for the ApplySnapshotPath endpoint. If nil, the endpoint is not served.
+	ApplySnapshot SnapshotApplier
+	// LogLevel is the log level of the control plane that the LogLevelPath endpoint changes.
+	LogLevel zap.AtomicLevel
+	// BindAddress is the address the admin server binds to.
+	BindAddress string
+}
+
+// Server is the admin HTTP server. It implements the manager.Runnable interface.
+type Server struct {
+	server *http.Server
+	logger logr.Logger
+}
+
+var (
+	_ manager.Runnable               = &Server{}
+	_ manager.LeaderElectionRunnable = &Server{}
+)
+
+// NewServer creates a new Server.
+func NewServer(cfg ServerConfig) *Server {
+	return &Server{
+		server: &http.Server{
+			Addr:              cfg.BindAddress,
+			Handler:           NewHandler(cfg),
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+		logger: cfg.Logger,
+	}
+}
+
+// NewHandler creates the http.Handler that serves the endpoints of the admin server.
+func NewHandler(cfg ServerConfig) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(LogLevelPath, cfg.LogLevel)
+
+	if cfg.GetSnapshot != nil {
+		mux.Handle(SnapshotPath, &snapshotHandler{
+			getSnapshot: cfg.GetSnapshot,
+			logger:      cfg.Logger,
+		})
+	}
+
+	if cfg.ApplySnapshot != nil {
+		mux.Handle(ApplySnapshotPath, &applySnapshotHandler{
+			applySnapshot: cfg.ApplySnapshot,
+			logger:        cfg.Logger,
+		})
+	}
+
+	auth := &authHandler{
+		next:     mux,
+		getToken: cfg.GetToken,
+		logger:   cfg.Logger,
+	}
+
+	if cfg.QuitNginx == nil {
+		return auth
+	}
+
+	// The pre-stop hook can't carry the token, so the pre-stop endpoint is not behind the authHandler.
+	root := http.NewServeMux()
+	root.Handle("/", auth)
+	root.Handle(PreStopPath, &preStopHandler{
+		quitNginx: cfg.QuitNginx,
+		logger:    cfg.Logger,
+	})
+
+	return root
+}
+
+// Start starts the Server.
+// This method will block until the Server stops, which will happen after the ctx is closed.
+func (s *Server) Start(ctx context.Context) error {
+	shutdownDone := make(chan struct{})
+
+	go func() {
+		<-ctx.Done()
+		if err := s.server.Shutdown(context.Background()); err != nil {
+			s.logger.Error(err, "error shutting down admin server")
+		}
+		close(shutdownDone)
+	}()
+
+	s.logger.Info("Starting admin server", "address", s.server.Addr)
+
+	if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	<-shutdownDone
+	return nil
+}
+
+// NeedLeaderElection returns false, because every replica of the control plane must serve the admin endpoints.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// authHandler passes a request to the next handler only if the request carries the expected bearer token.
+type authHandler struct {
+	next     http.Handler
+	getToken TokenGetter
+	logger   logr.Logger
+}
+
+func (h *authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	expected, err := h.getToken(r.Context())
+	if err != nil {
+		h.logger.Error(err, "failed to get the admin token")
+		http.Error(w, "failed to get the admin token", http.StatusInternalServerError)
+		return
+	}
+
+	// An empty token would let through requests without any credentials.
+	if expected == "" {
+		h.logger.Info("Rejecting admin request because the admin token is empty")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, bearerPrefix) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	token := strings.TrimPrefix(header, bearerPrefix)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	h.next.ServeHTTP(w, r)
+}
+
+// preStopHandler shuts down NGINX before the Pod is terminated, so that NGINX can finish handling the open
+// connections instead of being killed. It only serves the requests from the loopback interface.
+type preStopHandler struct {
+	quitNginx NginxQuitter
+	logger    logr.Logger
+}
+
+func (h *preStopHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !isLoopback(r.RemoteAddr) {
+		h.logger.Info("Rejecting pre-stop request from a non-loopback address", "remote_address", r.RemoteAddr)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	h.logger.Info("Shutting down NGINX before the Pod stops")
+
+	if err := h.quitNginx(r.Context()); err != nil {
+		h.logger.Error(err, "failed to shut down NGINX")
+		http.Error(w, "failed to shut down NGINX", http.StatusInternalServerError)
+		return
+	}
+
+	h.logger.Info("NGINX exited")
+	w.WriteHeader(http.StatusOK)
+}
+
+// isLoopback returns true if the remote address of a request, in the form of host:port, is a loopback address.
+func isLoopback(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+// snapshotHandler exports the latest snapshot of the state of the control plane.
+type snapshotHandler struct {
+	getSnapshot SnapshotGetter
+	logger      logr.Logger
+}
+
+func (h *snapshotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	snapshot, exists := h.getSnapshot()
+	if !exists {
+		http.Error(w, "no snapshot yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	body, err := json.Marshal(snapshot)
+	if err != nil {
+		h.logger.Error(err, "failed to encode the snapshot")
+		http.Error(w, "failed to encode the snapshot", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(body); err != nil {
+		h.logger.Error(err, "failed to write the snapshot")
+	}
+}
+
+// applySnapshotHandler applies the snapshot from the request body to the data plane.
+type applySnapshotHandler struct {
+	applySnapshot SnapshotApplier
+	logger        logr.Logger
+}
+
+func (h *applySnapshotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSnapshotSize))
+	if err != nil {
+		http.Error(w, "failed to read the snapshot: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info("Applying snapshot", "remote_address", r.RemoteAddr, "size", len(body))
+
+	if err := h.applySnapshot(r.Context(), body); err != nil {
+		h.logger.Error(err, "failed to apply the snapshot", "remote_address", r.RemoteAddr)
+
+		code := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidSnapshot) {
+			code = http.StatusBadRequest
+		}
+
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	h.logger.Info("Snapshot applied", "remote_address", r.RemoteAddr)
+	w.WriteHeader(http.StatusOK)
+}
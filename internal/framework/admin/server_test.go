@@ -0,0 +1,482 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestHandler(t *testing.T) {
+	const token = "secret-token"
+
+	getToken := func(context.Context) (string, error) {
+		return token, nil
+	}
+
+	tests := []struct {
+		getToken      TokenGetter
+		name          string
+		method        string
+		authorization string
+		body          string
+		expectedCode  int
+		expectedLevel zapcore.Level
+	}{
+		{
+			name:          "valid level",
+			getToken:      getToken,
+			method:        http.MethodPut,
+			authorization: "Bearer " + token,
+			body:          `{"level": "debug"}`,
+			expectedCode:  http.StatusOK,
+			expectedLevel: zapcore.DebugLevel,
+		},
+		{
+			name:          "invalid level",
+			getToken:      getToken,
+			method:        http.MethodPut,
+			authorization: "Bearer " + token,
+			body:          `{"level": "verbose"}`,
+			expectedCode:  http.StatusBadRequest,
+			expectedLevel: zapcore.InfoLevel,
+		},
+		{
+			name:          "get level",
+			getToken:      getToken,
+			method:        http.MethodGet,
+			authorization: "Bearer " + token,
+			expectedCode:  http.StatusOK,
+			expectedLevel: zapcore.InfoLevel,
+		},
+		{
+			name:          "no token",
+			getToken:      getToken,
+			method:        http.MethodPut,
+			body:          `{"level": "debug"}`,
+			expectedCode:  http.StatusUnauthorized,
+			expectedLevel: zapcore.InfoLevel,
+		},
+		{
+			name:          "wrong token",
+			getToken:      getToken,
+			method:        http.MethodPut,
+			authorization: "Bearer wrong-token",
+			body:          `{"level": "debug"}`,
+			expectedCode:  http.StatusUnauthorized,
+			expectedLevel: zapcore.InfoLevel,
+		},
+		{
+			name:          "not a bearer token",
+			getToken:      getToken,
+			method:        http.MethodPut,
+			authorization: "Basic " + token,
+			body:          `{"level": "debug"}`,
+			expectedCode:  http.StatusUnauthorized,
+			expectedLevel: zapcore.InfoLevel,
+		},
+		{
+			name: "empty expected token",
+			getToken: func(context.Context) (string, error) {
+				return "", nil
+			},
+			method:        http.MethodPut,
+			authorization: "Bearer ",
+			body:          `{"level": "debug"}`,
+			expectedCode:  http.StatusUnauthorized,
+			expectedLevel: zapcore.InfoLevel,
+		},
+		{
+			name: "failed to get token",
+			getToken: func(context.Context) (string, error) {
+				return "", errors.New("test")
+			},
+			method:        http.MethodPut,
+			authorization: "Bearer " + token,
+			body:          `{"level": "debug"}`,
+			expectedCode:  http.StatusInternalServerError,
+			expectedLevel: zapcore.InfoLevel,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			level := zap.NewAtomicLevel()
+
+			handler := NewHandler(ServerConfig{
+				Logger:   logr.Discard(),
+				GetToken: test.getToken,
+				LogLevel: level,
+			})
+
+			req := httptest.NewRequest(test.method, LogLevelPath, strings.NewReader(test.body))
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			g.Expect(rec.Code).To(Equal(test.expectedCode))
+			g.Expect(level.Level()).To(Equal(test.expectedLevel))
+		})
+	}
+}
+
+func TestPreStopHandlerBlocksUntilNginxExits(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	const token = "secret-token"
+
+	quitStarted := make(chan struct{})
+	nginxExited := make(chan struct{})
+
+	handler := NewHandler(ServerConfig{
+		Logger: logr.Discard(),
+		GetToken: func(context.Context) (string, error) {
+			return token, nil
+		},
+		QuitNginx: func(context.Context) error {
+			close(quitStarted)
+			<-nginxExited
+			return nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, PreStopPath, nil)
+	req.RemoteAddr = "127.0.0.1:12345"
+
+	rec := httptest.NewRecorder()
+	served := make(chan struct{})
+
+	go func() {
+		handler.ServeHTTP(rec, req)
+		close(served)
+	}()
+
+	g.Eventually(quitStarted).Should(BeClosed())
+	g.Consistently(served, 100*time.Millisecond).ShouldNot(BeClosed())
+
+	close(nginxExited)
+
+	g.Eventually(served).Should(BeClosed())
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+}
+
+func TestPreStopHandler(t *testing.T) {
+	const token = "secret-token"
+
+	getToken := func(context.Context) (string, error) {
+		return token, nil
+	}
+
+	tests := []struct {
+		quitNginx         NginxQuitter
+		name              string
+		method            string
+		remoteAddr        string
+		expectedCode      int
+		expectedQuitCalls int
+	}{
+		{
+			name: "NGINX exits",
+			quitNginx: func(context.Context) error {
+				return nil
+			},
+			method:            http.MethodGet,
+			remoteAddr:        "127.0.0.1:12345",
+			expectedCode:      http.StatusOK,
+			expectedQuitCalls: 1,
+		},
+		{
+			name: "NGINX exits, IPv6 loopback",
+			quitNginx: func(context.Context) error {
+				return nil
+			},
+			method:            http.MethodGet,
+			remoteAddr:        "[::1]:12345",
+			expectedCode:      http.StatusOK,
+			expectedQuitCalls: 1,
+		},
+		{
+			name: "NGINX fails to exit",
+			quitNginx: func(context.Context) error {
+				return errors.New("test")
+			},
+			method:            http.MethodGet,
+			remoteAddr:        "127.0.0.1:12345",
+			expectedCode:      http.StatusInternalServerError,
+			expectedQuitCalls: 1,
+		},
+		{
+			name: "wrong method",
+			quitNginx: func(context.Context) error {
+				return nil
+			},
+			method:            http.MethodPost,
+			remoteAddr:        "127.0.0.1:12345",
+			expectedCode:      http.StatusMethodNotAllowed,
+			expectedQuitCalls: 0,
+		},
+		{
+			name: "non-loopback address",
+			quitNginx: func(context.Context) error {
+				return nil
+			},
+			method:            http.MethodGet,
+			remoteAddr:        "10.0.0.1:12345",
+			expectedCode:      http.StatusForbidden,
+			expectedQuitCalls: 0,
+		},
+		{
+			name: "invalid address",
+			quitNginx: func(context.Context) error {
+				return nil
+			},
+			method:            http.MethodGet,
+			remoteAddr:        "invalid",
+			expectedCode:      http.StatusForbidden,
+			expectedQuitCalls: 0,
+		},
+		{
+			name:         "endpoint disabled",
+			method:       http.MethodGet,
+			remoteAddr:   "127.0.0.1:12345",
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			quitCalls := 0
+
+			var quitNginx NginxQuitter
+			if test.quitNginx != nil {
+				quitNginx = func(ctx context.Context) error {
+					quitCalls++
+					return test.quitNginx(ctx)
+				}
+			}
+
+			handler := NewHandler(ServerConfig{
+				Logger:    logr.Discard(),
+				GetToken:  getToken,
+				QuitNginx: quitNginx,
+			})
+
+			// The pre-stop endpoint doesn't need the token.
+			req := httptest.NewRequest(test.method, PreStopPath, nil)
+			req.RemoteAddr = test.remoteAddr
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			g.Expect(rec.Code).To(Equal(test.expectedCode))
+			g.Expect(quitCalls).To(Equal(test.expectedQuitCalls))
+		})
+	}
+}
+
+func TestSnapshotHandler(t *testing.T) {
+	const token = "secret-token"
+
+	getToken := func(context.Context) (string, error) {
+		return token, nil
+	}
+
+	tests := []struct {
+		getSnapshot  SnapshotGetter
+		name         string
+		method       string
+		expectedBody string
+		expectedCode int
+	}{
+		{
+			name: "snapshot exists",
+			getSnapshot: func() (interface{}, bool) {
+				return map[string]string{"ConfigHash": "abc"}, true
+			},
+			method:       http.MethodGet,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"ConfigHash": "abc"}`,
+		},
+		{
+			name: "no snapshot yet",
+			getSnapshot: func() (interface{}, bool) {
+				return nil, false
+			},
+			method:       http.MethodGet,
+			expectedCode: http.StatusServiceUnavailable,
+		},
+		{
+			name: "snapshot can't be encoded",
+			getSnapshot: func() (interface{}, bool) {
+				return make(chan struct{}), true
+			},
+			method:       http.MethodGet,
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name: "wrong method",
+			getSnapshot: func() (interface{}, bool) {
+				return map[string]string{}, true
+			},
+			method:       http.MethodPost,
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "endpoint disabled",
+			method:       http.MethodGet,
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			handler := NewHandler(ServerConfig{
+				Logger:      logr.Discard(),
+				GetToken:    getToken,
+				GetSnapshot: test.getSnapshot,
+			})
+
+			req := httptest.NewRequest(test.method, SnapshotPath, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			g.Expect(rec.Code).To(Equal(test.expectedCode))
+			if test.expectedBody != "" {
+				g.Expect(rec.Body.String()).To(MatchJSON(test.expectedBody))
+				g.Expect(rec.Header().Get("Content-Type")).To(Equal("application/json"))
+			}
+		})
+	}
+}
+
+func TestSnapshotHandlerRequiresToken(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	handler := NewHandler(ServerConfig{
+		Logger: logr.Discard(),
+		GetToken: func(context.Context) (string, error) {
+			return "secret-token", nil
+		},
+		GetSnapshot: func() (interface{}, bool) {
+			return map[string]string{}, true
+		},
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, SnapshotPath, nil))
+
+	g.Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+}
+
+func TestApplySnapshotHandler(t *testing.T) {
+	const (
+		token    = "secret-token"
+		snapshot = `{"HTTPServers": []}`
+	)
+
+	getToken := func(context.Context) (string, error) {
+		return token, nil
+	}
+
+	tests := []struct {
+		applySnapshot SnapshotApplier
+		name          string
+		method        string
+		body          string
+		expectedCode  int
+	}{
+		{
+			name: "snapshot applied",
+			applySnapshot: func(_ context.Context, body []byte) error {
+				if string(body) != snapshot {
+					return errors.New("unexpected snapshot")
+				}
+				return nil
+			},
+			method:       http.MethodPost,
+			body:         snapshot,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "invalid snapshot",
+			applySnapshot: func(context.Context, []byte) error {
+				return fmt.Errorf("%w: test", ErrInvalidSnapshot)
+			},
+			method:       http.MethodPost,
+			body:         snapshot,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "failed to apply snapshot",
+			applySnapshot: func(context.Context, []byte) error {
+				return errors.New("test")
+			},
+			method:       http.MethodPost,
+			body:         snapshot,
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name: "snapshot too large",
+			applySnapshot: func(context.Context, []byte) error {
+				return nil
+			},
+			method:       http.MethodPost,
+			body:         strings.Repeat(" ", maxSnapshotSize+1),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "wrong method",
+			applySnapshot: func(context.Context, []byte) error {
+				return nil
+			},
+			method:       http.MethodGet,
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "endpoint disabled",
+			method:       http.MethodPost,
+			body:         snapshot,
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			handler := NewHandler(ServerConfig{
+				Logger:        logr.Discard(),
+				GetToken:      getToken,
+				ApplySnapshot: test.applySnapshot,
+			})
+
+			req := httptest.NewRequest(test.method, ApplySnapshotPath, strings.NewReader(test.body))
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			g.Expect(rec.Code).To(Equal(test.expectedCode))
+		})
+	}
+}
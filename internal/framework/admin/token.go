@@ -0,0 +1,32 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TokenSecretKey is the key of the admin token in the Secret.
+const TokenSecretKey = "token"
+
+// NewSecretTokenGetter creates a TokenGetter that reads the token from the TokenSecretKey of the Secret.
+// The Secret is read on every call, so that a rotated token takes effect without a restart.
+func NewSecretTokenGetter(reader client.Reader, secretNsName types.NamespacedName) TokenGetter {
+	return func(ctx context.Context) (string, error) {
+		var secret apiv1.Secret
+
+		if err := reader.Get(ctx, secretNsName, &secret); err != nil {
+			return "", fmt.Errorf("failed to get Secret %s: %w", secretNsName, err)
+		}
+
+		token, exists := secret.Data[TokenSecretKey]
+		if !exists {
+			return "", fmt.Errorf("the Secret %s doesn't have the key %q", secretNsName, TokenSecretKey)
+		}
+
+		return string(token), nil
+	}
+}
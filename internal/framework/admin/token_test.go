@@ -0,0 +1,70 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSecretTokenGetter(t *testing.T) {
+	secret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "admin",
+		},
+		Data: map[string][]byte{
+			TokenSecretKey: []byte("secret-token"),
+		},
+	}
+	secretNoToken := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "no-token",
+		},
+	}
+
+	reader := fake.NewClientBuilder().WithObjects(secret, secretNoToken).Build()
+
+	tests := []struct {
+		name          string
+		expectedToken string
+		secretNsName  types.NamespacedName
+		expectErr     bool
+	}{
+		{
+			name:          "token exists",
+			secretNsName:  types.NamespacedName{Namespace: "test", Name: "admin"},
+			expectedToken: "secret-token",
+		},
+		{
+			name:         "token key doesn't exist",
+			secretNsName: types.NamespacedName{Namespace: "test", Name: "no-token"},
+			expectErr:    true,
+		},
+		{
+			name:         "secret doesn't exist",
+			secretNsName: types.NamespacedName{Namespace: "test", Name: "missing"},
+			expectErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			token, err := NewSecretTokenGetter(reader, test.secretNsName)(context.Background())
+
+			if test.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(token).To(Equal(test.expectedToken))
+		})
+	}
+}
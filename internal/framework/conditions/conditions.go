@@ -1,6 +1,8 @@
 package conditions
 
 import (
+	"fmt"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 )
@@ -37,12 +39,12 @@ func NewDefaultGatewayClassConditions() []Condition {
 }
 
 // NewGatewayClassConflict returns a Condition that indicates that the GatewayClass is not accepted
-// due to a conflict with another GatewayClass.
-func NewGatewayClassConflict() Condition {
+// due to a conflict with another GatewayClass. winnerName is the name of the GatewayClass that is used instead.
+func NewGatewayClassConflict(winnerName string) Condition {
 	return Condition{
 		Type:    string(v1beta1.GatewayClassConditionStatusAccepted),
 		Status:  metav1.ConditionFalse,
 		Reason:  string(GatewayClassReasonGatewayClassConflict),
-		Message: GatewayClassMessageGatewayClassConflict,
+		Message: fmt.Sprintf("%s; the GatewayClass %q is used instead", GatewayClassMessageGatewayClassConflict, winnerName),
 	}
 }
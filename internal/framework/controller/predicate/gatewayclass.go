@@ -1,44 +1,52 @@
 package predicate
 
 import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 // GatewayClassPredicate implements a predicate function based on the controllerName of a GatewayClass.
-// This predicate will skip events for GatewayClasses that don't reference this controller.
+// This predicate will skip events for GatewayClasses that don't reference any of the controllers.
 type GatewayClassPredicate struct {
 	predicate.Funcs
-	ControllerName string
+	ControllerNames []string
+}
+
+// NewGatewayClassPredicate creates a GatewayClassPredicate for a single controller.
+func NewGatewayClassPredicate(controllerName string) GatewayClassPredicate {
+	return GatewayClassPredicate{ControllerNames: []string{controllerName}}
 }
 
 // Create implements default CreateEvent filter for validating a GatewayClass controllerName.
 func (gcp GatewayClassPredicate) Create(e event.CreateEvent) bool {
-	if e.Object == nil {
+	return gcp.referencesController(e.Object)
+}
+
+// Update implements default UpdateEvent filter for validating a GatewayClass controllerName.
+func (gcp GatewayClassPredicate) Update(e event.UpdateEvent) bool {
+	return gcp.referencesController(e.ObjectOld) || gcp.referencesController(e.ObjectNew)
+}
+
+// Delete implements default DeleteEvent filter for validating a GatewayClass controllerName.
+func (gcp GatewayClassPredicate) Delete(e event.DeleteEvent) bool {
+	return gcp.referencesController(e.Object)
+}
+
+// referencesController returns true if the object is a GatewayClass that references one of the controllers.
+func (gcp GatewayClassPredicate) referencesController(obj client.Object) bool {
+	if obj == nil {
 		return false
 	}
 
-	gc, ok := e.Object.(*v1beta1.GatewayClass)
+	gc, ok := obj.(*v1beta1.GatewayClass)
 	if !ok {
 		return false
 	}
 
-	return string(gc.Spec.ControllerName) == gcp.ControllerName
-}
-
-// Update implements default UpdateEvent filter for validating a GatewayClass controllerName.
-func (gcp GatewayClassPredicate) Update(e event.UpdateEvent) bool {
-	if e.ObjectOld != nil {
-		gcOld, ok := e.ObjectOld.(*v1beta1.GatewayClass)
-		if ok && string(gcOld.Spec.ControllerName) == gcp.ControllerName {
-			return true
-		}
-	}
-
-	if e.ObjectNew != nil {
-		gcNew, ok := e.ObjectNew.(*v1beta1.GatewayClass)
-		if ok && string(gcNew.Spec.ControllerName) == gcp.ControllerName {
+	for _, name := range gcp.ControllerNames {
+		if string(gc.Spec.ControllerName) == name {
 			return true
 		}
 	}
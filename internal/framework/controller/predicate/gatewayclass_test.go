@@ -11,7 +11,7 @@ import (
 func TestGatewayClassPredicate(t *testing.T) {
 	g := NewGomegaWithT(t)
 
-	p := GatewayClassPredicate{ControllerName: "nginx-ctlr"}
+	p := NewGatewayClassPredicate("nginx-ctlr")
 
 	gc := &v1beta1.GatewayClass{
 		Spec: v1beta1.GatewayClassSpec{
@@ -21,6 +21,7 @@ func TestGatewayClassPredicate(t *testing.T) {
 
 	g.Expect(p.Create(event.CreateEvent{Object: gc})).To(BeTrue())
 	g.Expect(p.Update(event.UpdateEvent{ObjectNew: gc})).To(BeTrue())
+	g.Expect(p.Delete(event.DeleteEvent{Object: gc})).To(BeTrue())
 
 	gc2 := &v1beta1.GatewayClass{
 		Spec: v1beta1.GatewayClassSpec{
@@ -31,4 +32,60 @@ func TestGatewayClassPredicate(t *testing.T) {
 	g.Expect(p.Update(event.UpdateEvent{ObjectOld: gc, ObjectNew: gc2})).To(BeTrue())
 	g.Expect(p.Update(event.UpdateEvent{ObjectOld: gc2, ObjectNew: gc})).To(BeTrue())
 	g.Expect(p.Update(event.UpdateEvent{ObjectOld: gc2, ObjectNew: gc2})).To(BeFalse())
+	g.Expect(p.Delete(event.DeleteEvent{Object: gc2})).To(BeFalse())
+}
+
+func TestGatewayClassPredicateMultipleControllers(t *testing.T) {
+	createGatewayClass := func(controllerName string) *v1beta1.GatewayClass {
+		return &v1beta1.GatewayClass{
+			Spec: v1beta1.GatewayClassSpec{
+				ControllerName: v1beta1.GatewayController(controllerName),
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		controllerName  string
+		controllerNames []string
+		expected        bool
+	}{
+		{
+			name:            "first controller",
+			controllerNames: []string{"nginx-ctlr-1", "nginx-ctlr-2"},
+			controllerName:  "nginx-ctlr-1",
+			expected:        true,
+		},
+		{
+			name:            "second controller",
+			controllerNames: []string{"nginx-ctlr-1", "nginx-ctlr-2"},
+			controllerName:  "nginx-ctlr-2",
+			expected:        true,
+		},
+		{
+			name:            "unknown controller",
+			controllerNames: []string{"nginx-ctlr-1", "nginx-ctlr-2"},
+			controllerName:  "unknown",
+			expected:        false,
+		},
+		{
+			name:            "no controllers",
+			controllerNames: nil,
+			controllerName:  "nginx-ctlr-1",
+			expected:        false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			p := GatewayClassPredicate{ControllerNames: test.controllerNames}
+			gc := createGatewayClass(test.controllerName)
+
+			g.Expect(p.Create(event.CreateEvent{Object: gc})).To(Equal(test.expected))
+			g.Expect(p.Update(event.UpdateEvent{ObjectOld: gc, ObjectNew: gc})).To(Equal(test.expected))
+			g.Expect(p.Delete(event.DeleteEvent{Object: gc})).To(Equal(test.expected))
+		})
+	}
 }
@@ -0,0 +1,66 @@
+package predicate
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// HTTPRoutePredicate implements a predicate function based on the parentRefs of an HTTPRoute.
+// This predicate will skip events for HTTPRoutes that don't reference the Gateway.
+type HTTPRoutePredicate struct {
+	predicate.Funcs
+	GatewayNamespace string
+	GatewayName      string
+}
+
+// Create implements default CreateEvent filter for validating HTTPRoute parentRefs.
+func (hrp HTTPRoutePredicate) Create(e event.CreateEvent) bool {
+	return hrp.referencesGateway(e.Object)
+}
+
+// Update implements default UpdateEvent filter for validating HTTPRoute parentRefs.
+// Both the old and the new HTTPRoute are checked, so that the events of HTTPRoutes detached from the Gateway
+// are not skipped.
+func (hrp HTTPRoutePredicate) Update(e event.UpdateEvent) bool {
+	return hrp.referencesGateway(e.ObjectOld) || hrp.referencesGateway(e.ObjectNew)
+}
+
+// Delete implements default DeleteEvent filter for validating HTTPRoute parentRefs.
+func (hrp HTTPRoutePredicate) Delete(e event.DeleteEvent) bool {
+	return hrp.referencesGateway(e.Object)
+}
+
+// referencesGateway returns true if the object is an HTTPRoute with a parentRef to the Gateway.
+func (hrp HTTPRoutePredicate) referencesGateway(obj client.Object) bool {
+	if obj == nil {
+		return false
+	}
+
+	hr, ok := obj.(*v1beta1.HTTPRoute)
+	if !ok {
+		return false
+	}
+
+	for _, ref := range hr.Spec.ParentRefs {
+		if ref.Group != nil && *ref.Group != v1beta1.GroupName {
+			continue
+		}
+		if ref.Kind != nil && *ref.Kind != "Gateway" {
+			continue
+		}
+
+		// If the namespace is not set, the Gateway is in the namespace of the HTTPRoute.
+		ns := hr.Namespace
+		if ref.Namespace != nil {
+			ns = string(*ref.Namespace)
+		}
+
+		if ns == hrp.GatewayNamespace && string(ref.Name) == hrp.GatewayName {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,117 @@
+package predicate
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
+)
+
+func TestHTTPRoutePredicate(t *testing.T) {
+	createHTTPRoute := func(parentRefs ...v1beta1.ParentReference) *v1beta1.HTTPRoute {
+		return &v1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "route",
+			},
+			Spec: v1beta1.HTTPRouteSpec{
+				CommonRouteSpec: v1beta1.CommonRouteSpec{
+					ParentRefs: parentRefs,
+				},
+			},
+		}
+	}
+
+	gatewayRef := v1beta1.ParentReference{Name: "gateway"}
+	otherGatewayRef := v1beta1.ParentReference{Name: "other-gateway"}
+
+	tests := []struct {
+		hr       *v1beta1.HTTPRoute
+		name     string
+		expected bool
+	}{
+		{
+			hr:       createHTTPRoute(gatewayRef),
+			name:     "matching parentRef",
+			expected: true,
+		},
+		{
+			hr: createHTTPRoute(v1beta1.ParentReference{
+				Namespace: helpers.GetPointer[v1beta1.Namespace]("test"),
+				Name:      "gateway",
+			}),
+			name:     "matching parentRef with namespace",
+			expected: true,
+		},
+		{
+			hr:       createHTTPRoute(),
+			name:     "no parentRefs",
+			expected: false,
+		},
+		{
+			hr:       createHTTPRoute(otherGatewayRef),
+			name:     "different parentRef",
+			expected: false,
+		},
+		{
+			hr: createHTTPRoute(v1beta1.ParentReference{
+				Namespace: helpers.GetPointer[v1beta1.Namespace]("other"),
+				Name:      "gateway",
+			}),
+			name:     "parentRef with different namespace",
+			expected: false,
+		},
+		{
+			hr: createHTTPRoute(v1beta1.ParentReference{
+				Kind: helpers.GetPointer[v1beta1.Kind]("Service"),
+				Name: "gateway",
+			}),
+			name:     "parentRef with different kind",
+			expected: false,
+		},
+		{
+			hr:       createHTTPRoute(otherGatewayRef, gatewayRef),
+			name:     "multiple parentRefs with one matching",
+			expected: true,
+		},
+	}
+
+	p := HTTPRoutePredicate{GatewayNamespace: "test", GatewayName: "gateway"}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			g.Expect(p.Create(event.CreateEvent{Object: test.hr})).To(Equal(test.expected))
+			g.Expect(p.Update(event.UpdateEvent{ObjectOld: test.hr, ObjectNew: test.hr})).To(Equal(test.expected))
+			g.Expect(p.Delete(event.DeleteEvent{Object: test.hr})).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestHTTPRoutePredicateUpdate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	p := HTTPRoutePredicate{GatewayNamespace: "test", GatewayName: "gateway"}
+
+	attached := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "route"},
+		Spec: v1beta1.HTTPRouteSpec{
+			CommonRouteSpec: v1beta1.CommonRouteSpec{
+				ParentRefs: []v1beta1.ParentReference{{Name: "gateway"}},
+			},
+		},
+	}
+	detached := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "route"},
+	}
+
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: attached, ObjectNew: detached})).To(BeTrue())
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: detached, ObjectNew: attached})).To(BeTrue())
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: detached, ObjectNew: detached})).To(BeFalse())
+	g.Expect(p.Create(event.CreateEvent{Object: &v1beta1.Gateway{}})).To(BeFalse())
+}
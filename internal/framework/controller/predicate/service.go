@@ -1,6 +1,8 @@
 package predicate
 
 import (
+	"strings"
+
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -63,3 +65,61 @@ func (ServicePortsChangedPredicate) Update(e event.UpdateEvent) bool {
 
 	return len(newPortSet) > 0
 }
+
+// nkgAnnotationPrefix is the prefix of the annotations that the Gateway cares about.
+const nkgAnnotationPrefix = "gateway.nginx.org/"
+
+// ServiceAnnotationPredicate implements an update predicate function based on the annotations of a Service.
+// This predicate will skip update events that have no change in the annotations with
+// the gateway.nginx.org/ prefix.
+type ServiceAnnotationPredicate struct {
+	predicate.Funcs
+}
+
+// Update implements default UpdateEvent filter for validating Service annotation changes.
+func (ServiceAnnotationPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil {
+		return false
+	}
+	if e.ObjectNew == nil {
+		return false
+	}
+
+	oldSvc, ok := e.ObjectOld.(*apiv1.Service)
+	if !ok {
+		return false
+	}
+
+	newSvc, ok := e.ObjectNew.(*apiv1.Service)
+	if !ok {
+		return false
+	}
+
+	oldAnnotations := nkgAnnotations(oldSvc.Annotations)
+	newAnnotations := nkgAnnotations(newSvc.Annotations)
+
+	if len(oldAnnotations) != len(newAnnotations) {
+		return true
+	}
+
+	for key, oldValue := range oldAnnotations {
+		if newValue, exists := newAnnotations[key]; !exists || newValue != oldValue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nkgAnnotations returns the annotations with the gateway.nginx.org/ prefix.
+func nkgAnnotations(annotations map[string]string) map[string]string {
+	result := make(map[string]string)
+
+	for key, value := range annotations {
+		if strings.HasPrefix(key, nkgAnnotationPrefix) {
+			result[key] = value
+		}
+	}
+
+	return result
+}
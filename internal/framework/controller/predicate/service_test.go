@@ -5,6 +5,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -246,3 +247,133 @@ func TestServicePortsChangedPredicate(t *testing.T) {
 	g.Expect(p.Create(event.CreateEvent{Object: &v1.Service{}})).To(BeTrue())
 	g.Expect(p.Generic(event.GenericEvent{Object: &v1.Service{}})).To(BeTrue())
 }
+
+func TestServiceAnnotationPredicate_Update(t *testing.T) {
+	createService := func(annotations map[string]string) *v1.Service {
+		return &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: annotations,
+			},
+		}
+	}
+
+	testcases := []struct {
+		objectOld client.Object
+		objectNew client.Object
+		msg       string
+		expUpdate bool
+	}{
+		{
+			msg:       "nil objectOld",
+			objectOld: nil,
+			objectNew: &v1.Service{},
+			expUpdate: false,
+		},
+		{
+			msg:       "nil objectNew",
+			objectOld: &v1.Service{},
+			objectNew: nil,
+			expUpdate: false,
+		},
+		{
+			msg:       "non-Service objectOld",
+			objectOld: &v1.Namespace{},
+			objectNew: &v1.Service{},
+			expUpdate: false,
+		},
+		{
+			msg:       "non-Service objectNew",
+			objectOld: &v1.Service{},
+			objectNew: &v1.Namespace{},
+			expUpdate: false,
+		},
+		{
+			msg: "no annotations changed",
+			objectOld: createService(map[string]string{
+				"gateway.nginx.org/test": "value",
+				"other":                  "value",
+			}),
+			objectNew: createService(map[string]string{
+				"gateway.nginx.org/test": "value",
+				"other":                  "value",
+			}),
+			expUpdate: false,
+		},
+		{
+			msg: "irrelevant annotation changed",
+			objectOld: createService(map[string]string{
+				"gateway.nginx.org/test": "value",
+				"other":                  "value",
+			}),
+			objectNew: createService(map[string]string{
+				"gateway.nginx.org/test": "value",
+				"other":                  "changed",
+				"example.com/test":       "value",
+			}),
+			expUpdate: false,
+		},
+		{
+			msg:       "relevant annotation added",
+			objectOld: createService(nil),
+			objectNew: createService(map[string]string{
+				"gateway.nginx.org/test": "value",
+			}),
+			expUpdate: true,
+		},
+		{
+			msg: "relevant annotation changed",
+			objectOld: createService(map[string]string{
+				"gateway.nginx.org/test": "value",
+			}),
+			objectNew: createService(map[string]string{
+				"gateway.nginx.org/test": "changed",
+			}),
+			expUpdate: true,
+		},
+		{
+			msg: "relevant annotation deleted",
+			objectOld: createService(map[string]string{
+				"gateway.nginx.org/test": "value",
+				"other":                  "value",
+			}),
+			objectNew: createService(map[string]string{
+				"other": "value",
+			}),
+			expUpdate: true,
+		},
+		{
+			msg: "relevant annotation replaced",
+			objectOld: createService(map[string]string{
+				"gateway.nginx.org/test": "value",
+			}),
+			objectNew: createService(map[string]string{
+				"gateway.nginx.org/other": "value",
+			}),
+			expUpdate: true,
+		},
+	}
+
+	p := ServiceAnnotationPredicate{}
+
+	for _, tc := range testcases {
+		t.Run(tc.msg, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			update := p.Update(event.UpdateEvent{
+				ObjectOld: tc.objectOld,
+				ObjectNew: tc.objectNew,
+			})
+
+			g.Expect(update).To(Equal(tc.expUpdate))
+		})
+	}
+}
+
+func TestServiceAnnotationPredicate(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	p := ServiceAnnotationPredicate{}
+
+	g.Expect(p.Delete(event.DeleteEvent{Object: &v1.Service{}})).To(BeTrue())
+	g.Expect(p.Create(event.CreateEvent{Object: &v1.Service{}})).To(BeTrue())
+	g.Expect(p.Generic(event.GenericEvent{Object: &v1.Service{}})).To(BeTrue())
+}
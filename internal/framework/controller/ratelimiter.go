@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+)
+
+const (
+	// retryBaseDelay and retryMaxDelay are the delays of the per-object exponential backoff of
+	// the default rate limiter of controller-runtime.
+	retryBaseDelay = 5 * time.Millisecond
+	retryMaxDelay  = 1000 * time.Second
+)
+
+// NewRateLimiter creates a rate limiter for a controller, which limits the overall rate of reconciles to
+// qps per second with bursts of up to burst reconciles. Like the default rate limiter of controller-runtime,
+// it also backs off exponentially the reconciles of an object that fail.
+func NewRateLimiter(qps, burst int) ratelimiter.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(retryBaseDelay, retryMaxDelay),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(qps), burst)},
+	)
+}
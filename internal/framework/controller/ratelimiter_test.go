@@ -0,0 +1,49 @@
+package controller_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/controller"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	const (
+		qps    = 10
+		burst  = 5
+		events = 100
+	)
+
+	rateLimiter := controller.NewRateLimiter(qps, burst)
+
+	var lastDelay time.Duration
+	for i := 0; i < events; i++ {
+		lastDelay = rateLimiter.When(fmt.Sprintf("object-%d", i))
+	}
+
+	// The first burst events are processed right away, the rest at the rate of qps per second.
+	// Allow some slack for the time that passes while the events are added.
+	minDelay := time.Duration(events-burst) * time.Second / qps
+	g.Expect(lastDelay).To(BeNumerically(">=", minDelay-100*time.Millisecond))
+	g.Expect(lastDelay).To(BeNumerically("<=", minDelay))
+}
+
+func TestNewRateLimiterBacksOffFailedObject(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	rateLimiter := controller.NewRateLimiter(1000, 1000)
+
+	first := rateLimiter.When("object")
+	second := rateLimiter.When("object")
+
+	g.Expect(second).To(BeNumerically(">", first))
+	g.Expect(rateLimiter.NumRequeues("object")).To(Equal(2))
+
+	rateLimiter.Forget("object")
+	g.Expect(rateLimiter.NumRequeues("object")).To(BeZero())
+}
@@ -7,8 +7,10 @@ import (
 
 	ctlr "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/controller/index"
 )
@@ -19,10 +21,12 @@ const (
 )
 
 type config struct {
-	namespacedNameFilter NamespacedNameFilterFunc
-	k8sPredicate         predicate.Predicate
-	fieldIndices         index.FieldIndices
-	newReconciler        NewReconcilerFunc
+	namespacedNameFilter    NamespacedNameFilterFunc
+	k8sPredicate            predicate.Predicate
+	fieldIndices            index.FieldIndices
+	newReconciler           NewReconcilerFunc
+	rateLimiter             ratelimiter.RateLimiter
+	maxConcurrentReconciles int
 }
 
 // NewReconcilerFunc defines a function that creates a new Reconciler. Used for unit-testing.
@@ -52,6 +56,22 @@ func WithFieldIndices(fieldIndices index.FieldIndices) Option {
 	}
 }
 
+// WithRateLimiter limits how frequently the controller reconciles the objects.
+// If not set, the default rate limiter of controller-runtime is used.
+func WithRateLimiter(rateLimiter ratelimiter.RateLimiter) Option {
+	return func(cfg *config) {
+		cfg.rateLimiter = rateLimiter
+	}
+}
+
+// WithMaxConcurrentReconciles sets the maximum number of concurrent reconciles of the controller.
+// If not set, the controller reconciles one object at a time.
+func WithMaxConcurrentReconciles(n int) Option {
+	return func(cfg *config) {
+		cfg.maxConcurrentReconciles = n
+	}
+}
+
 // WithNewReconciler allows us to mock reconciler creation in the unit tests.
 func WithNewReconciler(newReconciler NewReconcilerFunc) Option {
 	return func(cfg *config) {
@@ -94,6 +114,13 @@ func Register(
 		builder = builder.WithEventFilter(cfg.k8sPredicate)
 	}
 
+	if cfg.rateLimiter != nil || cfg.maxConcurrentReconciles > 0 {
+		builder = builder.WithOptions(controller.Options{
+			RateLimiter:             cfg.rateLimiter,
+			MaxConcurrentReconciles: cfg.maxConcurrentReconciles,
+		})
+	}
+
 	recCfg := ReconcilerConfig{
 		Getter:               mgr.GetClient(),
 		ObjectType:           objectType,
@@ -118,6 +118,8 @@ func TestRegister(t *testing.T) {
 				controller.WithK8sPredicate(predicate.ServicePortsChangedPredicate{}),
 				controller.WithFieldIndices(fieldIndexes),
 				controller.WithNewReconciler(newReconciler),
+				controller.WithRateLimiter(controller.NewRateLimiter(10, 100)),
+				controller.WithMaxConcurrentReconciles(5),
 			)
 
 			if test.expectedErr == nil {
@@ -140,6 +142,12 @@ func TestRegister(t *testing.T) {
 
 			addCallCount := test.fakes.mgr.AddCallCount()
 			g.Expect(addCallCount).To(Equal(test.expectedMgrAddCallCount))
+
+			if addCallCount > 0 {
+				// The controller type of controller-runtime is internal, so its field is read through reflection.
+				ctlr := reflect.Indirect(reflect.ValueOf(test.fakes.mgr.AddArgsForCall(0)))
+				g.Expect(ctlr.FieldByName("MaxConcurrentReconciles").Int()).To(BeEquivalentTo(5))
+			}
 		})
 	}
 }
@@ -0,0 +1,39 @@
+package events
+
+import (
+	"errors"
+	"net"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// IsTransient returns true if the error is likely to go away if the operation is retried later. For example,
+// the errors of the requests to the API server when it is overloaded or unavailable are transient.
+// The error can wrap or join other errors: it is transient if any of them is transient.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if apierrors.IsServerTimeout(err) || apierrors.IsTooManyRequests(err) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	// errors.As and the apierrors functions only find the first matching error, so we check the joined errors
+	// one by one.
+	var joined interface{ Unwrap() []error }
+	if errors.As(err, &joined) {
+		for _, e := range joined.Unwrap() {
+			if IsTransient(e) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
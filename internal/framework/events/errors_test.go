@@ -0,0 +1,85 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsTransient(t *testing.T) {
+	gr := schema.GroupResource{Group: "gateway.networking.k8s.io", Resource: "httproutes"}
+
+	tests := []struct {
+		err      error
+		name     string
+		expected bool
+	}{
+		{
+			name:     "nil",
+			err:      nil,
+			expected: false,
+		},
+		{
+			name:     "server timeout",
+			err:      apierrors.NewServerTimeout(gr, "update", 1),
+			expected: true,
+		},
+		{
+			name:     "too many requests",
+			err:      apierrors.NewTooManyRequests("try again later", 1),
+			expected: true,
+		},
+		{
+			name:     "network error",
+			err:      &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			expected: true,
+		},
+		{
+			name:     "wrapped transient error",
+			err:      fmt.Errorf("failed to update status: %w", apierrors.NewTooManyRequests("try again later", 1)),
+			expected: true,
+		},
+		{
+			name: "joined permanent and transient errors",
+			err: errors.Join(
+				apierrors.NewConflict(gr, "route", errors.New("conflict")),
+				apierrors.NewServerTimeout(gr, "update", 1),
+			),
+			expected: true,
+		},
+		{
+			name:     "not found",
+			err:      apierrors.NewNotFound(gr, "route"),
+			expected: false,
+		},
+		{
+			name:     "invalid",
+			err:      apierrors.NewBadRequest("invalid"),
+			expected: false,
+		},
+		{
+			name:     "context canceled",
+			err:      context.Canceled,
+			expected: false,
+		},
+		{
+			name:     "generic error",
+			err:      errors.New("test"),
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			g.Expect(IsTransient(test.err)).To(Equal(test.expected))
+		})
+	}
+}
@@ -23,7 +23,9 @@ func TestEventLoop_SwapBatches(t *testing.T) {
 		"event6",
 	}
 
-	eventLoop.nextBatch = nextBatch
+	for _, e := range nextBatch {
+		eventLoop.queue.Enqueue(e)
+	}
 
 	eventLoop.swapBatches()
 
@@ -35,6 +37,10 @@ func TestEventLoop_SwapBatches(t *testing.T) {
 		t.Errorf("EventLoop.swapBatches() mismatch on current batch events (-want +got):\n%s", diff)
 	}
 
+	if l := eventLoop.queue.Len(); l != 0 {
+		t.Errorf("EventLoop.swapBatches() mismatch. Expected 0 events in the queue, got %d", l)
+	}
+
 	if l := len(eventLoop.nextBatch); l != 0 {
 		t.Errorf("EventLoop.swapBatches() mismatch. Expected 0 events in the next batch, got %d", l)
 	}
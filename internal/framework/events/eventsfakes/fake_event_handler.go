@@ -9,28 +9,40 @@ import (
 )
 
 type FakeEventHandler struct {
-	HandleEventBatchStub        func(context.Context, events.EventBatch)
+	HandleEventBatchStub        func(context.Context, events.EventBatch) error
 	handleEventBatchMutex       sync.RWMutex
 	handleEventBatchArgsForCall []struct {
 		arg1 context.Context
 		arg2 events.EventBatch
 	}
+	handleEventBatchReturns struct {
+		result1 error
+	}
+	handleEventBatchReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeEventHandler) HandleEventBatch(arg1 context.Context, arg2 events.EventBatch) {
+func (fake *FakeEventHandler) HandleEventBatch(arg1 context.Context, arg2 events.EventBatch) error {
 	fake.handleEventBatchMutex.Lock()
+	ret, specificReturn := fake.handleEventBatchReturnsOnCall[len(fake.handleEventBatchArgsForCall)]
 	fake.handleEventBatchArgsForCall = append(fake.handleEventBatchArgsForCall, struct {
 		arg1 context.Context
 		arg2 events.EventBatch
 	}{arg1, arg2})
 	stub := fake.HandleEventBatchStub
+	fakeReturns := fake.handleEventBatchReturns
 	fake.recordInvocation("HandleEventBatch", []interface{}{arg1, arg2})
 	fake.handleEventBatchMutex.Unlock()
 	if stub != nil {
-		fake.HandleEventBatchStub(arg1, arg2)
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
 	}
+	return fakeReturns.result1
 }
 
 func (fake *FakeEventHandler) HandleEventBatchCallCount() int {
@@ -39,7 +51,7 @@ func (fake *FakeEventHandler) HandleEventBatchCallCount() int {
 	return len(fake.handleEventBatchArgsForCall)
 }
 
-func (fake *FakeEventHandler) HandleEventBatchCalls(stub func(context.Context, events.EventBatch)) {
+func (fake *FakeEventHandler) HandleEventBatchCalls(stub func(context.Context, events.EventBatch) error) {
 	fake.handleEventBatchMutex.Lock()
 	defer fake.handleEventBatchMutex.Unlock()
 	fake.HandleEventBatchStub = stub
@@ -52,6 +64,29 @@ func (fake *FakeEventHandler) HandleEventBatchArgsForCall(i int) (context.Contex
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeEventHandler) HandleEventBatchReturns(result1 error) {
+	fake.handleEventBatchMutex.Lock()
+	defer fake.handleEventBatchMutex.Unlock()
+	fake.HandleEventBatchStub = nil
+	fake.handleEventBatchReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeEventHandler) HandleEventBatchReturnsOnCall(i int, result1 error) {
+	fake.handleEventBatchMutex.Lock()
+	defer fake.handleEventBatchMutex.Unlock()
+	fake.HandleEventBatchStub = nil
+	if fake.handleEventBatchReturnsOnCall == nil {
+		fake.handleEventBatchReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.handleEventBatchReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeEventHandler) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -42,24 +42,33 @@ type FirstEventBatchPreparerImpl struct {
 }
 
 // NewFirstEventBatchPreparerImpl creates a new FirstEventBatchPreparerImpl.
-// objects and objectList specify which resources will be included in the first batch.
-// For each object from objects, FirstEventBatchPreparerImpl will get the corresponding resource from the reader.
-// The object must specify its namespace (if any) and name.
-// For each list from objectLists, FirstEventBatchPreparerImpl will list the resources of the corresponding type from
-// the reader.
-func NewFirstEventBatchPreparerImpl(
-	reader Reader,
-	objects []client.Object,
-	objectLists []client.ObjectList,
-) *FirstEventBatchPreparerImpl {
+// The resources to include in the first batch are registered with RegisterType.
+func NewFirstEventBatchPreparerImpl(reader Reader) *FirstEventBatchPreparerImpl {
 	return &FirstEventBatchPreparerImpl{
 		reader:       reader,
-		objects:      objects,
-		objectLists:  objectLists,
 		eachListItem: meta.EachListItem,
 	}
 }
 
+// RegisterType registers a resource type to be included in the first batch. Either obj or list can be nil.
+// If obj is not nil, FirstEventBatchPreparerImpl will get the corresponding resource from the reader.
+// The object must specify its namespace (if any) and name.
+// If list is not nil, FirstEventBatchPreparerImpl will list the resources of the corresponding type from the reader.
+// RegisterType returns the FirstEventBatchPreparerImpl, so that the calls can be chained.
+func (p *FirstEventBatchPreparerImpl) RegisterType(
+	obj client.Object,
+	list client.ObjectList,
+) *FirstEventBatchPreparerImpl {
+	if obj != nil {
+		p.objects = append(p.objects, obj)
+	}
+	if list != nil {
+		p.objectLists = append(p.objectLists, list)
+	}
+
+	return p
+}
+
 // SetEachListItem sets the EachListItemFunc function.
 // Used for unit testing.
 func (p *FirstEventBatchPreparerImpl) SetEachListItem(eachListItem EachListItemFunc) {
@@ -30,12 +30,9 @@ var _ = Describe("FirstEventBatchPreparer", func() {
 
 	BeforeEach(func() {
 		fakeReader = &eventsfakes.FakeReader{}
-		preparer = events.NewFirstEventBatchPreparerImpl(
-			fakeReader,
-			[]client.Object{&v1beta1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: gcName}}},
-			[]client.ObjectList{
-				&v1beta1.HTTPRouteList{},
-			})
+		preparer = events.NewFirstEventBatchPreparerImpl(fakeReader).
+			RegisterType(&v1beta1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: gcName}}, nil).
+			RegisterType(nil, &v1beta1.HTTPRouteList{})
 	})
 
 	Describe("Normal cases", func() {
@@ -101,6 +98,59 @@ var _ = Describe("FirstEventBatchPreparer", func() {
 		})
 	})
 
+	Describe("Registering types", func() {
+		It("should prepare events for the object and the list registered together", func() {
+			preparer = events.NewFirstEventBatchPreparerImpl(fakeReader).
+				RegisterType(
+					&v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}},
+					&v1beta1.HTTPRouteList{},
+				)
+
+			gateway := v1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"}}
+			httpRoute := v1beta1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "route"}}
+
+			fakeReader.GetCalls(
+				func(ctx context.Context, name types.NamespacedName, object client.Object, opts ...client.GetOption) error {
+					Expect(name).Should(Equal(types.NamespacedName{Namespace: "test", Name: "gateway"}))
+					Expect(object).Should(BeAssignableToTypeOf(&v1beta1.Gateway{}))
+
+					reflect.Indirect(reflect.ValueOf(object)).Set(reflect.Indirect(reflect.ValueOf(&gateway)))
+					return nil
+				},
+			)
+			fakeReader.ListCalls(func(ctx context.Context, list client.ObjectList, option ...client.ListOption) error {
+				typedList, ok := list.(*v1beta1.HTTPRouteList)
+				Expect(ok).To(BeTrue())
+				typedList.Items = append(typedList.Items, httpRoute)
+
+				return nil
+			})
+
+			expectedBatch := events.EventBatch{
+				&events.UpsertEvent{Resource: &gateway},
+				&events.UpsertEvent{Resource: &httpRoute},
+			}
+
+			batch, err := preparer.Prepare(context.Background())
+
+			Expect(batch).Should(Equal(expectedBatch))
+			Expect(err).Should(BeNil())
+			Expect(fakeReader.GetCallCount()).Should(Equal(1))
+			Expect(fakeReader.ListCallCount()).Should(Equal(1))
+		})
+
+		It("should ignore nil object and list", func() {
+			preparer = events.NewFirstEventBatchPreparerImpl(fakeReader).RegisterType(nil, nil)
+
+			batch, err := preparer.Prepare(context.Background())
+
+			Expect(batch).Should(BeEmpty())
+			Expect(err).Should(BeNil())
+			Expect(fakeReader.GetCallCount()).Should(BeZero())
+			Expect(fakeReader.ListCallCount()).Should(BeZero())
+		})
+	})
+
 	Describe("Edge cases", func() {
 		Describe("EachListItem cases", func() {
 			BeforeEach(func() {
@@ -10,5 +10,6 @@ import (
 type EventHandler interface {
 	// HandleEventBatch handles a batch of events.
 	// EventBatch can include duplicated events.
-	HandleEventBatch(ctx context.Context, batch EventBatch)
+	// If the returned error is transient (see IsTransient), the events of the batch are handled again later.
+	HandleEventBatch(ctx context.Context, batch EventBatch) error
 }
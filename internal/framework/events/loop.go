@@ -3,10 +3,16 @@ package events
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/client-go/util/workqueue"
 )
 
+// retryKey is the key of the failed batches in the retry rate limiter. All batches share the key, so that
+// the backoff grows with every consecutive failure, no matter the events in the batches.
+const retryKey = "batch"
+
 // EventLoop is the main event loop of the Gateway. It handles events coming through the event channel.
 //
 // When a new event comes, there are two cases:
@@ -22,16 +28,26 @@ import (
 // FIXME(pleshakov): better document the side effects and how to prevent and mitigate them.
 // So when the EventLoop have 100 saved events, it is better to process them at once rather than one by one.
 // https://github.com/nginxinc/nginx-kubernetes-gateway/issues/551
+//
+// If handling a batch fails with a transient error, the events of the batch are handled again after an exponential
+// backoff. The events that come in the meantime are handled after them, so that they override the retried events.
 type EventLoop struct {
 	handler  EventHandler
 	preparer FirstEventBatchPreparer
 	eventCh  <-chan interface{}
 	logger   logr.Logger
 
+	// queue holds the events that come while the current batch is being handled. It orders the events so that
+	// the deletions of HTTPRoutes and Gateways are handled first.
+	queue *PriorityEventQueue
+
+	// retryRateLimiter calculates the backoff before retrying a batch that failed with a transient error.
+	retryRateLimiter workqueue.RateLimiter
+
 	// The EventLoop uses double buffering to handle event batch processing.
 	// The goroutine that handles the batch will always read from the currentBatch slice.
-	// While the current batch is being handled, new events are added to the nextBatch slice.
-	// The batches are swapped before starting the handler goroutine.
+	// Before starting the handler goroutine, the queued events are drained into the nextBatch slice and
+	// the batches are swapped.
 	currentBatch EventBatch
 	nextBatch    EventBatch
 }
@@ -44,12 +60,14 @@ func NewEventLoop(
 	preparer FirstEventBatchPreparer,
 ) *EventLoop {
 	return &EventLoop{
-		eventCh:      eventCh,
-		logger:       logger,
-		handler:      handler,
-		preparer:     preparer,
-		currentBatch: make(EventBatch, 0),
-		nextBatch:    make(EventBatch, 0),
+		eventCh:          eventCh,
+		logger:           logger,
+		handler:          handler,
+		preparer:         preparer,
+		queue:            NewPriorityEventQueue(),
+		retryRateLimiter: workqueue.DefaultItemBasedRateLimiter(),
+		currentBatch:     make(EventBatch, 0),
+		nextBatch:        make(EventBatch, 0),
 	}
 }
 
@@ -58,17 +76,39 @@ func NewEventLoop(
 func (el *EventLoop) Start(ctx context.Context) error {
 	// handling tells if any batch is currently being handled.
 	var handling bool
-	// handlingDone is used to signal the completion of handling a batch.
-	handlingDone := make(chan struct{})
+	// handlingDone is used to signal the completion of handling a batch. It receives the batch if it must be retried.
+	handlingDone := make(chan EventBatch)
 
 	handleBatch := func() {
 		go func(batch EventBatch) {
 			el.logger.Info("Handling events from the batch", "total", len(batch))
 
-			el.handler.HandleEventBatch(ctx, batch)
+			err := el.handler.HandleEventBatch(ctx, batch)
+			if err == nil {
+				el.retryRateLimiter.Forget(retryKey)
+				el.logger.Info("Finished handling the batch")
+				handlingDone <- nil
+				return
+			}
+
+			if !IsTransient(err) {
+				el.retryRateLimiter.Forget(retryKey)
+				el.logger.Error(err, "Failed to handle the batch")
+				handlingDone <- nil
+				return
+			}
+
+			delay := el.retryRateLimiter.When(retryKey)
+			el.logger.Error(err, "Failed to handle the batch, will retry", "delay", delay)
+
+			// The loop doesn't start handling the next batch until the backoff is over,
+			// so that the events of the next batch are handled after the retried events.
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+			}
 
-			el.logger.Info("Finished handling the batch")
-			handlingDone <- struct{}{}
+			handlingDone <- batch
 		}(el.currentBatch)
 	}
 
@@ -111,32 +151,52 @@ func (el *EventLoop) Start(ctx context.Context) error {
 			}
 			return nil
 		case e := <-el.eventCh:
-			// Add the event to the current batch.
-			el.nextBatch = append(el.nextBatch, e)
+			// Add the event to the queue of the next batch.
+			el.queue.Enqueue(e)
 
 			el.logger.Info(
 				"added an event to the next batch",
 				"type", fmt.Sprintf("%T", e),
-				"total", len(el.nextBatch),
+				"total", el.queue.Len(),
 			)
 
 			// If no batch is currently being handled, swap batches and begin handling the batch.
 			if !handling {
 				swapAndHandleBatch()
 			}
-		case <-handlingDone:
+		case retryBatch := <-handlingDone:
 			handling = false
 
-			// If there's at least one event in the next batch, swap batches and begin handling the batch.
-			if len(el.nextBatch) > 0 {
+			// The retried events must be read before the batches are swapped, because the batch buffer is reused.
+			if retryBatch != nil {
+				el.requeue(retryBatch)
+			}
+
+			// If there's at least one event in the queue, swap batches and begin handling the batch.
+			if el.queue.Len() > 0 {
 				swapAndHandleBatch()
 			}
 		}
 	}
 }
 
-// swapBatches swaps the current and next batches.
+// swapBatches drains the queued events into the next batch and swaps the current and next batches.
 func (el *EventLoop) swapBatches() {
+	el.nextBatch = el.queue.DrainTo(el.nextBatch[:0])
 	el.currentBatch, el.nextBatch = el.nextBatch, el.currentBatch
 	el.nextBatch = el.nextBatch[:0]
 }
+
+// requeue adds the events of the batch to the queue ahead of the events that are already in the queue.
+// The events in the queue came after the batch, so they must override the events of the batch.
+func (el *EventLoop) requeue(batch EventBatch) {
+	newer := el.queue.DrainTo(nil)
+
+	for _, e := range batch {
+		el.queue.Enqueue(e)
+	}
+
+	for _, e := range newer {
+		el.queue.Enqueue(e)
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/events"
@@ -82,9 +84,10 @@ var _ = Describe("EventLoop", func() {
 
 			// The func below will pause the handler goroutine while it is processing the batch with e1 until
 			// sentSecondAndThirdEvents is closed. This way we can add e2 and e3 to the current batch in the meantime.
-			fakeHandler.HandleEventBatchCalls(func(ctx context.Context, batch events.EventBatch) {
+			fakeHandler.HandleEventBatchCalls(func(ctx context.Context, batch events.EventBatch) error {
 				close(firstHandleEventBatchCallInProgress)
 				<-sentSecondAndThirdEvents
+				return nil
 			})
 
 			e1 := "event1"
@@ -121,6 +124,72 @@ var _ = Describe("EventLoop", func() {
 		})
 	})
 
+	Describe("Retrying", func() {
+		BeforeEach(func() {
+			fakePreparer.PrepareReturns(events.EventBatch{"event0"}, nil)
+		})
+
+		AfterEach(func() {
+			cancel()
+
+			var err error
+			Eventually(errorCh).Should(Receive(&err))
+			Expect(err).To(BeNil())
+		})
+
+		It("should retry a batch that fails with a transient error", func() {
+			fakeHandler.HandleEventBatchReturnsOnCall(0, apierrors.NewTooManyRequests("test", 0))
+
+			go func() {
+				errorCh <- eventLoop.Start(ctx)
+			}()
+
+			Eventually(fakeHandler.HandleEventBatchCallCount).Should(Equal(2))
+			_, batch := fakeHandler.HandleEventBatchArgsForCall(1)
+
+			var expectedBatch events.EventBatch = []interface{}{"event0"}
+			Expect(batch).Should(Equal(expectedBatch))
+		})
+
+		It("should not retry a batch that fails with a permanent error", func() {
+			fakeHandler.HandleEventBatchReturnsOnCall(0, errors.New("test"))
+
+			go func() {
+				errorCh <- eventLoop.Start(ctx)
+			}()
+
+			Eventually(fakeHandler.HandleEventBatchCallCount).Should(Equal(1))
+			Consistently(fakeHandler.HandleEventBatchCallCount, "100ms").Should(Equal(1))
+		})
+
+		It("should handle the events that come during the backoff after the retried events", func() {
+			firstCallInProgress := make(chan struct{})
+			sentNewerEvent := make(chan struct{})
+
+			fakeHandler.HandleEventBatchCalls(func(ctx context.Context, batch events.EventBatch) error {
+				close(firstCallInProgress)
+				<-sentNewerEvent
+				return apierrors.NewServerTimeout(schema.GroupResource{}, "update", 0)
+			})
+
+			go func() {
+				errorCh <- eventLoop.Start(ctx)
+			}()
+
+			<-firstCallInProgress
+			eventCh <- "event1"
+
+			fakeHandler.HandleEventBatchCalls(nil)
+			close(sentNewerEvent)
+
+			Eventually(fakeHandler.HandleEventBatchCallCount).Should(Equal(2))
+			_, batch := fakeHandler.HandleEventBatchArgsForCall(1)
+
+			var expectedBatch events.EventBatch = []interface{}{"event0", "event1"}
+			Expect(batch).Should(Equal(expectedBatch))
+		})
+	})
+
 	Describe("Edge cases", func() {
 		It("should return error when preparer returns error without blocking", func() {
 			preparerError := errors.New("test")
@@ -0,0 +1,159 @@
+package events
+
+import (
+	"container/heap"
+	"reflect"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// eventPriority is the priority of an event. Events with a lower value are dequeued first.
+type eventPriority int
+
+const (
+	// priorityHigh is the priority of the events that remove resources which NGINX routes traffic for.
+	priorityHigh eventPriority = iota
+	// priorityNormal is the priority of all other events.
+	priorityNormal
+)
+
+// PriorityEventQueue is a queue of events that dequeues DeleteEvents for HTTPRoutes and Gateways before
+// any other events. This reduces the time window during which NGINX routes traffic for deleted resources
+// when a batch includes many events (for example, when a namespace is being deleted).
+// Events with the same priority are dequeued in the order they were enqueued.
+//
+// To preserve the outcome of the events, when a DeleteEvent is enqueued, the queue drops the UpsertEvents
+// enqueued before for the same resource: otherwise, the upsert would be dequeued after the delete and
+// the deleted resource would be considered existing.
+//
+// PriorityEventQueue is not thread safe.
+type PriorityEventQueue struct {
+	items eventHeap
+	// seq is the sequence number of the next enqueued event.
+	seq uint64
+}
+
+// NewPriorityEventQueue creates a new PriorityEventQueue.
+func NewPriorityEventQueue() *PriorityEventQueue {
+	return &PriorityEventQueue{}
+}
+
+// Enqueue adds an event to the queue.
+func (q *PriorityEventQueue) Enqueue(event interface{}) {
+	priority := priorityNormal
+
+	if e, ok := event.(*DeleteEvent); ok {
+		q.removeUpsertEvents(e)
+
+		switch e.Type.(type) {
+		case *v1beta1.HTTPRoute, *v1beta1.Gateway:
+			priority = priorityHigh
+		}
+	}
+
+	heap.Push(&q.items, &eventItem{
+		event:    event,
+		priority: priority,
+		seq:      q.seq,
+	})
+	q.seq++
+}
+
+// Dequeue removes the event with the highest priority from the queue and returns it.
+// It returns false if the queue is empty.
+func (q *PriorityEventQueue) Dequeue() (interface{}, bool) {
+	if len(q.items) == 0 {
+		return nil, false
+	}
+
+	item := heap.Pop(&q.items).(*eventItem)
+
+	return item.event, true
+}
+
+// Len returns the number of events in the queue.
+func (q *PriorityEventQueue) Len() int {
+	return len(q.items)
+}
+
+// DrainTo dequeues all events, appends them to the batch in the order of their priority and returns the batch.
+func (q *PriorityEventQueue) DrainTo(batch EventBatch) EventBatch {
+	for {
+		e, ok := q.Dequeue()
+		if !ok {
+			return batch
+		}
+
+		batch = append(batch, e)
+	}
+}
+
+func (q *PriorityEventQueue) removeUpsertEvents(deleteEvent *DeleteEvent) {
+	deletedType := reflect.TypeOf(deleteEvent.Type)
+
+	// Removing the items one by one with heap.Remove while iterating over the heap can skip items, because
+	// heap.Remove can move the last item to an index that was already checked. Instead, we filter the items
+	// and restore the heap invariants.
+	items := q.items[:0]
+
+	for _, item := range q.items {
+		e, ok := item.event.(*UpsertEvent)
+
+		if ok &&
+			reflect.TypeOf(e.Resource) == deletedType &&
+			client.ObjectKeyFromObject(e.Resource) == deleteEvent.NamespacedName {
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	// avoid memory leak
+	for i := len(items); i < len(q.items); i++ {
+		q.items[i] = nil
+	}
+
+	q.items = items
+	heap.Init(&q.items)
+}
+
+type eventItem struct {
+	event    interface{}
+	priority eventPriority
+	seq      uint64
+}
+
+// eventHeap implements heap.Interface.
+type eventHeap []*eventItem
+
+func (h eventHeap) Len() int {
+	return len(h)
+}
+
+func (h eventHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority < h[j].priority
+	}
+
+	return h[i].seq < h[j].seq
+}
+
+func (h eventHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *eventHeap) Push(x any) {
+	*h = append(*h, x.(*eventItem))
+}
+
+func (h *eventHeap) Pop() any {
+	old := *h
+	n := len(old)
+
+	item := old[n-1]
+	old[n-1] = nil // avoid memory leak
+	*h = old[:n-1]
+
+	return item
+}
@@ -0,0 +1,136 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestPriorityEventQueue(t *testing.T) {
+	hrNsName := types.NamespacedName{Namespace: "test", Name: "hr"}
+	gwNsName := types.NamespacedName{Namespace: "test", Name: "gw"}
+	svcNsName := types.NamespacedName{Namespace: "test", Name: "svc"}
+
+	upsertHR := &UpsertEvent{
+		Resource: &v1beta1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr"}},
+	}
+	upsertOtherHR := &UpsertEvent{
+		Resource: &v1beta1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "other-hr"}},
+	}
+	upsertSvc := &UpsertEvent{
+		Resource: &apiv1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "svc"}},
+	}
+	// upsertSvcNamedHR has the same namespaced name as the deleted HTTPRoute but a different type.
+	upsertSvcNamedHR := &UpsertEvent{
+		Resource: &apiv1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr"}},
+	}
+	deleteHR := &DeleteEvent{Type: &v1beta1.HTTPRoute{}, NamespacedName: hrNsName}
+	deleteGW := &DeleteEvent{Type: &v1beta1.Gateway{}, NamespacedName: gwNsName}
+	deleteOtherGW := &DeleteEvent{
+		Type:           &v1beta1.Gateway{},
+		NamespacedName: types.NamespacedName{Namespace: "test", Name: "other-gw"},
+	}
+	deleteSvc := &DeleteEvent{Type: &apiv1.Service{}, NamespacedName: svcNsName}
+
+	tests := []struct {
+		name     string
+		events   []interface{}
+		expected EventBatch
+	}{
+		{
+			name:     "empty queue",
+			events:   nil,
+			expected: EventBatch{},
+		},
+		{
+			name:     "events of the same priority are dequeued in order",
+			events:   []interface{}{"event0", upsertSvc, upsertOtherHR, "event1"},
+			expected: EventBatch{"event0", upsertSvc, upsertOtherHR, "event1"},
+		},
+		{
+			name:     "deletes of HTTPRoutes and Gateways are dequeued first",
+			events:   []interface{}{upsertSvc, "event0", upsertOtherHR, deleteHR, deleteSvc, deleteGW},
+			expected: EventBatch{deleteHR, deleteGW, "event0", upsertOtherHR, deleteSvc},
+		},
+		{
+			name:     "delete drops the preceding upserts of the same resource",
+			events:   []interface{}{upsertHR, upsertSvcNamedHR, upsertOtherHR, upsertHR, deleteHR},
+			expected: EventBatch{deleteHR, upsertSvcNamedHR, upsertOtherHR},
+		},
+		{
+			// Before the delete of the HTTPRoute, the last item of the heap is an upsert of the HTTPRoute, which
+			// moves above the already checked items when another upsert of the HTTPRoute is removed.
+			name: "delete drops the preceding upserts of the same resource with mixed priorities",
+			events: []interface{}{
+				upsertHR, upsertSvc, deleteGW, upsertHR, upsertHR, deleteOtherGW, deleteHR,
+			},
+			expected: EventBatch{deleteGW, deleteOtherGW, deleteHR, upsertSvc},
+		},
+		{
+			name:     "delete keeps the following upserts of the same resource",
+			events:   []interface{}{deleteHR, upsertHR},
+			expected: EventBatch{deleteHR, upsertHR},
+		},
+		{
+			name:     "delete of a low priority resource drops the preceding upserts of the same resource",
+			events:   []interface{}{upsertSvc, upsertOtherHR, deleteSvc},
+			expected: EventBatch{upsertOtherHR, deleteSvc},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			queue := NewPriorityEventQueue()
+
+			for _, e := range test.events {
+				queue.Enqueue(e)
+			}
+
+			result := queue.DrainTo(EventBatch{})
+
+			if diff := cmp.Diff(test.expected, result); diff != "" {
+				t.Errorf("DrainTo() mismatch (-want +got):\n%s", diff)
+			}
+
+			if l := queue.Len(); l != 0 {
+				t.Errorf("Len() mismatch. Expected 0 events in the queue after draining, got %d", l)
+			}
+		})
+	}
+}
+
+func TestPriorityEventQueue_Dequeue(t *testing.T) {
+	queue := NewPriorityEventQueue()
+
+	deleteGW := &DeleteEvent{
+		Type:           &v1beta1.Gateway{},
+		NamespacedName: types.NamespacedName{Namespace: "test", Name: "gw"},
+	}
+
+	queue.Enqueue("event0")
+	queue.Enqueue(deleteGW)
+
+	if l := queue.Len(); l != 2 {
+		t.Fatalf("Len() mismatch. Expected 2, got %d", l)
+	}
+
+	expected := []interface{}{deleteGW, "event0"}
+
+	for i, exp := range expected {
+		e, ok := queue.Dequeue()
+		if !ok {
+			t.Fatalf("Dequeue() #%d returned no event", i)
+		}
+		if diff := cmp.Diff(exp, e); diff != "" {
+			t.Errorf("Dequeue() #%d mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+
+	if _, ok := queue.Dequeue(); ok {
+		t.Errorf("Dequeue() returned an event from the empty queue")
+	}
+}
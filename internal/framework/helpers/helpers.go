@@ -22,43 +22,59 @@ func Diff(want, got any) string {
 }
 
 // GetStringPointer takes a string and returns a pointer to it.
+//
+// Deprecated: use GetPointer instead.
 func GetStringPointer(s string) *string {
-	return &s
+	return GetPointer(s)
 }
 
 // GetIntPointer takes an int and returns a pointer to it.
+//
+// Deprecated: use GetPointer instead.
 func GetIntPointer(i int) *int {
-	return &i
+	return GetPointer(i)
 }
 
 // GetInt32Pointer takes an int32 and returns a pointer to it.
+//
+// Deprecated: use GetPointer instead.
 func GetInt32Pointer(i int32) *int32 {
-	return &i
+	return GetPointer(i)
 }
 
 // GetHTTPMethodPointer takes an HTTPMethod and returns a pointer to it.
+//
+// Deprecated: use GetPointer instead.
 func GetHTTPMethodPointer(m v1beta1.HTTPMethod) *v1beta1.HTTPMethod {
-	return &m
+	return GetPointer(m)
 }
 
 // GetHeaderMatchTypePointer takes an HeaderMatchType and returns a pointer to it.
+//
+// Deprecated: use GetPointer instead.
 func GetHeaderMatchTypePointer(t v1beta1.HeaderMatchType) *v1beta1.HeaderMatchType {
-	return &t
+	return GetPointer(t)
 }
 
 // GetQueryParamMatchTypePointer takes an QueryParamMatchType and returns a pointer to it.
+//
+// Deprecated: use GetPointer instead.
 func GetQueryParamMatchTypePointer(t v1beta1.QueryParamMatchType) *v1beta1.QueryParamMatchType {
-	return &t
+	return GetPointer(t)
 }
 
 // GetTLSModePointer takes a TLSModeType and returns a pointer to it.
+//
+// Deprecated: use GetPointer instead.
 func GetTLSModePointer(t v1beta1.TLSModeType) *v1beta1.TLSModeType {
-	return &t
+	return GetPointer(t)
 }
 
 // GetBoolPointer takes a bool and returns a pointer to it.
+//
+// Deprecated: use GetPointer instead.
 func GetBoolPointer(b bool) *bool {
-	return &b
+	return GetPointer(b)
 }
 
 // GetPointer takes a value of any type and returns a pointer to it.
@@ -0,0 +1,25 @@
+package helpers_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
+)
+
+func TestGetPointer(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(helpers.GetPointer("foo")).To(Equal(helpers.GetStringPointer("foo")))
+	g.Expect(helpers.GetPointer(8080)).To(Equal(helpers.GetIntPointer(8080)))
+	g.Expect(helpers.GetPointer[int32](8080)).To(Equal(helpers.GetInt32Pointer(8080)))
+	g.Expect(helpers.GetPointer(true)).To(Equal(helpers.GetBoolPointer(true)))
+	g.Expect(helpers.GetPointer(v1beta1.HTTPMethodGet)).To(Equal(helpers.GetHTTPMethodPointer(v1beta1.HTTPMethodGet)))
+	g.Expect(helpers.GetPointer(v1beta1.HeaderMatchExact)).
+		To(Equal(helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact)))
+	g.Expect(helpers.GetPointer(v1beta1.QueryParamMatchExact)).
+		To(Equal(helpers.GetQueryParamMatchTypePointer(v1beta1.QueryParamMatchExact)))
+	g.Expect(helpers.GetPointer(v1beta1.TLSModeTerminate)).To(Equal(helpers.GetTLSModePointer(v1beta1.TLSModeTerminate)))
+}
@@ -0,0 +1,142 @@
+// Package logging contains the helpers that keep sensitive data, such as TLS private keys and bearer tokens,
+// out of the logs.
+package logging
+
+import (
+	"strings"
+
+	"github.com/go-logr/logr"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// Redacted replaces the sensitive values in the logs.
+const Redacted = "[REDACTED]"
+
+const (
+	bearerPrefix = "Bearer "
+	// lastAppliedConfigAnnotation is set by kubectl apply and includes the data of the applied Secret.
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// Redact returns a copy of the value with the sensitive data replaced with Redacted:
+// - The data of a Secret, including the copy of the data in the last-applied-configuration annotation.
+// - The token in a "Bearer <token>" string.
+// Other values are returned as is.
+func Redact(v interface{}) interface{} {
+	switch typed := v.(type) {
+	case *apiv1.Secret:
+		if typed == nil {
+			return typed
+		}
+		return redactSecret(typed)
+	case apiv1.Secret:
+		return *redactSecret(&typed)
+	case *apiv1.SecretList:
+		if typed == nil {
+			return typed
+		}
+
+		list := typed.DeepCopy()
+		for i := range list.Items {
+			list.Items[i] = *redactSecret(&list.Items[i])
+		}
+
+		return list
+	case string:
+		if strings.HasPrefix(typed, bearerPrefix) {
+			return bearerPrefix + Redacted
+		}
+		return typed
+	default:
+		return v
+	}
+}
+
+func redactSecret(secret *apiv1.Secret) *apiv1.Secret {
+	redacted := secret.DeepCopy()
+
+	for k := range redacted.Data {
+		redacted.Data[k] = []byte(Redacted)
+	}
+	for k := range redacted.StringData {
+		redacted.StringData[k] = Redacted
+	}
+	if _, exists := redacted.Annotations[lastAppliedConfigAnnotation]; exists {
+		redacted.Annotations[lastAppliedConfigAnnotation] = Redacted
+	}
+
+	return redacted
+}
+
+// NewRedactingLogger returns a logger that applies Redact to all values before passing them to the logger,
+// including the values added with WithValues.
+func NewRedactingLogger(logger logr.Logger) logr.Logger {
+	sink := logger.GetSink()
+	if sink == nil {
+		// the logger discards all messages
+		return logger
+	}
+
+	return logger.WithSink(&redactingSink{sink: sink})
+}
+
+// redactingSink is a logr.LogSink that redacts the values before passing them to the wrapped sink.
+type redactingSink struct {
+	sink logr.LogSink
+}
+
+var (
+	_ logr.LogSink          = &redactingSink{}
+	_ logr.CallDepthLogSink = &redactingSink{}
+)
+
+func (s *redactingSink) Init(info logr.RuntimeInfo) {
+	// The redactingSink adds one frame to the call stack.
+	info.CallDepth++
+	s.sink.Init(info)
+}
+
+func (s *redactingSink) Enabled(level int) bool {
+	return s.sink.Enabled(level)
+}
+
+func (s *redactingSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	s.sink.Info(level, msg, redactKeysAndValues(keysAndValues)...)
+}
+
+func (s *redactingSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	s.sink.Error(err, msg, redactKeysAndValues(keysAndValues)...)
+}
+
+func (s *redactingSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &redactingSink{sink: s.sink.WithValues(redactKeysAndValues(keysAndValues)...)}
+}
+
+func (s *redactingSink) WithName(name string) logr.LogSink {
+	return &redactingSink{sink: s.sink.WithName(name)}
+}
+
+func (s *redactingSink) WithCallDepth(depth int) logr.LogSink {
+	sink, ok := s.sink.(logr.CallDepthLogSink)
+	if !ok {
+		return s
+	}
+
+	return &redactingSink{sink: sink.WithCallDepth(depth)}
+}
+
+// redactKeysAndValues redacts the values of the key/value pairs. The keys are not redacted.
+func redactKeysAndValues(keysAndValues []interface{}) []interface{} {
+	redacted := make([]interface{}, len(keysAndValues))
+
+	for i, v := range keysAndValues {
+		if i%2 == 0 {
+			redacted[i] = v
+			continue
+		}
+
+		redacted[i] = Redact(v)
+	}
+
+	return redacted
+}
@@ -0,0 +1,145 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+func createSecret() *apiv1.Secret {
+	return &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "secret",
+			Annotations: map[string]string{
+				lastAppliedConfigAnnotation: `{"data":{"tls.key":"c2VjcmV0LWtleQ=="}}`,
+				"other":                     "value",
+			},
+		},
+		Data: map[string][]byte{
+			apiv1.TLSCertKey:       []byte("secret-cert"),
+			apiv1.TLSPrivateKeyKey: []byte("secret-key"),
+		},
+		StringData: map[string]string{
+			"token": "secret-token",
+		},
+		Type: apiv1.SecretTypeTLS,
+	}
+}
+
+func TestRedact(t *testing.T) {
+	expectedSecret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "secret",
+			Annotations: map[string]string{
+				lastAppliedConfigAnnotation: Redacted,
+				"other":                     "value",
+			},
+		},
+		Data: map[string][]byte{
+			apiv1.TLSCertKey:       []byte(Redacted),
+			apiv1.TLSPrivateKeyKey: []byte(Redacted),
+		},
+		StringData: map[string]string{
+			"token": Redacted,
+		},
+		Type: apiv1.SecretTypeTLS,
+	}
+
+	tests := []struct {
+		value    interface{}
+		expected interface{}
+		msg      string
+	}{
+		{
+			value:    createSecret(),
+			expected: expectedSecret,
+			msg:      "secret pointer",
+		},
+		{
+			value:    *createSecret(),
+			expected: *expectedSecret,
+			msg:      "secret",
+		},
+		{
+			value:    &apiv1.SecretList{Items: []apiv1.Secret{*createSecret()}},
+			expected: &apiv1.SecretList{Items: []apiv1.Secret{*expectedSecret}},
+			msg:      "secret list",
+		},
+		{
+			value:    (*apiv1.Secret)(nil),
+			expected: (*apiv1.Secret)(nil),
+			msg:      "nil secret",
+		},
+		{
+			value:    "Bearer token",
+			expected: "Bearer " + Redacted,
+			msg:      "bearer token",
+		},
+		{
+			value:    "value",
+			expected: "value",
+			msg:      "string",
+		},
+		{
+			value:    10,
+			expected: 10,
+			msg:      "other value",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(Redact(test.value)).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestRedactDoesNotModifyValue(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	secret := createSecret()
+	Redact(secret)
+
+	g.Expect(secret).To(Equal(createSecret()))
+}
+
+func TestRedactingLogger(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	logs := &bytes.Buffer{}
+	logger := NewRedactingLogger(zap.New(zap.WriteTo(logs), zap.JSONEncoder()))
+
+	logger.WithName("test").WithValues("secret", createSecret()).Info("Secret upserted")
+	logger.Info("Secret upserted", "secret", *createSecret(), "authorization", "Bearer secret-token")
+	logger.Error(errors.New("test"), "Failed to process Secret", "secret", createSecret())
+
+	g.Expect(logs.String()).To(ContainSubstring(`"name":"secret"`))
+	g.Expect(logs.String()).To(ContainSubstring(`"logger":"test"`))
+	g.Expect(logs.String()).To(ContainSubstring(`"other":"value"`))
+
+	// The Secret data is logged encoded in base64.
+	g.Expect(logs.String()).To(ContainSubstring(base64.StdEncoding.EncodeToString([]byte(Redacted))))
+
+	for _, sensitive := range []string{"secret-cert", "secret-key", "c2VjcmV0LWtleQ", "secret-token"} {
+		g.Expect(logs.String()).ToNot(ContainSubstring(sensitive))
+		g.Expect(logs.String()).ToNot(ContainSubstring(base64.StdEncoding.EncodeToString([]byte(sensitive))))
+	}
+}
+
+func TestNewRedactingLoggerWithDiscardLogger(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(func() {
+		NewRedactingLogger(logr.Discard()).Info("Secret upserted", "secret", createSecret())
+	}).ToNot(Panic())
+}
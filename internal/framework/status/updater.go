@@ -2,12 +2,17 @@ package status
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/logging"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . Updater
@@ -15,7 +20,9 @@ import (
 // Updater updates statuses of the Gateway API resources.
 type Updater interface {
 	// Update updates the statuses of the resources.
-	Update(context.Context, Statuses)
+	// It returns the errors of the failed updates joined together. The statuses of the other resources are still
+	// updated.
+	Update(context.Context, Statuses) error
 }
 
 // UpdaterConfig holds configuration parameters for Updater.
@@ -34,6 +41,8 @@ type UpdaterConfig struct {
 	PodIP string
 	// UpdateGatewayClassStatus enables updating the status of the GatewayClass resource.
 	UpdateGatewayClassStatus bool
+	// DryRun makes Updater log the status changes as JSON merge patches instead of sending them to the API server.
+	DryRun bool
 }
 
 // updaterImpl updates statuses of the Gateway API resources.
@@ -47,14 +56,13 @@ type UpdaterConfig struct {
 //
 // (3) It is synchronous, which means the status reporter can slow down the event loop.
 // Consider the following cases:
-// (a) Sometimes the Gateway will need to update statuses of all resources it handles, which could be ~1000. Making 1000
-// status API calls sequentially will take time.
+// (a) Sometimes the Gateway will need to update statuses of all resources it handles, which could be ~1000.
+// updaterImpl makes the status API calls concurrently, but the number of concurrent calls is bounded, so the calls
+// still take time.
 // (b) k8s API can become slow or even timeout. This will increase every update status API call.
 // Making updaterImpl asynchronous will prevent it from adding variable delays to the event loop.
 //
-// (4) It doesn't retry on failures. This means there is a chance that some resources will not have up-to-do statuses.
-// Statuses are important part of the Gateway API, so we need to ensure that the Gateway always keep the resources
-// statuses up-to-date.
+// (4) It doesn't retry on failures. Instead, it returns the errors, so that the caller can retry the update.
 //
 // (5) It doesn't clear the statuses of a resources that are no longer handled by the Gateway. For example, if
 // an HTTPRoute resource no longer has the parentRef to the Gateway resources, the Gateway must update the status
@@ -69,32 +77,82 @@ type UpdaterConfig struct {
 // To support new resources, updaterImpl needs to be modified. Consider making updaterImpl extendable, so that it
 // goes along the Open-closed principle.
 type updaterImpl struct {
-	cfg UpdaterConfig
+	// objectLocks maps the key of a resource (see objectKey) to the lock that serializes the updates
+	// of the resource. A lock is deleted once no update holds or waits for it, so that the map doesn't grow
+	// with every resource the updater has ever seen.
+	objectLocks map[string]*objectLock
+	// workers bounds the number of concurrent status updates across all Update calls.
+	workers chan struct{}
+	cfg     UpdaterConfig
+	// objectLocksMu protects objectLocks.
+	objectLocksMu sync.Mutex
+}
+
+// objectLock serializes the updates of a resource.
+type objectLock struct {
+	// refs is the number of the updates that hold or wait for the lock. Protected by updaterImpl.objectLocksMu.
+	refs int
+	mu   sync.Mutex
 }
 
+// maxConcurrentUpdates is the maximum number of concurrent status updates.
+const maxConcurrentUpdates = 10
+
 // NewUpdater creates a new Updater.
 func NewUpdater(cfg UpdaterConfig) Updater {
+	// The updater logs the resources when their status update fails.
+	cfg.Logger = logging.NewRedactingLogger(cfg.Logger)
+
 	return &updaterImpl{
-		cfg: cfg,
+		cfg:         cfg,
+		workers:     make(chan struct{}, maxConcurrentUpdates),
+		objectLocks: make(map[string]*objectLock),
 	}
 }
 
-func (upd *updaterImpl) Update(ctx context.Context, statuses Statuses) {
+// Update updates the statuses concurrently. The updates of the same resource, which can come from concurrent
+// Update calls, are serialized, so that they don't race with each other.
+func (upd *updaterImpl) Update(ctx context.Context, statuses Statuses) error {
 	// FIXME(pleshakov) Merge the new Conditions in the status with the existing Conditions
 	// https://github.com/nginxinc/nginx-kubernetes-gateway/issues/558
 
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	run := func(nsname types.NamespacedName, obj client.Object, statusSetter func(client.Object)) {
+		upd.workers <- struct{}{}
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-upd.workers
+				wg.Done()
+			}()
+
+			err := upd.updateSerialized(ctx, nsname, obj, statusSetter)
+
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}()
+	}
+
 	if upd.cfg.UpdateGatewayClassStatus {
 		for nsname, gcs := range statuses.GatewayClassStatuses {
-			upd.update(ctx, nsname, &v1beta1.GatewayClass{}, func(object client.Object) {
+			gcs := gcs
+			run(nsname, &v1beta1.GatewayClass{}, func(object client.Object) {
 				gc := object.(*v1beta1.GatewayClass)
 				gc.Status = prepareGatewayClassStatus(gcs, upd.cfg.Clock.Now())
-			},
-			)
+			})
 		}
 	}
 
 	for nsname, gs := range statuses.GatewayStatuses {
-		upd.update(ctx, nsname, &v1beta1.Gateway{}, func(object client.Object) {
+		gs := gs
+		run(nsname, &v1beta1.Gateway{}, func(object client.Object) {
 			gw := object.(*v1beta1.Gateway)
 			gw.Status = prepareGatewayStatus(gs, upd.cfg.PodIP, upd.cfg.Clock.Now())
 		})
@@ -103,11 +161,13 @@ func (upd *updaterImpl) Update(ctx context.Context, statuses Statuses) {
 	for nsname, rs := range statuses.HTTPRouteStatuses {
 		select {
 		case <-ctx.Done():
-			return
+			wg.Wait()
+			return errors.Join(errs...)
 		default:
 		}
 
-		upd.update(ctx, nsname, &v1beta1.HTTPRoute{}, func(object client.Object) {
+		rs := rs
+		run(nsname, &v1beta1.HTTPRoute{}, func(object client.Object) {
 			hr := object.(*v1beta1.HTTPRoute)
 			// statuses.GatewayStatus is never nil when len(statuses.HTTPRouteStatuses) > 0
 			hr.Status = prepareHTTPRouteStatus(
@@ -117,6 +177,62 @@ func (upd *updaterImpl) Update(ctx context.Context, statuses Statuses) {
 			)
 		})
 	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// updateSerialized updates the status of the resource, making sure no other update of the same resource runs
+// at the same time.
+func (upd *updaterImpl) updateSerialized(
+	ctx context.Context,
+	nsname types.NamespacedName,
+	obj client.Object,
+	statusSetter func(client.Object),
+) error {
+	key := objectKey(nsname, obj)
+
+	upd.lockObject(key)
+	defer upd.unlockObject(key)
+
+	return upd.update(ctx, nsname, obj, statusSetter)
+}
+
+// lockObject locks the resource with the key, creating the lock if no other update holds or waits for it.
+func (upd *updaterImpl) lockObject(key string) {
+	upd.objectLocksMu.Lock()
+
+	lock, exists := upd.objectLocks[key]
+	if !exists {
+		lock = &objectLock{}
+		upd.objectLocks[key] = lock
+	}
+	lock.refs++
+
+	upd.objectLocksMu.Unlock()
+
+	lock.mu.Lock()
+}
+
+// unlockObject unlocks the resource with the key and deletes the lock if no other update waits for it.
+func (upd *updaterImpl) unlockObject(key string) {
+	upd.objectLocksMu.Lock()
+	defer upd.objectLocksMu.Unlock()
+
+	lock := upd.objectLocks[key]
+	lock.mu.Unlock()
+
+	lock.refs--
+	if lock.refs == 0 {
+		delete(upd.objectLocks, key)
+	}
+}
+
+// objectKey returns the key of the resource for the objectLocks map. The key includes the type of the resource,
+// because resources of different types can have the same namespace and name.
+func objectKey(nsname types.NamespacedName, obj client.Object) string {
+	return fmt.Sprintf("%T/%s", obj, nsname)
 }
 
 func (upd *updaterImpl) update(
@@ -124,8 +240,8 @@ func (upd *updaterImpl) update(
 	nsname types.NamespacedName,
 	obj client.Object,
 	statusSetter func(client.Object),
-) {
-	// The function handles errors by reporting them in the logs.
+) error {
+	// The function reports errors in the logs and returns them.
 	// We need to get the latest version of the resource.
 	// Otherwise, the Update status API call can fail.
 	// Note: the default client uses a cache for reads, so we're not making an unnecessary API call here.
@@ -137,17 +253,45 @@ func (upd *updaterImpl) update(
 				"namespace", nsname.Namespace,
 				"name", nsname.Name,
 				"kind", obj.GetObjectKind().GroupVersionKind().Kind)
+
+			return fmt.Errorf("failed to get %T %s: %w", obj, nsname, err)
 		}
-		return
+		return nil
 	}
 
+	original := obj.DeepCopyObject().(client.Object)
+
 	statusSetter(obj)
 
+	if upd.cfg.DryRun {
+		return upd.logDryRun(nsname, original, obj)
+	}
+
 	err = upd.cfg.Client.Status().Update(ctx, obj)
 	if err != nil {
 		upd.cfg.Logger.Error(err, "Failed to update status",
 			"namespace", nsname.Namespace,
 			"name", nsname.Name,
 			"kind", obj.GetObjectKind().GroupVersionKind().Kind)
+
+		return fmt.Errorf("failed to update status of %T %s: %w", obj, nsname, err)
 	}
+
+	return nil
+}
+
+// logDryRun logs the status change of the resource as a JSON merge patch.
+func (upd *updaterImpl) logDryRun(nsname types.NamespacedName, original client.Object, obj client.Object) error {
+	patch, err := client.MergeFrom(original).Data(obj)
+	if err != nil {
+		return fmt.Errorf("failed to prepare status patch of %T %s: %w", obj, nsname, err)
+	}
+
+	upd.cfg.Logger.Info("Dry run: skipping status update",
+		"namespace", nsname.Namespace,
+		"name", nsname.Name,
+		"kind", obj.GetObjectKind().GroupVersionKind().Kind,
+		"patch", string(patch))
+
+	return nil
 }
@@ -0,0 +1,83 @@
+package status
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestObjectLocks(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	upd := NewUpdater(UpdaterConfig{}).(*updaterImpl)
+
+	const (
+		key       = "test"
+		lockCount = 50
+	)
+
+	var (
+		wg      sync.WaitGroup
+		holders int
+		maxHeld int
+	)
+
+	for i := 0; i < lockCount; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			upd.lockObject(key)
+			defer upd.unlockObject(key)
+
+			// holders and maxHeld are only accessed under the lock of the key.
+			holders++
+			if holders > maxHeld {
+				maxHeld = holders
+			}
+			holders--
+		}()
+	}
+
+	wg.Wait()
+
+	g.Expect(maxHeld).To(Equal(1))
+	g.Expect(upd.objectLocks).To(BeEmpty())
+}
+
+func TestObjectLocksKeepLockWithWaiters(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	upd := NewUpdater(UpdaterConfig{}).(*updaterImpl)
+
+	upd.lockObject("test")
+	upd.lockObject("other")
+
+	waiterDone := make(chan struct{})
+
+	go func() {
+		defer close(waiterDone)
+
+		upd.lockObject("test")
+		upd.unlockObject("test")
+	}()
+
+	g.Eventually(func() int {
+		upd.objectLocksMu.Lock()
+		defer upd.objectLocksMu.Unlock()
+
+		return upd.objectLocks["test"].refs
+	}).Should(Equal(2))
+
+	upd.unlockObject("test")
+	<-waiterDone
+
+	g.Expect(upd.objectLocks).To(HaveLen(1))
+	g.Expect(upd.objectLocks).To(HaveKey("other"))
+
+	upd.unlockObject("other")
+
+	g.Expect(upd.objectLocks).To(BeEmpty())
+}
@@ -1,7 +1,11 @@
 package status_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -11,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
@@ -166,7 +171,7 @@ var _ = Describe("Updater", func() {
 								ObservedGeneration: 1,
 								LastTransitionTime: fakeClockTime,
 								Reason:             string(staticConds.GatewayReasonGatewayConflict),
-								Message:            staticConds.GatewayMessageGatewayConflict,
+								Message:            staticConds.GatewayConflictCode + ": " + staticConds.GatewayMessageGatewayConflict,
 							},
 							{
 								Type:               string(v1beta1.GatewayConditionProgrammed),
@@ -174,7 +179,8 @@ var _ = Describe("Updater", func() {
 								ObservedGeneration: 1,
 								LastTransitionTime: fakeClockTime,
 								Reason:             string(staticConds.GatewayReasonGatewayConflict),
-								Message:            staticConds.GatewayMessageGatewayConflict,
+								Message: staticConds.GatewayConflictNotProgrammedCode + ": " +
+									staticConds.GatewayMessageGatewayConflict,
 							},
 						},
 						Addresses: []v1beta1.GatewayAddress{addr},
@@ -198,9 +204,9 @@ var _ = Describe("Updater", func() {
 								{
 									ControllerName: v1beta1.GatewayController(gatewayCtrlName),
 									ParentRef: v1beta1.ParentReference{
-										Namespace:   (*v1beta1.Namespace)(helpers.GetStringPointer("test")),
+										Namespace:   (*v1beta1.Namespace)(helpers.GetPointer("test")),
 										Name:        "gateway",
-										SectionName: (*v1beta1.SectionName)(helpers.GetStringPointer("http")),
+										SectionName: (*v1beta1.SectionName)(helpers.GetPointer("http")),
 									},
 									Conditions: status.CreateExpectedAPIConditions("Test", 5, fakeClockTime),
 								},
@@ -456,4 +462,217 @@ var _ = Describe("Updater", func() {
 			Expect(latestGc.Status).To(BeZero())
 		})
 	})
+
+	Describe("Concurrent updates", func() {
+		const (
+			resourceCount = 10
+			updateCount   = 50
+		)
+
+		var (
+			updater status.Updater
+			tracker *updateTracker
+		)
+
+		BeforeEach(func() {
+			tracker = newUpdateTracker()
+
+			scheme := runtime.NewScheme()
+			Expect(v1beta1.AddToScheme(scheme)).Should(Succeed())
+
+			trackingClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithStatusSubresource(&v1beta1.HTTPRoute{}).
+				WithInterceptorFuncs(interceptor.Funcs{SubResourceUpdate: tracker.subResourceUpdate}).
+				Build()
+
+			updater = status.NewUpdater(status.UpdaterConfig{
+				GatewayCtlrName:  gatewayCtrlName,
+				GatewayClassName: gcName,
+				Client:           trackingClient,
+				Logger:           zap.New(),
+				Clock:            fakeClock,
+			})
+
+			for i := 0; i < resourceCount; i++ {
+				hr := &v1beta1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: fmt.Sprintf("route%d", i)}}
+				Expect(trackingClient.Create(context.Background(), hr)).Should(Succeed())
+			}
+
+			client = trackingClient
+		})
+
+		It("should serialize the updates of the same resource", func() {
+			var wg sync.WaitGroup
+
+			for i := 0; i < updateCount; i++ {
+				wg.Add(1)
+
+				go func(idx int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					routeIdx := idx % resourceCount
+
+					statuses := status.Statuses{
+						HTTPRouteStatuses: status.HTTPRouteStatuses{
+							{Namespace: "test", Name: fmt.Sprintf("route%d", routeIdx)}: {
+								ObservedGeneration: int64(routeIdx + 1),
+								ParentStatuses: []status.ParentStatus{
+									{
+										GatewayNsName: types.NamespacedName{Namespace: "test", Name: "gateway"},
+										SectionName:   helpers.GetPointer[v1beta1.SectionName]("http"),
+										Conditions:    status.CreateTestConditions("Test"),
+									},
+								},
+							},
+						},
+					}
+
+					Expect(updater.Update(context.Background(), statuses)).To(Succeed())
+				}(i)
+			}
+
+			wg.Wait()
+
+			Expect(tracker.callsPerObject).To(HaveLen(resourceCount))
+			for key, calls := range tracker.callsPerObject {
+				Expect(calls).To(Equal(updateCount/resourceCount), key)
+			}
+			Expect(tracker.maxInFlightPerObject).To(Equal(1))
+
+			for i := 0; i < resourceCount; i++ {
+				latestHR := &v1beta1.HTTPRoute{}
+				nsname := types.NamespacedName{Namespace: "test", Name: fmt.Sprintf("route%d", i)}
+
+				Expect(client.Get(context.Background(), nsname, latestHR)).To(Succeed())
+				Expect(latestHR.Status.Parents).To(HaveLen(1))
+				Expect(latestHR.Status.Parents[0].Conditions).ToNot(BeEmpty())
+				Expect(latestHR.Status.Parents[0].Conditions[0].ObservedGeneration).To(Equal(int64(i + 1)))
+			}
+		})
+	})
+
+	Describe("Dry run", func() {
+		var (
+			updater status.Updater
+			tracker *updateTracker
+			logs    *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			tracker = newUpdateTracker()
+			logs = &bytes.Buffer{}
+
+			scheme := runtime.NewScheme()
+			Expect(v1beta1.AddToScheme(scheme)).Should(Succeed())
+
+			client = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithStatusSubresource(&v1beta1.HTTPRoute{}).
+				WithInterceptorFuncs(interceptor.Funcs{SubResourceUpdate: tracker.subResourceUpdate}).
+				Build()
+
+			updater = status.NewUpdater(status.UpdaterConfig{
+				GatewayCtlrName:  gatewayCtrlName,
+				GatewayClassName: gcName,
+				Client:           client,
+				Logger:           zap.New(zap.WriteTo(logs), zap.JSONEncoder()),
+				Clock:            fakeClock,
+				DryRun:           true,
+			})
+
+			hr := &v1beta1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "route1"}}
+			Expect(client.Create(context.Background(), hr)).Should(Succeed())
+		})
+
+		It("should log the status patch instead of updating the status", func() {
+			statuses := status.Statuses{
+				HTTPRouteStatuses: status.HTTPRouteStatuses{
+					{Namespace: "test", Name: "route1"}: {
+						ObservedGeneration: 5,
+						ParentStatuses: []status.ParentStatus{
+							{
+								GatewayNsName: types.NamespacedName{Namespace: "test", Name: "gateway"},
+								SectionName:   helpers.GetPointer[v1beta1.SectionName]("http"),
+								Conditions:    status.CreateTestConditions("Test"),
+							},
+						},
+					},
+				},
+			}
+
+			Expect(updater.Update(context.Background(), statuses)).To(Succeed())
+
+			Expect(tracker.callsPerObject).To(BeEmpty())
+
+			latestHR := &v1beta1.HTTPRoute{}
+			nsname := types.NamespacedName{Namespace: "test", Name: "route1"}
+			Expect(client.Get(context.Background(), nsname, latestHR)).To(Succeed())
+			Expect(latestHR.Status).To(BeZero())
+
+			var entry map[string]interface{}
+			Expect(json.Unmarshal(logs.Bytes(), &entry)).To(Succeed())
+			Expect(entry).To(HaveKeyWithValue("msg", "Dry run: skipping status update"))
+			Expect(entry).To(HaveKeyWithValue("namespace", "test"))
+			Expect(entry).To(HaveKeyWithValue("name", "route1"))
+			Expect(entry).To(HaveKey("patch"))
+
+			patch, ok := entry["patch"].(string)
+			Expect(ok).To(BeTrue())
+
+			var patchedHR v1beta1.HTTPRoute
+			Expect(json.Unmarshal([]byte(patch), &patchedHR)).To(Succeed())
+			Expect(patchedHR.Status.Parents).To(HaveLen(1))
+			Expect(patchedHR.Status.Parents[0].ParentRef.Name).To(Equal(v1beta1.ObjectName("gateway")))
+			Expect(patchedHR.Status.Parents[0].ControllerName).To(Equal(v1beta1.GatewayController(gatewayCtrlName)))
+			Expect(patchedHR.Status.Parents[0].Conditions).ToNot(BeEmpty())
+			Expect(patchedHR.Status.Parents[0].Conditions[0].ObservedGeneration).To(Equal(int64(5)))
+		})
+	})
 })
+
+// updateTracker records the status update API calls per resource and the maximum number of concurrent calls
+// for the same resource.
+type updateTracker struct {
+	callsPerObject       map[string]int
+	inFlightPerObject    map[string]int
+	maxInFlightPerObject int
+	mu                   sync.Mutex
+}
+
+func newUpdateTracker() *updateTracker {
+	return &updateTracker{
+		callsPerObject:    make(map[string]int),
+		inFlightPerObject: make(map[string]int),
+	}
+}
+
+func (t *updateTracker) subResourceUpdate(
+	ctx context.Context,
+	c client.Client,
+	subResourceName string,
+	obj client.Object,
+	opts ...client.SubResourceUpdateOption,
+) error {
+	key := client.ObjectKeyFromObject(obj).String()
+
+	t.mu.Lock()
+	t.callsPerObject[key]++
+	t.inFlightPerObject[key]++
+	if t.inFlightPerObject[key] > t.maxInFlightPerObject {
+		t.maxInFlightPerObject = t.inFlightPerObject[key]
+	}
+	t.mu.Unlock()
+
+	// Give a concurrent update of the same resource a chance to run.
+	time.Sleep(time.Millisecond)
+
+	defer func() {
+		t.mu.Lock()
+		t.inFlightPerObject[key]--
+		t.mu.Unlock()
+	}()
+
+	return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+}
@@ -11,8 +11,13 @@ import (
 // prepareDeployment prepares a new the static mode Deployment based on the YAML manifest.
 // It will use the specified id to set unique parts of the deployment, so it must be unique among all Deployments for
 // Gateways.
-// It will configure the Deployment to use the Gateway with the given NamespacedName.
-func prepareDeployment(depYAML []byte, id string, gwNsName types.NamespacedName) (*v1.Deployment, error) {
+// It will configure the Deployment to use the Gateway with the given NamespacedName and its GatewayClass.
+func prepareDeployment(
+	depYAML []byte,
+	id string,
+	gwNsName types.NamespacedName,
+	gcName string,
+) (*v1.Deployment, error) {
 	dep := &v1.Deployment{}
 	err := yaml.Unmarshal(depYAML, dep)
 	if err != nil {
@@ -23,7 +28,9 @@ func prepareDeployment(depYAML []byte, id string, gwNsName types.NamespacedName)
 	dep.Spec.Selector.MatchLabels["app"] = id
 	dep.Spec.Template.ObjectMeta.Labels["app"] = id
 
+	// The --gatewayclass arg overrides the one from the manifest, because the last occurrence of a flag wins.
 	extraArgs := []string{
+		"--gatewayclass=" + gcName,
 		"--gateway=" + gwNsName.String(),
 		"--update-gatewayclass-status=false",
 	}
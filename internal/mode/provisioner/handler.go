@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -14,58 +15,70 @@ import (
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/status"
 )
 
-// eventHandler ensures each Gateway for the specific GatewayClass has a corresponding Deployment
-// of NKG configured to use that specific Gateway.
+// eventHandler ensures each Gateway for the specific GatewayClasses has a corresponding Deployment
+// of NKG configured to use that specific Gateway and its GatewayClass.
 //
 // eventHandler implements events.Handler interface.
 type eventHandler struct {
-	gcName string
-	store  *store
+	store *store
 
-	// provisions maps NamespacedName of Gateway to its corresponding Deployment
-	provisions map[types.NamespacedName]*v1.Deployment
+	// gcNames holds the names of the GatewayClasses of the provisioner. The first one is used in the conditions
+	// of the conflicting GatewayClasses.
+	gcNames []string
+
+	// provisions maps NamespacedName of Gateway to its corresponding Deployment and RBAC resources
+	provisions map[types.NamespacedName]*provision
 
 	statusUpdater status.Updater
 	k8sClient     client.Client
 	logger        logr.Logger
 
 	staticModeDeploymentYAML []byte
+	staticModeRBACYAML       []byte
 
 	gatewayNextID int64
 }
 
+// provision holds the resources provisioned for a Gateway.
+type provision struct {
+	deployment *v1.Deployment
+	rbac       *rbacResources
+}
+
 func newEventHandler(
-	gcName string,
+	gcNames []string,
 	statusUpdater status.Updater,
 	k8sClient client.Client,
 	logger logr.Logger,
 	staticModeDeploymentYAML []byte,
+	staticModeRBACYAML []byte,
 ) *eventHandler {
 	return &eventHandler{
 		store:                    newStore(),
-		provisions:               make(map[types.NamespacedName]*v1.Deployment),
+		provisions:               make(map[types.NamespacedName]*provision),
 		statusUpdater:            statusUpdater,
-		gcName:                   gcName,
+		gcNames:                  gcNames,
 		k8sClient:                k8sClient,
 		logger:                   logger,
 		staticModeDeploymentYAML: staticModeDeploymentYAML,
+		staticModeRBACYAML:       staticModeRBACYAML,
 		gatewayNextID:            1,
 	}
 }
 
-func (h *eventHandler) setGatewayClassStatuses(ctx context.Context) {
+func (h *eventHandler) setGatewayClassStatuses(ctx context.Context) error {
 	statuses := status.Statuses{
 		GatewayClassStatuses: make(status.GatewayClassStatuses),
 	}
 
-	var gcExists bool
+	existingGcs := make(map[string]struct{}, len(h.gcNames))
 	for nsname, gc := range h.store.gatewayClasses {
 		var conds []conditions.Condition
-		if gc.Name == h.gcName {
-			gcExists = true
+		if h.isProvisionerGatewayClass(gc.Name) {
+			existingGcs[gc.Name] = struct{}{}
 			conds = conditions.NewDefaultGatewayClassConditions()
 		} else {
-			conds = []conditions.Condition{conditions.NewGatewayClassConflict()}
+			conds = []conditions.Condition{conditions.NewGatewayClassConflict(h.gcNames[0])}
 		}
 
 		statuses.GatewayClassStatuses[nsname] = status.GatewayClassStatus{
@@ -73,21 +86,33 @@ func (h *eventHandler) setGatewayClassStatuses(ctx context.Context) {
 			ObservedGeneration: gc.Generation,
 		}
 	}
-	if !gcExists {
-		panic(fmt.Errorf("GatewayClass %s must exist", h.gcName))
+	if _, exists := existingGcs[h.gcNames[0]]; !exists {
+		panic(fmt.Errorf("GatewayClass %s must exist", h.gcNames[0]))
+	}
+
+	// The additional GatewayClasses are optional. The Gateways of a missing one are not provisioned.
+	for _, gcName := range h.gcNames[1:] {
+		if _, exists := existingGcs[gcName]; !exists {
+			h.logger.Info("Additional GatewayClass doesn't exist", "gatewayClass", gcName)
+		}
 	}
 
-	h.statusUpdater.Update(ctx, statuses)
+	return h.statusUpdater.Update(ctx, statuses)
 }
 
 func (h *eventHandler) ensureDeploymentsMatchGateways(ctx context.Context) {
-	var gwsWithoutDeps, removedGwsWithDeps []types.NamespacedName
+	var gwsWithoutDeps, gwsWithDeps, removedGwsWithDeps []types.NamespacedName
 
 	for nsname, gw := range h.store.gateways {
-		if string(gw.Spec.GatewayClassName) != h.gcName {
+		gcName := string(gw.Spec.GatewayClassName)
+		if !h.isProvisionerGatewayClass(gcName) {
+			continue
+		}
+		if _, exists := h.store.gatewayClasses[types.NamespacedName{Name: gcName}]; !exists {
 			continue
 		}
 		if _, exist := h.provisions[nsname]; exist {
+			gwsWithDeps = append(gwsWithDeps, nsname)
 			continue
 		}
 
@@ -105,34 +130,67 @@ func (h *eventHandler) ensureDeploymentsMatchGateways(ctx context.Context) {
 	// Create new deployments
 
 	for _, nsname := range gwsWithoutDeps {
-		deployment, err := prepareDeployment(h.staticModeDeploymentYAML, h.generateDeploymentID(), nsname)
+		gcName := string(h.store.gateways[nsname].Spec.GatewayClassName)
+
+		deployment, err := prepareDeployment(h.staticModeDeploymentYAML, h.generateDeploymentID(), nsname, gcName)
 		if err != nil {
 			panic(fmt.Errorf("failed to prepare deployment: %w", err))
 		}
 
+		rbac, err := prepareRBAC(h.staticModeRBACYAML, deployment.Namespace, nsname)
+		if err != nil {
+			panic(fmt.Errorf("failed to prepare RBAC: %w", err))
+		}
+
+		deployment.Spec.Template.Spec.ServiceAccountName = rbac.serviceAccount.Name
+
+		// The ServiceAccount must exist before the Deployment, so that the Pods of the Deployment can be created.
+		for _, obj := range []client.Object{rbac.serviceAccount, rbac.clusterRoleBinding} {
+			if err := h.k8sClient.Create(ctx, obj); err != nil {
+				panic(fmt.Errorf("failed to create %T %s: %w", obj, client.ObjectKeyFromObject(obj), err))
+			}
+		}
+
 		err = h.k8sClient.Create(ctx, deployment)
 		if err != nil {
 			panic(fmt.Errorf("failed to create deployment: %w", err))
 		}
 
-		h.provisions[nsname] = deployment
+		h.provisions[nsname] = &provision{
+			deployment: deployment,
+			rbac:       rbac,
+		}
 
 		h.logger.Info("Created deployment",
 			"deployment", client.ObjectKeyFromObject(deployment),
 			"gateway", nsname,
+			"gatewayClass", gcName,
 		)
 	}
 
+	// Update outdated deployments
+
+	for _, nsname := range gwsWithDeps {
+		h.ensureDeploymentUpToDate(ctx, nsname)
+	}
+
 	// Remove unnecessary deployments
 
 	for _, nsname := range removedGwsWithDeps {
-		deployment := h.provisions[nsname]
+		p := h.provisions[nsname]
+		deployment := p.deployment
 
 		err := h.k8sClient.Delete(ctx, deployment)
 		if err != nil {
 			panic(fmt.Errorf("failed to delete deployment: %w", err))
 		}
 
+		for _, obj := range []client.Object{p.rbac.clusterRoleBinding, p.rbac.serviceAccount} {
+			if err := h.k8sClient.Delete(ctx, obj); err != nil {
+				panic(fmt.Errorf("failed to delete %T %s: %w", obj, client.ObjectKeyFromObject(obj), err))
+			}
+		}
+
 		delete(h.provisions, nsname)
 
 		h.logger.Info("Deleted deployment",
@@ -142,10 +200,70 @@ func (h *eventHandler) ensureDeploymentsMatchGateways(ctx context.Context) {
 	}
 }
 
-func (h *eventHandler) HandleEventBatch(ctx context.Context, batch events.EventBatch) {
+// ensureDeploymentUpToDate updates the Deployment of the Gateway if its spec differs from the spec generated from
+// the static mode Deployment YAML manifest. For example, the spec differs after the provisioner is upgraded to
+// a new version with a new manifest.
+func (h *eventHandler) ensureDeploymentUpToDate(ctx context.Context, gwNsName types.NamespacedName) {
+	p := h.provisions[gwNsName]
+	gcName := string(h.store.gateways[gwNsName].Spec.GatewayClassName)
+
+	expected, err := prepareDeployment(h.staticModeDeploymentYAML, p.deployment.Name, gwNsName, gcName)
+	if err != nil {
+		panic(fmt.Errorf("failed to prepare deployment: %w", err))
+	}
+
+	expected.Spec.Template.Spec.ServiceAccountName = p.rbac.serviceAccount.Name
+
+	deployment := &v1.Deployment{}
+
+	err = h.k8sClient.Get(ctx, client.ObjectKeyFromObject(p.deployment), deployment)
+	if err != nil {
+		panic(fmt.Errorf("failed to get deployment: %w", err))
+	}
+
+	// DeepDerivative ignores the fields that are unset in the expected spec, so that the fields defaulted by
+	// the API server don't cause an update.
+	if equality.Semantic.DeepDerivative(expected.Spec, deployment.Spec) {
+		return
+	}
+
+	deployment.Spec = expected.Spec
+
+	err = h.k8sClient.Update(ctx, deployment)
+	if err != nil {
+		panic(fmt.Errorf("failed to update deployment: %w", err))
+	}
+
+	p.deployment = deployment
+
+	h.logger.Info("Updated deployment",
+		"deployment", client.ObjectKeyFromObject(deployment),
+		"gateway", gwNsName,
+		"gatewayClass", gcName,
+	)
+}
+
+func (h *eventHandler) HandleEventBatch(ctx context.Context, batch events.EventBatch) error {
 	h.store.update(batch)
-	h.setGatewayClassStatuses(ctx)
+	err := h.setGatewayClassStatuses(ctx)
 	h.ensureDeploymentsMatchGateways(ctx)
+
+	if err != nil {
+		return fmt.Errorf("failed to update GatewayClass statuses: %w", err)
+	}
+
+	return nil
+}
+
+// isProvisionerGatewayClass returns true if the GatewayClass with the name belongs to the provisioner.
+func (h *eventHandler) isProvisionerGatewayClass(name string) bool {
+	for _, gcName := range h.gcNames {
+		if gcName == name {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (h *eventHandler) generateDeploymentID() string {
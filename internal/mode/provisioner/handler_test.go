@@ -3,14 +3,19 @@ package provisioner
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	v1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
@@ -34,6 +39,9 @@ var _ = Describe("handler", func() {
 
 		statusUpdater status.Updater
 		k8sclient     client.Client
+
+		// createdObjects records the objects created through k8sclient in the order of their creation.
+		createdObjects []string
 	)
 
 	BeforeEach(OncePerOrdered, func() {
@@ -41,6 +49,10 @@ var _ = Describe("handler", func() {
 
 		Expect(v1beta1.AddToScheme(scheme)).Should(Succeed())
 		Expect(v1.AddToScheme(scheme)).Should(Succeed())
+		Expect(apiv1.AddToScheme(scheme)).Should(Succeed())
+		Expect(rbacv1.AddToScheme(scheme)).Should(Succeed())
+
+		createdObjects = nil
 
 		k8sclient = fake.NewClientBuilder().
 			WithScheme(scheme).
@@ -48,6 +60,17 @@ var _ = Describe("handler", func() {
 				&v1beta1.Gateway{},
 				&v1beta1.GatewayClass{},
 			).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(
+					ctx context.Context,
+					c client.WithWatch,
+					obj client.Object,
+					opts ...client.CreateOption,
+				) error {
+					createdObjects = append(createdObjects, createdObjectKey(obj))
+					return c.Create(ctx, obj, opts...)
+				},
+			}).
 			Build()
 
 		fakeClockTime = helpers.PrepareTimeForFakeClient(metav1.Now())
@@ -143,7 +166,55 @@ var _ = Describe("handler", func() {
 		Expect(dep.Spec.Template.Spec.Containers[0].Args).To(ContainElement("static-mode"))
 		expectedGwFlag := fmt.Sprintf("--gateway=%s", gwNsName.String())
 		Expect(dep.Spec.Template.Spec.Containers[0].Args).To(ContainElement(expectedGwFlag))
+		Expect(dep.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--gatewayclass=" + gcName))
 		Expect(dep.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--update-gatewayclass-status=false"))
+
+		rbacName := fmt.Sprintf("nkg-%s-%s-sa", gwNsName.Namespace, gwNsName.Name)
+
+		Expect(dep.Spec.Template.Spec.ServiceAccountName).To(Equal(rbacName))
+
+		sa := &apiv1.ServiceAccount{}
+		err = k8sclient.Get(context.Background(), types.NamespacedName{Namespace: "nginx-gateway", Name: rbacName}, sa)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		// The provisioner doesn't create a ClusterRole for the Gateway.
+		err = k8sclient.Get(context.Background(), types.NamespacedName{Name: rbacName}, &rbacv1.ClusterRole{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		clusterRoleBinding := &rbacv1.ClusterRoleBinding{}
+		err = k8sclient.Get(context.Background(), types.NamespacedName{Name: rbacName}, clusterRoleBinding)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(clusterRoleBinding.RoleRef).To(Equal(rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     "nginx-gateway",
+		}))
+		Expect(clusterRoleBinding.Subjects).To(ConsistOf(rbacv1.Subject{
+			Kind:      rbacv1.ServiceAccountKind,
+			Namespace: "nginx-gateway",
+			Name:      rbacName,
+		}))
+
+		// The ServiceAccount must be created before the Deployment.
+		saKey := createdObjectKey(sa)
+		depKey := createdObjectKey(dep)
+		Expect(createdObjects).To(ContainElement(saKey))
+		Expect(createdObjects).To(ContainElement(depKey))
+		Expect(indexOf(createdObjects, saKey)).To(BeNumerically("<", indexOf(createdObjects, depKey)))
+	}
+
+	itShouldDeleteRBAC := func(gwNsName types.NamespacedName) {
+		rbacName := fmt.Sprintf("nkg-%s-%s-sa", gwNsName.Namespace, gwNsName.Name)
+
+		err := k8sclient.Get(
+			context.Background(),
+			types.NamespacedName{Namespace: "nginx-gateway", Name: rbacName},
+			&apiv1.ServiceAccount{},
+		)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		err = k8sclient.Get(context.Background(), types.NamespacedName{Name: rbacName}, &rbacv1.ClusterRoleBinding{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
 	}
 
 	itShouldPanicWhenUpsertingGateway := func(gwNsName types.NamespacedName) {
@@ -174,11 +245,12 @@ var _ = Describe("handler", func() {
 			}
 
 			handler = newEventHandler(
-				gcName,
+				[]string{gcName},
 				statusUpdater,
 				k8sclient,
 				zap.New(),
 				embeddedfiles.StaticModeDeploymentYAML,
+				embeddedfiles.StaticModeRBACYAML,
 			)
 		})
 
@@ -206,6 +278,32 @@ var _ = Describe("handler", func() {
 			})
 		})
 
+		When("upserting first Gateway with an outdated Deployment", func() {
+			It("should update first Deployment", func() {
+				depNsName := types.NamespacedName{
+					Namespace: "nginx-gateway",
+					Name:      "nginx-gateway-1",
+				}
+
+				// Simulate a Deployment created from an older version of the manifest.
+
+				dep := &v1.Deployment{}
+				Expect(k8sclient.Get(context.Background(), depNsName, dep)).Should(Succeed())
+
+				dep.Spec.Template.Spec.Containers[0].Image = "ghcr.io/nginxinc/nginx-kubernetes-gateway:old"
+				dep.Spec.Template.Spec.Containers[0].Args = []string{"static-mode"}
+				Expect(k8sclient.Update(context.Background(), dep)).Should(Succeed())
+
+				itShouldUpsertGateway(gwNsName1, 1)
+
+				updatedDep := &v1.Deployment{}
+				Expect(k8sclient.Get(context.Background(), depNsName, updatedDep)).Should(Succeed())
+
+				Expect(updatedDep.Spec.Template.Spec.Containers[0].Image).
+					ToNot(Equal("ghcr.io/nginxinc/nginx-kubernetes-gateway:old"))
+			})
+		})
+
 		When("deleting first Gateway", func() {
 			It("should remove first Deployment", func() {
 				batch := []interface{}{
@@ -223,6 +321,8 @@ var _ = Describe("handler", func() {
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(deps.Items).To(HaveLen(1))
 				Expect(deps.Items[0].ObjectMeta.Name).To(Equal("nginx-gateway-2"))
+
+				itShouldDeleteRBAC(gwNsName1)
 			})
 		})
 
@@ -243,6 +343,8 @@ var _ = Describe("handler", func() {
 
 				Expect(err).ShouldNot(HaveOccurred())
 				Expect(deps.Items).To(HaveLen(0))
+
+				itShouldDeleteRBAC(gwNsName2)
 			})
 		})
 
@@ -306,7 +408,8 @@ var _ = Describe("handler", func() {
 						ObservedGeneration: 0,
 						LastTransitionTime: fakeClockTime,
 						Reason:             string(conditions.GatewayClassReasonGatewayClassConflict),
-						Message:            string(conditions.GatewayClassMessageGatewayClassConflict),
+						Message: conditions.GatewayClassMessageGatewayClassConflict +
+							`; the GatewayClass "test-gc" is used instead`,
 					},
 				}
 
@@ -315,6 +418,134 @@ var _ = Describe("handler", func() {
 		})
 	})
 
+	Describe("Multiple GatewayClasses", Ordered, func() {
+		const otherGcName = "other-gc"
+
+		var fastGwNsName, slowGwNsName types.NamespacedName
+
+		BeforeAll(func() {
+			fastGwNsName = types.NamespacedName{
+				Namespace: "test-ns",
+				Name:      "fast-gw",
+			}
+			slowGwNsName = types.NamespacedName{
+				Namespace: "test-ns",
+				Name:      "slow-gw",
+			}
+
+			handler = newEventHandler(
+				[]string{gcName, otherGcName},
+				statusUpdater,
+				k8sclient,
+				zap.New(),
+				embeddedfiles.StaticModeDeploymentYAML,
+				embeddedfiles.StaticModeRBACYAML,
+			)
+		})
+
+		When("upserting both GatewayClasses", func() {
+			It("should make both GatewayClasses Accepted", func() {
+				gcs := []*v1beta1.GatewayClass{
+					{ObjectMeta: metav1.ObjectMeta{Name: gcName}},
+					{ObjectMeta: metav1.ObjectMeta{Name: otherGcName}},
+				}
+
+				batch := make([]interface{}, 0, len(gcs))
+				for _, gc := range gcs {
+					Expect(k8sclient.Create(context.Background(), gc)).Should(Succeed())
+					batch = append(batch, &events.UpsertEvent{Resource: gc})
+				}
+
+				handler.HandleEventBatch(context.Background(), batch)
+
+				for _, gc := range gcs {
+					clusterGc := &v1beta1.GatewayClass{}
+					err := k8sclient.Get(context.Background(), client.ObjectKeyFromObject(gc), clusterGc)
+					Expect(err).ShouldNot(HaveOccurred())
+
+					Expect(clusterGc.Status.Conditions).To(HaveLen(1))
+					Expect(clusterGc.Status.Conditions[0].Status).To(Equal(metav1.ConditionTrue))
+				}
+			})
+		})
+
+		When("upserting a Gateway for each GatewayClass", func() {
+			It("should create a separate Deployment for each GatewayClass", func() {
+				fastGw := createGateway(fastGwNsName)
+				slowGw := createGateway(slowGwNsName)
+				slowGw.Spec.GatewayClassName = otherGcName
+
+				batch := []interface{}{
+					&events.UpsertEvent{Resource: fastGw},
+					&events.UpsertEvent{Resource: slowGw},
+				}
+
+				handler.HandleEventBatch(context.Background(), batch)
+
+				deps := &v1.DeploymentList{}
+				Expect(k8sclient.List(context.Background(), deps)).Should(Succeed())
+				Expect(deps.Items).To(HaveLen(2))
+
+				gcArgsPerGw := make(map[string]string)
+				for _, dep := range deps.Items {
+					var gwArg, gcArg string
+					for _, arg := range dep.Spec.Template.Spec.Containers[0].Args {
+						switch {
+						case strings.HasPrefix(arg, "--gateway="):
+							gwArg = arg
+						case strings.HasPrefix(arg, "--gatewayclass="):
+							gcArg = arg
+						}
+					}
+					gcArgsPerGw[gwArg] = gcArg
+				}
+
+				Expect(gcArgsPerGw).To(Equal(map[string]string{
+					"--gateway=" + fastGwNsName.String(): "--gatewayclass=" + gcName,
+					"--gateway=" + slowGwNsName.String(): "--gatewayclass=" + otherGcName,
+				}))
+			})
+		})
+	})
+
+	Describe("Missing additional GatewayClass", func() {
+		const missingGcName = "missing-gc"
+
+		BeforeEach(func() {
+			handler = newEventHandler(
+				[]string{gcName, missingGcName},
+				statusUpdater,
+				k8sclient,
+				zap.New(),
+				embeddedfiles.StaticModeDeploymentYAML,
+				embeddedfiles.StaticModeRBACYAML,
+			)
+		})
+
+		It("should not provision the Gateways of the missing GatewayClass", func() {
+			gc := &v1beta1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: gcName}}
+			Expect(k8sclient.Create(context.Background(), gc)).Should(Succeed())
+
+			gw := createGateway(types.NamespacedName{Namespace: "test-ns", Name: "test-gw"})
+			gw.Spec.GatewayClassName = missingGcName
+
+			batch := []interface{}{
+				&events.UpsertEvent{Resource: gc},
+				&events.UpsertEvent{Resource: gw},
+			}
+
+			handle := func() {
+				Expect(handler.HandleEventBatch(context.Background(), batch)).Should(Succeed())
+			}
+
+			Expect(handle).ShouldNot(Panic())
+
+			deps := &v1.DeploymentList{}
+			Expect(k8sclient.List(context.Background(), deps)).Should(Succeed())
+			Expect(deps.Items).To(BeEmpty())
+		})
+	})
+
 	Describe("Edge cases", func() {
 		var gwNsName types.NamespacedName
 
@@ -325,11 +556,12 @@ var _ = Describe("handler", func() {
 			}
 
 			handler = newEventHandler(
-				gcName,
+				[]string{gcName},
 				statusUpdater,
 				k8sclient,
 				zap.New(),
 				embeddedfiles.StaticModeDeploymentYAML,
+				embeddedfiles.StaticModeRBACYAML,
 			)
 		})
 
@@ -437,11 +669,12 @@ var _ = Describe("handler", func() {
 		When("upserting Gateway with broken static Deployment YAML", func() {
 			It("it should panic", func() {
 				handler = newEventHandler(
-					gcName,
+					[]string{gcName},
 					statusUpdater,
 					k8sclient,
 					zap.New(),
 					[]byte("broken YAML"),
+					embeddedfiles.StaticModeRBACYAML,
 				)
 
 				itShouldUpsertGatewayClass()
@@ -450,3 +683,17 @@ var _ = Describe("handler", func() {
 		})
 	})
 })
+
+func createdObjectKey(obj client.Object) string {
+	return fmt.Sprintf("%T %s", obj, client.ObjectKeyFromObject(obj))
+}
+
+func indexOf(items []string, item string) int {
+	for i, it := range items {
+		if it == item {
+			return i
+		}
+	}
+
+	return -1
+}
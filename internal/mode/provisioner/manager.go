@@ -5,6 +5,8 @@ import (
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -22,13 +24,14 @@ import (
 
 // Config is configuration for the provisioner mode.
 type Config struct {
-	Logger           logr.Logger
-	GatewayClassName string
-	GatewayCtlrName  string
+	Logger          logr.Logger
+	GatewayCtlrName string
+	// GatewayClassNames are the names of the GatewayClasses of the provisioner. It must include at least one name.
+	GatewayClassNames []string
 }
 
 // StartManager starts a Manager for the provisioner mode, which provisions
-// a Deployment of NKG (static mode) for each Gateway of the provisioner GatewayClass.
+// a Deployment of NKG (static mode) for each Gateway of the provisioner GatewayClasses.
 //
 // The provisioner mode is introduced to allow running Gateway API conformance tests for NKG, which expects
 // an independent data plane instance being provisioned for each Gateway.
@@ -39,6 +42,8 @@ func StartManager(cfg Config) error {
 	scheme := runtime.NewScheme()
 	utilruntime.Must(gatewayv1beta1.AddToScheme(scheme))
 	utilruntime.Must(v1.AddToScheme(scheme))
+	utilruntime.Must(apiv1.AddToScheme(scheme))
+	utilruntime.Must(rbacv1.AddToScheme(scheme))
 
 	options := manager.Options{
 		Scheme: scheme,
@@ -60,7 +65,7 @@ func StartManager(cfg Config) error {
 		{
 			objectType: &gatewayv1beta1.GatewayClass{},
 			options: []controller.Option{
-				controller.WithK8sPredicate(predicate.GatewayClassPredicate{ControllerName: cfg.GatewayCtlrName}),
+				controller.WithK8sPredicate(predicate.NewGatewayClassPredicate(cfg.GatewayCtlrName)),
 			},
 		},
 		{
@@ -78,32 +83,35 @@ func StartManager(cfg Config) error {
 		}
 	}
 
-	firstBatchPreparer := events.NewFirstEventBatchPreparerImpl(
-		mgr.GetCache(),
-		[]client.Object{
-			&gatewayv1beta1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: cfg.GatewayClassName}},
-		},
-		[]client.ObjectList{
-			&gatewayv1beta1.GatewayList{},
-		},
-	)
+	firstBatchPreparer := events.NewFirstEventBatchPreparerImpl(mgr.GetCache()).
+		RegisterType(nil, &gatewayv1beta1.GatewayList{})
+	for _, gcName := range cfg.GatewayClassNames {
+		firstBatchPreparer.RegisterType(&gatewayv1beta1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: gcName}}, nil)
+	}
 
 	statusUpdater := status.NewUpdater(
 		status.UpdaterConfig{
 			Client:                   mgr.GetClient(),
 			Clock:                    status.NewRealClock(),
 			Logger:                   cfg.Logger.WithName("statusUpdater"),
-			GatewayClassName:         cfg.GatewayClassName,
+			GatewayClassName:         cfg.GatewayClassNames[0],
 			UpdateGatewayClassStatus: true,
 		},
 	)
 
+	// The zero values render the static mode Deployment manifest.
+	deploymentYAML, err := embeddedfiles.RenderDeploymentYAML(embeddedfiles.DeploymentTemplateValues{})
+	if err != nil {
+		return fmt.Errorf("cannot render static mode deployment: %w", err)
+	}
+
 	handler := newEventHandler(
-		cfg.GatewayClassName,
+		cfg.GatewayClassNames,
 		statusUpdater,
 		mgr.GetClient(),
 		cfg.Logger.WithName("eventHandler"),
-		embeddedfiles.StaticModeDeploymentYAML,
+		deploymentYAML,
+		embeddedfiles.StaticModeRBACYAML,
 	)
 
 	eventLoop := events.NewEventLoop(
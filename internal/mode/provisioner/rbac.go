@@ -0,0 +1,101 @@
+package provisioner
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	apiv1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// rbacResources holds the resources that grant the static mode Deployment of a Gateway the permissions it needs.
+type rbacResources struct {
+	serviceAccount     *apiv1.ServiceAccount
+	clusterRoleBinding *rbacv1.ClusterRoleBinding
+}
+
+// prepareRBAC prepares a ServiceAccount in the namespace for the static mode Deployment of the Gateway with the given
+// NamespacedName, and a ClusterRoleBinding that binds the ServiceAccount to the ClusterRole of the static mode RBAC
+// YAML manifest. The ClusterRole is not created by the provisioner: it must be installed from the manifest
+// beforehand, so that the provisioner only needs the permission to bind it and not the permissions of the ClusterRole
+// itself.
+//
+// The resources are named nkg-<gateway namespace>-<gateway name>-sa. The name includes the namespace of the Gateway,
+// because ClusterRoleBindings are cluster-scoped.
+func prepareRBAC(rbacYAML []byte, namespace string, gwNsName types.NamespacedName) (*rbacResources, error) {
+	clusterRoleName, err := getClusterRoleName(rbacYAML)
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("nkg-%s-%s-sa", gwNsName.Namespace, gwNsName.Name)
+
+	sa := &apiv1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Namespace: namespace,
+				Name:      name,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     clusterRoleName,
+		},
+	}
+
+	return &rbacResources{
+		serviceAccount:     sa,
+		clusterRoleBinding: clusterRoleBinding,
+	}, nil
+}
+
+// getClusterRoleName returns the name of the first ClusterRole in the multi-document YAML manifest.
+func getClusterRoleName(rbacYAML []byte) (string, error) {
+	reader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(rbacYAML)))
+
+	for {
+		doc, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return "", fmt.Errorf("failed to read RBAC manifest: %w", err)
+		}
+
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+			return "", fmt.Errorf("failed to unmarshal RBAC resource: %w", err)
+		}
+
+		if typeMeta.Kind != "ClusterRole" {
+			continue
+		}
+
+		var clusterRole rbacv1.ClusterRole
+		if err := yaml.Unmarshal(doc, &clusterRole); err != nil {
+			return "", fmt.Errorf("failed to unmarshal ClusterRole: %w", err)
+		}
+
+		return clusterRole.Name, nil
+	}
+
+	return "", errors.New("ClusterRole not found in RBAC manifest")
+}
@@ -16,12 +16,18 @@ type nginxReloadResult struct {
 }
 
 // buildStatuses builds status.Statuses from a Graph.
-func buildStatuses(graph *graph.Graph, nginxReloadRes nginxReloadResult) status.Statuses {
+// gatewayClassName is the name of the GatewayClass that NKG uses, which the statuses of the ignored
+// GatewayClasses refer to.
+func buildStatuses(graph *graph.Graph, gatewayClassName string, nginxReloadRes nginxReloadResult) status.Statuses {
 	statuses := status.Statuses{
 		HTTPRouteStatuses: make(status.HTTPRouteStatuses),
 	}
 
-	statuses.GatewayClassStatuses = buildGatewayClassStatuses(graph.GatewayClass, graph.IgnoredGatewayClasses)
+	statuses.GatewayClassStatuses = buildGatewayClassStatuses(
+		graph.GatewayClass,
+		graph.IgnoredGatewayClasses,
+		gatewayClassName,
+	)
 
 	statuses.GatewayStatuses = buildGatewayStatuses(graph.Gateway, graph.IgnoredGateways, nginxReloadRes)
 
@@ -73,6 +79,7 @@ func buildStatuses(graph *graph.Graph, nginxReloadRes nginxReloadResult) status.
 func buildGatewayClassStatuses(
 	gc *graph.GatewayClass,
 	ignoredGwClasses map[types.NamespacedName]*v1beta1.GatewayClass,
+	gatewayClassName string,
 ) status.GatewayClassStatuses {
 	statuses := make(status.GatewayClassStatuses)
 
@@ -94,7 +101,7 @@ func buildGatewayClassStatuses(
 
 	for nsname, gwClass := range ignoredGwClasses {
 		statuses[nsname] = status.GatewayClassStatus{
-			Conditions:         []conditions.Condition{conditions.NewGatewayClassConflict()},
+			Conditions:         []conditions.Condition{conditions.NewGatewayClassConflict(gatewayClassName)},
 			ObservedGeneration: gwClass.Generation,
 		}
 	}
@@ -138,7 +145,8 @@ func buildGatewayStatus(gateway *graph.Gateway, nginxReloadRes nginxReloadResult
 		var conds []conditions.Condition
 
 		if l.Valid {
-			conds = staticConds.NewDefaultListenerConditions()
+			// A valid listener can have conditions with warnings, which override the default conditions.
+			conds = append(staticConds.NewDefaultListenerConditions(), l.Conditions...)
 			validListenerCount++
 		} else {
 			conds = l.Conditions
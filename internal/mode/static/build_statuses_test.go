@@ -196,7 +196,7 @@ func TestBuildStatuses(t *testing.T) {
 	g := NewGomegaWithT(t)
 
 	var nginxReloadRes nginxReloadResult
-	result := buildStatuses(graph, nginxReloadRes)
+	result := buildStatuses(graph, "gc", nginxReloadRes)
 	g.Expect(helpers.Diff(expected, result)).To(BeEmpty())
 }
 
@@ -288,11 +288,13 @@ func TestBuildStatusesNginxErr(t *testing.T) {
 	g := NewGomegaWithT(t)
 
 	nginxReloadRes := nginxReloadResult{error: errors.New("test error")}
-	result := buildStatuses(graph, nginxReloadRes)
+	result := buildStatuses(graph, "gc", nginxReloadRes)
 	g.Expect(helpers.Diff(expected, result)).To(BeEmpty())
 }
 
 func TestBuildGatewayClassStatuses(t *testing.T) {
+	const gcName = "valid-gc"
+
 	tests := []struct {
 		gc             *graph.GatewayClass
 		ignoredClasses map[types.NamespacedName]*v1beta1.GatewayClass
@@ -319,11 +321,11 @@ func TestBuildGatewayClassStatuses(t *testing.T) {
 			},
 			expected: status.GatewayClassStatuses{
 				{Name: "ignored-1"}: {
-					Conditions:         []conditions.Condition{conditions.NewGatewayClassConflict()},
+					Conditions:         []conditions.Condition{conditions.NewGatewayClassConflict(gcName)},
 					ObservedGeneration: 1,
 				},
 				{Name: "ignored-2"}: {
-					Conditions:         []conditions.Condition{conditions.NewGatewayClassConflict()},
+					Conditions:         []conditions.Condition{conditions.NewGatewayClassConflict(gcName)},
 					ObservedGeneration: 2,
 				},
 			},
@@ -345,13 +347,57 @@ func TestBuildGatewayClassStatuses(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid gatewayclass and ignored gatewayclass of the same controller",
+			gc: &graph.GatewayClass{
+				Source: &v1beta1.GatewayClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "valid-gc",
+						Generation: 1,
+					},
+					Spec: v1beta1.GatewayClassSpec{
+						ControllerName: "my.controller",
+					},
+				},
+				Valid: true,
+			},
+			ignoredClasses: map[types.NamespacedName]*v1beta1.GatewayClass{
+				{Name: "ignored"}: {
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "ignored",
+						Generation: 3,
+					},
+					Spec: v1beta1.GatewayClassSpec{
+						ControllerName: "my.controller",
+					},
+				},
+			},
+			expected: status.GatewayClassStatuses{
+				{Name: "valid-gc"}: {
+					Conditions:         conditions.NewDefaultGatewayClassConditions(),
+					ObservedGeneration: 1,
+				},
+				{Name: "ignored"}: {
+					Conditions: []conditions.Condition{
+						{
+							Type:   string(v1beta1.GatewayClassConditionStatusAccepted),
+							Status: metav1.ConditionFalse,
+							Reason: string(conditions.GatewayClassReasonGatewayClassConflict),
+							Message: "The resource is ignored due to a conflicting GatewayClass resource; " +
+								`the GatewayClass "valid-gc" is used instead`,
+						},
+					},
+					ObservedGeneration: 3,
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			g := NewGomegaWithT(t)
 
-			result := buildGatewayClassStatuses(test.gc, test.ignoredClasses)
+			result := buildGatewayClassStatuses(test.gc, test.ignoredClasses, gcName)
 			g.Expect(helpers.Diff(test.expected, result)).To(BeEmpty())
 		})
 	}
@@ -431,6 +477,41 @@ func TestBuildGatewayStatuses(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid gateway; valid listener with a warning",
+			gateway: &graph.Gateway{
+				Source: gw,
+				Listeners: map[string]*graph.Listener{
+					"listener-valid": {
+						Valid: true,
+						Routes: map[types.NamespacedName]*graph.Route{
+							{Namespace: "test", Name: "hr-1"}: {},
+						},
+						Conditions: []conditions.Condition{
+							staticConds.NewListenerAcceptedWithWarning("warning"),
+						},
+					},
+				},
+				Valid: true,
+			},
+			expected: status.GatewayStatuses{
+				{Namespace: "test", Name: "gateway"}: {
+					Conditions: staticConds.NewDefaultGatewayConditions(),
+					ListenerStatuses: map[string]status.ListenerStatus{
+						"listener-valid": {
+							AttachedRoutes: 1,
+							Conditions: []conditions.Condition{
+								staticConds.NewListenerProgrammed(),
+								staticConds.NewListenerResolvedRefs(),
+								staticConds.NewListenerNoConflicts(),
+								staticConds.NewListenerAcceptedWithWarning("warning"),
+							},
+						},
+					},
+					ObservedGeneration: 2,
+				},
+			},
+		},
 		{
 			name: "valid gateway; some valid listeners",
 			gateway: &graph.Gateway{
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"time"
+
 	"github.com/go-logr/logr"
+	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -11,10 +14,50 @@ type Config struct {
 	// GatewayNsName is the namespaced name of a Gateway resource that the Gateway will use.
 	// The Gateway will ignore all other Gateway resources.
 	GatewayNsName *types.NamespacedName
+	// NginxConfigMapNsName is the namespaced name of the ConfigMap with the NGINX configuration snippets.
+	// If nil, no snippets are added to the NGINX configuration.
+	NginxConfigMapNsName *types.NamespacedName
+	// AdminSecretNsName is the namespaced name of the Secret with the token of the admin server.
+	// If nil, the admin server only serves the pre-stop endpoint on the loopback interface.
+	AdminSecretNsName *types.NamespacedName
+	// LogLevel is the level of the Logger. The admin server changes it at runtime.
+	LogLevel zap.AtomicLevel
 	// GatewayClassName is the name of the GatewayClass resource that the Gateway will use.
 	GatewayClassName string
 	// PodIP is the IP address of this Pod.
 	PodIP string
+	// NodeName is the name of the node of this Pod. If empty, the endpoints of the Services with the Local external
+	// traffic policy are not limited to this node.
+	NodeName string
+	// HealthProbeBindAddress is the address that the health probe endpoints (/healthz and /readyz) bind to.
+	// If empty, the health probe endpoints are disabled.
+	HealthProbeBindAddress string
+	// AdminBindAddress is the address that the admin server binds to.
+	AdminBindAddress string
+	// MetricsBindAddress is the address that the Prometheus metrics endpoint binds to.
+	// If empty, the metrics endpoint and the scraping of the NGINX metrics are disabled.
+	MetricsBindAddress string
+	// NginxReloadTimeout is the time to wait for NGINX to start new worker processes after a reload.
+	NginxReloadTimeout time.Duration
+	// NginxStatusScrapeInterval is the interval between two scrapes of the NGINX stub_status endpoint.
+	NginxStatusScrapeInterval time.Duration
+	// NginxStatusPort is the port of the NGINX stub_status endpoint on 127.0.0.1.
+	NginxStatusPort int
+	// MaxConcurrentReconciles is the maximum number of concurrent reconciles of every controller.
+	MaxConcurrentReconciles int
+	// PprofPort is the port of the pprof endpoint. It is used only if PprofEnabled is true.
+	PprofPort int
+	// ReconcileRateLimitQPS is the maximum number of reconciles per second of every controller.
+	ReconcileRateLimitQPS int
+	// ReconcileRateLimitBurst is the maximum number of reconciles of every controller that can exceed
+	// ReconcileRateLimitQPS at once.
+	ReconcileRateLimitBurst int
 	// UpdateGatewayClassStatus enables updating the status of the GatewayClass resource.
 	UpdateGatewayClassStatus bool
+	// PprofEnabled enables the pprof endpoint, which serves runtime profiling data.
+	PprofEnabled bool
+	// EnableSnippets allows the annotations of the Gateway API resources that add NGINX configuration snippets.
+	EnableSnippets bool
+	// StatusDryRun makes the status updater log the status changes instead of writing them to the API server.
+	StatusDryRun bool
 }
@@ -1,12 +1,25 @@
 package static
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/admin"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/events"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/logging"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/status"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/file"
@@ -14,6 +27,7 @@ import (
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/resolver"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/validation"
 )
 
 // eventHandlerConfig holds configuration parameters for eventHandlerImpl.
@@ -30,8 +44,14 @@ type eventHandlerConfig struct {
 	nginxRuntimeMgr runtime.Manager
 	// statusUpdater updates statuses on Kubernetes resources.
 	statusUpdater status.Updater
+	// httpFieldsValidator validates the HTTPRoute fields of the applied snapshots.
+	httpFieldsValidator validation.HTTPFieldsValidator
 	// logger is the logger to be used by the EventHandler.
 	logger logr.Logger
+	// gatewayClassName is the name of the GatewayClass that NKG uses.
+	gatewayClassName string
+	// enableSnippets allows the snippets in the applied snapshots.
+	enableSnippets bool
 }
 
 // eventHandlerImpl implements EventHandler.
@@ -40,21 +60,47 @@ type eventHandlerConfig struct {
 // (2) Keeping the statuses of the Gateway API resources updated.
 type eventHandlerImpl struct {
 	cfg eventHandlerConfig
+	// nginxLock serializes the updates of NGINX by the event batches and by the applied snapshots.
+	// It also protects latestConfigHash and latestKeyPairs.
+	nginxLock sync.Mutex
+	// latestKeyPairs are the SSL key pairs of the latest configuration that was successfully applied to NGINX.
+	// The snapshots don't include the private keys, so an applied snapshot reuses them.
+	latestKeyPairs map[dataplane.SSLKeyPairID]dataplane.SSLKeyPair
+	// latestConfigHash is the hash of the latest configuration that was successfully applied to NGINX.
+	// It is reset when an update of NGINX fails.
+	latestConfigHash [32]byte
+	// configApplied indicates whether any configuration was successfully applied to NGINX.
+	// It is read by the readiness check, which runs in a different goroutine.
+	configApplied atomic.Bool
+	// pendingStatuses holds the statuses that failed to be updated. The handler updates them again when it handles
+	// the next batch, even if the batch doesn't change the configuration.
+	pendingStatuses *status.Statuses
+	// latestSnapshot is the snapshot of the latest configuration and statuses. It is read by the admin server,
+	// which runs in a different goroutine.
+	latestSnapshot atomic.Pointer[configSnapshot]
 }
 
 // newEventHandlerImpl creates a new eventHandlerImpl.
 func newEventHandlerImpl(cfg eventHandlerConfig) *eventHandlerImpl {
+	// The handler logs the events, which can include Secrets.
+	cfg.logger = logging.NewRedactingLogger(cfg.logger)
+
 	return &eventHandlerImpl{
 		cfg: cfg,
 	}
 }
 
-func (h *eventHandlerImpl) HandleEventBatch(ctx context.Context, batch events.EventBatch) {
+func (h *eventHandlerImpl) HandleEventBatch(ctx context.Context, batch events.EventBatch) error {
+	// The reconcile ID allows correlating all log messages about the handling of the same batch.
+	logger := h.cfg.logger.WithValues("reconcile_id", uuid.NewUUID())
+
 	for _, event := range batch {
 		switch e := event.(type) {
 		case *events.UpsertEvent:
+			logger.Info("Handling upsert event", resourceLogValues(e.Resource, client.ObjectKeyFromObject(e.Resource))...)
 			h.cfg.processor.CaptureUpsertChange(e.Resource)
 		case *events.DeleteEvent:
+			logger.Info("Handling delete event", resourceLogValues(e.Type, e.NamespacedName)...)
 			h.cfg.processor.CaptureDeleteChange(e.Type, e.NamespacedName)
 		default:
 			panic(fmt.Errorf("unknown event type %T", e))
@@ -63,20 +109,160 @@ func (h *eventHandlerImpl) HandleEventBatch(ctx context.Context, batch events.Ev
 
 	changed, graph := h.cfg.processor.Process()
 	if !changed {
-		h.cfg.logger.Info("Handling events didn't result into NGINX configuration changes")
-		return
+		logger.Info("Handling events didn't result into NGINX configuration changes")
+		return h.updatePendingStatuses(ctx)
 	}
 
 	var nginxReloadRes nginxReloadResult
-	err := h.updateNginx(ctx, dataplane.BuildConfiguration(ctx, graph, h.cfg.serviceResolver))
-	if err != nil {
-		h.cfg.logger.Error(err, "Failed to update NGINX configuration")
+
+	conf := dataplane.BuildConfiguration(ctx, graph, h.cfg.serviceResolver)
+	confHash := conf.ConfigHash()
+
+	if err := h.applyConfiguration(ctx, logger, conf, confHash); err != nil {
 		nginxReloadRes.error = err
-	} else {
-		h.cfg.logger.Info("NGINX configuration was successfully updated")
 	}
 
-	h.cfg.statusUpdater.Update(ctx, buildStatuses(graph, nginxReloadRes))
+	statuses := buildStatuses(graph, h.cfg.gatewayClassName, nginxReloadRes)
+	h.latestSnapshot.Store(newConfigSnapshot(conf, confHash, statuses, nginxReloadRes.error == nil))
+
+	return h.updateStatuses(ctx, statuses)
+}
+
+// applyConfiguration updates NGINX with the configuration, unless NGINX already runs it.
+func (h *eventHandlerImpl) applyConfiguration(
+	ctx context.Context,
+	logger logr.Logger,
+	conf dataplane.Configuration,
+	confHash [32]byte,
+) error {
+	h.nginxLock.Lock()
+	defer h.nginxLock.Unlock()
+
+	if h.configApplied.Load() && confHash == h.latestConfigHash {
+		// Generating the configuration and reloading NGINX is expensive, so we skip it if nothing changed.
+		logger.Info("NGINX configuration is unchanged, skipping the update")
+		return nil
+	}
+
+	if err := h.updateNginx(ctx, conf); err != nil {
+		logger.Error(err, "Failed to update NGINX configuration")
+		// The files of the failed configuration can stay on the disk, so the next configuration must be applied
+		// even if it is the same as the previous one.
+		h.latestConfigHash = [32]byte{}
+		return err
+	}
+
+	logger.Info("NGINX configuration was successfully updated")
+	h.latestConfigHash = confHash
+	h.latestKeyPairs = conf.SSLKeyPairs
+	h.configApplied.Store(true)
+
+	return nil
+}
+
+// applySnapshot applies the dataplane configuration in JSON to NGINX. It implements admin.SnapshotApplier.
+// The configuration stays in NGINX until the next event batch that changes the configuration built from the
+// cluster state.
+// The exported snapshots don't include the private keys of the SSL key pairs. If a key pair of the configuration
+// doesn't have the private key, the key pair of the configuration that NGINX runs with the same certificate
+// provides it. Otherwise, the configuration must include the private key.
+func (h *eventHandlerImpl) applySnapshot(ctx context.Context, snapshot []byte) error {
+	var conf dataplane.Configuration
+
+	decoder := json.NewDecoder(bytes.NewReader(snapshot))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&conf); err != nil {
+		return fmt.Errorf("%w: failed to decode the configuration: %w", admin.ErrInvalidSnapshot, err)
+	}
+
+	h.nginxLock.Lock()
+	conf.SSLKeyPairs = restorePrivateKeys(conf.SSLKeyPairs, h.latestKeyPairs)
+	h.nginxLock.Unlock()
+
+	if err := conf.Validate(h.cfg.httpFieldsValidator, h.cfg.enableSnippets); err != nil {
+		return fmt.Errorf("%w: %w", admin.ErrInvalidSnapshot, err)
+	}
+
+	confHash := conf.ConfigHash()
+	logger := h.cfg.logger.WithValues("snapshot_config_hash", hex.EncodeToString(confHash[:]))
+
+	logger.Info("Applying the configuration from the snapshot",
+		"http_servers", len(conf.HTTPServers),
+		"ssl_servers", len(conf.SSLServers),
+		"upstreams", len(conf.Upstreams),
+	)
+
+	return h.applyConfiguration(ctx, logger, conf, confHash)
+}
+
+// restorePrivateKeys returns the key pairs where the missing private keys are taken from the latest key pairs
+// with the same certificates.
+func restorePrivateKeys(
+	pairs map[dataplane.SSLKeyPairID]dataplane.SSLKeyPair,
+	latestPairs map[dataplane.SSLKeyPairID]dataplane.SSLKeyPair,
+) map[dataplane.SSLKeyPairID]dataplane.SSLKeyPair {
+	for id, pair := range pairs {
+		if len(pair.Key) > 0 {
+			continue
+		}
+
+		if latest, exists := latestPairs[id]; exists && len(pair.Cert) > 0 && bytes.Equal(pair.Cert, latest.Cert) {
+			pair.Key = latest.Key
+			pairs[id] = pair
+		}
+	}
+
+	return pairs
+}
+
+// getSnapshot returns the latest snapshot. It implements admin.SnapshotGetter.
+func (h *eventHandlerImpl) getSnapshot() (interface{}, bool) {
+	snapshot := h.latestSnapshot.Load()
+	if snapshot == nil {
+		return nil, false
+	}
+
+	return snapshot, true
+}
+
+// updateStatuses updates the statuses of the resources. If the update fails, the statuses become pending.
+func (h *eventHandlerImpl) updateStatuses(ctx context.Context, statuses status.Statuses) error {
+	if err := h.cfg.statusUpdater.Update(ctx, statuses); err != nil {
+		h.pendingStatuses = &statuses
+		return fmt.Errorf("failed to update statuses: %w", err)
+	}
+
+	h.pendingStatuses = nil
+
+	return nil
+}
+
+// updatePendingStatuses updates the statuses that failed to be updated before, if any.
+func (h *eventHandlerImpl) updatePendingStatuses(ctx context.Context) error {
+	if h.pendingStatuses == nil {
+		return nil
+	}
+
+	return h.updateStatuses(ctx, *h.pendingStatuses)
+}
+
+// resourceLogValues returns the key/value pairs that identify a resource in the log messages.
+func resourceLogValues(resourceType client.Object, nsname types.NamespacedName) []interface{} {
+	return []interface{}{
+		"resource_kind", reflect.TypeOf(resourceType).Elem().Name(),
+		"namespace", nsname.Namespace,
+		"resource_name", nsname.Name,
+	}
+}
+
+// readyCheck is a readiness check that fails until the first configuration is successfully applied to NGINX.
+func (h *eventHandlerImpl) readyCheck(_ *http.Request) error {
+	if !h.configApplied.Load() {
+		return errors.New("NGINX has not been configured yet")
+	}
+
+	return nil
 }
 
 func (h *eventHandlerImpl) updateNginx(ctx context.Context, conf dataplane.Configuration) error {
@@ -1,23 +1,42 @@
 package static
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
 
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/admin"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/events"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/status/statusfakes"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config/configfakes"
+	ngxvalidation "github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config/validation"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/file"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/file/filefakes"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/runtime/runtimefakes"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/graph"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/resolver"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/resolver/resolverfakes"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/statefakes"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/validation"
 )
 
 var _ = Describe("eventHandler", func() {
@@ -53,12 +72,13 @@ var _ = Describe("eventHandler", func() {
 		fakeStatusUpdater = &statusfakes.FakeUpdater{}
 
 		handler = newEventHandlerImpl(eventHandlerConfig{
-			processor:       fakeProcessor,
-			generator:       fakeGenerator,
-			logger:          zap.New(),
-			nginxFileMgr:    fakeNginxFileMgr,
-			nginxRuntimeMgr: fakeNginxRuntimeMgr,
-			statusUpdater:   fakeStatusUpdater,
+			processor:           fakeProcessor,
+			generator:           fakeGenerator,
+			logger:              zap.New(),
+			nginxFileMgr:        fakeNginxFileMgr,
+			nginxRuntimeMgr:     fakeNginxRuntimeMgr,
+			statusUpdater:       fakeStatusUpdater,
+			httpFieldsValidator: ngxvalidation.HTTPValidator{},
 		})
 	})
 
@@ -130,6 +150,381 @@ var _ = Describe("eventHandler", func() {
 				handler.HandleEventBatch(context.Background(), batch)
 			})
 		})
+
+		It("should log the events with the same reconcile ID in JSON", func() {
+			var buf bytes.Buffer
+			handler.cfg.logger = zap.New(zap.WriteTo(&buf), zap.JSONEncoder())
+
+			upsertEvent := &events.UpsertEvent{
+				Resource: &v1beta1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "route-1"}},
+			}
+			deleteEvent := &events.DeleteEvent{
+				Type:           &v1beta1.HTTPRoute{},
+				NamespacedName: types.NamespacedName{Namespace: "test", Name: "route-2"},
+			}
+
+			handler.HandleEventBatch(context.Background(), []interface{}{upsertEvent, deleteEvent})
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			Expect(lines).To(HaveLen(3))
+
+			entries := make([]map[string]interface{}, 0, len(lines))
+			for _, line := range lines {
+				var entry map[string]interface{}
+				Expect(json.Unmarshal([]byte(line), &entry)).To(Succeed())
+				Expect(entry).To(HaveKey("reconcile_id"))
+
+				entries = append(entries, entry)
+			}
+
+			for _, entry := range entries {
+				Expect(entry["reconcile_id"]).To(Equal(entries[0]["reconcile_id"]))
+			}
+
+			for i, name := range []string{"route-1", "route-2"} {
+				Expect(entries[i]).To(HaveKeyWithValue("resource_kind", "HTTPRoute"))
+				Expect(entries[i]).To(HaveKeyWithValue("namespace", "test"))
+				Expect(entries[i]).To(HaveKeyWithValue("resource_name", name))
+			}
+		})
+
+		When("the configuration is unchanged", func() {
+			It("should not update NGINX", func() {
+				e := &events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}
+				batch := []interface{}{e}
+
+				handler.HandleEventBatch(context.Background(), batch)
+				handler.HandleEventBatch(context.Background(), batch)
+
+				Expect(fakeProcessor.ProcessCallCount()).Should(Equal(2))
+				Expect(fakeGenerator.GenerateCallCount()).Should(Equal(1))
+				Expect(fakeNginxFileMgr.ReplaceFilesCallCount()).Should(Equal(1))
+				Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(1))
+				Expect(fakeStatusUpdater.UpdateCallCount()).Should(Equal(2))
+			})
+
+			It("should update NGINX if the previous update failed", func() {
+				fakeNginxRuntimeMgr.ReloadReturnsOnCall(0, errors.New("reload error"))
+
+				e := &events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}
+				batch := []interface{}{e}
+
+				handler.HandleEventBatch(context.Background(), batch)
+				handler.HandleEventBatch(context.Background(), batch)
+
+				Expect(fakeGenerator.GenerateCallCount()).Should(Equal(2))
+				Expect(fakeNginxRuntimeMgr.ReloadCallCount()).Should(Equal(2))
+			})
+		})
+
+		When("the status update fails", func() {
+			It("should return the error and update the statuses again on the next batch", func() {
+				fakeStatusUpdater.UpdateReturnsOnCall(0, errors.New("update error"))
+
+				e := &events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}
+				batch := []interface{}{e}
+
+				Expect(handler.HandleEventBatch(context.Background(), batch)).ToNot(Succeed())
+
+				fakeProcessor.ProcessReturns(false /* changed */, &graph.Graph{})
+
+				Expect(handler.HandleEventBatch(context.Background(), batch)).To(Succeed())
+				Expect(fakeStatusUpdater.UpdateCallCount()).Should(Equal(2))
+				_, failedStatuses := fakeStatusUpdater.UpdateArgsForCall(0)
+				_, retriedStatuses := fakeStatusUpdater.UpdateArgsForCall(1)
+				Expect(retriedStatuses).To(Equal(failedStatuses))
+
+				Expect(handler.HandleEventBatch(context.Background(), batch)).To(Succeed())
+				Expect(fakeStatusUpdater.UpdateCallCount()).Should(Equal(2))
+			})
+		})
+	})
+
+	Describe("Snapshot", func() {
+		getSnapshot := func() *httptest.ResponseRecorder {
+			const token = "secret-token"
+
+			adminHandler := admin.NewHandler(admin.ServerConfig{
+				Logger: logr.Discard(),
+				GetToken: func(context.Context) (string, error) {
+					return token, nil
+				},
+				GetSnapshot: handler.getSnapshot,
+			})
+
+			req := httptest.NewRequest(http.MethodGet, admin.SnapshotPath, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			rec := httptest.NewRecorder()
+			adminHandler.ServeHTTP(rec, req)
+
+			return rec
+		}
+
+		It("should not export a snapshot before the first batch", func() {
+			Expect(getSnapshot().Code).To(Equal(http.StatusServiceUnavailable))
+		})
+
+		It("should export the configuration and the statuses of the handled batch", func() {
+			fakeResolver := &resolverfakes.FakeServiceResolver{}
+			fakeResolver.ResolveReturns([]resolver.Endpoint{{Address: "10.0.0.1", Port: 8080}}, nil)
+			handler.cfg.serviceResolver = fakeResolver
+			handler.cfg.gatewayClassName = "nginx"
+
+			g := buildBenchmarkGraph(2)
+			fakeProcessor.ProcessReturns(true /* changed */, g)
+
+			batch := []interface{}{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}}
+			Expect(handler.HandleEventBatch(context.Background(), batch)).To(Succeed())
+
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+			conf := fakeGenerator.GenerateArgsForCall(0)
+			confHash := conf.ConfigHash()
+
+			Expect(fakeStatusUpdater.UpdateCallCount()).To(Equal(1))
+			_, statuses := fakeStatusUpdater.UpdateArgsForCall(0)
+
+			expected, err := json.Marshal(map[string]interface{}{
+				"GatewayClassStatuses": map[string]interface{}{
+					"nginx": statuses.GatewayClassStatuses[types.NamespacedName{Name: "nginx"}],
+				},
+				"GatewayStatuses": map[string]interface{}{
+					"test/gateway": statuses.GatewayStatuses[types.NamespacedName{Namespace: "test", Name: "gateway"}],
+				},
+				"HTTPRouteStatuses": map[string]interface{}{
+					"test/hr-0": statuses.HTTPRouteStatuses[types.NamespacedName{Namespace: "test", Name: "hr-0"}],
+					"test/hr-1": statuses.HTTPRouteStatuses[types.NamespacedName{Namespace: "test", Name: "hr-1"}],
+				},
+				"ConfigHash":    hex.EncodeToString(confHash[:]),
+				"Configuration": conf,
+				"Applied":       true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			rec := getSnapshot()
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Body.String()).To(MatchJSON(expected))
+		})
+
+		It("should report that the configuration was not applied if NGINX fails to reload", func() {
+			fakeProcessor.ProcessReturns(true /* changed */, &graph.Graph{})
+			fakeNginxRuntimeMgr.ReloadReturns(errors.New("reload error"))
+
+			batch := []interface{}{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}}
+			Expect(handler.HandleEventBatch(context.Background(), batch)).To(Succeed())
+
+			rec := getSnapshot()
+			Expect(rec.Code).To(Equal(http.StatusOK))
+
+			var snapshot configSnapshot
+			Expect(json.Unmarshal(rec.Body.Bytes(), &snapshot)).To(Succeed())
+			Expect(snapshot.Applied).To(BeFalse())
+		})
+	})
+
+	Describe("Apply snapshot", func() {
+		applySnapshot := func(body string) *httptest.ResponseRecorder {
+			const token = "secret-token"
+
+			adminHandler := admin.NewHandler(admin.ServerConfig{
+				Logger: logr.Discard(),
+				GetToken: func(context.Context) (string, error) {
+					return token, nil
+				},
+				ApplySnapshot: handler.applySnapshot,
+			})
+
+			req := httptest.NewRequest(http.MethodPost, admin.ApplySnapshotPath, strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			rec := httptest.NewRecorder()
+			adminHandler.ServeHTTP(rec, req)
+
+			return rec
+		}
+
+		fakeCfgFiles := []file.File{
+			{
+				Type: file.TypeRegular,
+				Path: "test.conf",
+			},
+		}
+
+		conf := dataplane.Configuration{
+			HTTPServers: []dataplane.VirtualServer{
+				{
+					Hostname:  "foo.example.com",
+					IsDefault: true,
+					Port:      80,
+				},
+			},
+			Upstreams: []dataplane.Upstream{{Name: "test_foo_80"}},
+		}
+
+		BeforeEach(func() {
+			fakeGenerator.GenerateReturns(fakeCfgFiles)
+		})
+
+		It("should apply a valid snapshot", func() {
+			body, err := json.Marshal(conf)
+			Expect(err).ToNot(HaveOccurred())
+
+			rec := applySnapshot(string(body))
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+			Expect(fakeGenerator.GenerateArgsForCall(0)).To(Equal(conf))
+			Expect(fakeNginxFileMgr.ReplaceFilesCallCount()).To(Equal(1))
+			Expect(fakeNginxFileMgr.ReplaceFilesArgsForCall(0)).To(Equal(fakeCfgFiles))
+			Expect(fakeNginxRuntimeMgr.ReloadCallCount()).To(Equal(1))
+			Expect(handler.readyCheck(nil)).To(Succeed())
+		})
+
+		DescribeTable("should reject an invalid snapshot",
+			func(body string) {
+				rec := applySnapshot(body)
+
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(fakeGenerator.GenerateCallCount()).To(BeZero())
+				Expect(fakeNginxFileMgr.ReplaceFilesCallCount()).To(BeZero())
+				Expect(fakeNginxRuntimeMgr.ReloadCallCount()).To(BeZero())
+			},
+			Entry("malformed JSON", `{"HTTPServers": [`),
+			Entry("unknown field", `{"Servers": []}`),
+			Entry("wrong type", `{"HTTPServers": "foo"}`),
+			Entry("inconsistent configuration", `{"SSLServers": [{"SSL": {"KeyPairID": "missing"}}]}`),
+			Entry(
+				"injected path",
+				`{"HTTPServers": [{"Hostname": "foo.example.com", "Port": 80, `+
+					`"PathRules": [{"Path": "/foo;\n    proxy_pass http://example.com", "PathType": "prefix"}]}]}`,
+			),
+			Entry(
+				"server snippet with snippets disabled",
+				`{"HTTPServers": [{"Hostname": "foo.example.com", "Port": 80, "ServerSnippet": "return 200;"}]}`,
+			),
+		)
+
+		It("should reject an injected server snippet if the snippets are enabled", func() {
+			handler.cfg.enableSnippets = true
+
+			rec := applySnapshot(
+				`{"HTTPServers": [{"Hostname": "foo.example.com", "Port": 80, ` +
+					`"ServerSnippet": "return 200;\n}\nserver {\n    listen 8080;"}]}`,
+			)
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+			Expect(rec.Body.String()).To(ContainSubstring("unexpected }"))
+			Expect(fakeNginxRuntimeMgr.ReloadCallCount()).To(BeZero())
+		})
+
+		Describe("private keys", func() {
+			createSSLConf := func(cert, key string) dataplane.Configuration {
+				return dataplane.Configuration{
+					SSLKeyPairs: map[dataplane.SSLKeyPairID]dataplane.SSLKeyPair{
+						"ssl_keypair_test_secret": {Cert: []byte(cert), Key: []byte(key)},
+					},
+					SSLServers: []dataplane.VirtualServer{
+						{
+							Hostname: "foo.example.com",
+							SSL:      &dataplane.SSL{KeyPairID: "ssl_keypair_test_secret"},
+							Port:     443,
+						},
+					},
+				}
+			}
+
+			apply := func(conf dataplane.Configuration) *httptest.ResponseRecorder {
+				body, err := json.Marshal(conf)
+				Expect(err).ToNot(HaveOccurred())
+
+				return applySnapshot(string(body))
+			}
+
+			BeforeEach(func() {
+				Expect(apply(createSSLConf("cert", "key")).Code).To(Equal(http.StatusOK))
+			})
+
+			It("should reuse the private key of the applied key pair with the same cert", func() {
+				conf := createSSLConf("cert", "")
+				conf.HTTPServers = []dataplane.VirtualServer{{IsDefault: true, Port: 80}}
+
+				Expect(apply(conf).Code).To(Equal(http.StatusOK))
+
+				expectedConf := createSSLConf("cert", "key")
+				expectedConf.HTTPServers = conf.HTTPServers
+
+				Expect(fakeGenerator.GenerateCallCount()).To(Equal(2))
+				Expect(fakeGenerator.GenerateArgsForCall(1)).To(Equal(expectedConf))
+			})
+
+			It("should not update NGINX with the exported snapshot of the applied configuration", func() {
+				Expect(apply(createSSLConf("cert", "")).Code).To(Equal(http.StatusOK))
+
+				Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+				Expect(fakeNginxRuntimeMgr.ReloadCallCount()).To(Equal(1))
+			})
+
+			It("should reject a key pair without the private key if the cert is different", func() {
+				rec := apply(createSSLConf("other-cert", ""))
+
+				Expect(rec.Code).To(Equal(http.StatusBadRequest))
+				Expect(rec.Body.String()).To(ContainSubstring("cert and key are required"))
+				Expect(fakeGenerator.GenerateCallCount()).To(Equal(1))
+			})
+		})
+
+		It("should return an error if NGINX rejects the configuration", func() {
+			fakeNginxRuntimeMgr.ReloadReturns(errors.New("reload error"))
+
+			body, err := json.Marshal(conf)
+			Expect(err).ToNot(HaveOccurred())
+
+			rec := applySnapshot(string(body))
+
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+			Expect(rec.Body.String()).To(ContainSubstring("reload error"))
+			Expect(fakeNginxRuntimeMgr.ReloadCallCount()).To(Equal(1))
+			Expect(handler.readyCheck(nil)).ToNot(Succeed())
+		})
+
+		It("should update NGINX with the previous configuration after a failed snapshot", func() {
+			fakeProcessor.ProcessReturns(true /* changed */, &graph.Graph{})
+
+			batch := []interface{}{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}}
+			Expect(handler.HandleEventBatch(context.Background(), batch)).To(Succeed())
+
+			fakeNginxRuntimeMgr.ReloadReturnsOnCall(1, errors.New("reload error"))
+
+			body, err := json.Marshal(conf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(applySnapshot(string(body)).Code).To(Equal(http.StatusInternalServerError))
+
+			// The configuration from the cluster state is the same as before the snapshot, but the files of
+			// the snapshot replaced it on the disk.
+			Expect(handler.HandleEventBatch(context.Background(), batch)).To(Succeed())
+
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(3))
+			Expect(fakeGenerator.GenerateArgsForCall(2)).To(Equal(dataplane.Configuration{}))
+			Expect(fakeNginxFileMgr.ReplaceFilesCallCount()).To(Equal(3))
+			Expect(fakeNginxRuntimeMgr.ReloadCallCount()).To(Equal(3))
+			Expect(handler.readyCheck(nil)).To(Succeed())
+		})
+
+		It("should restore the configuration from the cluster state on the next batch", func() {
+			body, err := json.Marshal(conf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(applySnapshot(string(body)).Code).To(Equal(http.StatusOK))
+
+			fakeProcessor.ProcessReturns(true /* changed */, &graph.Graph{})
+
+			batch := []interface{}{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}}
+			Expect(handler.HandleEventBatch(context.Background(), batch)).To(Succeed())
+
+			Expect(fakeGenerator.GenerateCallCount()).To(Equal(2))
+			Expect(fakeGenerator.GenerateArgsForCall(1)).To(Equal(dataplane.Configuration{}))
+			Expect(fakeNginxRuntimeMgr.ReloadCallCount()).To(Equal(2))
+		})
 	})
 
 	It("should panic for an unknown event type", func() {
@@ -143,3 +538,145 @@ var _ = Describe("eventHandler", func() {
 		Expect(handle).Should(Panic())
 	})
 })
+
+func BenchmarkHandleEventBatch(b *testing.B) {
+	const routes = 50
+
+	createHandler := func() *eventHandlerImpl {
+		fakeProcessor := &statefakes.FakeChangeProcessor{}
+		fakeProcessor.ProcessReturns(true /* changed */, buildBenchmarkGraph(routes))
+
+		fakeResolver := &resolverfakes.FakeServiceResolver{}
+		fakeResolver.ResolveReturns(
+			[]resolver.Endpoint{
+				{Address: "10.0.0.1", Port: 8080},
+				{Address: "10.0.0.2", Port: 8080},
+			},
+			nil,
+		)
+
+		return newEventHandlerImpl(eventHandlerConfig{
+			processor:       fakeProcessor,
+			serviceResolver: fakeResolver,
+			generator:       config.NewGeneratorImpl(),
+			logger:          logr.Discard(),
+			nginxFileMgr:    &filefakes.FakeManager{},
+			nginxRuntimeMgr: &runtimefakes.FakeManager{},
+			statusUpdater:   &statusfakes.FakeUpdater{},
+		})
+	}
+
+	batch := []interface{}{&events.UpsertEvent{Resource: &v1beta1.HTTPRoute{}}}
+
+	b.Run("changed configuration", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			handler := createHandler()
+			b.StartTimer()
+
+			handler.HandleEventBatch(context.Background(), batch)
+		}
+	})
+
+	b.Run("unchanged configuration", func(b *testing.B) {
+		handler := createHandler()
+		handler.HandleEventBatch(context.Background(), batch)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			handler.HandleEventBatch(context.Background(), batch)
+		}
+	})
+}
+
+// buildBenchmarkGraph builds a Graph with a Gateway with an HTTP listener and n HTTPRoutes attached to it.
+func buildBenchmarkGraph(n int) *graph.Graph {
+	const (
+		gcName         = "nginx"
+		controllerName = "k8s-gateway.nginx.org/nginx-gateway-controller"
+	)
+
+	gc := &v1beta1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: gcName},
+		Spec:       v1beta1.GatewayClassSpec{ControllerName: controllerName},
+	}
+
+	gw := &v1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "gateway"},
+		Spec: v1beta1.GatewaySpec{
+			GatewayClassName: gcName,
+			Listeners: []v1beta1.Listener{
+				{
+					Name:     "http",
+					Port:     80,
+					Protocol: v1beta1.HTTPProtocolType,
+				},
+			},
+		},
+	}
+
+	state := graph.ClusterState{
+		GatewayClasses: map[types.NamespacedName]*v1beta1.GatewayClass{{Name: gcName}: gc},
+		Gateways:       map[types.NamespacedName]*v1beta1.Gateway{{Namespace: "test", Name: "gateway"}: gw},
+		HTTPRoutes:     make(map[types.NamespacedName]*v1beta1.HTTPRoute, n),
+		Services:       make(map[types.NamespacedName]*v1.Service, n),
+	}
+
+	for i := 0; i < n; i++ {
+		svcName := fmt.Sprintf("svc-%d", i)
+
+		state.Services[types.NamespacedName{Namespace: "test", Name: svcName}] = &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: svcName},
+			Spec: v1.ServiceSpec{
+				Ports: []v1.ServicePort{{Port: 80}},
+			},
+		}
+
+		hr := &v1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: fmt.Sprintf("hr-%d", i)},
+			Spec: v1beta1.HTTPRouteSpec{
+				CommonRouteSpec: v1beta1.CommonRouteSpec{
+					ParentRefs: []v1beta1.ParentReference{{Name: "gateway"}},
+				},
+				Hostnames: []v1beta1.Hostname{v1beta1.Hostname(fmt.Sprintf("foo-%d.example.com", i))},
+				Rules: []v1beta1.HTTPRouteRule{
+					{
+						Matches: []v1beta1.HTTPRouteMatch{
+							{
+								Path: &v1beta1.HTTPPathMatch{
+									Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+									Value: helpers.GetPointer("/"),
+								},
+							},
+						},
+						BackendRefs: []v1beta1.HTTPBackendRef{
+							{
+								BackendRef: v1beta1.BackendRef{
+									BackendObjectReference: v1beta1.BackendObjectReference{
+										Kind: helpers.GetPointer[v1beta1.Kind]("Service"),
+										Name: v1beta1.ObjectName(svcName),
+										Port: helpers.GetPointer[v1beta1.PortNumber](80),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		state.HTTPRoutes[types.NamespacedName{Namespace: "test", Name: hr.Name}] = hr
+	}
+
+	return graph.BuildGraph(
+		state,
+		controllerName,
+		gcName,
+		validation.Validators{HTTPFieldsValidator: ngxvalidation.HTTPValidator{}},
+		false,
+	)
+}
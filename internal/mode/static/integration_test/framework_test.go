@@ -0,0 +1,543 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	apiv1 "k8s.io/api/core/v1"
+	discoveryV1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctlrzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/config"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/file"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/runtime/runtimefakes"
+)
+
+const (
+	// eventuallyTimeout is the time to wait for the manager to reconcile a change.
+	eventuallyTimeout = 30 * time.Second
+	pollInterval      = 100 * time.Millisecond
+	// managerStopTimeout is the time to wait for the manager to stop at the end of a test.
+	managerStopTimeout = 10 * time.Second
+
+	httpConfigFile = "/etc/nginx/conf.d/http.conf"
+	serversFolder  = "/etc/nginx/conf.d/servers"
+	secretsFolder  = "/etc/nginx/secrets"
+)
+
+var testCounter atomic.Int32
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+	utilruntime.Must(apiv1.AddToScheme(scheme))
+	utilruntime.Must(discoveryV1.AddToScheme(scheme))
+
+	return scheme
+}
+
+// framework runs an instance of the static mode manager for a single test.
+//
+// To isolate the tests from each other, every test gets its own GatewayClass and controller name,
+// so that the manager ignores the Gateways of the other tests, and its own namespace for the namespaced resources.
+// The manager writes the NGINX configuration files into a temporary directory instead of the root of
+// the file system and doesn't reload NGINX.
+type framework struct {
+	t                *testing.T
+	runtimeMgr       *runtimefakes.FakeManager
+	namespace        string
+	gatewayClassName string
+	configRoot       string
+}
+
+func newFramework(t *testing.T) *framework {
+	t.Helper()
+
+	id := fmt.Sprintf("test-%d", testCounter.Add(1))
+
+	f := &framework{
+		t:                t,
+		runtimeMgr:       &runtimefakes.FakeManager{},
+		namespace:        id,
+		gatewayClassName: "nginx-" + id,
+		configRoot:       t.TempDir(),
+	}
+
+	f.create(&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: f.namespace}})
+
+	controllerName := "k8s-gateway.nginx.org/" + id
+
+	f.create(&v1beta1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: f.gatewayClassName},
+		Spec: v1beta1.GatewayClassSpec{
+			ControllerName: v1beta1.GatewayController(controllerName),
+		},
+	})
+
+	f.startManager(controllerName)
+
+	return f
+}
+
+func (f *framework) startManager(controllerName string) {
+	logger := logr.Discard()
+	if testing.Verbose() {
+		logger = ctlrzap.New(ctlrzap.WriteTo(os.Stderr)).WithName(f.namespace)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- static.RunManager(
+			ctx,
+			clusterCfg,
+			config.Config{
+				GatewayCtlrName:          controllerName,
+				GatewayClassName:         f.gatewayClassName,
+				Logger:                   logger,
+				PodIP:                    "10.0.0.1",
+				UpdateGatewayClassStatus: true,
+			},
+			static.NginxDependencies{
+				FileMgr:    file.NewManagerImpl(logger, newRootedOSFileManager(f.configRoot)),
+				RuntimeMgr: f.runtimeMgr,
+			},
+		)
+	}()
+
+	f.t.Cleanup(func() {
+		cancel()
+
+		select {
+		case err := <-errCh:
+			if err != nil {
+				f.t.Errorf("manager failed: %v", err)
+			}
+		case <-time.After(managerStopTimeout):
+			f.t.Errorf("manager didn't stop within %v", managerStopTimeout)
+		}
+	})
+}
+
+func (f *framework) create(obj client.Object) {
+	f.t.Helper()
+
+	if err := k8sClient.Create(context.Background(), obj); err != nil {
+		f.t.Fatalf("failed to create %T %s: %v", obj, client.ObjectKeyFromObject(obj), err)
+	}
+}
+
+// update fetches the latest version of the object, applies the mutation and updates the object.
+func (f *framework) update(obj client.Object, mutate func()) {
+	f.t.Helper()
+
+	ctx := context.Background()
+
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		f.t.Fatalf("failed to get %T %s: %v", obj, client.ObjectKeyFromObject(obj), err)
+	}
+
+	mutate()
+
+	if err := k8sClient.Update(ctx, obj); err != nil {
+		f.t.Fatalf("failed to update %T %s: %v", obj, client.ObjectKeyFromObject(obj), err)
+	}
+}
+
+func (f *framework) delete(obj client.Object) {
+	f.t.Helper()
+
+	if err := k8sClient.Delete(context.Background(), obj); err != nil {
+		f.t.Fatalf("failed to delete %T %s: %v", obj, client.ObjectKeyFromObject(obj), err)
+	}
+}
+
+// eventually polls the condition until it returns true or the timeout expires.
+func (f *framework) eventually(msg string, condition func() bool) {
+	f.t.Helper()
+
+	err := wait.PollUntilContextTimeout(
+		context.Background(),
+		pollInterval,
+		eventuallyTimeout,
+		true, /* poll immediately */
+		func(context.Context) (bool, error) {
+			return condition(), nil
+		},
+	)
+	if err != nil {
+		f.t.Fatalf("timed out waiting for %s", msg)
+	}
+}
+
+// readConfigFile reads the file written by the manager. The path is the path of the file in the NGINX container.
+func (f *framework) readConfigFile(path string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(f.configRoot, path))
+}
+
+// readHTTPConfig reads the HTTP configuration file followed by the configuration files of the servers.
+func (f *framework) readHTTPConfig() ([]byte, error) {
+	content, err := f.readConfigFile(httpConfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// The entries are sorted by name, like the files included by NGINX.
+	entries, err := os.ReadDir(filepath.Join(f.configRoot, serversFolder))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		b, err := f.readConfigFile(filepath.Join(serversFolder, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		content = append(content, b...)
+	}
+
+	return content, nil
+}
+
+// expectHTTPConfig waits until the HTTP configuration includes all of the expected substrings.
+// It returns the content of the file.
+func (f *framework) expectHTTPConfig(expected ...string) string {
+	f.t.Helper()
+
+	var content string
+
+	f.eventually(fmt.Sprintf("the HTTP configuration to include %q", expected), func() bool {
+		b, err := f.readHTTPConfig()
+		if err != nil {
+			return false
+		}
+		content = string(b)
+
+		for _, e := range expected {
+			if !strings.Contains(content, e) {
+				return false
+			}
+		}
+
+		return true
+	})
+
+	return content
+}
+
+// expectHTTPConfigExcludes waits until the HTTP configuration doesn't include any of the substrings.
+func (f *framework) expectHTTPConfigExcludes(excluded ...string) {
+	f.t.Helper()
+
+	f.eventually(fmt.Sprintf("the HTTP configuration to exclude %q", excluded), func() bool {
+		b, err := f.readHTTPConfig()
+		if err != nil {
+			return false
+		}
+
+		for _, e := range excluded {
+			if bytes.Contains(b, []byte(e)) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+func (f *framework) nsname(name string) types.NamespacedName {
+	return types.NamespacedName{Namespace: f.namespace, Name: name}
+}
+
+func (f *framework) createGateway(name string, listeners ...v1beta1.Listener) *v1beta1.Gateway {
+	gw := &v1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: f.namespace,
+			Name:      name,
+		},
+		Spec: v1beta1.GatewaySpec{
+			GatewayClassName: v1beta1.ObjectName(f.gatewayClassName),
+			Listeners:        listeners,
+		},
+	}
+
+	f.create(gw)
+
+	return gw
+}
+
+func createHTTPListener(name string, port v1beta1.PortNumber) v1beta1.Listener {
+	return v1beta1.Listener{
+		Name:     v1beta1.SectionName(name),
+		Port:     port,
+		Protocol: v1beta1.HTTPProtocolType,
+	}
+}
+
+func createHTTPSListener(name string, port v1beta1.PortNumber, secretName string) v1beta1.Listener {
+	return v1beta1.Listener{
+		Name:     v1beta1.SectionName(name),
+		Port:     port,
+		Protocol: v1beta1.HTTPSProtocolType,
+		TLS: &v1beta1.GatewayTLSConfig{
+			Mode: helpers.GetPointer[v1beta1.TLSModeType](v1beta1.TLSModeTerminate),
+			CertificateRefs: []v1beta1.SecretObjectReference{
+				{
+					Kind: (*v1beta1.Kind)(helpers.GetPointer("Secret")),
+					Name: v1beta1.ObjectName(secretName),
+				},
+			},
+		},
+	}
+}
+
+// createHTTPRoute creates an HTTPRoute that routes the requests for the hostname and the path prefix to
+// port 80 of the Service.
+func (f *framework) createHTTPRoute(
+	name string,
+	gatewayName string,
+	hostname string,
+	path string,
+	svcName string,
+) *v1beta1.HTTPRoute {
+	hr := f.newHTTPRoute(name, gatewayName, hostname, path, svcName)
+
+	f.create(hr)
+
+	return hr
+}
+
+// newHTTPRoute returns the HTTPRoute created by createHTTPRoute without creating it,
+// so that the test can customize it first.
+func (f *framework) newHTTPRoute(
+	name string,
+	gatewayName string,
+	hostname string,
+	path string,
+	svcName string,
+) *v1beta1.HTTPRoute {
+	return &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: f.namespace,
+			Name:      name,
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			CommonRouteSpec: v1beta1.CommonRouteSpec{
+				ParentRefs: []v1beta1.ParentReference{
+					{
+						Name: v1beta1.ObjectName(gatewayName),
+					},
+				},
+			},
+			Hostnames: []v1beta1.Hostname{v1beta1.Hostname(hostname)},
+			Rules: []v1beta1.HTTPRouteRule{
+				{
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+								Value: helpers.GetPointer(path),
+							},
+						},
+					},
+					BackendRefs: []v1beta1.HTTPBackendRef{
+						{
+							BackendRef: v1beta1.BackendRef{
+								BackendObjectReference: v1beta1.BackendObjectReference{
+									Name: v1beta1.ObjectName(svcName),
+									Port: helpers.GetPointer[v1beta1.PortNumber](80),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// createService creates a Service with port 80 that targets port 8080 of the Pods.
+func (f *framework) createService(name string) *apiv1.Service {
+	svc := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: f.namespace,
+			Name:      name,
+		},
+		Spec: apiv1.ServiceSpec{
+			Selector: map[string]string{"app": name},
+			Ports: []apiv1.ServicePort{
+				{
+					Name: "http",
+					Port: 80,
+				},
+			},
+		},
+	}
+
+	f.create(svc)
+
+	return svc
+}
+
+// createEndpointSlice creates an EndpointSlice for the Service created by createService with a ready endpoint
+// for every address.
+func (f *framework) createEndpointSlice(svcName string, addresses ...string) *discoveryV1.EndpointSlice {
+	endpoints := make([]discoveryV1.Endpoint, 0, len(addresses))
+	for _, addr := range addresses {
+		endpoints = append(endpoints, discoveryV1.Endpoint{
+			Addresses: []string{addr},
+			Conditions: discoveryV1.EndpointConditions{
+				Ready: helpers.GetPointer(true),
+			},
+		})
+	}
+
+	slice := &discoveryV1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: f.namespace,
+			Name:      svcName + "-slice",
+			Labels: map[string]string{
+				discoveryV1.LabelServiceName: svcName,
+			},
+		},
+		AddressType: discoveryV1.AddressTypeIPv4,
+		Endpoints:   endpoints,
+		Ports: []discoveryV1.EndpointPort{
+			{
+				Name: helpers.GetPointer("http"),
+				Port: helpers.GetPointer[int32](8080),
+			},
+		},
+	}
+
+	f.create(slice)
+
+	return slice
+}
+
+// createTLSSecret creates a TLS Secret with a self-signed certificate.
+func (f *framework) createTLSSecret(name string) *apiv1.Secret {
+	f.t.Helper()
+
+	cert, key, err := generateSelfSignedCert()
+	if err != nil {
+		f.t.Fatalf("failed to generate a certificate: %v", err)
+	}
+
+	secret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: f.namespace,
+			Name:      name,
+		},
+		Type: apiv1.SecretTypeTLS,
+		Data: map[string][]byte{
+			apiv1.TLSCertKey:       cert,
+			apiv1.TLSPrivateKeyKey: key,
+		},
+	}
+
+	f.create(secret)
+
+	return secret
+}
+
+func generateSelfSignedCert() (cert, key []byte, err error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"*.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	key = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return cert, key, nil
+}
+
+func findCondition(conditions []metav1.Condition, condType string) *metav1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == condType {
+			return &conditions[i]
+		}
+	}
+
+	return nil
+}
+
+// rootedOSFileManager is a file.OSFileManager that resolves all paths relative to the root directory.
+// The manager uses it to write the NGINX configuration files into a temporary directory.
+type rootedOSFileManager struct {
+	*file.StdLibOSFileManager
+	root string
+}
+
+func newRootedOSFileManager(root string) *rootedOSFileManager {
+	return &rootedOSFileManager{
+		StdLibOSFileManager: file.NewStdLibOSFileManager(),
+		root:                root,
+	}
+}
+
+func (m *rootedOSFileManager) ReadDir(dirname string) ([]fs.DirEntry, error) {
+	return m.StdLibOSFileManager.ReadDir(filepath.Join(m.root, dirname))
+}
+
+func (m *rootedOSFileManager) Remove(name string) error {
+	return m.StdLibOSFileManager.Remove(filepath.Join(m.root, name))
+}
+
+func (m *rootedOSFileManager) Create(name string) (*os.File, error) {
+	path := filepath.Join(m.root, name)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil && !errors.Is(err, fs.ErrExist) {
+		return nil, err
+	}
+
+	return m.StdLibOSFileManager.Create(path)
+}
@@ -0,0 +1,62 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
+)
+
+func TestGatewayListenerAttachedRoutes(t *testing.T) {
+	f := newFramework(t)
+
+	f.createTLSSecret("cafe-secret")
+	f.createGateway(
+		"gateway",
+		createHTTPListener("http", 80),
+		createHTTPSListener("https", 443, "cafe-secret"),
+	)
+	f.createService("coffee")
+
+	routes := []struct {
+		name        string
+		sectionName string
+	}{
+		{name: "coffee", sectionName: "http"},
+		{name: "tea", sectionName: "http"},
+		{name: "secure-coffee", sectionName: "https"},
+	}
+
+	for _, r := range routes {
+		hr := f.newHTTPRoute(r.name, "gateway", r.name+".example.com", "/", "coffee")
+		hr.Spec.ParentRefs[0].SectionName = helpers.GetPointer(v1beta1.SectionName(r.sectionName))
+
+		f.create(hr)
+	}
+
+	expectedAttachedRoutes := map[v1beta1.SectionName]int32{
+		"http":  2,
+		"https": 1,
+	}
+
+	f.eventually("the listeners to report the attached routes", func() bool {
+		var gw v1beta1.Gateway
+		if err := k8sClient.Get(context.Background(), f.nsname("gateway"), &gw); err != nil {
+			return false
+		}
+
+		if len(gw.Status.Listeners) != len(expectedAttachedRoutes) {
+			return false
+		}
+
+		for _, l := range gw.Status.Listeners {
+			if l.AttachedRoutes != expectedAttachedRoutes[l.Name] {
+				return false
+			}
+		}
+
+		return true
+	})
+}
@@ -0,0 +1,58 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
+)
+
+func TestGatewayClassStatusObservedGeneration(t *testing.T) {
+	f := newFramework(t)
+
+	var gc v1beta1.GatewayClass
+	gcNsName := types.NamespacedName{Name: f.gatewayClassName}
+
+	// expectObservedGeneration waits until the Accepted condition of the GatewayClass is observed
+	// for the current generation of the GatewayClass.
+	expectObservedGeneration := func() {
+		f.eventually("the GatewayClass status to observe the latest generation", func() bool {
+			if err := k8sClient.Get(context.Background(), gcNsName, &gc); err != nil {
+				return false
+			}
+
+			cond := findCondition(gc.Status.Conditions, string(v1beta1.GatewayClassConditionStatusAccepted))
+
+			return cond != nil && cond.Status == metav1.ConditionTrue && cond.ObservedGeneration == gc.Generation
+		})
+	}
+
+	expectObservedGeneration()
+
+	generation := gc.Generation
+
+	f.update(&gc, func() {
+		gc.Spec.Description = helpers.GetPointer("updated")
+	})
+
+	if gc.Generation <= generation {
+		t.Fatalf("expected the update of the spec to bump the generation %d, got %d", generation, gc.Generation)
+	}
+
+	expectObservedGeneration()
+
+	for _, cond := range gc.Status.Conditions {
+		if cond.ObservedGeneration != gc.Generation {
+			t.Errorf(
+				"expected condition %s to have observedGeneration %d, got %d",
+				cond.Type,
+				gc.Generation,
+				cond.ObservedGeneration,
+			)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package integration_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/graph"
+)
+
+func TestHTTPRouteCreate(t *testing.T) {
+	f := newFramework(t)
+
+	f.createGateway("gateway", createHTTPListener("http", 80))
+	f.createService("coffee")
+	f.createHTTPRoute("coffee", "gateway", "cafe.example.com", "/coffee", "coffee")
+
+	f.expectHTTPConfig(
+		"listen 80 default_server;",
+		"server_name cafe.example.com;",
+		"location /coffee/ {",
+		"location = /coffee {",
+		"proxy_pass http://"+f.namespace+"_coffee_80$request_uri;",
+		"upstream "+f.namespace+"_coffee_80 {",
+	)
+
+	if f.runtimeMgr.ReloadCallCount() == 0 {
+		t.Error("expected NGINX to be reloaded")
+	}
+}
+
+func TestHTTPRouteUpdate(t *testing.T) {
+	f := newFramework(t)
+
+	f.createGateway("gateway", createHTTPListener("http", 80))
+	f.createService("coffee")
+	hr := f.createHTTPRoute("coffee", "gateway", "cafe.example.com", "/coffee", "coffee")
+
+	f.expectHTTPConfig("location /coffee/ {")
+
+	f.update(hr, func() {
+		hr.Spec.Rules[0].Matches[0].Path.Value = helpers.GetPointer("/tea")
+	})
+
+	f.expectHTTPConfig("location /tea/ {")
+	f.expectHTTPConfigExcludes("location /coffee/ {")
+}
+
+func TestHTTPRouteStreamingProxy(t *testing.T) {
+	f := newFramework(t)
+
+	f.createGateway("gateway", createHTTPListener("http", 80))
+	f.createService("events")
+	hr := f.createHTTPRoute("events", "gateway", "cafe.example.com", "/events", "events")
+
+	streamingDirectives := []string{
+		"proxy_buffering off;",
+		"proxy_cache off;",
+		"proxy_read_timeout 3600s;",
+	}
+
+	f.expectHTTPConfig("location /events/ {")
+	f.expectHTTPConfigExcludes(streamingDirectives...)
+
+	f.update(hr, func() {
+		hr.Annotations = map[string]string{graph.StreamingProxyAnnotation: "true"}
+	})
+
+	f.expectHTTPConfig(streamingDirectives...)
+
+	f.update(hr, func() {
+		hr.Annotations = nil
+	})
+
+	f.expectHTTPConfigExcludes(streamingDirectives...)
+}
+
+func TestHTTPRouteDelete(t *testing.T) {
+	f := newFramework(t)
+
+	f.createGateway("gateway", createHTTPListener("http", 80))
+	f.createService("coffee")
+	hr := f.createHTTPRoute("coffee", "gateway", "cafe.example.com", "/coffee", "coffee")
+
+	f.expectHTTPConfig("server_name cafe.example.com;")
+
+	f.delete(hr)
+
+	f.expectHTTPConfigExcludes("server_name cafe.example.com;", "upstream "+f.namespace+"_coffee_80 {")
+}
+
+func TestHTTPRouteEndpoints(t *testing.T) {
+	f := newFramework(t)
+
+	f.createGateway("gateway", createHTTPListener("http", 80))
+	f.createService("coffee")
+	f.createHTTPRoute("coffee", "gateway", "cafe.example.com", "/coffee", "coffee")
+
+	// The Service doesn't have any endpoints yet.
+	f.expectHTTPConfig("server unix:/var/lib/nginx/nginx-502-server.sock;")
+
+	f.createEndpointSlice("coffee", "10.0.0.10", "10.0.0.11")
+
+	f.expectHTTPConfig(
+		"server 10.0.0.10:8080;",
+		"server 10.0.0.11:8080;",
+	)
+}
+
+func TestHTTPRouteForGatewayOfAnotherGatewayClassIsIgnored(t *testing.T) {
+	f := newFramework(t)
+
+	f.createGateway("gateway", createHTTPListener("http", 80))
+	f.createService("coffee")
+
+	other := &v1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: f.namespace,
+			Name:      "other-gateway",
+		},
+		Spec: v1beta1.GatewaySpec{
+			GatewayClassName: "other-class",
+			Listeners:        []v1beta1.Listener{createHTTPListener("http", 80)},
+		},
+	}
+	f.create(other)
+
+	f.createHTTPRoute("ignored", "other-gateway", "ignored.example.com", "/ignored", "coffee")
+	f.createHTTPRoute("coffee", "gateway", "cafe.example.com", "/coffee", "coffee")
+
+	// Once the configuration includes the second route, the first route has already been processed.
+	content := f.expectHTTPConfig("server_name cafe.example.com;")
+
+	if strings.Contains(content, "ignored.example.com") {
+		t.Errorf("expected the HTTP configuration to exclude the route of another Gateway:\n%s", content)
+	}
+}
+
+func TestHTTPRouteStatus(t *testing.T) {
+	f := newFramework(t)
+
+	f.createGateway("gateway", createHTTPListener("http", 80))
+	f.createService("coffee")
+	f.createHTTPRoute("coffee", "gateway", "cafe.example.com", "/coffee", "coffee")
+
+	f.eventually("the HTTPRoute to be accepted", func() bool {
+		var hr v1beta1.HTTPRoute
+		if err := k8sClient.Get(context.Background(), f.nsname("coffee"), &hr); err != nil {
+			return false
+		}
+
+		if len(hr.Status.Parents) != 1 {
+			return false
+		}
+
+		cond := findCondition(hr.Status.Parents[0].Conditions, string(v1beta1.RouteConditionAccepted))
+
+		return cond != nil && cond.Status == metav1.ConditionTrue
+	})
+}
+
+func TestHTTPRouteStatusForMissingGateway(t *testing.T) {
+	f := newFramework(t)
+
+	f.createGateway("gateway", createHTTPListener("http", 80))
+	f.createService("coffee")
+	f.createHTTPRoute("coffee", "missing-gateway", "cafe.example.com", "/coffee", "coffee")
+
+	f.eventually("the HTTPRoute to be rejected with the NoMatchingParent reason", func() bool {
+		var hr v1beta1.HTTPRoute
+		if err := k8sClient.Get(context.Background(), f.nsname("coffee"), &hr); err != nil {
+			return false
+		}
+
+		if len(hr.Status.Parents) != 1 {
+			return false
+		}
+
+		cond := findCondition(hr.Status.Parents[0].Conditions, string(v1beta1.RouteConditionAccepted))
+
+		return cond != nil &&
+			cond.Status == metav1.ConditionFalse &&
+			cond.Reason == string(v1beta1.RouteReasonNoMatchingParent)
+	})
+}
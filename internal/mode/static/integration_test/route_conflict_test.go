@@ -0,0 +1,72 @@
+package integration_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestRouteConflictOlderRouteWins(t *testing.T) {
+	f := newFramework(t)
+
+	f.createGateway("gateway", createHTTPListener("http", 80))
+	f.createService("coffee-v1")
+	f.createService("coffee-v2")
+
+	f.createHTTPRoute("coffee-v1", "gateway", "cafe.example.com", "/coffee", "coffee-v1")
+	// The creation timestamp has the precision of one second.
+	time.Sleep(1100 * time.Millisecond)
+	f.createHTTPRoute("coffee-v2", "gateway", "cafe.example.com", "/coffee", "coffee-v2")
+
+	olderUpstream := "proxy_pass http://" + f.namespace + "_coffee-v1_80$request_uri;"
+	newerUpstream := "proxy_pass http://" + f.namespace + "_coffee-v2_80$request_uri;"
+
+	content := f.expectHTTPConfig(olderUpstream, newerUpstream)
+
+	// Both routes have the same path, so NGINX will route the requests according to the order of the matches,
+	// where the first match wins. The match of the older route must come first.
+	if strings.Index(content, olderUpstream) > strings.Index(content, newerUpstream) {
+		t.Errorf("expected the older route to take precedence:\n%s", content)
+	}
+}
+
+func TestRouteConflictDeleteOlderRoute(t *testing.T) {
+	f := newFramework(t)
+
+	f.createGateway("gateway", createHTTPListener("http", 80))
+	f.createService("coffee-v1")
+	f.createService("coffee-v2")
+
+	older := f.createHTTPRoute("coffee-v1", "gateway", "cafe.example.com", "/coffee", "coffee-v1")
+	f.createHTTPRoute("coffee-v2", "gateway", "cafe.example.com", "/coffee", "coffee-v2")
+
+	f.expectHTTPConfig("_coffee-v1_80$request_uri;", "_coffee-v2_80$request_uri;")
+
+	f.delete(older)
+
+	f.expectHTTPConfigExcludes("_coffee-v1_80$request_uri;")
+	f.expectHTTPConfig("location /coffee/ {", "_coffee-v2_80$request_uri;")
+}
+
+func TestListenerProtocolConflict(t *testing.T) {
+	f := newFramework(t)
+
+	f.createTLSSecret("cafe-secret")
+	f.createGateway(
+		"gateway",
+		createHTTPListener("http", 80),
+		createHTTPSListener("https", 80, "cafe-secret"),
+	)
+
+	for _, listener := range []string{"http", "https"} {
+		f.eventually("the listener "+listener+" to be conflicted", func() bool {
+			return listenerConditionStatus(f, "gateway", listener, string(v1beta1.ListenerConditionConflicted)) ==
+				metav1.ConditionTrue
+		})
+	}
+
+	f.expectHTTPConfigExcludes("listen 80")
+}
@@ -0,0 +1,78 @@
+package integration_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+// The integration tests run the static mode manager against a real API server and etcd started by envtest.
+// They require the envtest binaries, which can be installed with setup-envtest:
+// https://pkg.go.dev/sigs.k8s.io/controller-runtime/tools/setup-envtest
+// If the KUBEBUILDER_ASSETS environment variable is not set, the tests are skipped.
+// See the integration-test target in the Makefile.
+
+var (
+	clusterCfg *rest.Config
+	k8sClient  client.Client
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		fmt.Println("Skipping the integration tests: KUBEBUILDER_ASSETS is not set")
+		return 0
+	}
+
+	crdsDir, err := findGatewayAPICRDsDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to find the Gateway API CRDs: %v\n", err)
+		return 1
+	}
+
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths:     []string{crdsDir},
+		ErrorIfCRDPathMissing: true,
+	}
+
+	clusterCfg, err = testEnv.Start()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to start the test environment: %v\n", err)
+		return 1
+	}
+
+	defer func() {
+		if err := testEnv.Stop(); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to stop the test environment: %v\n", err)
+		}
+	}()
+
+	k8sClient, err = client.New(clusterCfg, client.Options{Scheme: newScheme()})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create the client: %v\n", err)
+		return 1
+	}
+
+	return m.Run()
+}
+
+// findGatewayAPICRDsDir finds the directory with the standard Gateway API CRDs in the Gateway API module,
+// so that the CRDs always match the version of the Gateway API used by NKG.
+func findGatewayAPICRDsDir() (string, error) {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "sigs.k8s.io/gateway-api").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the Gateway API module: %w", err)
+	}
+
+	return filepath.Join(strings.TrimSpace(string(out)), "config", "crd", "standard"), nil
+}
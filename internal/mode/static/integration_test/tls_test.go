@@ -0,0 +1,109 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestTLSListener(t *testing.T) {
+	f := newFramework(t)
+
+	secret := f.createTLSSecret("cafe-secret")
+	f.createGateway(
+		"gateway",
+		createHTTPListener("http", 80),
+		createHTTPSListener("https", 443, "cafe-secret"),
+	)
+	f.createService("coffee")
+	f.createHTTPRoute("coffee", "gateway", "cafe.example.com", "/coffee", "coffee")
+
+	pemFile := filepath.Join(secretsFolder, "ssl_keypair_"+f.namespace+"_cafe-secret.pem")
+
+	f.expectHTTPConfig(
+		"listen 443 ssl default_server;",
+		"listen 443 ssl;",
+		"ssl_certificate "+pemFile+";",
+		"ssl_certificate_key "+pemFile+";",
+	)
+
+	pem, err := f.readConfigFile(pemFile)
+	if err != nil {
+		t.Fatalf("failed to read the PEM file: %v", err)
+	}
+
+	if !bytes.Contains(pem, secret.Data["tls.crt"]) || !bytes.Contains(pem, secret.Data["tls.key"]) {
+		t.Errorf("expected the PEM file to include the certificate and the key of the Secret:\n%s", pem)
+	}
+}
+
+func TestTLSListenerSecretCreatedAfterGateway(t *testing.T) {
+	f := newFramework(t)
+
+	f.createGateway(
+		"gateway",
+		createHTTPListener("http", 80),
+		createHTTPSListener("https", 443, "cafe-secret"),
+	)
+	f.createService("coffee")
+	f.createHTTPRoute("coffee", "gateway", "cafe.example.com", "/coffee", "coffee")
+
+	// The HTTPS listener is invalid, because the Secret doesn't exist.
+	content := f.expectHTTPConfig("server_name cafe.example.com;")
+	if strings.Contains(content, "ssl_certificate ") {
+		t.Errorf("expected the HTTP configuration to exclude the SSL server:\n%s", content)
+	}
+
+	f.createTLSSecret("cafe-secret")
+
+	f.expectHTTPConfig("ssl_certificate " + filepath.Join(secretsFolder, "ssl_keypair_"+f.namespace+"_cafe-secret.pem"))
+}
+
+func TestTLSListenerInvalidSecret(t *testing.T) {
+	f := newFramework(t)
+
+	secret := f.createTLSSecret("cafe-secret")
+	f.createGateway("gateway", createHTTPSListener("https", 443, "cafe-secret"))
+
+	f.eventually("the HTTPS listener to be programmed", func() bool {
+		return listenerConditionStatus(f, "gateway", "https", string(v1beta1.ListenerConditionProgrammed)) ==
+			metav1.ConditionTrue
+	})
+
+	f.update(secret, func() {
+		secret.Data["tls.crt"] = []byte("invalid")
+	})
+
+	f.eventually("the HTTPS listener to have unresolved refs", func() bool {
+		return listenerConditionStatus(f, "gateway", "https", string(v1beta1.ListenerConditionResolvedRefs)) ==
+			metav1.ConditionFalse
+	})
+
+	f.expectHTTPConfigExcludes("ssl_certificate ")
+}
+
+// listenerConditionStatus returns the status of the condition of the listener of the Gateway.
+// It returns an empty string if the condition is not found.
+func listenerConditionStatus(f *framework, gatewayName, listenerName, condType string) metav1.ConditionStatus {
+	var gw v1beta1.Gateway
+	if err := k8sClient.Get(context.Background(), f.nsname(gatewayName), &gw); err != nil {
+		return ""
+	}
+
+	for _, l := range gw.Status.Listeners {
+		if string(l.Name) != listenerName {
+			continue
+		}
+
+		if cond := findCondition(l.Conditions, condType); cond != nil {
+			return cond.Status
+		}
+	}
+
+	return ""
+}
@@ -1,7 +1,11 @@
 package static
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"net/http"
+	"strconv"
 	"time"
 
 	apiv1 "k8s.io/api/core/v1"
@@ -10,12 +14,16 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/rest"
 	ctlr "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctlrmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	k8spredicate "sigs.k8s.io/controller-runtime/pkg/predicate"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/admin"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/controller"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/controller/filter"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/controller/index"
@@ -26,6 +34,7 @@ import (
 	ngxcfg "github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config"
 	ngxvalidation "github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config/validation"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/file"
+	ngxmetrics "github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/metrics"
 	ngxruntime "github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/runtime"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/relationship"
@@ -36,6 +45,8 @@ import (
 const (
 	// clusterTimeout is a timeout for connections to the Kubernetes API
 	clusterTimeout = 10 * time.Second
+	// nginxStatusTimeout is a timeout for the requests to the NGINX stub_status endpoint
+	nginxStatusTimeout = 5 * time.Second
 )
 
 var scheme = runtime.NewScheme()
@@ -46,29 +57,53 @@ func init() {
 	utilruntime.Must(discoveryV1.AddToScheme(scheme))
 }
 
+// StartManager starts the manager for the static mode. It blocks until the process receives a termination signal.
 func StartManager(cfg config.Config) error {
 	logger := cfg.Logger
 
-	options := manager.Options{
-		Scheme: scheme,
-		Logger: logger,
-		// We disable the metrics server because we reserve all ports (1-65535) for the data plane.
-		// Once we add support for Prometheus, we can make this port configurable by the user.
-		MetricsBindAddress: "0",
+	clusterCfg := ctlr.GetConfigOrDie()
+	clusterCfg.Timeout = clusterTimeout
+
+	// Clear the configuration folders to ensure that no files are left over in case the control plane was restarted
+	// (this assumes the folders are in a shared volume).
+	removedPaths, err := file.ClearFolders(file.NewStdLibOSFileManager(), ngxcfg.ConfigFolders)
+	for _, path := range removedPaths {
+		logger.Info("removed configuration file", "path", path)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot clear NGINX configuration folders: %w", err)
 	}
 
-	eventCh := make(chan interface{})
+	return RunManager(ctlr.SetupSignalHandler(), clusterCfg, cfg, NginxDependencies{
+		FileMgr:    file.NewManagerImpl(logger.WithName("nginxFileManager"), file.NewStdLibOSFileManager()),
+		RuntimeMgr: ngxruntime.NewManagerImpl(ngxruntime.WithReloadTimeout(cfg.NginxReloadTimeout)),
+	})
+}
 
-	clusterCfg := ctlr.GetConfigOrDie()
-	clusterCfg.Timeout = clusterTimeout
+// NginxDependencies holds the dependencies of the manager that interact with NGINX.
+type NginxDependencies struct {
+	// FileMgr writes the NGINX configuration files.
+	FileMgr file.Manager
+	// RuntimeMgr reloads NGINX.
+	RuntimeMgr ngxruntime.Manager
+}
+
+// RunManager runs the manager for the static mode against the cluster defined by clusterCfg.
+// Unlike StartManager, it doesn't require NGINX to run: the NGINX dependencies are provided by the caller.
+// This allows the integration tests to run the manager.
+// It blocks until the context is canceled.
+func RunManager(ctx context.Context, clusterCfg *rest.Config, cfg config.Config, nginxDeps NginxDependencies) error {
+	logger := cfg.Logger
+
+	eventCh := make(chan interface{})
 
-	mgr, err := manager.New(clusterCfg, options)
+	mgr, err := manager.New(clusterCfg, createManagerOptions(cfg))
 	if err != nil {
 		return fmt.Errorf("cannot build runtime manager: %w", err)
 	}
 
 	// Note: for any new object type or a change to the existing one,
-	// make sure to also update prepareFirstEventBatchPreparerArgs()
+	// make sure to also update prepareFirstEventBatchPreparer()
 	controllerRegCfgs := []struct {
 		objectType client.Object
 		options    []controller.Option
@@ -76,7 +111,7 @@ func StartManager(cfg config.Config) error {
 		{
 			objectType: &gatewayv1beta1.GatewayClass{},
 			options: []controller.Option{
-				controller.WithK8sPredicate(predicate.GatewayClassPredicate{ControllerName: cfg.GatewayCtlrName}),
+				controller.WithK8sPredicate(predicate.NewGatewayClassPredicate(cfg.GatewayCtlrName)),
 			},
 		},
 		{
@@ -96,7 +131,10 @@ func StartManager(cfg config.Config) error {
 		{
 			objectType: &apiv1.Service{},
 			options: []controller.Option{
-				controller.WithK8sPredicate(predicate.ServicePortsChangedPredicate{}),
+				controller.WithK8sPredicate(k8spredicate.Or(
+					predicate.ServicePortsChangedPredicate{},
+					predicate.ServiceAnnotationPredicate{},
+				)),
 			},
 		},
 		{
@@ -120,10 +158,28 @@ func StartManager(cfg config.Config) error {
 		},
 	}
 
-	ctx := ctlr.SetupSignalHandler()
+	if cfg.NginxConfigMapNsName != nil {
+		controllerRegCfgs = append(controllerRegCfgs, struct {
+			objectType client.Object
+			options    []controller.Option
+		}{
+			objectType: &apiv1.ConfigMap{},
+			options: []controller.Option{
+				controller.WithNamespacedNameFilter(filter.CreateSingleResourceFilter(*cfg.NginxConfigMapNsName)),
+			},
+		})
+	}
 
 	for _, regCfg := range controllerRegCfgs {
-		err := controller.Register(ctx, regCfg.objectType, mgr, eventCh, regCfg.options...)
+		// Every controller gets its own rate limiter, so that the changes of one kind of resource
+		// don't delay the reconciles of other kinds.
+		options := append(
+			regCfg.options,
+			controller.WithRateLimiter(controller.NewRateLimiter(cfg.ReconcileRateLimitQPS, cfg.ReconcileRateLimitBurst)),
+			controller.WithMaxConcurrentReconciles(cfg.MaxConcurrentReconciles),
+		)
+
+		err := controller.Register(ctx, regCfg.objectType, mgr, eventCh, options...)
 		if err != nil {
 			return fmt.Errorf("cannot register controller for %T: %w", regCfg.objectType, err)
 		}
@@ -140,24 +196,19 @@ func StartManager(cfg config.Config) error {
 		Validators: validation.Validators{
 			HTTPFieldsValidator: ngxvalidation.HTTPValidator{},
 		},
-		EventRecorder: recorder,
-		Scheme:        scheme,
+		EventRecorder:  recorder,
+		Scheme:         scheme,
+		EnableSnippets: cfg.EnableSnippets,
 	})
 
 	configGenerator := ngxcfg.NewGeneratorImpl()
 
-	// Clear the configuration folders to ensure that no files are left over in case the control plane was restarted
-	// (this assumes the folders are in a shared volume).
-	removedPaths, err := file.ClearFolders(file.NewStdLibOSFileManager(), ngxcfg.ConfigFolders)
-	for _, path := range removedPaths {
-		logger.Info("removed configuration file", "path", path)
-	}
-	if err != nil {
-		return fmt.Errorf("cannot clear NGINX configuration folders: %w", err)
-	}
+	serviceResolver := resolver.NewServiceResolverImpl(resolver.ServiceResolverConfig{
+		Client:   mgr.GetClient(),
+		Logger:   cfg.Logger.WithName("serviceResolver"),
+		NodeName: cfg.NodeName,
+	})
 
-	nginxFileMgr := file.NewManagerImpl(logger.WithName("nginxFileManager"), file.NewStdLibOSFileManager())
-	nginxRuntimeMgr := ngxruntime.NewManagerImpl()
 	statusUpdater := status.NewUpdater(status.UpdaterConfig{
 		GatewayCtlrName:          cfg.GatewayCtlrName,
 		GatewayClassName:         cfg.GatewayClassName,
@@ -166,20 +217,64 @@ func StartManager(cfg config.Config) error {
 		Logger:                   cfg.Logger.WithName("statusUpdater"),
 		Clock:                    status.NewRealClock(),
 		UpdateGatewayClassStatus: cfg.UpdateGatewayClassStatus,
+		DryRun:                   cfg.StatusDryRun,
 	})
 
 	eventHandler := newEventHandlerImpl(eventHandlerConfig{
-		processor:       processor,
-		serviceResolver: resolver.NewServiceResolverImpl(mgr.GetClient()),
-		generator:       configGenerator,
-		logger:          cfg.Logger.WithName("eventHandler"),
-		nginxFileMgr:    nginxFileMgr,
-		nginxRuntimeMgr: nginxRuntimeMgr,
-		statusUpdater:   statusUpdater,
+		processor:           processor,
+		serviceResolver:     serviceResolver,
+		generator:           configGenerator,
+		logger:              cfg.Logger.WithName("eventHandler"),
+		nginxFileMgr:        nginxDeps.FileMgr,
+		nginxRuntimeMgr:     nginxDeps.RuntimeMgr,
+		statusUpdater:       statusUpdater,
+		httpFieldsValidator: ngxvalidation.HTTPValidator{},
+		gatewayClassName:    cfg.GatewayClassName,
+		enableSnippets:      cfg.EnableSnippets,
 	})
 
-	objects, objectLists := prepareFirstEventBatchPreparerArgs(cfg.GatewayClassName, cfg.GatewayNsName)
-	firstBatchPreparer := events.NewFirstEventBatchPreparerImpl(mgr.GetCache(), objects, objectLists)
+	if err := addHealthChecks(mgr, eventHandler); err != nil {
+		return err
+	}
+
+	adminBindAddress, err := getAdminBindAddress(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Without the Secret, the token is empty, so the admin server rejects all requests except the pre-stop
+	// requests from the loopback interface.
+	getAdminToken := func(context.Context) (string, error) { return "", nil }
+	if cfg.AdminSecretNsName != nil {
+		getAdminToken = admin.NewSecretTokenGetter(mgr.GetClient(), *cfg.AdminSecretNsName)
+	}
+
+	adminServer := admin.NewServer(admin.ServerConfig{
+		Logger:        cfg.Logger.WithName("adminServer"),
+		GetToken:      getAdminToken,
+		QuitNginx:     nginxDeps.RuntimeMgr.Quit,
+		GetSnapshot:   eventHandler.getSnapshot,
+		ApplySnapshot: eventHandler.applySnapshot,
+		LogLevel:      cfg.LogLevel,
+		BindAddress:   adminBindAddress,
+	})
+
+	if err := mgr.Add(adminServer); err != nil {
+		return fmt.Errorf("cannot register admin server: %w", err)
+	}
+
+	if cfg.MetricsBindAddress != "" {
+		if err := addNginxStatusScraper(mgr, cfg); err != nil {
+			return err
+		}
+	}
+
+	firstBatchPreparer := prepareFirstEventBatchPreparer(
+		mgr.GetCache(),
+		cfg.GatewayClassName,
+		cfg.GatewayNsName,
+		cfg.NginxConfigMapNsName,
+	)
 
 	eventLoop := events.NewEventLoop(
 		eventCh,
@@ -196,30 +291,115 @@ func StartManager(cfg config.Config) error {
 	return mgr.Start(ctx)
 }
 
-func prepareFirstEventBatchPreparerArgs(
-	gcName string,
-	gwNsName *types.NamespacedName,
-) ([]client.Object, []client.ObjectList) {
-	objects := []client.Object{
-		&gatewayv1beta1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: gcName}},
+func createManagerOptions(cfg config.Config) manager.Options {
+	options := manager.Options{
+		Scheme: scheme,
+		Logger: cfg.Logger,
+		// By default, we disable the metrics server and the health probes because we reserve all ports (1-65535)
+		// for the data plane.
+		MetricsBindAddress:     "0",
+		HealthProbeBindAddress: "0",
 	}
-	objectLists := []client.ObjectList{
-		&apiv1.ServiceList{},
-		&apiv1.SecretList{},
-		&apiv1.NamespaceList{},
-		&discoveryV1.EndpointSliceList{},
-		&gatewayv1beta1.HTTPRouteList{},
-		&gatewayv1beta1.ReferenceGrantList{},
+
+	if cfg.MetricsBindAddress != "" {
+		options.MetricsBindAddress = cfg.MetricsBindAddress
 	}
 
+	if cfg.HealthProbeBindAddress != "" {
+		options.HealthProbeBindAddress = cfg.HealthProbeBindAddress
+	}
+
+	if cfg.PprofEnabled {
+		// The pprof endpoint exposes sensitive information about the process, so we only listen on the loopback
+		// interface. To access it, use kubectl port-forward.
+		options.PprofBindAddress = net.JoinHostPort("127.0.0.1", strconv.Itoa(cfg.PprofPort))
+	}
+
+	return options
+}
+
+// getAdminBindAddress returns the address of the admin server. Without the admin Secret, the admin server only
+// serves the pre-stop hook of the Pod, so it only listens on the loopback interface.
+func getAdminBindAddress(cfg config.Config) (string, error) {
+	if cfg.AdminSecretNsName != nil {
+		return cfg.AdminBindAddress, nil
+	}
+
+	_, port, err := net.SplitHostPort(cfg.AdminBindAddress)
+	if err != nil {
+		return "", fmt.Errorf("invalid admin bind address: %w", err)
+	}
+
+	return net.JoinHostPort("127.0.0.1", port), nil
+}
+
+// addHealthChecks registers the liveness and readiness checks of the manager.
+// The manager reports ready only after the eventHandler configures NGINX for the first time.
+func addHealthChecks(mgr manager.Manager, eventHandler *eventHandlerImpl) error {
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		return fmt.Errorf("cannot add health check: %w", err)
+	}
+
+	if err := mgr.AddReadyzCheck("readyz", eventHandler.readyCheck); err != nil {
+		return fmt.Errorf("cannot add readiness check: %w", err)
+	}
+
+	return nil
+}
+
+// addNginxStatusScraper registers the scraper of the NGINX stub_status endpoint, so that the NGINX metrics are
+// exposed along with the metrics of the manager.
+func addNginxStatusScraper(mgr manager.Manager, cfg config.Config) error {
+	scraper, err := ngxmetrics.NewStatusScraper(ngxmetrics.StatusScraperConfig{
+		Registerer: ctlrmetrics.Registry,
+		HTTPClient: &http.Client{Timeout: nginxStatusTimeout},
+		Logger:     cfg.Logger.WithName("nginxStatusScraper"),
+		URL:        fmt.Sprintf("http://%s/nginx_status", net.JoinHostPort("127.0.0.1", strconv.Itoa(cfg.NginxStatusPort))),
+		Interval:   cfg.NginxStatusScrapeInterval,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot create NGINX status scraper: %w", err)
+	}
+
+	if err := mgr.Add(scraper); err != nil {
+		return fmt.Errorf("cannot register NGINX status scraper: %w", err)
+	}
+
+	return nil
+}
+
+func prepareFirstEventBatchPreparer(
+	reader events.Reader,
+	gcName string,
+	gwNsName *types.NamespacedName,
+	nginxConfigMapNsName *types.NamespacedName,
+) *events.FirstEventBatchPreparerImpl {
+	preparer := events.NewFirstEventBatchPreparerImpl(reader).
+		RegisterType(&gatewayv1beta1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: gcName}}, nil).
+		RegisterType(nil, &apiv1.ServiceList{}).
+		RegisterType(nil, &apiv1.SecretList{}).
+		RegisterType(nil, &apiv1.NamespaceList{}).
+		RegisterType(nil, &discoveryV1.EndpointSliceList{}).
+		RegisterType(nil, &gatewayv1beta1.HTTPRouteList{}).
+		RegisterType(nil, &gatewayv1beta1.ReferenceGrantList{})
+
 	if gwNsName == nil {
-		objectLists = append(objectLists, &gatewayv1beta1.GatewayList{})
+		preparer.RegisterType(nil, &gatewayv1beta1.GatewayList{})
 	} else {
-		objects = append(
-			objects,
+		preparer.RegisterType(
 			&gatewayv1beta1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: gwNsName.Name, Namespace: gwNsName.Namespace}},
+			nil,
+		)
+	}
+
+	if nginxConfigMapNsName != nil {
+		preparer.RegisterType(
+			&apiv1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: nginxConfigMapNsName.Name, Namespace: nginxConfigMapNsName.Namespace},
+			},
+			nil,
 		)
 	}
 
-	return objects, objectLists
+	return preparer
 }
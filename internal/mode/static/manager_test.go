@@ -1,25 +1,43 @@
 package static
 
 import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
 	"testing"
 
+	"github.com/go-logr/logr"
 	. "github.com/onsi/gomega"
 	apiv1 "k8s.io/api/core/v1"
 	discoveryV1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/events"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/events/eventsfakes"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/status/statusfakes"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/config"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config/configfakes"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/file/filefakes"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/runtime/runtimefakes"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/graph"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/statefakes"
 )
 
-func TestPrepareFirstEventBatchPreparerArgs(t *testing.T) {
+func TestPrepareFirstEventBatchPreparer(t *testing.T) {
 	const gcName = "nginx"
 
 	tests := []struct {
-		name                string
-		gwNsName            *types.NamespacedName
-		expectedObjects     []client.Object
-		expectedObjectLists []client.ObjectList
+		name                 string
+		gwNsName             *types.NamespacedName
+		nginxConfigMapNsName *types.NamespacedName
+		expectedObjects      []client.Object
+		expectedObjectLists  []client.ObjectList
 	}{
 		{
 			name:     "gwNsName is nil",
@@ -56,16 +74,264 @@ func TestPrepareFirstEventBatchPreparerArgs(t *testing.T) {
 				&gatewayv1beta1.ReferenceGrantList{},
 			},
 		},
+		{
+			name: "nginxConfigMapNsName is not nil",
+			nginxConfigMapNsName: &types.NamespacedName{
+				Namespace: "nginx-gateway",
+				Name:      "nginx-config",
+			},
+			expectedObjects: []client.Object{
+				&gatewayv1beta1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "nginx"}},
+				&apiv1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "nginx-config", Namespace: "nginx-gateway"}},
+			},
+			expectedObjectLists: []client.ObjectList{
+				&apiv1.ServiceList{},
+				&apiv1.SecretList{},
+				&apiv1.NamespaceList{},
+				&discoveryV1.EndpointSliceList{},
+				&gatewayv1beta1.HTTPRouteList{},
+				&gatewayv1beta1.GatewayList{},
+				&gatewayv1beta1.ReferenceGrantList{},
+			},
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			g := NewGomegaWithT(t)
 
-			objects, objectLists := prepareFirstEventBatchPreparerArgs(gcName, test.gwNsName)
+			fakeReader := &eventsfakes.FakeReader{}
+
+			preparer := prepareFirstEventBatchPreparer(fakeReader, gcName, test.gwNsName, test.nginxConfigMapNsName)
+
+			_, err := preparer.Prepare(context.Background())
+			g.Expect(err).ToNot(HaveOccurred())
+
+			objects := make([]client.Object, 0, fakeReader.GetCallCount())
+			for i := 0; i < fakeReader.GetCallCount(); i++ {
+				_, _, obj, _ := fakeReader.GetArgsForCall(i)
+				objects = append(objects, obj)
+			}
+
+			objectLists := make([]client.ObjectList, 0, fakeReader.ListCallCount())
+			for i := 0; i < fakeReader.ListCallCount(); i++ {
+				_, list, _ := fakeReader.ListArgsForCall(i)
+				objectLists = append(objectLists, list)
+			}
 
 			g.Expect(objects).To(ConsistOf(test.expectedObjects))
 			g.Expect(objectLists).To(ConsistOf(test.expectedObjectLists))
 		})
 	}
 }
+
+func TestCreateManagerOptions(t *testing.T) {
+	tests := []struct {
+		name                           string
+		expectedPprofBindAddress       string
+		expectedMetricsBindAddress     string
+		expectedHealthProbeBindAddress string
+		cfg                            config.Config
+	}{
+		{
+			name:                           "pprof disabled",
+			cfg:                            config.Config{PprofPort: 6060, HealthProbeBindAddress: ":8081"},
+			expectedPprofBindAddress:       "",
+			expectedMetricsBindAddress:     "0",
+			expectedHealthProbeBindAddress: ":8081",
+		},
+		{
+			name:                           "pprof enabled",
+			cfg:                            config.Config{PprofEnabled: true, PprofPort: 6060, HealthProbeBindAddress: ":8081"},
+			expectedPprofBindAddress:       "127.0.0.1:6060",
+			expectedMetricsBindAddress:     "0",
+			expectedHealthProbeBindAddress: ":8081",
+		},
+		{
+			name:                           "metrics enabled",
+			cfg:                            config.Config{MetricsBindAddress: ":9113", HealthProbeBindAddress: ":8081"},
+			expectedPprofBindAddress:       "",
+			expectedMetricsBindAddress:     ":9113",
+			expectedHealthProbeBindAddress: ":8081",
+		},
+		{
+			name:                           "health probes disabled",
+			cfg:                            config.Config{PprofPort: 6060},
+			expectedPprofBindAddress:       "",
+			expectedMetricsBindAddress:     "0",
+			expectedHealthProbeBindAddress: "0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			options := createManagerOptions(test.cfg)
+
+			g.Expect(options.MetricsBindAddress).To(Equal(test.expectedMetricsBindAddress))
+			g.Expect(options.PprofBindAddress).To(Equal(test.expectedPprofBindAddress))
+			g.Expect(options.HealthProbeBindAddress).To(Equal(test.expectedHealthProbeBindAddress))
+		})
+	}
+}
+
+func TestGetAdminBindAddress(t *testing.T) {
+	tests := []struct {
+		name            string
+		expectedAddress string
+		cfg             config.Config
+	}{
+		{
+			name: "admin Secret",
+			cfg: config.Config{
+				AdminSecretNsName: &types.NamespacedName{Namespace: "test", Name: "admin"},
+				AdminBindAddress:  ":8082",
+			},
+			expectedAddress: ":8082",
+		},
+		{
+			name:            "no admin Secret",
+			cfg:             config.Config{AdminBindAddress: "0.0.0.0:8082"},
+			expectedAddress: "127.0.0.1:8082",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			address, err := getAdminBindAddress(test.cfg)
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(address).To(Equal(test.expectedAddress))
+		})
+	}
+}
+
+func TestGetAdminBindAddressInvalid(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	address, err := getAdminBindAddress(config.Config{AdminBindAddress: "8082"})
+
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(address).To(BeEmpty())
+}
+
+func TestPprofEndpoint(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// find a free ephemeral port for the pprof endpoint
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).ToNot(HaveOccurred())
+	port := listener.Addr().(*net.TCPAddr).Port
+	g.Expect(listener.Close()).To(Succeed())
+
+	cfg := config.Config{
+		Logger:       logr.Discard(),
+		PprofEnabled: true,
+		PprofPort:    port,
+	}
+
+	// The manager doesn't connect to the API server until a controller is registered,
+	// so the address of the API server doesn't need to be reachable.
+	mgr, err := manager.New(&rest.Config{Host: "http://127.0.0.1:1"}, createManagerOptions(cfg))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+
+	go func() {
+		errCh <- mgr.Start(ctx)
+	}()
+
+	defer func() {
+		cancel()
+		g.Expect(<-errCh).To(Succeed())
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://127.0.0.1:%d/debug/pprof/", port), nil)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Eventually(func() (int, error) {
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+
+		return resp.StatusCode, nil
+	}).Should(Equal(http.StatusOK))
+}
+
+func TestHealthProbeEndpoints(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// find a free ephemeral port for the health probe endpoints
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).ToNot(HaveOccurred())
+	port := listener.Addr().(*net.TCPAddr).Port
+	g.Expect(listener.Close()).To(Succeed())
+
+	cfg := config.Config{
+		Logger:                 logr.Discard(),
+		HealthProbeBindAddress: fmt.Sprintf("127.0.0.1:%d", port),
+	}
+
+	// The manager doesn't connect to the API server until a controller is registered,
+	// so the address of the API server doesn't need to be reachable.
+	mgr, err := manager.New(&rest.Config{Host: "http://127.0.0.1:1"}, createManagerOptions(cfg))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	fakeProcessor := &statefakes.FakeChangeProcessor{}
+	fakeProcessor.ProcessReturns(true /* changed */, &graph.Graph{})
+
+	handler := newEventHandlerImpl(eventHandlerConfig{
+		processor:       fakeProcessor,
+		generator:       &configfakes.FakeGenerator{},
+		logger:          logr.Discard(),
+		nginxFileMgr:    &filefakes.FakeManager{},
+		nginxRuntimeMgr: &runtimefakes.FakeManager{},
+		statusUpdater:   &statusfakes.FakeUpdater{},
+	})
+
+	g.Expect(addHealthChecks(mgr, handler)).To(Succeed())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+
+	go func() {
+		errCh <- mgr.Start(ctx)
+	}()
+
+	defer func() {
+		cancel()
+		g.Expect(<-errCh).To(Succeed())
+	}()
+
+	getStatusCode := func(path string) func() (int, error) {
+		return func() (int, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://127.0.0.1:%d%s", port, path), nil)
+			if err != nil {
+				return 0, err
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return 0, err
+			}
+			defer resp.Body.Close()
+
+			return resp.StatusCode, nil
+		}
+	}
+
+	g.Eventually(getStatusCode("/healthz")).Should(Equal(http.StatusOK))
+
+	// NGINX is not configured before the first batch is handled
+	g.Expect(getStatusCode("/readyz")()).To(Equal(http.StatusInternalServerError))
+
+	handler.HandleEventBatch(ctx, events.EventBatch{&events.UpsertEvent{Resource: &gatewayv1beta1.HTTPRoute{}}})
+
+	g.Expect(getStatusCode("/readyz")()).To(Equal(http.StatusOK))
+}
@@ -2,17 +2,30 @@ package config
 
 import (
 	"bytes"
+	"sync"
 	"text/template"
 )
 
+// bufferPool holds the buffers for executing templates, so that the buffers don't need to grow from scratch
+// every time the configuration is generated.
+var bufferPool = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
 // executes the template with the given data.
 func execute(template *template.Template, data interface{}) []byte {
-	var buf bytes.Buffer
+	buf := bufferPool.Get().(*bytes.Buffer)
+	defer func() {
+		buf.Reset()
+		bufferPool.Put(buf)
+	}()
 
-	err := template.Execute(&buf, data)
+	err := template.Execute(buf, data)
 	if err != nil {
 		panic(err)
 	}
 
-	return buf.Bytes()
+	return bytes.Clone(buf.Bytes())
 }
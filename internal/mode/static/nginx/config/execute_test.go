@@ -13,7 +13,7 @@ func TestExecute(t *testing.T) {
 		}
 	}()
 
-	bytes := execute(serversTemplate, []http.Server{})
+	bytes := execute(serversTemplate, []http.Server{{IsDefaultHTTP: true, Port: 80}})
 	if len(bytes) == 0 {
 		t.Error("template.execute() did not generate anything")
 	}
@@ -0,0 +1,337 @@
+package config_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config/validation"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/file"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/resolver"
+)
+
+// FuzzGenerate feeds arbitrary values into the fields of dataplane.Configuration that end up in the NGINX
+// configuration and verifies that the generated configuration is syntactically valid.
+//
+// Generate expects the caller to validate the configuration first, so the fuzzer only uses the values that pass
+// the same validation NKG applies when building the dataplane.Configuration. As a result, any failure here means that
+// either the validation rules or the templates allow a value to break the NGINX configuration.
+func FuzzGenerate(f *testing.F) {
+	f.Add("cafe.example.com", "/coffee", "x-version", "v1", "x-add", "my-value", "10.0.0.1", int32(8080))
+	f.Add("*.example.com", "/", "accept", "text/plain", "x-forwarded", `escaped \" quote`, "::1", int32(80))
+	f.Add("example.com", "/tea/{id}", "x", "$remote_addr", "x-set", "$request_uri", "10.0.0.2", int32(443))
+	f.Add("example.com", "/path;", "h", "v", "x-set", `\`, "not-an-ip", int32(-1))
+
+	validator := validation.HTTPValidator{}
+	generator := config.NewGeneratorImpl()
+
+	f.Fuzz(func(
+		t *testing.T,
+		hostname string,
+		path string,
+		headerName string,
+		headerValue string,
+		requestHeaderName string,
+		requestHeaderValue string,
+		address string,
+		port int32,
+	) {
+		if !isValidHostname(hostname) ||
+			validator.ValidatePathInMatch(path) != nil ||
+			validator.ValidateHeaderNameInMatch(headerName) != nil ||
+			validator.ValidateHeaderValueInMatch(headerValue) != nil ||
+			validator.ValidateRequestHeaderName(requestHeaderName) != nil ||
+			validator.ValidateRequestHeaderValue(requestHeaderValue) != nil ||
+			net.ParseIP(address) == nil ||
+			port <= 0 || port > 65535 {
+			t.Skip("invalid input")
+		}
+
+		conf := createFuzzConfiguration(
+			hostname,
+			path,
+			headerName,
+			headerValue,
+			requestHeaderName,
+			requestHeaderValue,
+			address,
+			port,
+		)
+
+		for _, generated := range generator.Generate(conf) {
+			if generated.Type != file.TypeRegular {
+				continue
+			}
+
+			if err := validateNGINXConfigSyntax(generated.Content); err != nil {
+				t.Fatalf(
+					"generated invalid NGINX configuration %s: %v\n%s",
+					generated.Path,
+					err,
+					generated.Content,
+				)
+			}
+		}
+	})
+}
+
+func isValidHostname(hostname string) bool {
+	// Gateway API allows a wildcard only as the first DNS label.
+	return len(k8svalidation.IsDNS1123Subdomain(strings.TrimPrefix(hostname, "*."))) == 0
+}
+
+func createFuzzConfiguration(
+	hostname string,
+	path string,
+	headerName string,
+	headerValue string,
+	requestHeaderName string,
+	requestHeaderValue string,
+	address string,
+	port int32,
+) dataplane.Configuration {
+	hr := &v1beta1.HTTPRoute{
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
+				{
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+								Value: helpers.GetPointer(path),
+							},
+							Headers: []v1beta1.HTTPHeaderMatch{
+								{
+									Type:  helpers.GetPointer(v1beta1.HeaderMatchExact),
+									Name:  v1beta1.HTTPHeaderName(headerName),
+									Value: headerValue,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	bg := dataplane.BackendGroup{
+		Source: types.NamespacedName{Namespace: "test", Name: "hr"},
+		Backends: []dataplane.Backend{
+			{UpstreamName: "fuzz", Valid: true, Weight: 1},
+			{UpstreamName: "fuzz-2", Valid: true, Weight: 1},
+		},
+	}
+
+	pathRules := []dataplane.PathRule{
+		{
+			Path:     path,
+			PathType: dataplane.PathTypePrefix,
+			MatchRules: []dataplane.MatchRule{
+				{
+					Source:       hr,
+					BackendGroup: bg,
+					Filters: dataplane.Filters{
+						RequestHeaderModifiers: &dataplane.HTTPHeaderFilter{
+							Set:    []dataplane.HTTPHeader{{Name: requestHeaderName, Value: requestHeaderValue}},
+							Add:    []dataplane.HTTPHeader{{Name: requestHeaderName, Value: requestHeaderValue}},
+							Remove: []string{requestHeaderName},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return dataplane.Configuration{
+		HTTPServers: []dataplane.VirtualServer{
+			{
+				IsDefault: true,
+				Port:      port,
+			},
+			{
+				Hostname:  hostname,
+				PathRules: pathRules,
+				Port:      port,
+			},
+		},
+		SSLServers: []dataplane.VirtualServer{
+			{
+				IsDefault: true,
+				Port:      port,
+			},
+			{
+				Hostname:  hostname,
+				SSL:       &dataplane.SSL{KeyPairID: "fuzz-keypair"},
+				PathRules: pathRules,
+				Port:      port,
+			},
+		},
+		Upstreams: []dataplane.Upstream{
+			{
+				Name:      "fuzz",
+				Endpoints: []resolver.Endpoint{{Address: address, Port: port}},
+			},
+			{
+				Name: "fuzz-2",
+			},
+		},
+		BackendGroups: []dataplane.BackendGroup{bg},
+		SSLKeyPairs: map[dataplane.SSLKeyPairID]dataplane.SSLKeyPair{
+			"fuzz-keypair": {
+				Cert: []byte("cert"),
+				Key:  []byte("key"),
+			},
+		},
+	}
+}
+
+// validateNGINXConfigSyntax validates the syntax of the NGINX configuration, following the tokenizing rules of
+// ngx_conf_read_token() in NGINX: it ensures that all quotes are closed, that quoted strings are followed by
+// a separator, that all blocks are balanced and that every directive is terminated either by ';' or '{'.
+// It doesn't validate the semantics of the directives.
+func validateNGINXConfigSyntax(conf []byte) error {
+	var (
+		depth      int
+		args       int
+		line       = 1
+		inWord     bool
+		variable   bool
+		quote      byte
+		escaped    bool
+		needSpace  bool
+		inComment  bool
+		lastIsWord bool
+	)
+
+	endWord := func() {
+		if inWord || lastIsWord {
+			args++
+		}
+		inWord = false
+		lastIsWord = false
+	}
+
+	for i := 0; i < len(conf); i++ {
+		ch := conf[i]
+
+		if ch == '\n' {
+			line++
+		}
+
+		if inComment {
+			if ch == '\n' {
+				inComment = false
+			}
+			continue
+		}
+
+		if quote != 0 {
+			switch {
+			case escaped:
+				escaped = false
+			case ch == '\\':
+				escaped = true
+			case ch == quote:
+				quote = 0
+				needSpace = true
+				lastIsWord = true
+			}
+			continue
+		}
+
+		if needSpace {
+			switch ch {
+			case ' ', '\t', '\r', '\n', ';', '{', ')':
+				needSpace = false
+			default:
+				return fmt.Errorf("line %d: unexpected %q after a quoted string", line, ch)
+			}
+		}
+
+		if inWord {
+			if escaped {
+				escaped = false
+				continue
+			}
+			if ch == '{' && variable {
+				variable = false
+				continue
+			}
+			variable = false
+
+			switch ch {
+			case '\\':
+				escaped = true
+				continue
+			case '$':
+				variable = true
+				continue
+			case ' ', '\t', '\r', '\n', ';', '{':
+				endWord()
+			default:
+				continue
+			}
+		}
+
+		switch ch {
+		case ' ', '\t', '\r', '\n':
+			endWord()
+		case '#':
+			endWord()
+			inComment = true
+		case '"', '\'':
+			endWord()
+			quote = ch
+		case ';':
+			endWord()
+			if args == 0 {
+				return fmt.Errorf("line %d: unexpected ';'", line)
+			}
+			args = 0
+		case '{':
+			endWord()
+			if args == 0 {
+				return fmt.Errorf("line %d: unexpected '{'", line)
+			}
+			args = 0
+			depth++
+		case '}':
+			endWord()
+			if args != 0 {
+				return fmt.Errorf("line %d: unexpected '}', the previous directive is not terminated", line)
+			}
+			if depth == 0 {
+				return fmt.Errorf("line %d: unexpected '}'", line)
+			}
+			depth--
+		default:
+			inWord = true
+			variable = ch == '$'
+		}
+	}
+
+	if quote != 0 {
+		return errors.New("unexpected end of file, expecting a closing quote")
+	}
+
+	endWord()
+
+	if args != 0 {
+		return errors.New("unexpected end of file, expecting ';' or '}'")
+	}
+
+	if depth != 0 {
+		return errors.New("unexpected end of file, expecting '}'")
+	}
+
+	return nil
+}
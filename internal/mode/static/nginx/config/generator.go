@@ -15,15 +15,26 @@ const (
 
 	// httpFolder is the folder where NGINX HTTP configuration files are stored.
 	httpFolder = configFolder + "/conf.d"
+	// serversFolder is the folder where the configuration files of the servers are stored.
+	serversFolder = httpFolder + "/servers"
 	// secretsFolder is the folder where secrets (like TLS certs/keys) are stored.
 	secretsFolder = configFolder + "/secrets"
+	// mainFolder is the folder where the configuration files of the main context are stored.
+	mainFolder = configFolder + "/main-includes"
+	// eventsFolder is the folder where the configuration files of the events context are stored.
+	eventsFolder = configFolder + "/events-includes"
 
 	// httpConfigFile is the path to the configuration file with HTTP configuration.
 	httpConfigFile = httpFolder + "/http.conf"
+	// mainConfigFile is the path to the configuration file with the configuration of the main context.
+	mainConfigFile = mainFolder + "/main.conf"
+	// eventsConfigFile is the path to the configuration file with the configuration of the events context.
+	eventsConfigFile = eventsFolder + "/events.conf"
 )
 
 // ConfigFolders is a list of folders where NGINX configuration files are stored.
-var ConfigFolders = []string{httpFolder, secretsFolder}
+// serversFolder comes before httpFolder, so that serversFolder is empty when httpFolder is cleared.
+var ConfigFolders = []string{serversFolder, httpFolder, secretsFolder, mainFolder, eventsFolder}
 
 // Generator generates NGINX configuration files.
 // This interface is used for testing purposes only.
@@ -36,10 +47,15 @@ type Generator interface {
 //
 // It generates files to be written to the following locations, which must exist and available for writing:
 // - httpFolder, for HTTP configuration files.
+// - serversFolder, for the configuration files of the servers. Every server has its own file.
 // - secretsFolder, for secrets.
+// - mainFolder, for the configuration of the main context, like the number of the worker processes.
+// - eventsFolder, for the configuration of the events context, like the number of the worker connections.
 //
 // It also expects that the main NGINX configuration file nginx.conf is located in configFolder and nginx.conf
-// includes (https://nginx.org/en/docs/ngx_core_module.html#include) the files from httpFolder.
+// includes (https://nginx.org/en/docs/ngx_core_module.html#include) the files from httpFolder and serversFolder
+// in the http context, the files from mainFolder in the main context, and the files from eventsFolder in the events
+// context.
 type GeneratorImpl struct{}
 
 // NewGeneratorImpl creates a new GeneratorImpl.
@@ -55,13 +71,27 @@ type executeFunc func(configuration dataplane.Configuration) []byte
 // In case of invalid configuration, NGINX will fail to reload or could be configured with malicious configuration.
 // To validate, use the validators from the validation package.
 func (g GeneratorImpl) Generate(conf dataplane.Configuration) []file.File {
-	files := make([]file.File, 0, len(conf.SSLKeyPairs)+1 /* http config */)
+	files := make(
+		[]file.File,
+		0,
+		len(conf.SSLKeyPairs)+3 /* http, main and events config */ +len(conf.HTTPServers)+len(conf.SSLServers),
+	)
 
 	for id, pair := range conf.SSLKeyPairs {
 		files = append(files, generatePEM(id, pair.Cert, pair.Key))
+
+		if len(pair.CACert) > 0 {
+			files = append(files, generateCACertPEM(id, pair.CACert))
+		}
+	}
+
+	for id, params := range conf.DHParams {
+		files = append(files, generateDHParamsPEM(id, params))
 	}
 
 	files = append(files, generateHTTPConfig(conf))
+	files = append(files, generateServerFiles(conf)...)
+	files = append(files, generateMainConfig(conf), generateEventsConfig(conf))
 
 	return files
 }
@@ -83,10 +113,49 @@ func generatePEMFileName(id dataplane.SSLKeyPairID) string {
 	return filepath.Join(secretsFolder, string(id)+".pem")
 }
 
+func generateCACertPEM(id dataplane.SSLKeyPairID, caCert []byte) file.File {
+	return file.File{
+		Content: caCert,
+		Path:    generateCACertPEMFileName(id),
+		Type:    file.TypeSecret,
+	}
+}
+
+// generateCACertPEMFileName returns the name of the file with the CA certificate of the key pair.
+// The underscore can't be a part of the names of Secrets and namespaces, so the file name doesn't conflict with
+// the file names of other key pairs.
+func generateCACertPEMFileName(id dataplane.SSLKeyPairID) string {
+	return filepath.Join(secretsFolder, string(id)+"_ca.pem")
+}
+
+func generateDHParamsPEM(id dataplane.DHParamsID, params []byte) file.File {
+	return file.File{
+		Content: params,
+		Path:    generateDHParamsPEMFileName(id),
+		Type:    file.TypeSecret,
+	}
+}
+
+func generateDHParamsPEMFileName(id dataplane.DHParamsID) string {
+	return filepath.Join(secretsFolder, string(id)+".pem")
+}
+
 func generateHTTPConfig(conf dataplane.Configuration) file.File {
-	var c []byte
-	for _, execute := range getExecuteFuncs() {
-		c = append(c, execute(conf)...)
+	executeFuncs := getExecuteFuncs()
+
+	results := make([][]byte, 0, len(executeFuncs))
+	size := 0
+
+	for _, execute := range executeFuncs {
+		result := execute(conf)
+		results = append(results, result)
+		size += len(result)
+	}
+
+	// allocate the content once instead of growing it with every result, because the config can be large.
+	c := make([]byte, 0, size)
+	for _, result := range results {
+		c = append(c, result...)
 	}
 
 	return file.File{
@@ -96,11 +165,33 @@ func generateHTTPConfig(conf dataplane.Configuration) file.File {
 	}
 }
 
+func generateMainConfig(conf dataplane.Configuration) file.File {
+	return file.File{
+		Content: executeMainConfig(conf),
+		Path:    mainConfigFile,
+		Type:    file.TypeRegular,
+	}
+}
+
+func generateEventsConfig(conf dataplane.Configuration) file.File {
+	return file.File{
+		Content: executeEventsConfig(conf),
+		Path:    eventsConfigFile,
+		Type:    file.TypeRegular,
+	}
+}
+
 func getExecuteFuncs() []executeFunc {
 	return []executeFunc{
+		executeServerTokens,
+		executeRealIP,
+		executeResolver,
+		executeOpenFileCache,
+		executeGzip,
+		executeHTTPSnippet,
 		executeUpstreams,
 		executeSplitClients,
-		executeServers,
+		executeInternalServers,
 		executeMaps,
 	}
 }
@@ -1,14 +1,20 @@
 package config_test
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/file"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/resolver"
 )
 
 func TestGenerate(t *testing.T) {
@@ -54,10 +60,17 @@ func TestGenerate(t *testing.T) {
 		BackendGroups: []dataplane.BackendGroup{bg},
 		SSLKeyPairs: map[dataplane.SSLKeyPairID]dataplane.SSLKeyPair{
 			"test-keypair": {
-				Cert: []byte("test-cert"),
-				Key:  []byte("test-key"),
+				Cert:   []byte("test-cert"),
+				Key:    []byte("test-key"),
+				CACert: []byte("test-ca-cert"),
 			},
 		},
+		DHParams: map[dataplane.DHParamsID][]byte{
+			"test-dhparams": []byte("test-dhparams-content"),
+		},
+		HTTPSnippet:       "gzip on;",
+		WorkerProcesses:   "auto",
+		WorkerConnections: 4096,
 	}
 	g := NewGomegaWithT(t)
 
@@ -65,7 +78,7 @@ func TestGenerate(t *testing.T) {
 
 	files := generator.Generate(conf)
 
-	g.Expect(files).To(HaveLen(2))
+	g.Expect(files).To(HaveLen(10))
 
 	g.Expect(files[0]).To(Equal(file.File{
 		Type:    file.TypeSecret,
@@ -73,13 +86,207 @@ func TestGenerate(t *testing.T) {
 		Content: []byte("test-cert\ntest-key"),
 	}))
 
-	g.Expect(files[1].Type).To(Equal(file.TypeRegular))
-	g.Expect(files[1].Path).To(Equal("/etc/nginx/conf.d/http.conf"))
-	httpCfg := string(files[1].Content) // converting to string so that on failure gomega prints strings not byte arrays
+	g.Expect(files[1]).To(Equal(file.File{
+		Type:    file.TypeSecret,
+		Path:    "/etc/nginx/secrets/test-keypair_ca.pem",
+		Content: []byte("test-ca-cert"),
+	}))
+
+	g.Expect(files[2]).To(Equal(file.File{
+		Type:    file.TypeSecret,
+		Path:    "/etc/nginx/secrets/test-dhparams.pem",
+		Content: []byte("test-dhparams-content"),
+	}))
+
+	g.Expect(files[3].Type).To(Equal(file.TypeRegular))
+	g.Expect(files[3].Path).To(Equal("/etc/nginx/conf.d/http.conf"))
+	httpCfg := string(files[3].Content) // converting to string so that on failure gomega prints strings not byte arrays
 	// Note: this only verifies that Generate() returns a byte array with upstream, server, and split_client blocks.
 	// It does not test the correctness of those blocks. That functionality is covered by other tests in this package.
-	g.Expect(httpCfg).To(ContainSubstring("listen 80"))
-	g.Expect(httpCfg).To(ContainSubstring("listen 443"))
+	g.Expect(httpCfg).ToNot(ContainSubstring("listen 80"))
+	g.Expect(httpCfg).ToNot(ContainSubstring("listen 443"))
 	g.Expect(httpCfg).To(ContainSubstring("upstream"))
 	g.Expect(httpCfg).To(ContainSubstring("split_clients"))
+	g.Expect(httpCfg).To(ContainSubstring("gzip on;"))
+	g.Expect(httpCfg).To(ContainSubstring("server_tokens off;"))
+	g.Expect(httpCfg).To(ContainSubstring("listen unix:/var/lib/nginx/nginx-502-server.sock;"))
+
+	// Every server has its own file.
+	expectedServerFiles := []struct {
+		path    string
+		content string
+	}{
+		{path: "/etc/nginx/conf.d/servers/_default_80.conf", content: "listen 80 default_server;"},
+		{path: "/etc/nginx/conf.d/servers/example.com_80.conf", content: "server_name example.com;"},
+		{path: "/etc/nginx/conf.d/servers/_default_443_ssl.conf", content: "listen 443 ssl default_server;"},
+		{path: "/etc/nginx/conf.d/servers/example.com_443_ssl.conf", content: "listen 443 ssl;"},
+	}
+
+	for i, expected := range expectedServerFiles {
+		f := files[4+i]
+
+		g.Expect(f.Type).To(Equal(file.TypeRegular))
+		g.Expect(f.Path).To(Equal(expected.path))
+		g.Expect(string(f.Content)).To(ContainSubstring(expected.content))
+	}
+
+	g.Expect(files[8].Type).To(Equal(file.TypeRegular))
+	g.Expect(files[8].Path).To(Equal("/etc/nginx/main-includes/main.conf"))
+	g.Expect(string(files[8].Content)).To(ContainSubstring("worker_processes auto;"))
+	g.Expect(string(files[8].Content)).To(ContainSubstring("worker_shutdown_timeout 10000ms;"))
+
+	g.Expect(files[9].Type).To(Equal(file.TypeRegular))
+	g.Expect(files[9].Path).To(Equal("/etc/nginx/events-includes/events.conf"))
+	g.Expect(string(files[9].Content)).To(ContainSubstring("worker_connections 4096;"))
+}
+
+func BenchmarkGenerateConfig(b *testing.B) {
+	tests := []struct {
+		// maxDuration is the maximum duration of a single Generate call. Zero means no limit.
+		maxDuration time.Duration
+		servers     int
+	}{
+		{servers: 10},
+		{servers: 50},
+		{servers: 100},
+		{servers: 500, maxDuration: 100 * time.Millisecond},
+	}
+
+	generator := config.NewGeneratorImpl()
+
+	for _, test := range tests {
+		conf := generateConfiguration(test.servers)
+
+		b.Run(fmt.Sprintf("%d servers", test.servers), func(b *testing.B) {
+			b.ReportAllocs()
+
+			var size int
+			for i := 0; i < b.N; i++ {
+				files := generator.Generate(conf)
+
+				size = 0
+				for _, f := range files {
+					size += len(f.Content)
+				}
+			}
+
+			b.SetBytes(int64(size))
+			b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "configs/s")
+
+			if perOp := b.Elapsed() / time.Duration(b.N); test.maxDuration != 0 && perOp > test.maxDuration {
+				b.Errorf("generating config for %d servers took %v, expected at most %v", test.servers, perOp, test.maxDuration)
+			}
+		})
+	}
+}
+
+// generateConfiguration generates a Configuration with n HTTP and n SSL servers, where every server routes
+// to its own backend group of two upstreams, each with three endpoints.
+func generateConfiguration(n int) dataplane.Configuration {
+	conf := dataplane.Configuration{
+		HTTPServers:   make([]dataplane.VirtualServer, 0, n+1),
+		SSLServers:    make([]dataplane.VirtualServer, 0, n+1),
+		Upstreams:     make([]dataplane.Upstream, 0, 2*n),
+		BackendGroups: make([]dataplane.BackendGroup, 0, n),
+		SSLKeyPairs: map[dataplane.SSLKeyPairID]dataplane.SSLKeyPair{
+			"test-keypair": {
+				Cert: []byte("test-cert"),
+				Key:  []byte("test-key"),
+			},
+		},
+	}
+
+	conf.HTTPServers = append(conf.HTTPServers, dataplane.VirtualServer{IsDefault: true, Port: 80})
+	conf.SSLServers = append(conf.SSLServers, dataplane.VirtualServer{IsDefault: true, Port: 443})
+
+	for i := 0; i < n; i++ {
+		hr := &v1beta1.HTTPRoute{
+			Spec: v1beta1.HTTPRouteSpec{
+				Rules: []v1beta1.HTTPRouteRule{
+					{
+						Matches: []v1beta1.HTTPRouteMatch{
+							{
+								Path: &v1beta1.HTTPPathMatch{
+									Value: helpers.GetPointer("/"),
+								},
+							},
+							{
+								Path: &v1beta1.HTTPPathMatch{
+									Value: helpers.GetPointer("/coffee"),
+								},
+								Headers: []v1beta1.HTTPHeaderMatch{
+									{
+										Type:  helpers.GetPointer(v1beta1.HeaderMatchExact),
+										Name:  "version",
+										Value: "v1",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		bg := dataplane.BackendGroup{
+			Source: types.NamespacedName{Namespace: "test", Name: fmt.Sprintf("hr-%d", i)},
+			Backends: []dataplane.Backend{
+				{UpstreamName: fmt.Sprintf("test_coffee-%d_80", i), Valid: true, Weight: 1},
+				{UpstreamName: fmt.Sprintf("test_tea-%d_80", i), Valid: true, Weight: 1},
+			},
+		}
+
+		pathRules := []dataplane.PathRule{
+			{
+				Path:     "/",
+				PathType: dataplane.PathTypePrefix,
+				MatchRules: []dataplane.MatchRule{
+					{
+						Source:       hr,
+						BackendGroup: bg,
+					},
+				},
+			},
+			{
+				Path:     "/coffee",
+				PathType: dataplane.PathTypePrefix,
+				MatchRules: []dataplane.MatchRule{
+					{
+						Source:       hr,
+						BackendGroup: bg,
+						MatchIdx:     1,
+					},
+				},
+			},
+		}
+
+		hostname := fmt.Sprintf("cafe-%d.example.com", i)
+
+		conf.HTTPServers = append(conf.HTTPServers, dataplane.VirtualServer{
+			Hostname:  hostname,
+			PathRules: pathRules,
+			Port:      80,
+		})
+		conf.SSLServers = append(conf.SSLServers, dataplane.VirtualServer{
+			Hostname:  hostname,
+			SSL:       &dataplane.SSL{KeyPairID: "test-keypair"},
+			PathRules: pathRules,
+			Port:      443,
+		})
+
+		for _, b := range bg.Backends {
+			conf.Upstreams = append(conf.Upstreams, dataplane.Upstream{
+				Name: b.UpstreamName,
+				Endpoints: []resolver.Endpoint{
+					{Address: fmt.Sprintf("10.0.%d.1", i%256), Port: 8080},
+					{Address: fmt.Sprintf("10.0.%d.2", i%256), Port: 8080},
+					{Address: fmt.Sprintf("10.0.%d.3", i%256), Port: 8080},
+				},
+			})
+		}
+
+		conf.BackendGroups = append(conf.BackendGroups, bg)
+	}
+
+	return conf
 }
@@ -0,0 +1,17 @@
+package config
+
+import (
+	gotemplate "text/template"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+)
+
+var gzipTemplate = gotemplate.Must(gotemplate.New("gzip").Parse(gzipTemplateText))
+
+func executeGzip(conf dataplane.Configuration) []byte {
+	if conf.Gzip == nil {
+		return nil
+	}
+
+	return execute(gzipTemplate, conf.Gzip)
+}
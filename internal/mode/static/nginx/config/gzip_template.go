@@ -0,0 +1,10 @@
+package config
+
+var gzipTemplateText = `
+gzip on;
+gzip_min_length {{ .MinLength }};
+gzip_comp_level {{ .Level }};
+{{- if .Types }}
+gzip_types{{ range $t := .Types }} {{ $t }}{{ end }};
+{{- end }}
+`
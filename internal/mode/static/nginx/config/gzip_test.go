@@ -0,0 +1,64 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+)
+
+func TestExecuteGzip(t *testing.T) {
+	tests := []struct {
+		expSubStrings map[string]int
+		gzip          *dataplane.Gzip
+		name          string
+	}{
+		{
+			name: "gzip with types",
+			gzip: &dataplane.Gzip{
+				Types:     []string{"application/json", "text/css"},
+				MinLength: 1000,
+				Level:     5,
+			},
+			expSubStrings: map[string]int{
+				"gzip on;":                              1,
+				"gzip_min_length 1000;":                 1,
+				"gzip_comp_level 5;":                    1,
+				"gzip_types application/json text/css;": 1,
+			},
+		},
+		{
+			name: "gzip with default types",
+			gzip: &dataplane.Gzip{
+				MinLength: 20,
+				Level:     1,
+			},
+			expSubStrings: map[string]int{
+				"gzip on;":            1,
+				"gzip_min_length 20;": 1,
+				"gzip_comp_level 1;":  1,
+				"gzip_types":          0,
+			},
+		},
+		{
+			name: "gzip disabled",
+			expSubStrings: map[string]int{
+				"gzip": 0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			gzip := string(executeGzip(dataplane.Configuration{Gzip: test.gzip}))
+
+			for expSubStr, expCount := range test.expSubStrings {
+				g.Expect(strings.Count(gzip, expSubStr)).To(Equal(expCount), expSubStr)
+			}
+		})
+	}
+}
@@ -2,9 +2,16 @@ package http
 
 // Server holds all configuration for an HTTP server.
 type Server struct {
-	SSL           *SSL
-	ServerName    string
-	Locations     []Location
+	SSL               *SSL
+	ServerName        string
+	ClientMaxBodySize string
+	RequestIDHeader   string
+	Locations         []Location
+	ErrorPages        []ErrorPage
+	// BackendErrorPages are the error pages of the locations. The server includes their named locations.
+	BackendErrorPages []ErrorPage
+	// ServerSnippet holds the NGINX directives that are appended verbatim to the server block.
+	ServerSnippet string
 	IsDefaultHTTP bool
 	IsDefaultSSL  bool
 	Port          int32
@@ -12,12 +19,36 @@ type Server struct {
 
 // Location holds all configuration for an HTTP location.
 type Location struct {
-	Return          *Return
-	Path            string
-	ProxyPass       string
-	HTTPMatchVar    string
-	ProxySetHeaders []Header
-	Internal        bool
+	Return            *Return
+	Path              string
+	ProxyPass         string
+	HTTPMatchVar      string
+	ClientMaxBodySize string
+	// DNSAddress is the DNS name and port of the proxied server. If not empty, ProxyPass refers to a variable,
+	// which is set to the address, so that NGINX resolves it at runtime.
+	DNSAddress string
+	// ProxyNextUpstream is the space-separated list of the conditions in which a request is passed to the next
+	// proxied server. If empty, the NGINX default applies.
+	ProxyNextUpstream string
+	ProxySetHeaders   []Header
+	// ErrorPages are the error pages that replace the error responses of the proxied server.
+	ErrorPages []ErrorPage
+	// ProxyNextUpstreamTries limits the number of tries to pass a request to the next server. If 0, it is not limited.
+	ProxyNextUpstreamTries int32
+	Internal               bool
+	// Snippet holds the NGINX directives that are appended verbatim to the location block.
+	Snippet string
+	// StreamingProxy disables buffering of the responses of the proxied server.
+	StreamingProxy bool
+}
+
+// ErrorPage defines a custom error page for the responses with a status code.
+type ErrorPage struct {
+	// Location is the name of the named location that returns the error page.
+	Location    string
+	Body        string
+	ContentType string
+	Code        int
 }
 
 // Header defines a HTTP header to be passed to the proxied server.
@@ -36,6 +67,19 @@ type Return struct {
 type SSL struct {
 	Certificate    string
 	CertificateKey string
+	// SessionCache is the value of the ssl_session_cache directive. If empty, the directive is not generated.
+	SessionCache string
+	// SessionTickets is the value of the ssl_session_tickets directive. If empty, the directive is not generated.
+	SessionTickets string
+	// Protocols is the value of the ssl_protocols directive. If empty, the directive is not generated.
+	Protocols string
+	// Ciphers is the value of the ssl_ciphers directive. If empty, the directive is not generated.
+	Ciphers string
+	// DHParam is the file with the Diffie-Hellman parameters. If empty, the directive is not generated.
+	DHParam string
+	// TrustedCertificate is the file with the CA certificate to verify OCSP responses.
+	// If not empty, OCSP stapling is enabled.
+	TrustedCertificate string
 }
 
 // StatusCode is an HTTP status code.
@@ -52,13 +96,34 @@ const (
 
 // Upstream holds all configuration for an HTTP upstream.
 type Upstream struct {
-	Name    string
-	Servers []UpstreamServer
+	Name string
+	// ZoneSize is the size of the shared memory zone of the upstream, for example, "512k".
+	ZoneSize string
+	Servers  []UpstreamServer
+}
+
+// Resolver holds the configuration of the DNS servers, which NGINX uses to resolve the DNS names of the proxied
+// servers at runtime.
+type Resolver struct {
+	// Addresses are the addresses of the DNS servers.
+	Addresses []string
+}
+
+// OpenFileCache holds the configuration of the cache of the open file descriptors.
+type OpenFileCache struct {
+	// Inactive is the time after which a file, which is not accessed, is removed from the cache, for example, "60s".
+	Inactive string
+	// Valid is the time after which NGINX checks that a cached file is still valid, for example, "60s".
+	Valid string
+	// MaxFiles is the maximum number of the cached files.
+	MaxFiles int32
 }
 
 // UpstreamServer holds all configuration for an HTTP upstream server.
 type UpstreamServer struct {
 	Address string
+	// Zone is the zone of the node of the server. If not empty, it is added as a comment to the server.
+	Zone string
 }
 
 // SplitClient holds all configuration for an HTTP split client.
@@ -0,0 +1,15 @@
+package config
+
+import (
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+)
+
+// executeHTTPSnippet returns the snippet for the http context as is.
+// The snippet is not validated, so an invalid snippet makes the NGINX reload fail.
+func executeHTTPSnippet(conf dataplane.Configuration) []byte {
+	if conf.HTTPSnippet == "" {
+		return nil
+	}
+
+	return []byte("\n" + conf.HTTPSnippet + "\n")
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+)
+
+func TestExecuteHTTPSnippet(t *testing.T) {
+	tests := []struct {
+		name     string
+		snippet  string
+		expected string
+	}{
+		{
+			name:     "snippet",
+			snippet:  "gzip on;\ngzip_types text/plain;",
+			expected: "\ngzip on;\ngzip_types text/plain;\n",
+		},
+		{
+			name:     "no snippet",
+			snippet:  "",
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			result := string(executeHTTPSnippet(dataplane.Configuration{HTTPSnippet: test.snippet}))
+			g.Expect(result).To(Equal(test.expected))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	gotemplate "text/template"
+	"time"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+)
+
+var (
+	mainConfigTemplate   = gotemplate.Must(gotemplate.New("main").Parse(mainConfigTemplateText))
+	eventsConfigTemplate = gotemplate.Must(gotemplate.New("events").Parse(eventsConfigTemplateText))
+)
+
+// defaultWorkerShutdownTimeout is the default time, for which NGINX waits for the active connections to complete
+// during a graceful shutdown. Without the timeout, NGINX waits until all connections are closed, which can take longer
+// than the termination grace period of the Pod.
+const defaultWorkerShutdownTimeout = 10 * time.Second
+
+// mainConfig holds the configuration of the main context.
+type mainConfig struct {
+	// WorkerProcesses is the number of the worker processes. If empty, the directive is not generated.
+	WorkerProcesses string
+	// WorkerShutdownTimeout is the timeout of a graceful shutdown, for example, "10000ms".
+	WorkerShutdownTimeout string
+}
+
+// executeMainConfig generates the configuration of the main context.
+func executeMainConfig(conf dataplane.Configuration) []byte {
+	shutdownTimeout := conf.WorkerShutdownTimeout
+	if shutdownTimeout == 0 {
+		shutdownTimeout = defaultWorkerShutdownTimeout
+	}
+
+	mc := mainConfig{
+		WorkerProcesses:       conf.WorkerProcesses,
+		WorkerShutdownTimeout: formatDuration(shutdownTimeout),
+	}
+
+	return execute(mainConfigTemplate, mc)
+}
+
+// executeEventsConfig generates the configuration of the events context.
+func executeEventsConfig(conf dataplane.Configuration) []byte {
+	return execute(eventsConfigTemplate, conf)
+}
@@ -0,0 +1,14 @@
+package config
+
+var mainConfigTemplateText = `
+{{- if .WorkerProcesses }}
+worker_processes {{ .WorkerProcesses }};
+{{- end }}
+worker_shutdown_timeout {{ .WorkerShutdownTimeout }};
+`
+
+var eventsConfigTemplateText = `
+{{- if .WorkerConnections }}
+worker_connections {{ .WorkerConnections }};
+{{- end }}
+`
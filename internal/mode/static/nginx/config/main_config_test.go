@@ -0,0 +1,95 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+)
+
+func TestExecuteMainConfig(t *testing.T) {
+	tests := []struct {
+		expSubStrings map[string]int
+		name          string
+		conf          dataplane.Configuration
+	}{
+		{
+			name: "explicit worker processes",
+			conf: dataplane.Configuration{WorkerProcesses: "4"},
+			expSubStrings: map[string]int{
+				"worker_processes 4;":              1,
+				"worker_shutdown_timeout 10000ms;": 1,
+			},
+		},
+		{
+			name: "auto worker processes",
+			conf: dataplane.Configuration{WorkerProcesses: "auto"},
+			expSubStrings: map[string]int{
+				"worker_processes auto;": 1,
+			},
+		},
+		{
+			name: "worker shutdown timeout",
+			conf: dataplane.Configuration{WorkerShutdownTimeout: 30 * time.Second},
+			expSubStrings: map[string]int{
+				"worker_shutdown_timeout 30000ms;": 1,
+			},
+		},
+		{
+			name: "defaults",
+			expSubStrings: map[string]int{
+				"worker_processes":                 0,
+				"worker_shutdown_timeout 10000ms;": 1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			mainConfig := string(executeMainConfig(test.conf))
+
+			for expSubStr, expCount := range test.expSubStrings {
+				g.Expect(strings.Count(mainConfig, expSubStr)).To(Equal(expCount), expSubStr)
+			}
+		})
+	}
+}
+
+func TestExecuteEventsConfig(t *testing.T) {
+	tests := []struct {
+		expSubStrings map[string]int
+		name          string
+		conf          dataplane.Configuration
+	}{
+		{
+			name: "worker connections",
+			conf: dataplane.Configuration{WorkerConnections: 4096},
+			expSubStrings: map[string]int{
+				"worker_connections 4096;": 1,
+			},
+		},
+		{
+			name: "defaults",
+			expSubStrings: map[string]int{
+				"worker_connections": 0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			eventsConfig := string(executeEventsConfig(test.conf))
+
+			for expSubStr, expCount := range test.expSubStrings {
+				g.Expect(strings.Count(eventsConfig, expSubStr)).To(Equal(expCount), expSubStr)
+			}
+		})
+	}
+}
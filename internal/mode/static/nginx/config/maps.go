@@ -1,9 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"strings"
 	gotemplate "text/template"
 
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config/http"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
 )
@@ -16,7 +19,7 @@ func executeMaps(conf dataplane.Configuration) []byte {
 }
 
 func createMaps(servers []dataplane.VirtualServer) []http.Map {
-	return buildAddHeaderMaps(servers)
+	return append(buildAddHeaderMaps(servers), buildQueryParamRegexMaps(servers)...)
 }
 
 func buildAddHeaderMaps(servers []dataplane.VirtualServer) []http.Map {
@@ -70,3 +73,50 @@ func createAddHeadersMap(name string) http.Map {
 		Parameters: params,
 	}
 }
+
+// buildQueryParamRegexMaps builds a map for every unique query parameter regex match. The map tests the first value
+// of the query parameter (the $arg_<name> variable) against the regex and sets its variable to "1" if it matches.
+// NJS uses the variable to find the winning match.
+func buildQueryParamRegexMaps(servers []dataplane.VirtualServer) []http.Map {
+	var maps []http.Map
+	varNames := make(map[string]struct{})
+
+	for _, s := range servers {
+		for _, pr := range s.PathRules {
+			for _, mr := range pr.MatchRules {
+				for _, p := range mr.GetMatch().QueryParams {
+					if p.Type == nil || *p.Type != v1beta1.QueryParamMatchRegularExpression {
+						continue
+					}
+
+					varName := generateQueryParamRegexMapVariableName(string(p.Name), p.Value)
+					if _, ok := varNames[varName]; ok {
+						continue
+					}
+					varNames[varName] = struct{}{}
+
+					maps = append(maps, createQueryParamRegexMap(string(p.Name), p.Value, varName))
+				}
+			}
+		}
+	}
+
+	return maps
+}
+
+func createQueryParamRegexMap(name, regex, varName string) http.Map {
+	return http.Map{
+		Source:   "$arg_" + name,
+		Variable: "$" + varName,
+		Parameters: []http.MapParameter{
+			{
+				Value:  "default",
+				Result: "0",
+			},
+			{
+				Value:  fmt.Sprintf(`"~%s"`, regex),
+				Result: "1",
+			},
+		},
+	}
+}
@@ -5,17 +5,41 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config/http"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
 )
 
 func TestExecuteMaps(t *testing.T) {
 	g := NewGomegaWithT(t)
+
+	hr := &v1beta1.HTTPRoute{
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
+				{
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							QueryParams: []v1beta1.HTTPQueryParamMatch{
+								{
+									Type:  helpers.GetPointer(v1beta1.QueryParamMatchRegularExpression),
+									Name:  "version",
+									Value: "^v[0-9]+$",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
 	pathRules := []dataplane.PathRule{
 		{
 			MatchRules: []dataplane.MatchRule{
 				{
+					Source: hr,
 					Filters: dataplane.Filters{
 						RequestHeaderModifiers: &dataplane.HTTPHeaderFilter{
 							Add: []dataplane.HTTPHeader{
@@ -28,6 +52,7 @@ func TestExecuteMaps(t *testing.T) {
 					},
 				},
 				{
+					Source: hr,
 					Filters: dataplane.Filters{
 						RequestHeaderModifiers: &dataplane.HTTPHeaderFilter{
 							Add: []dataplane.HTTPHeader{
@@ -40,6 +65,7 @@ func TestExecuteMaps(t *testing.T) {
 					},
 				},
 				{
+					Source: hr,
 					Filters: dataplane.Filters{
 						RequestHeaderModifiers: &dataplane.HTTPHeaderFilter{
 							Set: []dataplane.HTTPHeader{
@@ -85,6 +111,8 @@ func TestExecuteMaps(t *testing.T) {
 		"~.* ${http_my_second_add_header},;":                                  1,
 		"map ${http_my_set_header} $my_set_header_header_var {":               0,
 		"map $http_host $gw_api_compliant_host {":                             1,
+		"map $arg_version $arg_version_regex_9b630460 {":                      1,
+		`"~^v[0-9]+$" 1;`: 1,
 	}
 
 	maps := string(executeMaps(conf))
@@ -185,3 +213,76 @@ func TestBuildAddHeaderMaps(t *testing.T) {
 
 	g.Expect(maps).To(ConsistOf(expectedMap))
 }
+
+func TestBuildQueryParamRegexMaps(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	createRoute := func(queryParams ...v1beta1.HTTPQueryParamMatch) *v1beta1.HTTPRoute {
+		return &v1beta1.HTTPRoute{
+			Spec: v1beta1.HTTPRouteSpec{
+				Rules: []v1beta1.HTTPRouteRule{
+					{
+						Matches: []v1beta1.HTTPRouteMatch{
+							{
+								QueryParams: queryParams,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	versionRegex := v1beta1.HTTPQueryParamMatch{
+		Type:  helpers.GetPointer(v1beta1.QueryParamMatchRegularExpression),
+		Name:  "version",
+		Value: "^v[0-9]+$",
+	}
+	colorRegex := v1beta1.HTTPQueryParamMatch{
+		Type:  helpers.GetPointer(v1beta1.QueryParamMatchRegularExpression),
+		Name:  "color",
+		Value: "red|green|blue",
+	}
+	exact := v1beta1.HTTPQueryParamMatch{
+		Type:  helpers.GetPointer(v1beta1.QueryParamMatchExact),
+		Name:  "exact",
+		Value: "value",
+	}
+
+	pathRules := []dataplane.PathRule{
+		{
+			MatchRules: []dataplane.MatchRule{
+				{Source: createRoute(versionRegex, exact)},
+				{Source: createRoute(colorRegex, versionRegex)},
+			},
+		},
+	}
+
+	servers := []dataplane.VirtualServer{
+		{PathRules: pathRules},
+		{PathRules: pathRules},
+		{IsDefault: true},
+	}
+
+	expectedMaps := []http.Map{
+		{
+			Source:   "$arg_version",
+			Variable: "$arg_version_regex_9b630460",
+			Parameters: []http.MapParameter{
+				{Value: "default", Result: "0"},
+				{Value: `"~^v[0-9]+$"`, Result: "1"},
+			},
+		},
+		{
+			Source:   "$arg_color",
+			Variable: "$" + generateQueryParamRegexMapVariableName("color", "red|green|blue"),
+			Parameters: []http.MapParameter{
+				{Value: "default", Result: "0"},
+				{Value: `"~red|green|blue"`, Result: "1"},
+			},
+		},
+	}
+
+	maps := buildQueryParamRegexMaps(servers)
+	g.Expect(maps).To(Equal(expectedMaps))
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	gotemplate "text/template"
+	"time"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config/http"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+)
+
+var openFileCacheTemplate = gotemplate.Must(gotemplate.New("openFileCache").Parse(openFileCacheTemplateText))
+
+func executeOpenFileCache(conf dataplane.Configuration) []byte {
+	if conf.OpenFileCache == nil {
+		return nil
+	}
+
+	return execute(openFileCacheTemplate, createOpenFileCache(*conf.OpenFileCache))
+}
+
+func createOpenFileCache(cache dataplane.OpenFileCache) http.OpenFileCache {
+	return http.OpenFileCache{
+		MaxFiles: cache.MaxFiles,
+		Inactive: formatDuration(cache.Inactive),
+		Valid:    formatDuration(cache.Valid),
+	}
+}
+
+// formatDuration formats the duration for NGINX in milliseconds, the smallest time unit of NGINX.
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
@@ -0,0 +1,8 @@
+package config
+
+var openFileCacheTemplateText = `
+open_file_cache max={{ .MaxFiles }} inactive={{ .Inactive }};
+open_file_cache_valid {{ .Valid }};
+open_file_cache_min_uses 2;
+open_file_cache_errors on;
+`
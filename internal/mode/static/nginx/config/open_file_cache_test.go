@@ -0,0 +1,52 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+)
+
+func TestExecuteOpenFileCache(t *testing.T) {
+	tests := []struct {
+		expSubStrings map[string]int
+		cache         *dataplane.OpenFileCache
+		name          string
+	}{
+		{
+			name: "cache enabled",
+			cache: &dataplane.OpenFileCache{
+				MaxFiles: 1000,
+				Inactive: 20 * time.Second,
+				Valid:    1500 * time.Millisecond,
+			},
+			expSubStrings: map[string]int{
+				"open_file_cache max=1000 inactive=20000ms;": 1,
+				"open_file_cache_valid 1500ms;":              1,
+				"open_file_cache_min_uses 2;":                1,
+				"open_file_cache_errors on;":                 1,
+			},
+		},
+		{
+			name: "cache disabled",
+			expSubStrings: map[string]int{
+				"open_file_cache": 0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			cache := string(executeOpenFileCache(dataplane.Configuration{OpenFileCache: test.cache}))
+
+			for expSubStr, expCount := range test.expSubStrings {
+				g.Expect(strings.Count(cache, expSubStr)).To(Equal(expCount), expSubStr)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package config
+
+import (
+	gotemplate "text/template"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+)
+
+var realIPTemplate = gotemplate.Must(gotemplate.New("realIP").Parse(realIPTemplateText))
+
+func executeRealIP(conf dataplane.Configuration) []byte {
+	return execute(realIPTemplate, conf.TrustedProxies)
+}
@@ -0,0 +1,11 @@
+package config
+
+var realIPTemplateText = `
+{{- if . -}}
+    {{ range $cidr := . }}
+set_real_ip_from {{ $cidr }};
+    {{- end }}
+real_ip_header X-Forwarded-For;
+real_ip_recursive on;
+{{ end -}}
+`
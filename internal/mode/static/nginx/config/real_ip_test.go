@@ -0,0 +1,47 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+)
+
+func TestExecuteRealIP(t *testing.T) {
+	tests := []struct {
+		expSubStrings  map[string]int
+		name           string
+		trustedProxies []string
+	}{
+		{
+			name:           "IPv4 and IPv6 trusted proxies",
+			trustedProxies: []string{"10.0.0.0/8", "2001:db8::/32"},
+			expSubStrings: map[string]int{
+				"set_real_ip_from 10.0.0.0/8;":    1,
+				"set_real_ip_from 2001:db8::/32;": 1,
+				"real_ip_header X-Forwarded-For;": 1,
+				"real_ip_recursive on;":           1,
+			},
+		},
+		{
+			name: "no trusted proxies",
+			expSubStrings: map[string]int{
+				"real_ip": 0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			realIP := string(executeRealIP(dataplane.Configuration{TrustedProxies: test.trustedProxies}))
+
+			for expSubStr, expCount := range test.expSubStrings {
+				g.Expect(strings.Count(realIP, expSubStr)).To(Equal(expCount), expSubStr)
+			}
+		})
+	}
+}
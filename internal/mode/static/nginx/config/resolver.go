@@ -0,0 +1,33 @@
+package config
+
+import (
+	"net"
+	gotemplate "text/template"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config/http"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+)
+
+var resolverTemplate = gotemplate.Must(gotemplate.New("resolver").Parse(resolverTemplateText))
+
+func executeResolver(conf dataplane.Configuration) []byte {
+	if len(conf.DNSResolvers) == 0 {
+		return nil
+	}
+
+	return execute(resolverTemplate, createResolver(conf.DNSResolvers))
+}
+
+func createResolver(ips []string) http.Resolver {
+	addresses := make([]string, 0, len(ips))
+
+	for _, ip := range ips {
+		// NGINX requires IPv6 addresses in square brackets.
+		if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() == nil {
+			ip = "[" + ip + "]"
+		}
+		addresses = append(addresses, ip)
+	}
+
+	return http.Resolver{Addresses: addresses}
+}
@@ -0,0 +1,5 @@
+package config
+
+var resolverTemplateText = `
+resolver{{ range $a := .Addresses }} {{ $a }}{{ end }} valid=30s ipv6=off;
+`
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+)
+
+func TestExecuteResolver(t *testing.T) {
+	tests := []struct {
+		expSubStrings map[string]int
+		name          string
+		dnsResolvers  []string
+	}{
+		{
+			name:         "IPv4 and IPv6 DNS resolvers",
+			dnsResolvers: []string{"10.96.0.10", "2001:db8::10"},
+			expSubStrings: map[string]int{
+				"resolver 10.96.0.10 [2001:db8::10] valid=30s ipv6=off;": 1,
+			},
+		},
+		{
+			name: "no DNS resolvers",
+			expSubStrings: map[string]int{
+				"resolver": 0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			resolver := string(executeResolver(dataplane.Configuration{DNSResolvers: test.dnsResolvers}))
+
+			for expSubStr, expCount := range test.expSubStrings {
+				g.Expect(strings.Count(resolver, expSubStr)).To(Equal(expCount), expSubStr)
+			}
+		})
+	}
+}
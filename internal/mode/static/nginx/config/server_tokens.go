@@ -0,0 +1,15 @@
+package config
+
+import (
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+)
+
+// executeServerTokens hides the NGINX version in the Server response header and on the error pages, unless
+// the configuration allows showing it.
+func executeServerTokens(conf dataplane.Configuration) []byte {
+	if conf.ShowServerVersion {
+		return nil
+	}
+
+	return []byte("\nserver_tokens off;\n")
+}
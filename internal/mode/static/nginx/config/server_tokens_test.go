@@ -0,0 +1,16 @@
+package config
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+)
+
+func TestExecuteServerTokens(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(string(executeServerTokens(dataplane.Configuration{}))).To(ContainSubstring("server_tokens off;"))
+	g.Expect(executeServerTokens(dataplane.Configuration{ShowServerVersion: true})).To(BeEmpty())
+}
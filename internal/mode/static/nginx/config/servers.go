@@ -3,27 +3,79 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"strconv"
 	"strings"
 	gotemplate "text/template"
 
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config/http"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/file"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
 )
 
-var serversTemplate = gotemplate.Must(gotemplate.New("servers").Parse(serversTemplateText))
+var (
+	serversTemplate         = gotemplate.Must(gotemplate.New("servers").Parse(serversTemplateText))
+	internalServersTemplate = gotemplate.Must(gotemplate.New("internalServers").Parse(internalServersTemplateText))
+)
 
 const (
 	// HeaderMatchSeparator is the separator for constructing header-based match for NJS.
 	HeaderMatchSeparator = ":"
 	rootPath             = "/"
+	// defaultServerFileName is the name of the files of the default servers. The underscore can't be a part of
+	// a hostname, so the name doesn't conflict with the names of the files of the other servers.
+	defaultServerFileName = "_default"
 )
 
-func executeServers(conf dataplane.Configuration) []byte {
+// generateServerFiles generates a configuration file for every server, so that NGINX reports the errors in the
+// configuration of a server with the name of its file.
+func generateServerFiles(conf dataplane.Configuration) []file.File {
 	servers := createServers(conf.HTTPServers, conf.SSLServers)
 
-	return execute(serversTemplate, servers)
+	files := make([]file.File, 0, len(servers))
+	for _, s := range servers {
+		files = append(files, file.File{
+			Content: execute(serversTemplate, []http.Server{s}),
+			Path:    generateServerFileName(s),
+			Type:    file.TypeRegular,
+		})
+	}
+
+	return files
+}
+
+// generateServerFileName returns the name of the configuration file of the server. The name includes the port
+// and whether the server is an SSL server, because the same hostname can have servers for multiple ports.
+func generateServerFileName(s http.Server) string {
+	name := defaultServerFileName
+	if !s.IsDefaultHTTP && !s.IsDefaultSSL {
+		name = sanitizeFileName(s.ServerName)
+	}
+
+	if s.IsDefaultSSL || s.SSL != nil {
+		return filepath.Join(serversFolder, fmt.Sprintf("%s_%d_ssl.conf", name, s.Port))
+	}
+
+	return filepath.Join(serversFolder, fmt.Sprintf("%s_%d.conf", name, s.Port))
+}
+
+// sanitizeFileName replaces the characters of the hostname that are not letters, digits, dots or hyphens
+// with underscores. For example, the wildcard hostname *.example.com becomes _.example.com.
+func sanitizeFileName(hostname string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, hostname)
+}
+
+func executeInternalServers(_ dataplane.Configuration) []byte {
+	return execute(internalServersTemplate, nil)
 }
 
 func createServers(httpServers, sslServers []dataplane.VirtualServer) []http.Server {
@@ -48,15 +100,95 @@ func createSSLServer(virtualServer dataplane.VirtualServer) http.Server {
 		}
 	}
 
+	ssl := &http.SSL{
+		Certificate:    generatePEMFileName(virtualServer.SSL.KeyPairID),
+		CertificateKey: generatePEMFileName(virtualServer.SSL.KeyPairID),
+		SessionCache:   createSSLSessionCache(virtualServer.SSL.SessionCacheSize),
+		SessionTickets: createSSLSessionTickets(virtualServer.SSL.SessionTickets),
+		Protocols:      createSSLProtocols(virtualServer.SSL.MinTLSVersion),
+		Ciphers:        strings.Join(virtualServer.SSL.Ciphers, ":"),
+	}
+
+	if virtualServer.SSL.DHParamsID != "" {
+		ssl.DHParam = generateDHParamsPEMFileName(virtualServer.SSL.DHParamsID)
+	}
+
+	if virtualServer.SSL.Stapling {
+		ssl.TrustedCertificate = generateCACertPEMFileName(virtualServer.SSL.KeyPairID)
+	}
+
+	locs := createLocations(virtualServer.PathRules, virtualServer.Port)
+
 	return http.Server{
-		ServerName: virtualServer.Hostname,
-		SSL: &http.SSL{
-			Certificate:    generatePEMFileName(virtualServer.SSL.KeyPairID),
-			CertificateKey: generatePEMFileName(virtualServer.SSL.KeyPairID),
-		},
-		Locations: createLocations(virtualServer.PathRules, virtualServer.Port),
-		Port:      virtualServer.Port,
+		ServerName:        virtualServer.Hostname,
+		SSL:               ssl,
+		ClientMaxBodySize: createClientMaxBodySize(virtualServer.ClientMaxBodySize),
+		RequestIDHeader:   virtualServer.RequestIDHeader,
+		Locations:         locs,
+		ErrorPages:        createErrorPages(virtualServer.ErrorPage),
+		BackendErrorPages: collectBackendErrorPages(locs),
+		ServerSnippet:     virtualServer.ServerSnippet,
+		Port:              virtualServer.Port,
+	}
+}
+
+// createErrorPages creates an error page with a named location for every status code of the custom error page.
+func createErrorPages(errorPage *dataplane.ErrorPage) []http.ErrorPage {
+	if errorPage == nil {
+		return nil
+	}
+
+	errorPages := make([]http.ErrorPage, 0, len(errorPage.StatusCodes))
+	for _, code := range errorPage.StatusCodes {
+		errorPages = append(errorPages, http.ErrorPage{
+			Location:    fmt.Sprintf("@custom_%d", code),
+			Body:        errorPage.Body,
+			ContentType: errorPage.ContentType,
+			Code:        code,
+		})
+	}
+
+	return errorPages
+}
+
+// createBackendErrorPages creates an error page with a named location for every status code of the custom backend
+// error page of the match rule. The names of the locations are unique for the match rule.
+func createBackendErrorPages(errorPage *dataplane.ErrorPage, pathRuleIdx, matchRuleIdx int) []http.ErrorPage {
+	if errorPage == nil {
+		return nil
+	}
+
+	errorPages := make([]http.ErrorPage, 0, len(errorPage.StatusCodes))
+	for _, code := range errorPage.StatusCodes {
+		errorPages = append(errorPages, http.ErrorPage{
+			Location:    fmt.Sprintf("@backend_error_%d_%d_%d", pathRuleIdx, matchRuleIdx, code),
+			Body:        errorPage.Body,
+			ContentType: errorPage.ContentType,
+			Code:        code,
+		})
+	}
+
+	return errorPages
+}
+
+// collectBackendErrorPages returns the backend error pages of the locations, so that the server includes their named
+// locations. The locations of a match rule share the error pages, so every error page is only returned once.
+func collectBackendErrorPages(locs []http.Location) []http.ErrorPage {
+	var errorPages []http.ErrorPage
+	seen := make(map[string]struct{})
+
+	for _, l := range locs {
+		for _, e := range l.ErrorPages {
+			if _, exists := seen[e.Location]; exists {
+				continue
+			}
+			seen[e.Location] = struct{}{}
+
+			errorPages = append(errorPages, e)
+		}
 	}
+
+	return errorPages
 }
 
 func createServer(virtualServer dataplane.VirtualServer) http.Server {
@@ -67,10 +199,17 @@ func createServer(virtualServer dataplane.VirtualServer) http.Server {
 		}
 	}
 
+	locs := createLocations(virtualServer.PathRules, virtualServer.Port)
+
 	return http.Server{
-		ServerName: virtualServer.Hostname,
-		Locations:  createLocations(virtualServer.PathRules, virtualServer.Port),
-		Port:       virtualServer.Port,
+		ServerName:        virtualServer.Hostname,
+		ClientMaxBodySize: createClientMaxBodySize(virtualServer.ClientMaxBodySize),
+		RequestIDHeader:   virtualServer.RequestIDHeader,
+		Locations:         locs,
+		ErrorPages:        createErrorPages(virtualServer.ErrorPage),
+		BackendErrorPages: collectBackendErrorPages(locs),
+		ServerSnippet:     virtualServer.ServerSnippet,
+		Port:              virtualServer.Port,
 	}
 }
 
@@ -79,10 +218,10 @@ func createLocations(pathRules []dataplane.PathRule, listenerPort int32) []http.
 	locs := make([]http.Location, 0, maxLocs)
 	var rootPathExists bool
 
-	for _, rule := range pathRules {
+	for pathRuleIdx, rule := range pathRules {
 		matches := make([]httpMatch, 0, len(rule.MatchRules))
 
-		if rule.Path == rootPath {
+		if rule.Path == rootPath && rule.PathType != dataplane.PathTypeRegularExpression {
 			rootPathExists = true
 		}
 
@@ -93,7 +232,8 @@ func createLocations(pathRules []dataplane.PathRule, listenerPort int32) []http.
 
 			buildLocations := extLocations
 			if len(rule.MatchRules) != 1 || !isPathOnlyMatch(m) {
-				intLocation, match := initializeInternalLocation(rule, matchRuleIdx, m)
+				intLocation, match := initializeInternalLocation(rule, pathRuleIdx, matchRuleIdx, m)
+				match.CaseInsensitiveHeaders = r.CaseInsensitiveHeaderValues && len(match.Headers) > 0
 				buildLocations = []http.Location{intLocation}
 				matches = append(matches, match)
 			}
@@ -106,6 +246,10 @@ func createLocations(pathRules []dataplane.PathRule, listenerPort int32) []http.
 				continue
 			}
 
+			for i := range buildLocations {
+				buildLocations[i].Snippet = r.LocationSnippet
+			}
+
 			// There could be a case when the filter has the type set but not the corresponding field.
 			// For example, type is v1beta1.HTTPRouteFilterRequestRedirect, but RequestRedirect field is nil.
 			// The imported Webhook validation webhook catches that.
@@ -130,9 +274,31 @@ func createLocations(pathRules []dataplane.PathRule, listenerPort int32) []http.
 				buildLocations[i].ProxySetHeaders = proxySetHeaders
 			}
 
+			// The size of the route overrides the size of the server.
+			clientMaxBodySize := createClientMaxBodySize(r.ClientMaxBodySize)
+			for i := range buildLocations {
+				buildLocations[i].ClientMaxBodySize = clientMaxBodySize
+				buildLocations[i].StreamingProxy = r.StreamingProxy
+			}
+
+			backendErrorPages := createBackendErrorPages(r.BackendErrorPage, pathRuleIdx, matchRuleIdx)
+			for i := range buildLocations {
+				buildLocations[i].ErrorPages = backendErrorPages
+			}
+
+			if r.NextUpstream != nil {
+				proxyNextUpstream := strings.Join(r.NextUpstream.Conditions, " ")
+				for i := range buildLocations {
+					buildLocations[i].ProxyNextUpstream = proxyNextUpstream
+					buildLocations[i].ProxyNextUpstreamTries = r.NextUpstream.Tries
+				}
+			}
+
 			proxyPass := createProxyPass(r.BackendGroup)
+			dnsAddress := getBackendGroupDNSAddress(r.BackendGroup)
 			for i := range buildLocations {
 				buildLocations[i].ProxyPass = proxyPass
+				buildLocations[i].DNSAddress = dnsAddress
 			}
 			locs = append(locs, buildLocations...)
 		}
@@ -227,10 +393,16 @@ func initializeExternalLocations(
 
 func initializeInternalLocation(
 	rule dataplane.PathRule,
+	pathRuleIdx,
 	matchRuleIdx int,
 	match v1beta1.HTTPRouteMatch,
 ) (http.Location, httpMatch) {
-	path := createPathForMatch(rule.Path, rule.PathType, matchRuleIdx)
+	var path string
+	if rule.PathType == dataplane.PathTypeRegularExpression {
+		path = createPathForRegexMatch(pathRuleIdx, matchRuleIdx)
+	} else {
+		path = createPathForMatch(rule.Path, rule.PathType, matchRuleIdx)
+	}
 	return createMatchLocation(path), createHTTPMatch(match, path)
 }
 
@@ -293,8 +465,13 @@ type httpMatch struct {
 	Headers []string `json:"headers,omitempty"`
 	// QueryParams is a list of HTTPQueryParams name value pairs with the format "{name}={value}".
 	QueryParams []string `json:"params,omitempty"`
+	// RegexQueryParams is a list of names of the variables that are set to "1" if the corresponding
+	// HTTPQueryParams match their regexes.
+	RegexQueryParams []string `json:"regexParams,omitempty"`
 	// Any represents a match with no match conditions.
 	Any bool `json:"any,omitempty"`
+	// CaseInsensitiveHeaders tells if the values of the Headers are compared in a case-insensitive manner.
+	CaseInsensitiveHeaders bool `json:"caseInsensitiveHeaders,omitempty"`
 }
 
 func createHTTPMatch(match v1beta1.HTTPRouteMatch, redirectPath string) httpMatch {
@@ -331,13 +508,19 @@ func createHTTPMatch(match v1beta1.HTTPRouteMatch, redirectPath string) httpMatc
 
 	if match.QueryParams != nil {
 		params := make([]string, 0, len(match.QueryParams))
+		var regexParams []string
 
 		for _, p := range match.QueryParams {
-			if *p.Type == v1beta1.QueryParamMatchExact {
+			switch *p.Type {
+			case v1beta1.QueryParamMatchExact:
 				params = append(params, createQueryParamKeyValString(p))
+			case v1beta1.QueryParamMatchRegularExpression:
+				// the variable is set by a map (see buildQueryParamRegexMaps)
+				regexParams = append(regexParams, generateQueryParamRegexMapVariableName(string(p.Name), p.Value))
 			}
 		}
 		hm.QueryParams = params
+		hm.RegexQueryParams = regexParams
 	}
 
 	return hm
@@ -350,10 +533,10 @@ func createQueryParamKeyValString(p v1beta1.HTTPQueryParamMatch) string {
 }
 
 // The name and values are delimited by ":". A name and value can always be recovered using strings.Split(arg, ":").
-// Header names are case-insensitive and header values are case-sensitive.
+// Header names are case-insensitive and header values are case-sensitive, unless the match is case-insensitive.
 // Ex. foo:bar == FOO:bar, but foo:bar != foo:BAR,
 // We preserve the case of the name here because NGINX allows us to look up the header names in a case-insensitive
-// manner.
+// manner. NJS compares the values in a case-insensitive manner if the match is case-insensitive.
 func createHeaderKeyValString(h v1beta1.HTTPHeaderMatch) string {
 	return string(h.Name) + HeaderMatchSeparator + h.Value
 }
@@ -363,6 +546,11 @@ func isPathOnlyMatch(match v1beta1.HTTPRouteMatch) bool {
 }
 
 func createProxyPass(backendGroup dataplane.BackendGroup) string {
+	if getBackendGroupDNSAddress(backendGroup) != "" {
+		// the servers template sets the $upstream variable to the DNS address
+		return "http://$upstream"
+	}
+
 	backendName := backendGroupName(backendGroup)
 	if backendGroupNeedsSplit(backendGroup) {
 		return "http://$" + convertStringToSafeVariableName(backendName)
@@ -371,6 +559,75 @@ func createProxyPass(backendGroup dataplane.BackendGroup) string {
 	return "http://" + backendName
 }
 
+// getBackendGroupDNSAddress returns the DNS address of the backend group if the group doesn't need to be split and
+// its backend has a DNS name. Otherwise, it returns an empty string.
+// The DNS address is set to a variable, so that proxy_pass resolves it at runtime instead of once, when NGINX loads
+// the configuration.
+func getBackendGroupDNSAddress(group dataplane.BackendGroup) string {
+	if len(group.Backends) != 1 {
+		return ""
+	}
+
+	b := group.Backends[0]
+	if b.Weight == 0 || !b.Valid {
+		return ""
+	}
+
+	return b.DNSAddress
+}
+
+// createClientMaxBodySize returns the value of the client_max_body_size directive for the size in bytes.
+// It returns an empty string if the size is nil, so that the directive is not generated.
+func createClientMaxBodySize(size *int64) string {
+	if size == nil {
+		return ""
+	}
+
+	return strconv.FormatInt(*size, 10)
+}
+
+// createSSLSessionCache returns the value of the ssl_session_cache directive for the size of the cache in bytes.
+// NGINX requires a shared memory zone to have the same size wherever it is used, so the name of the zone includes
+// the size. As a result, the servers with the same size share the cache.
+// It returns an empty string if the size is nil, so that the directive is not generated.
+func createSSLSessionCache(size *int64) string {
+	if size == nil {
+		return ""
+	}
+
+	s := strconv.FormatInt(*size, 10)
+
+	return "shared:ssl_sessions_" + s + ":" + s
+}
+
+// createSSLSessionTickets returns the value of the ssl_session_tickets directive.
+// It returns an empty string if tickets is nil, so that the directive is not generated.
+func createSSLSessionTickets(tickets *bool) string {
+	if tickets == nil {
+		return ""
+	}
+
+	if *tickets {
+		return "on"
+	}
+
+	return "off"
+}
+
+// createSSLProtocols returns the value of the ssl_protocols directive, which enables the TLS versions starting from
+// the minimum version. It returns an empty string if the minimum version is empty, so that the directive is not
+// generated.
+func createSSLProtocols(minTLSVersion string) string {
+	switch minTLSVersion {
+	case "":
+		return ""
+	case "TLSv1.3":
+		return "TLSv1.3"
+	default:
+		return "TLSv1.2 TLSv1.3"
+	}
+}
+
 func createMatchLocation(path string) http.Location {
 	return http.Location{
 		Path:     path,
@@ -446,15 +703,28 @@ func createPath(rule dataplane.PathRule) string {
 	switch rule.PathType {
 	case dataplane.PathTypeExact:
 		return exactPath(rule.Path)
+	case dataplane.PathTypeRegularExpression:
+		return regexPath(rule.Path)
 	default:
 		return rule.Path
 	}
 }
 
+func regexPath(path string) string {
+	return fmt.Sprintf("~ \"%s\"", path)
+}
+
 func createPathForMatch(path string, pathType dataplane.PathType, routeIdx int) string {
 	return fmt.Sprintf("%s_%s_route%d", path, pathType, routeIdx)
 }
 
+// createPathForRegexMatch builds the path of an internal location for a regex path rule.
+// The regex itself can't be used in the path, because the path gets into the NJS match and must be a valid
+// prefix location path.
+func createPathForRegexMatch(pathRuleIdx, routeIdx int) string {
+	return fmt.Sprintf("/_regex%d_route%d", pathRuleIdx, routeIdx)
+}
+
 func createDefaultRootLocation() http.Location {
 	return http.Location{
 		Path:   "/",
@@ -21,6 +21,27 @@ server {
     listen {{ $s.Port }} ssl;
     ssl_certificate {{ $s.SSL.Certificate }};
     ssl_certificate_key {{ $s.SSL.CertificateKey }};
+            {{- if $s.SSL.Protocols }}
+    ssl_protocols {{ $s.SSL.Protocols }};
+            {{- end }}
+            {{- if $s.SSL.Ciphers }}
+    ssl_ciphers {{ $s.SSL.Ciphers }};
+            {{- end }}
+            {{- if $s.SSL.DHParam }}
+    ssl_dhparam {{ $s.SSL.DHParam }};
+            {{- end }}
+            {{- if $s.SSL.SessionCache }}
+    ssl_session_cache {{ $s.SSL.SessionCache }};
+    ssl_session_timeout 1d;
+            {{- end }}
+            {{- if $s.SSL.SessionTickets }}
+    ssl_session_tickets {{ $s.SSL.SessionTickets }};
+            {{- end }}
+            {{- if $s.SSL.TrustedCertificate }}
+    ssl_stapling on;
+    ssl_stapling_verify on;
+    ssl_trusted_certificate {{ $s.SSL.TrustedCertificate }};
+            {{- end }}
 
     if ($ssl_server_name != $host) {
         return 421;
@@ -30,6 +51,18 @@ server {
         {{- end }}
 
     server_name {{ $s.ServerName }};
+        {{- if $s.ClientMaxBodySize }}
+
+    client_max_body_size {{ $s.ClientMaxBodySize }};
+        {{- end }}
+        {{- if $s.RequestIDHeader }}
+
+    add_header {{ $s.RequestIDHeader }} $request_id always;
+        {{- end }}
+        {{- range $e := $s.ErrorPages }}
+
+    error_page {{ $e.Code }} = {{ $e.Location }};
+        {{- end }}
 
         {{ range $l := $s.Locations }}
     location {{ $l.Path }} {
@@ -47,17 +80,64 @@ server {
         {{ end }}
 
         {{- if $l.ProxyPass -}}
+            {{ if $l.ClientMaxBodySize -}}
+        client_max_body_size {{ $l.ClientMaxBodySize }};
+            {{ end -}}
+            {{ if $l.StreamingProxy -}}
+        proxy_buffering off;
+        proxy_cache off;
+        proxy_read_timeout 3600s;
+            {{ end -}}
+            {{ if $l.ProxyNextUpstream -}}
+        proxy_next_upstream {{ $l.ProxyNextUpstream }};
+            {{ end -}}
+            {{ if $l.ProxyNextUpstreamTries -}}
+        proxy_next_upstream_tries {{ $l.ProxyNextUpstreamTries }};
+            {{ end -}}
+            {{ if $l.ErrorPages -}}
+        proxy_intercept_errors on;
+                {{- range $e := $l.ErrorPages }}
+        error_page {{ $e.Code }} = {{ $e.Location }};
+                {{- end }}
+            {{ end -}}
             {{ range $h := $l.ProxySetHeaders }}
         proxy_set_header {{ $h.Name }} "{{ $h.Value }}";
             {{- end }}
+            {{- if $s.RequestIDHeader }}
+        proxy_set_header {{ $s.RequestIDHeader }} $request_id;
+            {{- end }}
         proxy_set_header Host $gw_api_compliant_host;
+            {{- if $l.DNSAddress }}
+        set $upstream {{ $l.DNSAddress }};
+            {{- end }}
         proxy_pass {{ $l.ProxyPass }}$request_uri;
         {{- end }}
+        {{- if $l.Snippet }}
+        {{ $l.Snippet }}
+        {{- end }}
+    }
+        {{ end }}
+        {{- range $e := $s.ErrorPages }}
+    location {{ $e.Location }} {
+        default_type {{ $e.ContentType | printf "%q" }};
+        return {{ $e.Code }} {{ $e.Body | printf "%q" }};
     }
         {{ end }}
+        {{- range $e := $s.BackendErrorPages }}
+    location {{ $e.Location }} {
+        default_type {{ $e.ContentType | printf "%q" }};
+        return {{ $e.Code }} {{ $e.Body | printf "%q" }};
+    }
+        {{ end }}
+        {{- if $s.ServerSnippet }}
+    {{ $s.ServerSnippet }}
+        {{- end }}
 }
     {{- end }}
 {{ end }}
+`
+
+var internalServersTemplateText = `
 server {
     listen unix:/var/lib/nginx/nginx-502-server.sock;
     access_log off;
@@ -14,9 +14,85 @@ import (
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config/http"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/file"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
 )
 
+// executeServers generates the configuration of all servers of the configuration, so that the tests can check
+// the configuration of multiple servers at once.
+func executeServers(conf dataplane.Configuration) []byte {
+	var result []byte
+	for _, f := range generateServerFiles(conf) {
+		result = append(result, f.Content...)
+	}
+
+	return result
+}
+
+func TestGenerateServerFiles(t *testing.T) {
+	conf := dataplane.Configuration{
+		HTTPServers: []dataplane.VirtualServer{
+			{
+				IsDefault: true,
+				Port:      8080,
+			},
+			{
+				Hostname: "example.com",
+				Port:     8080,
+			},
+			{
+				Hostname: "*.example.com",
+				Port:     8080,
+			},
+		},
+		SSLServers: []dataplane.VirtualServer{
+			{
+				IsDefault: true,
+				Port:      8443,
+			},
+			{
+				Hostname: "example.com",
+				SSL: &dataplane.SSL{
+					KeyPairID: "test-keypair",
+				},
+				Port: 8443,
+			},
+		},
+	}
+
+	g := NewGomegaWithT(t)
+
+	files := generateServerFiles(conf)
+
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		g.Expect(f.Type).To(Equal(file.TypeRegular))
+		g.Expect(strings.Count(string(f.Content), "server {")).To(Equal(1))
+
+		paths = append(paths, f.Path)
+	}
+
+	g.Expect(paths).To(Equal([]string{
+		"/etc/nginx/conf.d/servers/_default_8080.conf",
+		"/etc/nginx/conf.d/servers/example.com_8080.conf",
+		"/etc/nginx/conf.d/servers/_.example.com_8080.conf",
+		"/etc/nginx/conf.d/servers/_default_8443_ssl.conf",
+		"/etc/nginx/conf.d/servers/example.com_8443_ssl.conf",
+	}))
+
+	g.Expect(string(files[1].Content)).To(ContainSubstring("server_name example.com;"))
+	g.Expect(string(files[4].Content)).To(ContainSubstring("listen 8443 ssl;"))
+}
+
+func TestExecuteInternalServers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	servers := string(executeInternalServers(dataplane.Configuration{}))
+
+	g.Expect(servers).To(ContainSubstring("listen unix:/var/lib/nginx/nginx-502-server.sock;"))
+	g.Expect(servers).To(ContainSubstring("listen unix:/var/lib/nginx/nginx-500-server.sock;"))
+}
+
 func TestExecuteServers(t *testing.T) {
 	conf := dataplane.Configuration{
 		HTTPServers: []dataplane.VirtualServer{
@@ -79,1127 +155,2583 @@ func TestExecuteServers(t *testing.T) {
 	}
 }
 
-func TestExecuteForDefaultServers(t *testing.T) {
-	testcases := []struct {
-		msg       string
-		conf      dataplane.Configuration
-		httpPorts []int
-		sslPorts  []int
-	}{
-		{
-			conf: dataplane.Configuration{},
-			msg:  "no default servers",
+func TestExecuteServersClientMaxBodySize(t *testing.T) {
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
 		},
-		{
-			conf: dataplane.Configuration{
-				HTTPServers: []dataplane.VirtualServer{
-					{
-						IsDefault: true,
-						Port:      80,
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
+				{
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/upload"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchExact),
+							},
+						},
 					},
 				},
 			},
-			httpPorts: []int{80},
-			msg:       "only HTTP default server",
 		},
-		{
-			conf: dataplane.Configuration{
-				SSLServers: []dataplane.VirtualServer{
+	}
+
+	createPathRules := func(routeSize *int64) []dataplane.PathRule {
+		return []dataplane.PathRule{
+			{
+				Path:     "/upload",
+				PathType: dataplane.PathTypeExact,
+				MatchRules: []dataplane.MatchRule{
 					{
-						IsDefault: true,
-						Port:      443,
+						Source:            hr,
+						ClientMaxBodySize: routeSize,
+						BackendGroup: dataplane.BackendGroup{
+							Source: types.NamespacedName{Namespace: "test", Name: "route1"},
+						},
 					},
 				},
 			},
-			sslPorts: []int{443},
-			msg:      "only HTTPS default server",
+		}
+	}
+
+	tests := []struct {
+		serverSize    *int64
+		routeSize     *int64
+		expSubStrings map[string]int
+		name          string
+	}{
+		{
+			name: "not set",
+			expSubStrings: map[string]int{
+				"client_max_body_size": 0,
+			},
 		},
 		{
-			conf: dataplane.Configuration{
-				HTTPServers: []dataplane.VirtualServer{
-					{
-						IsDefault: true,
-						Port:      80,
-					},
-					{
-						IsDefault: true,
-						Port:      8080,
-					},
-				},
-				SSLServers: []dataplane.VirtualServer{
-					{
-						IsDefault: true,
-						Port:      443,
-					},
-					{
-						IsDefault: true,
-						Port:      8443,
-					},
-				},
+			name:       "GB on the server",
+			serverSize: helpers.GetPointer[int64](1 << 30),
+			expSubStrings: map[string]int{
+				"client_max_body_size 1073741824;": 1,
+				"client_max_body_size":             1,
+			},
+		},
+		{
+			name:       "MB on the route overrides the server",
+			serverSize: helpers.GetPointer[int64](1 << 30),
+			routeSize:  helpers.GetPointer[int64](10 * 1000 * 1000),
+			expSubStrings: map[string]int{
+				"client_max_body_size 1073741824;": 1,
+				"client_max_body_size 10000000;":   1,
+			},
+		},
+		{
+			name:      "zero (unlimited) on the route",
+			routeSize: helpers.GetPointer[int64](0),
+			expSubStrings: map[string]int{
+				"client_max_body_size 0;": 1,
+				"client_max_body_size":    1,
 			},
-			httpPorts: []int{80, 8080},
-			sslPorts:  []int{443, 8443},
-			msg:       "multiple HTTP and HTTPS default servers",
 		},
 	}
 
-	sslDefaultFmt := "listen %d ssl default_server"
-	httpDefaultFmt := "listen %d default_server"
-
-	for _, tc := range testcases {
-		t.Run(tc.msg, func(t *testing.T) {
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
 			g := NewGomegaWithT(t)
 
-			cfg := string(executeServers(tc.conf))
-
-			for _, expPort := range tc.httpPorts {
-				g.Expect(cfg).To(ContainSubstring(fmt.Sprintf(httpDefaultFmt, expPort)))
+			conf := dataplane.Configuration{
+				HTTPServers: []dataplane.VirtualServer{
+					{
+						Hostname:          "example.com",
+						PathRules:         createPathRules(test.routeSize),
+						ClientMaxBodySize: test.serverSize,
+						Port:              8080,
+					},
+				},
 			}
 
-			for _, expPort := range tc.sslPorts {
-				g.Expect(cfg).To(ContainSubstring(fmt.Sprintf(sslDefaultFmt, expPort)))
+			servers := string(executeServers(conf))
+
+			for expSubStr, expCount := range test.expSubStrings {
+				g.Expect(strings.Count(servers, expSubStr)).To(Equal(expCount), expSubStr)
 			}
 		})
 	}
 }
 
-func TestCreateServers(t *testing.T) {
-	const (
-		sslKeyPairID = "test-keypair"
-	)
-
+func TestExecuteServersStreamingProxy(t *testing.T) {
 	hr := &v1beta1.HTTPRoute{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: "test",
 			Name:      "route1",
 		},
 		Spec: v1beta1.HTTPRouteSpec{
-			Hostnames: []v1beta1.Hostname{
-				"cafe.example.com",
-			},
 			Rules: []v1beta1.HTTPRouteRule{
 				{
-					// matches with path and methods
 					Matches: []v1beta1.HTTPRouteMatch{
 						{
 							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetStringPointer("/"),
-								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
-							},
-							Method: helpers.GetHTTPMethodPointer(v1beta1.HTTPMethodPost),
-						},
-						{
-							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetStringPointer("/"),
-								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+								Value: helpers.GetPointer("/events"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchExact),
 							},
-							Method: helpers.GetHTTPMethodPointer(v1beta1.HTTPMethodPatch),
 						},
-						{
-							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetStringPointer(
-									"/", // should generate an "any" httpmatch since other matches exists for /
-								),
-								Type: helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+					},
+				},
+			},
+		},
+	}
+
+	directives := []string{
+		"proxy_buffering off;",
+		"proxy_cache off;",
+		"proxy_read_timeout 3600s;",
+	}
+
+	tests := []struct {
+		name           string
+		streamingProxy bool
+	}{
+		{
+			name:           "streaming proxy",
+			streamingProxy: true,
+		},
+		{
+			name:           "no streaming proxy",
+			streamingProxy: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			conf := dataplane.Configuration{
+				HTTPServers: []dataplane.VirtualServer{
+					{
+						Hostname: "example.com",
+						PathRules: []dataplane.PathRule{
+							{
+								Path:     "/events",
+								PathType: dataplane.PathTypeExact,
+								MatchRules: []dataplane.MatchRule{
+									{
+										Source:         hr,
+										StreamingProxy: test.streamingProxy,
+										BackendGroup: dataplane.BackendGroup{
+											Source: types.NamespacedName{Namespace: "test", Name: "route1"},
+										},
+									},
+								},
 							},
 						},
+						Port: 8080,
 					},
 				},
+			}
+
+			servers := string(executeServers(conf))
+
+			expCount := 0
+			if test.streamingProxy {
+				expCount = 1
+			}
+
+			for _, directive := range directives {
+				g.Expect(strings.Count(servers, directive)).To(Equal(expCount), directive)
+			}
+		})
+	}
+}
+
+func TestExecuteServersCaseInsensitiveHeaderValues(t *testing.T) {
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
 				{
-					// A match with all possible fields set
 					Matches: []v1beta1.HTTPRouteMatch{
 						{
 							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetStringPointer("/test"),
+								Value: helpers.GetPointer("/coffee"),
 								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
 							},
-							Method: helpers.GetHTTPMethodPointer(v1beta1.HTTPMethodGet),
 							Headers: []v1beta1.HTTPHeaderMatch{
 								{
-									Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
-									Name:  "Version",
-									Value: "V1",
-								},
-								{
-									Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
-									Name:  "test",
-									Value: "foo",
-								},
-								{
-									Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
-									Name:  "my-header",
-									Value: "my-value",
+									Type:  helpers.GetPointer(v1beta1.HeaderMatchExact),
+									Name:  "X-Foo",
+									Value: "Bar",
 								},
 							},
-							QueryParams: []v1beta1.HTTPQueryParamMatch{
-								{
-									Type:  helpers.GetQueryParamMatchTypePointer(v1beta1.QueryParamMatchExact),
-									Name:  "GrEat", // query names and values should not be normalized to lowercase
-									Value: "EXAMPLE",
-								},
-								{
-									Type:  helpers.GetQueryParamMatchTypePointer(v1beta1.QueryParamMatchExact),
-									Name:  "test",
-									Value: "foo=bar",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		caseInsensitive bool
+	}{
+		{
+			name:            "case-insensitive header values",
+			caseInsensitive: true,
+		},
+		{
+			name:            "case-sensitive header values",
+			caseInsensitive: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			conf := dataplane.Configuration{
+				HTTPServers: []dataplane.VirtualServer{
+					{
+						Hostname: "example.com",
+						PathRules: []dataplane.PathRule{
+							{
+								Path:     "/coffee",
+								PathType: dataplane.PathTypePrefix,
+								MatchRules: []dataplane.MatchRule{
+									{
+										Source:                      hr,
+										CaseInsensitiveHeaderValues: test.caseInsensitive,
+										BackendGroup: dataplane.BackendGroup{
+											Source: types.NamespacedName{Namespace: "test", Name: "route1"},
+										},
+									},
 								},
 							},
 						},
+						Port: 8080,
 					},
 				},
+			}
+
+			servers := string(executeServers(conf))
+
+			// The matches are in the http_matches variable for both the prefix and the exact location.
+			g.Expect(strings.Count(servers, `\"headers\":[\"X-Foo:Bar\"]`)).To(Equal(2))
+
+			expCount := 0
+			if test.caseInsensitive {
+				expCount = 2
+			}
+			g.Expect(strings.Count(servers, `\"caseInsensitiveHeaders\":true`)).To(Equal(expCount))
+		})
+	}
+}
+
+func TestExecuteServersNextUpstream(t *testing.T) {
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
 				{
-					// A match with just path
-					Matches: []v1beta1.HTTPRouteMatch{
-						{
-							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetStringPointer("/path-only"),
-								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
-							},
-						},
-					},
-				},
-				{
-					// A match with a redirect with implicit port
-					Matches: []v1beta1.HTTPRouteMatch{
-						{
-							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetStringPointer("/redirect-implicit-port"),
-								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
-							},
-						},
-					},
-					// redirect is set in the corresponding state.MatchRule
-				},
-				{
-					// A match with a redirect with explicit port
 					Matches: []v1beta1.HTTPRouteMatch{
 						{
 							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetStringPointer("/redirect-explicit-port"),
-								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+								Value: helpers.GetPointer("/coffee"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchExact),
 							},
 						},
 					},
-					// redirect is set in the corresponding state.MatchRule
 				},
-				{
-					// A match with a redirect and header matches
-					Matches: []v1beta1.HTTPRouteMatch{
-						{
-							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetStringPointer("/redirect-with-headers"),
-								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
-							},
-							Headers: []v1beta1.HTTPHeaderMatch{
-								{
-									Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
-									Name:  "redirect",
-									Value: "this",
+			},
+		},
+	}
+
+	tests := []struct {
+		nextUpstream  *dataplane.NextUpstream
+		expSubStrings map[string]int
+		name          string
+	}{
+		{
+			name: "default",
+			expSubStrings: map[string]int{
+				"proxy_next_upstream": 0,
+			},
+		},
+		{
+			name: "single condition",
+			nextUpstream: &dataplane.NextUpstream{
+				Conditions: []string{"http_502"},
+			},
+			expSubStrings: map[string]int{
+				"proxy_next_upstream http_502;": 1,
+				"proxy_next_upstream_tries":     0,
+			},
+		},
+		{
+			name: "multiple conditions",
+			nextUpstream: &dataplane.NextUpstream{
+				Conditions: []string{"error", "timeout", "http_502"},
+				Tries:      2,
+			},
+			expSubStrings: map[string]int{
+				"proxy_next_upstream error timeout http_502;": 1,
+				"proxy_next_upstream_tries 2;":                1,
+			},
+		},
+		{
+			name: "retries disabled",
+			nextUpstream: &dataplane.NextUpstream{
+				Conditions: []string{"off"},
+			},
+			expSubStrings: map[string]int{
+				"proxy_next_upstream off;":  1,
+				"proxy_next_upstream_tries": 0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			conf := dataplane.Configuration{
+				HTTPServers: []dataplane.VirtualServer{
+					{
+						Hostname: "example.com",
+						PathRules: []dataplane.PathRule{
+							{
+								Path:     "/coffee",
+								PathType: dataplane.PathTypeExact,
+								MatchRules: []dataplane.MatchRule{
+									{
+										Source:       hr,
+										NextUpstream: test.nextUpstream,
+										BackendGroup: dataplane.BackendGroup{
+											Source: types.NamespacedName{Namespace: "test", Name: "route1"},
+										},
+									},
 								},
 							},
 						},
+						Port: 8080,
 					},
 				},
+			}
+
+			servers := string(executeServers(conf))
+
+			for expSubStr, expCount := range test.expSubStrings {
+				g.Expect(strings.Count(servers, expSubStr)).To(Equal(expCount), expSubStr)
+			}
+		})
+	}
+}
+
+func TestExecuteServersDNSBackend(t *testing.T) {
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
 				{
-					// A match with an invalid filter
 					Matches: []v1beta1.HTTPRouteMatch{
 						{
 							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetPointer("/invalid-filter"),
+								Value: helpers.GetPointer("/"),
 								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
 							},
 						},
 					},
 				},
-				{
-					// A match with an invalid filter and headers
-					Matches: []v1beta1.HTTPRouteMatch{
-						{
-							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetPointer("/invalid-filter-with-headers"),
-								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
-							},
-							Headers: []v1beta1.HTTPHeaderMatch{
-								{
-									Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
-									Name:  "filter",
-									Value: "this",
+			},
+		},
+	}
+
+	conf := dataplane.Configuration{
+		HTTPServers: []dataplane.VirtualServer{
+			{
+				Hostname: "example.com",
+				PathRules: []dataplane.PathRule{
+					{
+						Path:     "/",
+						PathType: dataplane.PathTypePrefix,
+						MatchRules: []dataplane.MatchRule{
+							{
+								Source: hr,
+								BackendGroup: dataplane.BackendGroup{
+									Source: types.NamespacedName{Namespace: "test", Name: "route1"},
+									Backends: []dataplane.Backend{
+										{
+											UpstreamName: "test_external_80",
+											DNSAddress:   "external.example.com:80",
+											Valid:        true,
+											Weight:       1,
+										},
+									},
 								},
 							},
 						},
 					},
 				},
+				Port: 8080,
+			},
+		},
+	}
+
+	g := NewGomegaWithT(t)
+
+	servers := string(executeServers(conf))
+
+	expSubStrings := map[string]int{
+		"set $upstream external.example.com:80;":   1,
+		"proxy_pass http://$upstream$request_uri;": 1,
+		"test_external_80":                         0,
+	}
+
+	for expSubStr, expCount := range expSubStrings {
+		g.Expect(strings.Count(servers, expSubStr)).To(Equal(expCount), expSubStr)
+	}
+}
+
+func TestExecuteServersLocationSnippet(t *testing.T) {
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
 				{
-					// A match using type Exact
 					Matches: []v1beta1.HTTPRouteMatch{
 						{
 							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetPointer("/exact"),
+								Value: helpers.GetPointer("/coffee"),
 								Type:  helpers.GetPointer(v1beta1.PathMatchExact),
 							},
 						},
 					},
 				},
-				{
-					// A match using type Exact with method
-					Matches: []v1beta1.HTTPRouteMatch{
-						{
-							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetPointer("/test"),
-								Type:  helpers.GetPointer(v1beta1.PathMatchExact),
+			},
+		},
+	}
+
+	tests := []struct {
+		expSubStrings   map[string]int
+		name            string
+		locationSnippet string
+	}{
+		{
+			name:            "location snippet",
+			locationSnippet: "proxy_set_header X-Route route1;",
+			expSubStrings: map[string]int{
+				"$request_uri;\n        proxy_set_header X-Route route1;\n    }": 1,
+			},
+		},
+		{
+			name:            "no location snippet",
+			locationSnippet: "",
+			expSubStrings: map[string]int{
+				"X-Route": 0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			conf := dataplane.Configuration{
+				HTTPServers: []dataplane.VirtualServer{
+					{
+						Hostname: "example.com",
+						PathRules: []dataplane.PathRule{
+							{
+								Path:     "/coffee",
+								PathType: dataplane.PathTypeExact,
+								MatchRules: []dataplane.MatchRule{
+									{
+										Source:          hr,
+										LocationSnippet: test.locationSnippet,
+										BackendGroup: dataplane.BackendGroup{
+											Source: types.NamespacedName{Namespace: "test", Name: "route1"},
+										},
+									},
+								},
 							},
-							Method: helpers.GetHTTPMethodPointer(v1beta1.HTTPMethodGet),
 						},
+						Port: 8080,
 					},
 				},
+			}
+
+			servers := string(executeServers(conf))
+
+			for expSubStr, expCount := range test.expSubStrings {
+				g.Expect(strings.Count(servers, expSubStr)).To(Equal(expCount), expSubStr)
+			}
+		})
+	}
+}
+
+func TestExecuteServersRequestIDHeader(t *testing.T) {
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
 				{
-					// A match with requestHeaderModifier filter set
 					Matches: []v1beta1.HTTPRouteMatch{
 						{
 							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetStringPointer("/proxy-set-headers"),
-								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+								Value: helpers.GetPointer("/coffee"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchExact),
 							},
 						},
 					},
-					Filters: []v1beta1.HTTPRouteFilter{
-						{
-							Type: "RequestHeaderModifier",
-							RequestHeaderModifier: &v1beta1.HTTPHeaderFilter{
-								Add: []v1beta1.HTTPHeader{
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		expSubStrings   map[string]int
+		name            string
+		requestIDHeader string
+	}{
+		{
+			name:            "request ID header",
+			requestIDHeader: "X-Correlation-ID",
+			expSubStrings: map[string]int{
+				"add_header X-Correlation-ID $request_id always;": 1,
+				"proxy_set_header X-Correlation-ID $request_id;":  1,
+			},
+		},
+		{
+			name:            "no request ID header",
+			requestIDHeader: "",
+			expSubStrings: map[string]int{
+				"$request_id": 0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			conf := dataplane.Configuration{
+				HTTPServers: []dataplane.VirtualServer{
+					{
+						Hostname: "example.com",
+						PathRules: []dataplane.PathRule{
+							{
+								Path:     "/coffee",
+								PathType: dataplane.PathTypeExact,
+								MatchRules: []dataplane.MatchRule{
 									{
-										Name:  "my-header",
-										Value: "some-value-123",
+										Source: hr,
+										BackendGroup: dataplane.BackendGroup{
+											Source: types.NamespacedName{Namespace: "test", Name: "route1"},
+										},
 									},
 								},
 							},
 						},
+						RequestIDHeader: test.requestIDHeader,
+						Port:            8080,
 					},
 				},
-			},
-		},
-	}
+			}
 
-	hrNsName := types.NamespacedName{Namespace: hr.Namespace, Name: hr.Name}
+			servers := string(executeServers(conf))
 
-	fooGroup := dataplane.BackendGroup{
-		Source:  hrNsName,
-		RuleIdx: 0,
-		Backends: []dataplane.Backend{
-			{
-				UpstreamName: "test_foo_80",
-				Valid:        true,
-				Weight:       1,
+			for expSubStr, expCount := range test.expSubStrings {
+				g.Expect(strings.Count(servers, expSubStr)).To(Equal(expCount), expSubStr)
+			}
+		})
+	}
+}
+
+func TestExecuteServersServerSnippet(t *testing.T) {
+	tests := []struct {
+		expSubStrings map[string]int
+		name          string
+		serverSnippet string
+	}{
+		{
+			name:          "server snippet",
+			serverSnippet: "add_header X-Gateway nginx;",
+			expSubStrings: map[string]int{
+				"    add_header X-Gateway nginx;\n}": 1,
+			},
+		},
+		{
+			name:          "no server snippet",
+			serverSnippet: "",
+			expSubStrings: map[string]int{
+				"X-Gateway": 0,
 			},
 		},
 	}
 
-	// barGroup has two backends, which should generate a proxy pass with a variable.
-	barGroup := dataplane.BackendGroup{
-		Source:  hrNsName,
-		RuleIdx: 1,
-		Backends: []dataplane.Backend{
-			{
-				UpstreamName: "test_bar_80",
-				Valid:        true,
-				Weight:       50,
-			},
-			{
-				UpstreamName: "test_bar2_80",
-				Valid:        true,
-				Weight:       50,
-			},
-		},
-	}
-
-	// baz group has an invalid backend, which should generate a proxy pass to the invalid ref backend.
-	bazGroup := dataplane.BackendGroup{
-		Source:  hrNsName,
-		RuleIdx: 2,
-		Backends: []dataplane.Backend{
-			{
-				UpstreamName: "test_baz_80",
-				Valid:        false,
-				Weight:       1,
-			},
-		},
-	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
 
-	filterGroup1 := dataplane.BackendGroup{Source: hrNsName, RuleIdx: 3}
+			conf := dataplane.Configuration{
+				HTTPServers: []dataplane.VirtualServer{
+					{
+						Hostname:      "example.com",
+						ServerSnippet: test.serverSnippet,
+						Port:          8080,
+					},
+				},
+			}
 
-	filterGroup2 := dataplane.BackendGroup{Source: hrNsName, RuleIdx: 4}
+			servers := string(executeServers(conf))
 
-	invalidFilterGroup := dataplane.BackendGroup{Source: hrNsName, RuleIdx: 5}
+			for expSubStr, expCount := range test.expSubStrings {
+				g.Expect(strings.Count(servers, expSubStr)).To(Equal(expCount), expSubStr)
+			}
+		})
+	}
+}
 
-	cafePathRules := []dataplane.PathRule{
-		{
-			Path:     "/",
-			PathType: dataplane.PathTypePrefix,
-			MatchRules: []dataplane.MatchRule{
-				{
-					MatchIdx:     0,
-					RuleIdx:      0,
-					BackendGroup: fooGroup,
-					Source:       hr,
-				},
-				{
-					MatchIdx:     1,
-					RuleIdx:      0,
-					BackendGroup: fooGroup,
-					Source:       hr,
-				},
+func TestExecuteServersErrorPages(t *testing.T) {
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
 				{
-					MatchIdx:     2,
-					RuleIdx:      0,
-					BackendGroup: fooGroup,
-					Source:       hr,
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/coffee"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchExact),
+							},
+						},
+					},
 				},
 			},
 		},
+	}
+
+	tests := []struct {
+		errorPage     *dataplane.ErrorPage
+		expSubStrings map[string]int
+		name          string
+	}{
 		{
-			Path:     "/test",
-			PathType: dataplane.PathTypePrefix,
-			MatchRules: []dataplane.MatchRule{
-				{
-					MatchIdx:     0,
-					RuleIdx:      1,
-					BackendGroup: barGroup,
-					Source:       hr,
-				},
+			name: "custom 404",
+			errorPage: &dataplane.ErrorPage{
+				Body:        "<h1>Not found</h1>",
+				ContentType: "text/html",
+				StatusCodes: []int{404},
+			},
+			expSubStrings: map[string]int{
+				"error_page 404 = @custom_404;":    1,
+				"location @custom_404 {":           1,
+				`default_type "text/html";`:        1,
+				`return 404 "<h1>Not found</h1>";`: 1,
+				"@custom_503":                      0,
 			},
 		},
 		{
-			Path:     "/path-only",
-			PathType: dataplane.PathTypePrefix,
-			MatchRules: []dataplane.MatchRule{
-				{
-					MatchIdx:     0,
-					RuleIdx:      2,
-					BackendGroup: bazGroup,
-					Source:       hr,
-				},
+			name: "custom 503",
+			errorPage: &dataplane.ErrorPage{
+				Body:        `{"error": "unavailable"}`,
+				ContentType: "application/json",
+				StatusCodes: []int{503},
+			},
+			expSubStrings: map[string]int{
+				"error_page 503 = @custom_503;":              1,
+				"location @custom_503 {":                     1,
+				`default_type "application/json";`:           1,
+				`return 503 "{\"error\": \"unavailable\"}";`: 1,
+				"@custom_404":                                0,
 			},
 		},
 		{
-			Path:     "/redirect-implicit-port",
-			PathType: dataplane.PathTypePrefix,
-			MatchRules: []dataplane.MatchRule{
-				{
-					MatchIdx: 0,
-					RuleIdx:  3,
-					Source:   hr,
-					Filters: dataplane.Filters{
-						RequestRedirect: &v1beta1.HTTPRequestRedirectFilter{
-							Hostname: (*v1beta1.PreciseHostname)(helpers.GetStringPointer("foo.example.com")),
-						},
-					},
-					BackendGroup: filterGroup1,
-				},
+			name: "404 and 503",
+			errorPage: &dataplane.ErrorPage{
+				Body:        "Not available",
+				ContentType: "text/plain",
+				StatusCodes: []int{404, 503},
+			},
+			expSubStrings: map[string]int{
+				"error_page 404 = @custom_404;": 1,
+				"error_page 503 = @custom_503;": 1,
+				"location @custom_404 {":        1,
+				"location @custom_503 {":        1,
+				`return 404 "Not available";`:   1,
+				`return 503 "Not available";`:   1,
+				`default_type "text/plain";`:    2,
 			},
 		},
 		{
-			Path:     "/redirect-explicit-port",
-			PathType: dataplane.PathTypePrefix,
-			MatchRules: []dataplane.MatchRule{
-				{
-					MatchIdx: 0,
-					RuleIdx:  4,
-					Source:   hr,
-					Filters: dataplane.Filters{
-						RequestRedirect: &v1beta1.HTTPRequestRedirectFilter{
-							Hostname: (*v1beta1.PreciseHostname)(helpers.GetStringPointer("bar.example.com")),
-							Port:     (*v1beta1.PortNumber)(helpers.GetInt32Pointer(8080)),
-						},
-					},
-					BackendGroup: filterGroup2,
-				},
+			name: "no error page",
+			expSubStrings: map[string]int{
+				"error_page": 0,
 			},
 		},
-		{
-			Path:     "/redirect-with-headers",
-			PathType: dataplane.PathTypePrefix,
-			MatchRules: []dataplane.MatchRule{
-				{
-					MatchIdx: 0,
-					RuleIdx:  5,
-					Source:   hr,
-					Filters: dataplane.Filters{
-						RequestRedirect: &v1beta1.HTTPRequestRedirectFilter{
-							Hostname: helpers.GetPointer(v1beta1.PreciseHostname("foo.example.com")),
-							Port:     helpers.GetPointer(v1beta1.PortNumber(8080)),
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			conf := dataplane.Configuration{
+				HTTPServers: []dataplane.VirtualServer{
+					{
+						Hostname: "example.com",
+						PathRules: []dataplane.PathRule{
+							{
+								Path:     "/coffee",
+								PathType: dataplane.PathTypeExact,
+								MatchRules: []dataplane.MatchRule{
+									{
+										Source: hr,
+										BackendGroup: dataplane.BackendGroup{
+											Source: types.NamespacedName{Namespace: "test", Name: "route1"},
+										},
+									},
+								},
+							},
 						},
+						ErrorPage: test.errorPage,
+						Port:      8080,
 					},
-					BackendGroup: filterGroup1,
 				},
-			},
+			}
+
+			servers := string(executeServers(conf))
+
+			for expSubStr, expCount := range test.expSubStrings {
+				g.Expect(strings.Count(servers, expSubStr)).To(Equal(expCount), expSubStr)
+			}
+		})
+	}
+}
+
+func TestExecuteServersBackendErrorPages(t *testing.T) {
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
 		},
-		{
-			Path:     "/invalid-filter",
-			PathType: dataplane.PathTypePrefix,
-			MatchRules: []dataplane.MatchRule{
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
 				{
-					MatchIdx: 0,
-					RuleIdx:  6,
-					Source:   hr,
-					Filters: dataplane.Filters{
-						InvalidFilter: &dataplane.InvalidFilter{},
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/coffee"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+							},
+						},
 					},
-					BackendGroup: invalidFilterGroup,
 				},
 			},
 		},
+	}
+
+	tests := []struct {
+		backendErrorPage *dataplane.ErrorPage
+		expSubStrings    map[string]int
+		name             string
+	}{
 		{
-			Path:     "/invalid-filter-with-headers",
-			PathType: dataplane.PathTypePrefix,
-			MatchRules: []dataplane.MatchRule{
-				{
-					MatchIdx: 0,
-					RuleIdx:  7,
-					Source:   hr,
-					Filters: dataplane.Filters{
-						InvalidFilter: &dataplane.InvalidFilter{},
-					},
-					BackendGroup: invalidFilterGroup,
-				},
+			name: "5xx backend responses",
+			backendErrorPage: &dataplane.ErrorPage{
+				Body:        `{"error": "try again later"}`,
+				ContentType: "application/json",
+				StatusCodes: []int{500, 502, 503, 504},
 			},
-		},
-		{
-			Path:     "/exact",
-			PathType: dataplane.PathTypeExact,
-			MatchRules: []dataplane.MatchRule{
-				{
-					MatchIdx:     0,
-					RuleIdx:      8,
-					Source:       hr,
-					BackendGroup: fooGroup,
-				},
+			expSubStrings: map[string]int{
+				// the exact and prefix locations of the path share the error pages
+				"proxy_intercept_errors on;":                     2,
+				"error_page 503 = @backend_error_0_0_503;":       2,
+				"location @backend_error_0_0_503 {":              1,
+				`return 503 "{\"error\": \"try again later\"}";`: 1,
+				`default_type "application/json";`:               4,
+				"error_page 500 = @backend_error_0_0_500;":       2,
+				"error_page 502 = @backend_error_0_0_502;":       2,
+				"error_page 504 = @backend_error_0_0_504;":       2,
+				// other responses, like 200, pass through unmodified
+				"error_page 200": 0,
+				"error_page 404": 0,
 			},
 		},
 		{
-			Path:     "/test",
-			PathType: dataplane.PathTypeExact,
-			MatchRules: []dataplane.MatchRule{
-				{
-					MatchIdx:     0,
-					RuleIdx:      9,
-					Source:       hr,
-					BackendGroup: fooGroup,
-				},
+			name: "no backend error page",
+			expSubStrings: map[string]int{
+				"proxy_intercept_errors": 0,
+				"error_page":             0,
+				"@backend_error":         0,
 			},
 		},
-		{
-			Path:     "/proxy-set-headers",
-			PathType: dataplane.PathTypePrefix,
-			MatchRules: []dataplane.MatchRule{
-				{
-					MatchIdx:     0,
-					RuleIdx:      10,
-					Source:       hr,
-					BackendGroup: fooGroup,
-					Filters: dataplane.Filters{
-						RequestHeaderModifiers: &dataplane.HTTPHeaderFilter{
-							Add: []dataplane.HTTPHeader{
-								{
-									Name:  "my-header",
-									Value: "some-value-123",
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			conf := dataplane.Configuration{
+				HTTPServers: []dataplane.VirtualServer{
+					{
+						Hostname: "example.com",
+						PathRules: []dataplane.PathRule{
+							{
+								Path:     "/coffee",
+								PathType: dataplane.PathTypePrefix,
+								MatchRules: []dataplane.MatchRule{
+									{
+										Source:           hr,
+										BackendErrorPage: test.backendErrorPage,
+										BackendGroup: dataplane.BackendGroup{
+											Source: types.NamespacedName{Namespace: "test", Name: "route1"},
+										},
+									},
 								},
 							},
 						},
+						Port: 8080,
 					},
 				},
-			},
-		},
+			}
+
+			servers := string(executeServers(conf))
+
+			for expSubStr, expCount := range test.expSubStrings {
+				g.Expect(strings.Count(servers, expSubStr)).To(Equal(expCount), expSubStr)
+			}
+		})
 	}
+}
 
-	httpServers := []dataplane.VirtualServer{
+func TestExecuteServersSSLSettings(t *testing.T) {
+	tests := []struct {
+		ssl           *dataplane.SSL
+		expSubStrings map[string]int
+		name          string
+	}{
 		{
-			IsDefault: true,
-			Port:      8080,
+			name: "not set",
+			ssl:  &dataplane.SSL{KeyPairID: "test-keypair"},
+			expSubStrings: map[string]int{
+				"ssl_session_":  0,
+				"ssl_protocols": 0,
+				"ssl_ciphers":   0,
+				"ssl_stapling":  0,
+				"ssl_dhparam":   0,
+			},
 		},
 		{
-			Hostname:  "cafe.example.com",
-			PathRules: cafePathRules,
-			Port:      8080,
-		},
-	}
-
-	sslServers := []dataplane.VirtualServer{
-		{
-			IsDefault: true,
-			Port:      8443,
+			name: "session cache",
+			ssl: &dataplane.SSL{
+				KeyPairID:        "test-keypair",
+				SessionCacheSize: helpers.GetPointer[int64](10 * 1024 * 1024),
+			},
+			expSubStrings: map[string]int{
+				"ssl_session_cache shared:ssl_sessions_10485760:10485760;": 1,
+				"ssl_session_timeout 1d;":                                  1,
+				"ssl_session_tickets":                                      0,
+			},
 		},
 		{
-			Hostname:  "cafe.example.com",
-			SSL:       &dataplane.SSL{KeyPairID: sslKeyPairID},
-			PathRules: cafePathRules,
-			Port:      8443,
+			name: "TLSv1.3 only",
+			ssl: &dataplane.SSL{
+				KeyPairID:     "test-keypair",
+				MinTLSVersion: "TLSv1.3",
+			},
+			expSubStrings: map[string]int{
+				"ssl_protocols TLSv1.3;": 1,
+				"ssl_ciphers":            0,
+			},
 		},
-	}
-
-	expectedMatchString := func(m []httpMatch) string {
-		b, err := json.Marshal(m)
-		if err != nil {
-			t.Errorf("error marshaling test match: %v", err)
-		}
-		return string(b)
-	}
-
-	slashMatches := []httpMatch{
-		{Method: v1beta1.HTTPMethodPost, RedirectPath: "/_prefix_route0"},
-		{Method: v1beta1.HTTPMethodPatch, RedirectPath: "/_prefix_route1"},
-		{Any: true, RedirectPath: "/_prefix_route2"},
-	}
-	testMatches := []httpMatch{
 		{
-			Method:       v1beta1.HTTPMethodGet,
-			Headers:      []string{"Version:V1", "test:foo", "my-header:my-value"},
-			QueryParams:  []string{"GrEat=EXAMPLE", "test=foo=bar"},
-			RedirectPath: "/test_prefix_route0",
+			name: "TLSv1.2 and ciphers",
+			ssl: &dataplane.SSL{
+				KeyPairID:     "test-keypair",
+				MinTLSVersion: "TLSv1.2",
+				Ciphers:       []string{"ECDHE-RSA-AES128-GCM-SHA256", "!aNULL"},
+			},
+			expSubStrings: map[string]int{
+				"ssl_protocols TLSv1.2 TLSv1.3;":                  1,
+				"ssl_ciphers ECDHE-RSA-AES128-GCM-SHA256:!aNULL;": 1,
+			},
 		},
-	}
-	exactMatches := []httpMatch{
 		{
-			Method:       v1beta1.HTTPMethodGet,
-			RedirectPath: "/test_exact_route0",
+			name: "session tickets disabled",
+			ssl: &dataplane.SSL{
+				KeyPairID:      "test-keypair",
+				SessionTickets: helpers.GetPointer(false),
+			},
+			expSubStrings: map[string]int{
+				"ssl_session_tickets off;": 1,
+				"ssl_session_cache":        0,
+			},
 		},
-	}
-	redirectHeaderMatches := []httpMatch{
 		{
-			Headers:      []string{"redirect:this"},
-			RedirectPath: "/redirect-with-headers_prefix_route0",
+			name: "DH parameters",
+			ssl: &dataplane.SSL{
+				KeyPairID:  "test-keypair",
+				DHParamsID: "test-dhparams",
+			},
+			expSubStrings: map[string]int{
+				"ssl_dhparam /etc/nginx/secrets/test-dhparams.pem;": 1,
+			},
 		},
-	}
-	invalidFilterHeaderMatches := []httpMatch{
 		{
-			Headers:      []string{"filter:this"},
-			RedirectPath: "/invalid-filter-with-headers_prefix_route0",
+			name: "OCSP stapling",
+			ssl: &dataplane.SSL{
+				KeyPairID: "test-keypair",
+				Stapling:  true,
+			},
+			expSubStrings: map[string]int{
+				"ssl_stapling on;":        1,
+				"ssl_stapling_verify on;": 1,
+				"ssl_trusted_certificate /etc/nginx/secrets/test-keypair_ca.pem;": 1,
+			},
 		},
 	}
 
-	getExpectedLocations := func(isHTTPS bool) []http.Location {
-		port := 8080
-		if isHTTPS {
-			port = 8443
-		}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
 
-		return []http.Location{
-			{
-				Path:      "/_prefix_route0",
-				Internal:  true,
-				ProxyPass: "http://test_foo_80",
-			},
-			{
-				Path:      "/_prefix_route1",
-				Internal:  true,
-				ProxyPass: "http://test_foo_80",
-			},
-			{
-				Path:      "/_prefix_route2",
-				Internal:  true,
-				ProxyPass: "http://test_foo_80",
-			},
-			{
-				Path:         "/",
-				HTTPMatchVar: expectedMatchString(slashMatches),
-			},
-			{
-				Path:      "/test_prefix_route0",
-				Internal:  true,
-				ProxyPass: "http://$test__route1_rule1",
-			},
-			{
-				Path:         "/test/",
-				HTTPMatchVar: expectedMatchString(testMatches),
-			},
-			{
-				Path:      "/path-only/",
-				ProxyPass: "http://invalid-backend-ref",
-			},
-			{
-				Path:      "= /path-only",
-				ProxyPass: "http://invalid-backend-ref",
-			},
-			{
-				Path: "/redirect-implicit-port/",
-				Return: &http.Return{
-					Code: 302,
-					Body: fmt.Sprintf("$scheme://foo.example.com:%d$request_uri", port),
+			conf := dataplane.Configuration{
+				SSLServers: []dataplane.VirtualServer{
+					{
+						Hostname: "example.com",
+						SSL:      test.ssl,
+						Port:     8443,
+					},
 				},
-			},
-			{
-				Path: "= /redirect-implicit-port",
-				Return: &http.Return{
-					Code: 302,
-					Body: fmt.Sprintf("$scheme://foo.example.com:%d$request_uri", port),
+			}
+
+			servers := string(executeServers(conf))
+
+			for expSubStr, expCount := range test.expSubStrings {
+				g.Expect(strings.Count(servers, expSubStr)).To(Equal(expCount), expSubStr)
+			}
+		})
+	}
+}
+
+func TestExecuteForDefaultServers(t *testing.T) {
+	testcases := []struct {
+		msg       string
+		conf      dataplane.Configuration
+		httpPorts []int
+		sslPorts  []int
+	}{
+		{
+			conf: dataplane.Configuration{},
+			msg:  "no default servers",
+		},
+		{
+			conf: dataplane.Configuration{
+				HTTPServers: []dataplane.VirtualServer{
+					{
+						IsDefault: true,
+						Port:      80,
+					},
 				},
 			},
-			{
-				Path: "/redirect-explicit-port/",
-				Return: &http.Return{
-					Code: 302,
-					Body: "$scheme://bar.example.com:8080$request_uri",
+			httpPorts: []int{80},
+			msg:       "only HTTP default server",
+		},
+		{
+			conf: dataplane.Configuration{
+				SSLServers: []dataplane.VirtualServer{
+					{
+						IsDefault: true,
+						Port:      443,
+					},
 				},
 			},
-			{
-				Path: "= /redirect-explicit-port",
-				Return: &http.Return{
-					Code: 302,
-					Body: "$scheme://bar.example.com:8080$request_uri",
+			sslPorts: []int{443},
+			msg:      "only HTTPS default server",
+		},
+		{
+			conf: dataplane.Configuration{
+				HTTPServers: []dataplane.VirtualServer{
+					{
+						IsDefault: true,
+						Port:      80,
+					},
+					{
+						IsDefault: true,
+						Port:      8080,
+					},
 				},
-			},
-			{
-				Path: "/redirect-with-headers_prefix_route0",
-				Return: &http.Return{
-					Body: "$scheme://foo.example.com:8080$request_uri",
-					Code: 302,
+				SSLServers: []dataplane.VirtualServer{
+					{
+						IsDefault: true,
+						Port:      443,
+					},
+					{
+						IsDefault: true,
+						Port:      8443,
+					},
 				},
-				Internal: true,
 			},
-			{
-				Path:         "/redirect-with-headers/",
-				HTTPMatchVar: expectedMatchString(redirectHeaderMatches),
+			httpPorts: []int{80, 8080},
+			sslPorts:  []int{443, 8443},
+			msg:       "multiple HTTP and HTTPS default servers",
+		},
+	}
+
+	sslDefaultFmt := "listen %d ssl default_server"
+	httpDefaultFmt := "listen %d default_server"
+
+	for _, tc := range testcases {
+		t.Run(tc.msg, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			cfg := string(executeServers(tc.conf))
+
+			for _, expPort := range tc.httpPorts {
+				g.Expect(cfg).To(ContainSubstring(fmt.Sprintf(httpDefaultFmt, expPort)))
+			}
+
+			for _, expPort := range tc.sslPorts {
+				g.Expect(cfg).To(ContainSubstring(fmt.Sprintf(sslDefaultFmt, expPort)))
+			}
+		})
+	}
+}
+
+func TestCreateServers(t *testing.T) {
+	const (
+		sslKeyPairID = "test-keypair"
+	)
+
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			Hostnames: []v1beta1.Hostname{
+				"cafe.example.com",
 			},
-			{
-				Path:         "= /redirect-with-headers",
-				HTTPMatchVar: expectedMatchString(redirectHeaderMatches),
+			Rules: []v1beta1.HTTPRouteRule{
+				{
+					// matches with path and methods
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+							},
+							Method: helpers.GetPointer[v1beta1.HTTPMethod](v1beta1.HTTPMethodPost),
+						},
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+							},
+							Method: helpers.GetPointer[v1beta1.HTTPMethod](v1beta1.HTTPMethodPatch),
+						},
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer(
+									"/", // should generate an "any" httpmatch since other matches exists for /
+								),
+								Type: helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+							},
+						},
+					},
+				},
+				{
+					// A match with all possible fields set
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/test"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+							},
+							Method: helpers.GetPointer[v1beta1.HTTPMethod](v1beta1.HTTPMethodGet),
+							Headers: []v1beta1.HTTPHeaderMatch{
+								{
+									Type:  helpers.GetPointer[v1beta1.HeaderMatchType](v1beta1.HeaderMatchExact),
+									Name:  "Version",
+									Value: "V1",
+								},
+								{
+									Type:  helpers.GetPointer[v1beta1.HeaderMatchType](v1beta1.HeaderMatchExact),
+									Name:  "test",
+									Value: "foo",
+								},
+								{
+									Type:  helpers.GetPointer[v1beta1.HeaderMatchType](v1beta1.HeaderMatchExact),
+									Name:  "my-header",
+									Value: "my-value",
+								},
+							},
+							QueryParams: []v1beta1.HTTPQueryParamMatch{
+								{
+									Type:  helpers.GetPointer[v1beta1.QueryParamMatchType](v1beta1.QueryParamMatchExact),
+									Name:  "GrEat", // query names and values should not be normalized to lowercase
+									Value: "EXAMPLE",
+								},
+								{
+									Type:  helpers.GetPointer[v1beta1.QueryParamMatchType](v1beta1.QueryParamMatchExact),
+									Name:  "test",
+									Value: "foo=bar",
+								},
+							},
+						},
+					},
+				},
+				{
+					// A match with just path
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/path-only"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+							},
+						},
+					},
+				},
+				{
+					// A match with a redirect with implicit port
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/redirect-implicit-port"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+							},
+						},
+					},
+					// redirect is set in the corresponding state.MatchRule
+				},
+				{
+					// A match with a redirect with explicit port
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/redirect-explicit-port"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+							},
+						},
+					},
+					// redirect is set in the corresponding state.MatchRule
+				},
+				{
+					// A match with a redirect and header matches
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/redirect-with-headers"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+							},
+							Headers: []v1beta1.HTTPHeaderMatch{
+								{
+									Type:  helpers.GetPointer[v1beta1.HeaderMatchType](v1beta1.HeaderMatchExact),
+									Name:  "redirect",
+									Value: "this",
+								},
+							},
+						},
+					},
+				},
+				{
+					// A match with an invalid filter
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/invalid-filter"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+							},
+						},
+					},
+				},
+				{
+					// A match with an invalid filter and headers
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/invalid-filter-with-headers"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+							},
+							Headers: []v1beta1.HTTPHeaderMatch{
+								{
+									Type:  helpers.GetPointer[v1beta1.HeaderMatchType](v1beta1.HeaderMatchExact),
+									Name:  "filter",
+									Value: "this",
+								},
+							},
+						},
+					},
+				},
+				{
+					// A match using type Exact
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/exact"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchExact),
+							},
+						},
+					},
+				},
+				{
+					// A match using type Exact with method
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/test"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchExact),
+							},
+							Method: helpers.GetPointer[v1beta1.HTTPMethod](v1beta1.HTTPMethodGet),
+						},
+					},
+				},
+				{
+					// A match with requestHeaderModifier filter set
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/proxy-set-headers"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+							},
+						},
+					},
+					Filters: []v1beta1.HTTPRouteFilter{
+						{
+							Type: "RequestHeaderModifier",
+							RequestHeaderModifier: &v1beta1.HTTPHeaderFilter{
+								Add: []v1beta1.HTTPHeader{
+									{
+										Name:  "my-header",
+										Value: "some-value-123",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	hrNsName := types.NamespacedName{Namespace: hr.Namespace, Name: hr.Name}
+
+	fooGroup := dataplane.BackendGroup{
+		Source:  hrNsName,
+		RuleIdx: 0,
+		Backends: []dataplane.Backend{
+			{
+				UpstreamName: "test_foo_80",
+				Valid:        true,
+				Weight:       1,
+			},
+		},
+	}
+
+	// barGroup has two backends, which should generate a proxy pass with a variable.
+	barGroup := dataplane.BackendGroup{
+		Source:  hrNsName,
+		RuleIdx: 1,
+		Backends: []dataplane.Backend{
+			{
+				UpstreamName: "test_bar_80",
+				Valid:        true,
+				Weight:       50,
+			},
+			{
+				UpstreamName: "test_bar2_80",
+				Valid:        true,
+				Weight:       50,
+			},
+		},
+	}
+
+	// baz group has an invalid backend, which should generate a proxy pass to the invalid ref backend.
+	bazGroup := dataplane.BackendGroup{
+		Source:  hrNsName,
+		RuleIdx: 2,
+		Backends: []dataplane.Backend{
+			{
+				UpstreamName: "test_baz_80",
+				Valid:        false,
+				Weight:       1,
+			},
+		},
+	}
+
+	filterGroup1 := dataplane.BackendGroup{Source: hrNsName, RuleIdx: 3}
+
+	filterGroup2 := dataplane.BackendGroup{Source: hrNsName, RuleIdx: 4}
+
+	invalidFilterGroup := dataplane.BackendGroup{Source: hrNsName, RuleIdx: 5}
+
+	cafePathRules := []dataplane.PathRule{
+		{
+			Path:     "/",
+			PathType: dataplane.PathTypePrefix,
+			MatchRules: []dataplane.MatchRule{
+				{
+					MatchIdx:     0,
+					RuleIdx:      0,
+					BackendGroup: fooGroup,
+					Source:       hr,
+				},
+				{
+					MatchIdx:     1,
+					RuleIdx:      0,
+					BackendGroup: fooGroup,
+					Source:       hr,
+				},
+				{
+					MatchIdx:     2,
+					RuleIdx:      0,
+					BackendGroup: fooGroup,
+					Source:       hr,
+				},
+			},
+		},
+		{
+			Path:     "/test",
+			PathType: dataplane.PathTypePrefix,
+			MatchRules: []dataplane.MatchRule{
+				{
+					MatchIdx:     0,
+					RuleIdx:      1,
+					BackendGroup: barGroup,
+					Source:       hr,
+				},
+			},
+		},
+		{
+			Path:     "/path-only",
+			PathType: dataplane.PathTypePrefix,
+			MatchRules: []dataplane.MatchRule{
+				{
+					MatchIdx:     0,
+					RuleIdx:      2,
+					BackendGroup: bazGroup,
+					Source:       hr,
+				},
+			},
+		},
+		{
+			Path:     "/redirect-implicit-port",
+			PathType: dataplane.PathTypePrefix,
+			MatchRules: []dataplane.MatchRule{
+				{
+					MatchIdx: 0,
+					RuleIdx:  3,
+					Source:   hr,
+					Filters: dataplane.Filters{
+						RequestRedirect: &v1beta1.HTTPRequestRedirectFilter{
+							Hostname: (*v1beta1.PreciseHostname)(helpers.GetPointer("foo.example.com")),
+						},
+					},
+					BackendGroup: filterGroup1,
+				},
+			},
+		},
+		{
+			Path:     "/redirect-explicit-port",
+			PathType: dataplane.PathTypePrefix,
+			MatchRules: []dataplane.MatchRule{
+				{
+					MatchIdx: 0,
+					RuleIdx:  4,
+					Source:   hr,
+					Filters: dataplane.Filters{
+						RequestRedirect: &v1beta1.HTTPRequestRedirectFilter{
+							Hostname: (*v1beta1.PreciseHostname)(helpers.GetPointer("bar.example.com")),
+							Port:     (*v1beta1.PortNumber)(helpers.GetPointer[int32](8080)),
+						},
+					},
+					BackendGroup: filterGroup2,
+				},
+			},
+		},
+		{
+			Path:     "/redirect-with-headers",
+			PathType: dataplane.PathTypePrefix,
+			MatchRules: []dataplane.MatchRule{
+				{
+					MatchIdx: 0,
+					RuleIdx:  5,
+					Source:   hr,
+					Filters: dataplane.Filters{
+						RequestRedirect: &v1beta1.HTTPRequestRedirectFilter{
+							Hostname: helpers.GetPointer(v1beta1.PreciseHostname("foo.example.com")),
+							Port:     helpers.GetPointer(v1beta1.PortNumber(8080)),
+						},
+					},
+					BackendGroup: filterGroup1,
+				},
+			},
+		},
+		{
+			Path:     "/invalid-filter",
+			PathType: dataplane.PathTypePrefix,
+			MatchRules: []dataplane.MatchRule{
+				{
+					MatchIdx: 0,
+					RuleIdx:  6,
+					Source:   hr,
+					Filters: dataplane.Filters{
+						InvalidFilter: &dataplane.InvalidFilter{},
+					},
+					BackendGroup: invalidFilterGroup,
+				},
+			},
+		},
+		{
+			Path:     "/invalid-filter-with-headers",
+			PathType: dataplane.PathTypePrefix,
+			MatchRules: []dataplane.MatchRule{
+				{
+					MatchIdx: 0,
+					RuleIdx:  7,
+					Source:   hr,
+					Filters: dataplane.Filters{
+						InvalidFilter: &dataplane.InvalidFilter{},
+					},
+					BackendGroup: invalidFilterGroup,
+				},
+			},
+		},
+		{
+			Path:     "/exact",
+			PathType: dataplane.PathTypeExact,
+			MatchRules: []dataplane.MatchRule{
+				{
+					MatchIdx:     0,
+					RuleIdx:      8,
+					Source:       hr,
+					BackendGroup: fooGroup,
+				},
+			},
+		},
+		{
+			Path:     "/test",
+			PathType: dataplane.PathTypeExact,
+			MatchRules: []dataplane.MatchRule{
+				{
+					MatchIdx:     0,
+					RuleIdx:      9,
+					Source:       hr,
+					BackendGroup: fooGroup,
+				},
+			},
+		},
+		{
+			Path:     "/proxy-set-headers",
+			PathType: dataplane.PathTypePrefix,
+			MatchRules: []dataplane.MatchRule{
+				{
+					MatchIdx:     0,
+					RuleIdx:      10,
+					Source:       hr,
+					BackendGroup: fooGroup,
+					Filters: dataplane.Filters{
+						RequestHeaderModifiers: &dataplane.HTTPHeaderFilter{
+							Add: []dataplane.HTTPHeader{
+								{
+									Name:  "my-header",
+									Value: "some-value-123",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	httpServers := []dataplane.VirtualServer{
+		{
+			IsDefault: true,
+			Port:      8080,
+		},
+		{
+			Hostname:  "cafe.example.com",
+			PathRules: cafePathRules,
+			Port:      8080,
+		},
+	}
+
+	sslServers := []dataplane.VirtualServer{
+		{
+			IsDefault: true,
+			Port:      8443,
+		},
+		{
+			Hostname:  "cafe.example.com",
+			SSL:       &dataplane.SSL{KeyPairID: sslKeyPairID},
+			PathRules: cafePathRules,
+			Port:      8443,
+		},
+	}
+
+	expectedMatchString := func(m []httpMatch) string {
+		b, err := json.Marshal(m)
+		if err != nil {
+			t.Errorf("error marshaling test match: %v", err)
+		}
+		return string(b)
+	}
+
+	slashMatches := []httpMatch{
+		{Method: v1beta1.HTTPMethodPost, RedirectPath: "/_prefix_route0"},
+		{Method: v1beta1.HTTPMethodPatch, RedirectPath: "/_prefix_route1"},
+		{Any: true, RedirectPath: "/_prefix_route2"},
+	}
+	testMatches := []httpMatch{
+		{
+			Method:       v1beta1.HTTPMethodGet,
+			Headers:      []string{"Version:V1", "test:foo", "my-header:my-value"},
+			QueryParams:  []string{"GrEat=EXAMPLE", "test=foo=bar"},
+			RedirectPath: "/test_prefix_route0",
+		},
+	}
+	exactMatches := []httpMatch{
+		{
+			Method:       v1beta1.HTTPMethodGet,
+			RedirectPath: "/test_exact_route0",
+		},
+	}
+	redirectHeaderMatches := []httpMatch{
+		{
+			Headers:      []string{"redirect:this"},
+			RedirectPath: "/redirect-with-headers_prefix_route0",
+		},
+	}
+	invalidFilterHeaderMatches := []httpMatch{
+		{
+			Headers:      []string{"filter:this"},
+			RedirectPath: "/invalid-filter-with-headers_prefix_route0",
+		},
+	}
+
+	getExpectedLocations := func(isHTTPS bool) []http.Location {
+		port := 8080
+		if isHTTPS {
+			port = 8443
+		}
+
+		return []http.Location{
+			{
+				Path:      "/_prefix_route0",
+				Internal:  true,
+				ProxyPass: "http://test_foo_80",
+			},
+			{
+				Path:      "/_prefix_route1",
+				Internal:  true,
+				ProxyPass: "http://test_foo_80",
+			},
+			{
+				Path:      "/_prefix_route2",
+				Internal:  true,
+				ProxyPass: "http://test_foo_80",
+			},
+			{
+				Path:         "/",
+				HTTPMatchVar: expectedMatchString(slashMatches),
+			},
+			{
+				Path:      "/test_prefix_route0",
+				Internal:  true,
+				ProxyPass: "http://$test__route1_rule1",
+			},
+			{
+				Path:         "/test/",
+				HTTPMatchVar: expectedMatchString(testMatches),
+			},
+			{
+				Path:      "/path-only/",
+				ProxyPass: "http://invalid-backend-ref",
+			},
+			{
+				Path:      "= /path-only",
+				ProxyPass: "http://invalid-backend-ref",
+			},
+			{
+				Path: "/redirect-implicit-port/",
+				Return: &http.Return{
+					Code: 302,
+					Body: fmt.Sprintf("$scheme://foo.example.com:%d$request_uri", port),
+				},
+			},
+			{
+				Path: "= /redirect-implicit-port",
+				Return: &http.Return{
+					Code: 302,
+					Body: fmt.Sprintf("$scheme://foo.example.com:%d$request_uri", port),
+				},
+			},
+			{
+				Path: "/redirect-explicit-port/",
+				Return: &http.Return{
+					Code: 302,
+					Body: "$scheme://bar.example.com:8080$request_uri",
+				},
+			},
+			{
+				Path: "= /redirect-explicit-port",
+				Return: &http.Return{
+					Code: 302,
+					Body: "$scheme://bar.example.com:8080$request_uri",
+				},
+			},
+			{
+				Path: "/redirect-with-headers_prefix_route0",
+				Return: &http.Return{
+					Body: "$scheme://foo.example.com:8080$request_uri",
+					Code: 302,
+				},
+				Internal: true,
+			},
+			{
+				Path:         "/redirect-with-headers/",
+				HTTPMatchVar: expectedMatchString(redirectHeaderMatches),
+			},
+			{
+				Path:         "= /redirect-with-headers",
+				HTTPMatchVar: expectedMatchString(redirectHeaderMatches),
+			},
+			{
+				Path: "/invalid-filter/",
+				Return: &http.Return{
+					Code: http.StatusInternalServerError,
+				},
+			},
+			{
+				Path: "= /invalid-filter",
+				Return: &http.Return{
+					Code: http.StatusInternalServerError,
+				},
+			},
+			{
+				Path: "/invalid-filter-with-headers_prefix_route0",
+				Return: &http.Return{
+					Code: http.StatusInternalServerError,
+				},
+				Internal: true,
+			},
+			{
+				Path:         "/invalid-filter-with-headers/",
+				HTTPMatchVar: expectedMatchString(invalidFilterHeaderMatches),
+			},
+			{
+				Path:         "= /invalid-filter-with-headers",
+				HTTPMatchVar: expectedMatchString(invalidFilterHeaderMatches),
+			},
+			{
+				Path:      "= /exact",
+				ProxyPass: "http://test_foo_80",
+			},
+			{
+				Path:      "/test_exact_route0",
+				ProxyPass: "http://test_foo_80",
+				Internal:  true,
+			},
+			{
+				Path:         "= /test",
+				HTTPMatchVar: expectedMatchString(exactMatches),
+			},
+			{
+				Path:      "/proxy-set-headers/",
+				ProxyPass: "http://test_foo_80",
+				ProxySetHeaders: []http.Header{
+					{
+						Name:  "my-header",
+						Value: "${my_header_header_var}some-value-123",
+					},
+				},
+			},
+			{
+				Path:      "= /proxy-set-headers",
+				ProxyPass: "http://test_foo_80",
+				ProxySetHeaders: []http.Header{
+					{
+						Name:  "my-header",
+						Value: "${my_header_header_var}some-value-123",
+					},
+				},
+			},
+		}
+	}
+
+	expectedPEMPath := fmt.Sprintf("/etc/nginx/secrets/%s.pem", sslKeyPairID)
+
+	expectedServers := []http.Server{
+		{
+			IsDefaultHTTP: true,
+			Port:          8080,
+		},
+		{
+			ServerName: "cafe.example.com",
+			Locations:  getExpectedLocations(false),
+			Port:       8080,
+		},
+		{
+			IsDefaultSSL: true,
+			Port:         8443,
+		},
+		{
+			ServerName: "cafe.example.com",
+			SSL: &http.SSL{
+				Certificate:    expectedPEMPath,
+				CertificateKey: expectedPEMPath,
+			},
+			Locations: getExpectedLocations(true),
+			Port:      8443,
+		},
+	}
+
+	g := NewGomegaWithT(t)
+
+	result := createServers(httpServers, sslServers)
+	g.Expect(helpers.Diff(expectedServers, result)).To(BeEmpty())
+}
+
+func TestCreateServersConflicts(t *testing.T) {
+	type pathAndType struct {
+		path     string
+		pathType v1beta1.PathMatchType
+	}
+
+	createHR := func(pathsAndTypes []pathAndType) *v1beta1.HTTPRoute {
+		hr := &v1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "route",
+			},
+			Spec: v1beta1.HTTPRouteSpec{
+				Hostnames: []v1beta1.Hostname{
+					"cafe.example.com",
+				},
+				Rules: []v1beta1.HTTPRouteRule{},
+			},
+		}
+		for _, pt := range pathsAndTypes {
+			match := v1beta1.HTTPRouteMatch{
+				Path: &v1beta1.HTTPPathMatch{
+					Value: helpers.GetPointer(pt.path),
+					Type:  helpers.GetPointer(pt.pathType),
+				},
+			}
+			hr.Spec.Rules = append(hr.Spec.Rules, v1beta1.HTTPRouteRule{
+				Matches: []v1beta1.HTTPRouteMatch{match},
+			})
+		}
+
+		return hr
+	}
+
+	hr1 := createHR([]pathAndType{
+		{
+			path:     "/coffee",
+			pathType: v1beta1.PathMatchPathPrefix,
+		},
+		{
+			path:     "/coffee",
+			pathType: v1beta1.PathMatchExact,
+		},
+	})
+	hr2 := createHR([]pathAndType{
+		{
+			path:     "/coffee",
+			pathType: v1beta1.PathMatchPathPrefix,
+		},
+		{
+			path:     "/coffee/",
+			pathType: v1beta1.PathMatchPathPrefix,
+		},
+	})
+	hr3 := createHR([]pathAndType{
+		{
+			path:     "/coffee",
+			pathType: v1beta1.PathMatchPathPrefix,
+		},
+		{
+			path:     "/coffee/",
+			pathType: v1beta1.PathMatchPathPrefix,
+		},
+		{
+			path:     "/coffee",
+			pathType: v1beta1.PathMatchExact,
+		},
+	})
+
+	fooGroup := dataplane.BackendGroup{
+		Source:  types.NamespacedName{Namespace: "test", Name: "route"},
+		RuleIdx: 0,
+		Backends: []dataplane.Backend{
+			{
+				UpstreamName: "test_foo_80",
+				Valid:        true,
+				Weight:       1,
+			},
+		},
+	}
+	barGroup := dataplane.BackendGroup{
+		Source:  types.NamespacedName{Namespace: "test", Name: "route"},
+		RuleIdx: 0,
+		Backends: []dataplane.Backend{
+			{
+				UpstreamName: "test_bar_80",
+				Valid:        true,
+				Weight:       1,
+			},
+		},
+	}
+	bazGroup := dataplane.BackendGroup{
+		Source:  types.NamespacedName{Namespace: "test", Name: "route"},
+		RuleIdx: 0,
+		Backends: []dataplane.Backend{
+			{
+				UpstreamName: "test_baz_80",
+				Valid:        true,
+				Weight:       1,
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		rules   []dataplane.PathRule
+		expLocs []http.Location
+	}{
+		{
+			name: "/coffee prefix, /coffee exact",
+			rules: []dataplane.PathRule{
+				{
+					Path:     "/coffee",
+					PathType: dataplane.PathTypePrefix,
+					MatchRules: []dataplane.MatchRule{
+						{
+							MatchIdx:     0,
+							RuleIdx:      0,
+							Source:       hr1,
+							BackendGroup: fooGroup,
+						},
+					},
+				},
+				{
+					Path:     "/coffee",
+					PathType: dataplane.PathTypeExact,
+					MatchRules: []dataplane.MatchRule{
+						{
+							MatchIdx:     0,
+							RuleIdx:      0,
+							Source:       hr1,
+							BackendGroup: barGroup,
+						},
+					},
+				},
+			},
+			expLocs: []http.Location{
+				{
+					Path:      "/coffee/",
+					ProxyPass: "http://test_foo_80",
+				},
+				{
+					Path:      "= /coffee",
+					ProxyPass: "http://test_bar_80",
+				},
+				createDefaultRootLocation(),
+			},
+		},
+		{
+			name: "/coffee prefix, /coffee/ prefix",
+			rules: []dataplane.PathRule{
+				{
+					Path:     "/coffee",
+					PathType: dataplane.PathTypePrefix,
+					MatchRules: []dataplane.MatchRule{
+						{
+							MatchIdx:     0,
+							RuleIdx:      0,
+							Source:       hr2,
+							BackendGroup: fooGroup,
+						},
+					},
+				},
+				{
+					Path:     "/coffee/",
+					PathType: dataplane.PathTypePrefix,
+					MatchRules: []dataplane.MatchRule{
+						{
+							MatchIdx:     0,
+							RuleIdx:      1,
+							Source:       hr2,
+							BackendGroup: barGroup,
+						},
+					},
+				},
+			},
+			expLocs: []http.Location{
+				{
+					Path:      "= /coffee",
+					ProxyPass: "http://test_foo_80",
+				},
+				{
+					Path:      "/coffee/",
+					ProxyPass: "http://test_bar_80",
+				},
+				createDefaultRootLocation(),
+			},
+		},
+		{
+			name: "/coffee prefix, /coffee/ prefix, /coffee exact",
+			rules: []dataplane.PathRule{
+				{
+					Path:     "/coffee",
+					PathType: dataplane.PathTypePrefix,
+					MatchRules: []dataplane.MatchRule{
+						{
+							MatchIdx:     0,
+							RuleIdx:      0,
+							Source:       hr3,
+							BackendGroup: fooGroup,
+						},
+					},
+				},
+				{
+					Path:     "/coffee/",
+					PathType: dataplane.PathTypePrefix,
+					MatchRules: []dataplane.MatchRule{
+						{
+							MatchIdx:     0,
+							RuleIdx:      1,
+							Source:       hr3,
+							BackendGroup: barGroup,
+						},
+					},
+				},
+				{
+					Path:     "/coffee",
+					PathType: dataplane.PathTypeExact,
+					MatchRules: []dataplane.MatchRule{
+						{
+							MatchIdx: 0,
+							RuleIdx:  2,
+							Source: createHR([]pathAndType{
+								{
+									path:     "/coffee",
+									pathType: v1beta1.PathMatchPathPrefix,
+								},
+								{
+									path:     "/coffee/",
+									pathType: v1beta1.PathMatchPathPrefix,
+								},
+								{
+									path:     "/coffee",
+									pathType: v1beta1.PathMatchExact,
+								},
+							}),
+							BackendGroup: bazGroup,
+						},
+					},
+				},
+			},
+			expLocs: []http.Location{
+				{
+					Path:      "/coffee/",
+					ProxyPass: "http://test_bar_80",
+				},
+				{
+					Path:      "= /coffee",
+					ProxyPass: "http://test_baz_80",
+				},
+				createDefaultRootLocation(),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			httpServers := []dataplane.VirtualServer{
+				{
+					IsDefault: true,
+					Port:      8080,
+				},
+				{
+					Hostname:  "cafe.example.com",
+					PathRules: test.rules,
+					Port:      8080,
+				},
+			}
+			expectedServers := []http.Server{
+				{
+					IsDefaultHTTP: true,
+					Port:          8080,
+				},
+				{
+					ServerName: "cafe.example.com",
+					Locations:  test.expLocs,
+					Port:       8080,
+				},
+			}
+
+			g := NewGomegaWithT(t)
+
+			result := createServers(httpServers, []dataplane.VirtualServer{})
+			g.Expect(helpers.Diff(expectedServers, result)).To(BeEmpty())
+		})
+	}
+}
+
+func TestCreateLocationsRootPath(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	createRoute := func(rootPath bool) *v1beta1.HTTPRoute {
+		route := &v1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      "route1",
 			},
-			{
-				Path: "/invalid-filter/",
-				Return: &http.Return{
-					Code: http.StatusInternalServerError,
+			Spec: v1beta1.HTTPRouteSpec{
+				Hostnames: []v1beta1.Hostname{
+					"cafe.example.com",
 				},
-			},
-			{
-				Path: "= /invalid-filter",
-				Return: &http.Return{
-					Code: http.StatusInternalServerError,
+				Rules: []v1beta1.HTTPRouteRule{
+					{
+						Matches: []v1beta1.HTTPRouteMatch{
+							{
+								Path: &v1beta1.HTTPPathMatch{
+									Value: helpers.GetPointer("/path-1"),
+									Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+								},
+							},
+							{
+								Path: &v1beta1.HTTPPathMatch{
+									Value: helpers.GetPointer("/path-2"),
+									Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+								},
+							},
+						},
+					},
 				},
 			},
-			{
-				Path: "/invalid-filter-with-headers_prefix_route0",
-				Return: &http.Return{
-					Code: http.StatusInternalServerError,
+		}
+
+		if rootPath {
+			route.Spec.Rules[0].Matches = append(route.Spec.Rules[0].Matches, v1beta1.HTTPRouteMatch{
+				Path: &v1beta1.HTTPPathMatch{
+					Value: helpers.GetPointer("/"),
+					Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
 				},
-				Internal: true,
-			},
-			{
-				Path:         "/invalid-filter-with-headers/",
-				HTTPMatchVar: expectedMatchString(invalidFilterHeaderMatches),
-			},
-			{
-				Path:         "= /invalid-filter-with-headers",
-				HTTPMatchVar: expectedMatchString(invalidFilterHeaderMatches),
-			},
-			{
-				Path:      "= /exact",
-				ProxyPass: "http://test_foo_80",
-			},
-			{
-				Path:      "/test_exact_route0",
-				ProxyPass: "http://test_foo_80",
-				Internal:  true,
-			},
+			})
+		}
+
+		return route
+	}
+
+	hrWithRootPathRule := createRoute(true)
+
+	hrWithoutRootPathRule := createRoute(false)
+
+	hrNsName := types.NamespacedName{Namespace: "test", Name: "route1"}
+
+	fooGroup := dataplane.BackendGroup{
+		Source:  hrNsName,
+		RuleIdx: 0,
+		Backends: []dataplane.Backend{
 			{
-				Path:         "= /test",
-				HTTPMatchVar: expectedMatchString(exactMatches),
+				UpstreamName: "test_foo_80",
+				Valid:        true,
+				Weight:       1,
 			},
+		},
+	}
+
+	getPathRules := func(source *v1beta1.HTTPRoute, rootPath bool) []dataplane.PathRule {
+		rules := []dataplane.PathRule{
 			{
-				Path:      "/proxy-set-headers/",
-				ProxyPass: "http://test_foo_80",
-				ProxySetHeaders: []http.Header{
+				Path: "/path-1",
+				MatchRules: []dataplane.MatchRule{
 					{
-						Name:  "my-header",
-						Value: "${my_header_header_var}some-value-123",
+						Source:       source,
+						BackendGroup: fooGroup,
+						MatchIdx:     0,
+						RuleIdx:      0,
 					},
 				},
 			},
 			{
-				Path:      "= /proxy-set-headers",
-				ProxyPass: "http://test_foo_80",
-				ProxySetHeaders: []http.Header{
+				Path: "/path-2",
+				MatchRules: []dataplane.MatchRule{
 					{
-						Name:  "my-header",
-						Value: "${my_header_header_var}some-value-123",
+						Source:       source,
+						BackendGroup: fooGroup,
+						MatchIdx:     1,
+						RuleIdx:      0,
 					},
 				},
 			},
 		}
-	}
 
-	expectedPEMPath := fmt.Sprintf("/etc/nginx/secrets/%s.pem", sslKeyPairID)
+		if rootPath {
+			rules = append(rules, dataplane.PathRule{
+				Path: "/",
+				MatchRules: []dataplane.MatchRule{
+					{
+						Source:       source,
+						BackendGroup: fooGroup,
+						MatchIdx:     2,
+						RuleIdx:      0,
+					},
+				},
+			})
+		}
 
-	expectedServers := []http.Server{
-		{
-			IsDefaultHTTP: true,
-			Port:          8080,
-		},
+		return rules
+	}
+
+	tests := []struct {
+		name         string
+		pathRules    []dataplane.PathRule
+		expLocations []http.Location
+	}{
 		{
-			ServerName: "cafe.example.com",
-			Locations:  getExpectedLocations(false),
-			Port:       8080,
+			name:      "path rules with no root path should generate a default 404 root location",
+			pathRules: getPathRules(hrWithoutRootPathRule, false),
+			expLocations: []http.Location{
+				{
+					Path:      "/path-1",
+					ProxyPass: "http://test_foo_80",
+				},
+				{
+					Path:      "/path-2",
+					ProxyPass: "http://test_foo_80",
+				},
+				{
+					Path: "/",
+					Return: &http.Return{
+						Code: http.StatusNotFound,
+					},
+				},
+			},
 		},
 		{
-			IsDefaultSSL: true,
-			Port:         8443,
+			name:      "path rules with a root path should not generate a default 404 root path",
+			pathRules: getPathRules(hrWithRootPathRule, true),
+			expLocations: []http.Location{
+				{
+					Path:      "/path-1",
+					ProxyPass: "http://test_foo_80",
+				},
+				{
+					Path:      "/path-2",
+					ProxyPass: "http://test_foo_80",
+				},
+				{
+					Path:      "/",
+					ProxyPass: "http://test_foo_80",
+				},
+			},
 		},
 		{
-			ServerName: "cafe.example.com",
-			SSL: &http.SSL{
-				Certificate:    expectedPEMPath,
-				CertificateKey: expectedPEMPath,
+			name:      "nil path rules should generate a default 404 root path",
+			pathRules: nil,
+			expLocations: []http.Location{
+				{
+					Path: "/",
+					Return: &http.Return{
+						Code: http.StatusNotFound,
+					},
+				},
 			},
-			Locations: getExpectedLocations(true),
-			Port:      8443,
 		},
 	}
 
-	g := NewGomegaWithT(t)
-
-	result := createServers(httpServers, sslServers)
-	g.Expect(helpers.Diff(expectedServers, result)).To(BeEmpty())
+	for _, test := range tests {
+		locs := createLocations(test.pathRules, 80)
+		g.Expect(locs).To(Equal(test.expLocations), fmt.Sprintf("test case: %s", test.name))
+	}
 }
 
-func TestCreateServersConflicts(t *testing.T) {
-	type pathAndType struct {
-		path     string
-		pathType v1beta1.PathMatchType
-	}
+func TestCreateLocationsRegexPath(t *testing.T) {
+	g := NewGomegaWithT(t)
 
-	createHR := func(pathsAndTypes []pathAndType) *v1beta1.HTTPRoute {
-		hr := &v1beta1.HTTPRoute{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: "test",
-				Name:      "route",
-			},
-			Spec: v1beta1.HTTPRouteSpec{
-				Hostnames: []v1beta1.Hostname{
-					"cafe.example.com",
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
+				{
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/path/1"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchExact),
+							},
+						},
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("^/path/[0-9]+$"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchRegularExpression),
+							},
+						},
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer(`\.(jpg|png)$`),
+								Type:  helpers.GetPointer(v1beta1.PathMatchRegularExpression),
+							},
+							Method: helpers.GetPointer(v1beta1.HTTPMethodGet),
+						},
+					},
 				},
-				Rules: []v1beta1.HTTPRouteRule{},
 			},
-		}
-		for _, pt := range pathsAndTypes {
-			match := v1beta1.HTTPRouteMatch{
-				Path: &v1beta1.HTTPPathMatch{
-					Value: helpers.GetPointer(pt.path),
-					Type:  helpers.GetPointer(pt.pathType),
-				},
-			}
-			hr.Spec.Rules = append(hr.Spec.Rules, v1beta1.HTTPRouteRule{
-				Matches: []v1beta1.HTTPRouteMatch{match},
-			})
-		}
+		},
+	}
 
-		return hr
+	fooGroup := dataplane.BackendGroup{
+		Source:  types.NamespacedName{Namespace: "test", Name: "route1"},
+		RuleIdx: 0,
+		Backends: []dataplane.Backend{
+			{
+				UpstreamName: "test_foo_80",
+				Valid:        true,
+				Weight:       1,
+			},
+		},
 	}
 
-	hr1 := createHR([]pathAndType{
+	pathRules := []dataplane.PathRule{
 		{
-			path:     "/coffee",
-			pathType: v1beta1.PathMatchPathPrefix,
+			Path:     "/path/1",
+			PathType: dataplane.PathTypeExact,
+			MatchRules: []dataplane.MatchRule{
+				{
+					Source:       hr,
+					BackendGroup: fooGroup,
+					MatchIdx:     0,
+					RuleIdx:      0,
+				},
+			},
 		},
 		{
-			path:     "/coffee",
-			pathType: v1beta1.PathMatchExact,
+			Path:     "^/path/[0-9]+$",
+			PathType: dataplane.PathTypeRegularExpression,
+			MatchRules: []dataplane.MatchRule{
+				{
+					Source:       hr,
+					BackendGroup: fooGroup,
+					MatchIdx:     1,
+					RuleIdx:      0,
+				},
+			},
 		},
-	})
-	hr2 := createHR([]pathAndType{
 		{
-			path:     "/coffee",
-			pathType: v1beta1.PathMatchPathPrefix,
+			Path:     `\.(jpg|png)$`,
+			PathType: dataplane.PathTypeRegularExpression,
+			MatchRules: []dataplane.MatchRule{
+				{
+					Source:       hr,
+					BackendGroup: fooGroup,
+					MatchIdx:     2,
+					RuleIdx:      0,
+				},
+			},
 		},
+	}
+
+	matches := []httpMatch{
+		{Method: v1beta1.HTTPMethodGet, RedirectPath: "/_regex2_route0"},
+	}
+	b, err := json.Marshal(matches)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// The exact location wins over the regex locations, because NGINX doesn't check regex locations
+	// if the exact location matches.
+	expLocations := []http.Location{
 		{
-			path:     "/coffee/",
-			pathType: v1beta1.PathMatchPathPrefix,
+			Path:      "= /path/1",
+			ProxyPass: "http://test_foo_80",
 		},
-	})
-	hr3 := createHR([]pathAndType{
 		{
-			path:     "/coffee",
-			pathType: v1beta1.PathMatchPathPrefix,
+			Path:      `~ "^/path/[0-9]+$"`,
+			ProxyPass: "http://test_foo_80",
 		},
 		{
-			path:     "/coffee/",
-			pathType: v1beta1.PathMatchPathPrefix,
+			Path:      "/_regex2_route0",
+			Internal:  true,
+			ProxyPass: "http://test_foo_80",
 		},
 		{
-			path:     "/coffee",
-			pathType: v1beta1.PathMatchExact,
+			Path:         `~ "\.(jpg|png)$"`,
+			HTTPMatchVar: string(b),
 		},
-	})
-
-	fooGroup := dataplane.BackendGroup{
-		Source:  types.NamespacedName{Namespace: "test", Name: "route"},
-		RuleIdx: 0,
-		Backends: []dataplane.Backend{
-			{
-				UpstreamName: "test_foo_80",
-				Valid:        true,
-				Weight:       1,
+		{
+			Path: "/",
+			Return: &http.Return{
+				Code: http.StatusNotFound,
 			},
 		},
 	}
-	barGroup := dataplane.BackendGroup{
-		Source:  types.NamespacedName{Namespace: "test", Name: "route"},
-		RuleIdx: 0,
-		Backends: []dataplane.Backend{
-			{
-				UpstreamName: "test_bar_80",
-				Valid:        true,
-				Weight:       1,
+
+	locs := createLocations(pathRules, 80)
+	g.Expect(locs).To(Equal(expLocations))
+}
+
+func TestCreateLocationsRegexAndPrefixPath(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
+				{
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/coffee/latte"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+							},
+						},
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/coffee/.*"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchRegularExpression),
+							},
+						},
+					},
+				},
 			},
 		},
 	}
-	bazGroup := dataplane.BackendGroup{
-		Source:  types.NamespacedName{Namespace: "test", Name: "route"},
+
+	fooGroup := dataplane.BackendGroup{
+		Source:  types.NamespacedName{Namespace: "test", Name: "route1"},
 		RuleIdx: 0,
 		Backends: []dataplane.Backend{
 			{
-				UpstreamName: "test_baz_80",
+				UpstreamName: "test_foo_80",
 				Valid:        true,
 				Weight:       1,
 			},
 		},
 	}
 
-	tests := []struct {
-		name    string
-		rules   []dataplane.PathRule
-		expLocs []http.Location
-	}{
+	pathRules := []dataplane.PathRule{
 		{
-			name: "/coffee prefix, /coffee exact",
-			rules: []dataplane.PathRule{
-				{
-					Path:     "/coffee",
-					PathType: dataplane.PathTypePrefix,
-					MatchRules: []dataplane.MatchRule{
-						{
-							MatchIdx:     0,
-							RuleIdx:      0,
-							Source:       hr1,
-							BackendGroup: fooGroup,
-						},
-					},
-				},
-				{
-					Path:     "/coffee",
-					PathType: dataplane.PathTypeExact,
-					MatchRules: []dataplane.MatchRule{
-						{
-							MatchIdx:     0,
-							RuleIdx:      0,
-							Source:       hr1,
-							BackendGroup: barGroup,
-						},
-					},
-				},
-			},
-			expLocs: []http.Location{
-				{
-					Path:      "/coffee/",
-					ProxyPass: "http://test_foo_80",
-				},
+			Path:     "/coffee/latte",
+			PathType: dataplane.PathTypePrefix,
+			MatchRules: []dataplane.MatchRule{
 				{
-					Path:      "= /coffee",
-					ProxyPass: "http://test_bar_80",
+					Source:       hr,
+					BackendGroup: fooGroup,
+					MatchIdx:     0,
+					RuleIdx:      0,
 				},
-				createDefaultRootLocation(),
 			},
 		},
 		{
-			name: "/coffee prefix, /coffee/ prefix",
-			rules: []dataplane.PathRule{
-				{
-					Path:     "/coffee",
-					PathType: dataplane.PathTypePrefix,
-					MatchRules: []dataplane.MatchRule{
-						{
-							MatchIdx:     0,
-							RuleIdx:      0,
-							Source:       hr2,
-							BackendGroup: fooGroup,
-						},
-					},
-				},
-				{
-					Path:     "/coffee/",
-					PathType: dataplane.PathTypePrefix,
-					MatchRules: []dataplane.MatchRule{
-						{
-							MatchIdx:     0,
-							RuleIdx:      1,
-							Source:       hr2,
-							BackendGroup: barGroup,
-						},
-					},
-				},
-			},
-			expLocs: []http.Location{
-				{
-					Path:      "= /coffee",
-					ProxyPass: "http://test_foo_80",
-				},
+			Path:     "/coffee/.*",
+			PathType: dataplane.PathTypeRegularExpression,
+			MatchRules: []dataplane.MatchRule{
 				{
-					Path:      "/coffee/",
-					ProxyPass: "http://test_bar_80",
+					Source:       hr,
+					BackendGroup: fooGroup,
+					MatchIdx:     1,
+					RuleIdx:      0,
 				},
-				createDefaultRootLocation(),
 			},
 		},
+	}
+
+	// The prefix location is not generated with the ^~ modifier, so NGINX checks the regex location
+	// before using the longest prefix location, and the regex wins for /coffee/latte/mocha.
+	// Only the request to exactly /coffee/latte goes to the exact location of the prefix.
+	expLocations := []http.Location{
 		{
-			name: "/coffee prefix, /coffee/ prefix, /coffee exact",
-			rules: []dataplane.PathRule{
-				{
-					Path:     "/coffee",
-					PathType: dataplane.PathTypePrefix,
-					MatchRules: []dataplane.MatchRule{
-						{
-							MatchIdx:     0,
-							RuleIdx:      0,
-							Source:       hr3,
-							BackendGroup: fooGroup,
-						},
-					},
-				},
-				{
-					Path:     "/coffee/",
-					PathType: dataplane.PathTypePrefix,
-					MatchRules: []dataplane.MatchRule{
-						{
-							MatchIdx:     0,
-							RuleIdx:      1,
-							Source:       hr3,
-							BackendGroup: barGroup,
-						},
-					},
-				},
-				{
-					Path:     "/coffee",
-					PathType: dataplane.PathTypeExact,
-					MatchRules: []dataplane.MatchRule{
-						{
-							MatchIdx: 0,
-							RuleIdx:  2,
-							Source: createHR([]pathAndType{
-								{
-									path:     "/coffee",
-									pathType: v1beta1.PathMatchPathPrefix,
-								},
-								{
-									path:     "/coffee/",
-									pathType: v1beta1.PathMatchPathPrefix,
-								},
-								{
-									path:     "/coffee",
-									pathType: v1beta1.PathMatchExact,
-								},
-							}),
-							BackendGroup: bazGroup,
-						},
-					},
-				},
+			Path:      "/coffee/latte/",
+			ProxyPass: "http://test_foo_80",
+		},
+		{
+			Path:      "= /coffee/latte",
+			ProxyPass: "http://test_foo_80",
+		},
+		{
+			Path:      `~ "/coffee/.*"`,
+			ProxyPass: "http://test_foo_80",
+		},
+		{
+			Path: "/",
+			Return: &http.Return{
+				Code: http.StatusNotFound,
+			},
+		},
+	}
+
+	locs := createLocations(pathRules, 80)
+	g.Expect(locs).To(Equal(expLocations))
+}
+
+func TestCreateLocationsCatchAll(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	catchAllHR := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "catch-all"},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
+				{}, // no matches
 			},
-			expLocs: []http.Location{
-				{
-					Path:      "/coffee/",
-					ProxyPass: "http://test_bar_80",
-				},
+		},
+	}
+
+	explicitHR := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "explicit"},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
 				{
-					Path:      "= /coffee",
-					ProxyPass: "http://test_baz_80",
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/coffee/"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+							},
+						},
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Value: helpers.GetPointer("/"),
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+							},
+							Method: helpers.GetPointer(v1beta1.HTTPMethodPost),
+						},
+					},
 				},
-				createDefaultRootLocation(),
 			},
 		},
 	}
 
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-			httpServers := []dataplane.VirtualServer{
+	createGroup := func(upstream string) dataplane.BackendGroup {
+		return dataplane.BackendGroup{
+			Backends: []dataplane.Backend{
 				{
-					IsDefault: true,
-					Port:      8080,
+					UpstreamName: upstream,
+					Valid:        true,
+					Weight:       1,
 				},
+			},
+		}
+	}
+
+	// The path rules are sorted as in the dataplane: the catch-all rule is the last match rule of the root path.
+	pathRules := []dataplane.PathRule{
+		{
+			Path:     "/",
+			PathType: dataplane.PathTypePrefix,
+			MatchRules: []dataplane.MatchRule{
 				{
-					Hostname:  "cafe.example.com",
-					PathRules: test.rules,
-					Port:      8080,
+					Source:       explicitHR,
+					BackendGroup: createGroup("test_explicit_80"),
+					MatchIdx:     1,
 				},
-			}
-			expectedServers := []http.Server{
 				{
-					IsDefaultHTTP: true,
-					Port:          8080,
+					Source:       catchAllHR,
+					BackendGroup: createGroup("test_catch_all_80"),
 				},
+			},
+		},
+		{
+			Path:     "/coffee/",
+			PathType: dataplane.PathTypePrefix,
+			MatchRules: []dataplane.MatchRule{
 				{
-					ServerName: "cafe.example.com",
-					Locations:  test.expLocs,
-					Port:       8080,
+					Source:       explicitHR,
+					BackendGroup: createGroup("test_explicit_80"),
 				},
-			}
+			},
+		},
+	}
 
-			g := NewGomegaWithT(t)
+	b, err := json.Marshal([]httpMatch{
+		{Method: v1beta1.HTTPMethodPost, RedirectPath: "/_prefix_route0"},
+		{Any: true, RedirectPath: "/_prefix_route1"},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
 
-			result := createServers(httpServers, []dataplane.VirtualServer{})
-			g.Expect(helpers.Diff(expectedServers, result)).To(BeEmpty())
-		})
+	// There is a single root location, so no default root location is generated.
+	expLocations := []http.Location{
+		{
+			Path:      "/_prefix_route0",
+			Internal:  true,
+			ProxyPass: "http://test_explicit_80",
+		},
+		{
+			Path:      "/_prefix_route1",
+			Internal:  true,
+			ProxyPass: "http://test_catch_all_80",
+		},
+		{
+			Path:         "/",
+			HTTPMatchVar: string(b),
+		},
+		{
+			Path:      "/coffee/",
+			ProxyPass: "http://test_explicit_80",
+		},
 	}
+
+	locs := createLocations(pathRules, 80)
+	g.Expect(locs).To(Equal(expLocations))
 }
 
-func TestCreateLocationsRootPath(t *testing.T) {
+func TestCreateLocationsMethodMatch(t *testing.T) {
 	g := NewGomegaWithT(t)
 
-	createRoute := func(rootPath bool) *v1beta1.HTTPRoute {
-		route := &v1beta1.HTTPRoute{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: "test",
-				Name:      "route1",
-			},
-			Spec: v1beta1.HTTPRouteSpec{
-				Hostnames: []v1beta1.Hostname{
-					"cafe.example.com",
-				},
-				Rules: []v1beta1.HTTPRouteRule{
-					{
-						Matches: []v1beta1.HTTPRouteMatch{
-							{
-								Path: &v1beta1.HTTPPathMatch{
-									Value: helpers.GetStringPointer("/path-1"),
-									Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
-								},
-							},
-							{
-								Path: &v1beta1.HTTPPathMatch{
-									Value: helpers.GetStringPointer("/path-2"),
-									Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
-								},
-							},
-						},
-					},
-				},
+	createMatch := func(path string, method *v1beta1.HTTPMethod) v1beta1.HTTPRouteMatch {
+		return v1beta1.HTTPRouteMatch{
+			Path: &v1beta1.HTTPPathMatch{
+				Value: helpers.GetPointer(path),
+				Type:  helpers.GetPointer(v1beta1.PathMatchExact),
 			},
+			Method: method,
 		}
+	}
 
-		if rootPath {
-			route.Spec.Rules[0].Matches = append(route.Spec.Rules[0].Matches, v1beta1.HTTPRouteMatch{
-				Path: &v1beta1.HTTPPathMatch{
-					Value: helpers.GetStringPointer("/"),
-					Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "route1",
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
+				{
+					Matches: []v1beta1.HTTPRouteMatch{
+						createMatch("/get", helpers.GetPointer(v1beta1.HTTPMethodGet)),
+						createMatch("/post", helpers.GetPointer(v1beta1.HTTPMethodPost)),
+						createMatch("/any", nil),
+					},
 				},
-			})
-		}
-
-		return route
+			},
+		},
 	}
 
-	hrWithRootPathRule := createRoute(true)
-
-	hrWithoutRootPathRule := createRoute(false)
-
-	hrNsName := types.NamespacedName{Namespace: "test", Name: "route1"}
-
 	fooGroup := dataplane.BackendGroup{
-		Source:  hrNsName,
+		Source:  types.NamespacedName{Namespace: "test", Name: "route1"},
 		RuleIdx: 0,
 		Backends: []dataplane.Backend{
 			{
@@ -1210,109 +2742,82 @@ func TestCreateLocationsRootPath(t *testing.T) {
 		},
 	}
 
-	getPathRules := func(source *v1beta1.HTTPRoute, rootPath bool) []dataplane.PathRule {
-		rules := []dataplane.PathRule{
-			{
-				Path: "/path-1",
-				MatchRules: []dataplane.MatchRule{
-					{
-						Source:       source,
-						BackendGroup: fooGroup,
-						MatchIdx:     0,
-						RuleIdx:      0,
-					},
-				},
-			},
-			{
-				Path: "/path-2",
-				MatchRules: []dataplane.MatchRule{
-					{
-						Source:       source,
-						BackendGroup: fooGroup,
-						MatchIdx:     1,
-						RuleIdx:      0,
-					},
+	createPathRule := func(path string, matchIdx int) dataplane.PathRule {
+		return dataplane.PathRule{
+			Path:     path,
+			PathType: dataplane.PathTypeExact,
+			MatchRules: []dataplane.MatchRule{
+				{
+					Source:       hr,
+					BackendGroup: fooGroup,
+					MatchIdx:     matchIdx,
+					RuleIdx:      0,
 				},
 			},
 		}
+	}
 
-		if rootPath {
-			rules = append(rules, dataplane.PathRule{
-				Path: "/",
-				MatchRules: []dataplane.MatchRule{
-					{
-						Source:       source,
-						BackendGroup: fooGroup,
-						MatchIdx:     2,
-						RuleIdx:      0,
-					},
-				},
-			})
-		}
-
-		return rules
+	expectedMatchString := func(m []httpMatch) string {
+		b, err := json.Marshal(m)
+		g.Expect(err).ToNot(HaveOccurred())
+		return string(b)
 	}
 
 	tests := []struct {
 		name         string
-		pathRules    []dataplane.PathRule
+		pathRule     dataplane.PathRule
 		expLocations []http.Location
 	}{
 		{
-			name:      "path rules with no root path should generate a default 404 root location",
-			pathRules: getPathRules(hrWithoutRootPathRule, false),
+			name:     "GET only",
+			pathRule: createPathRule("/get", 0),
 			expLocations: []http.Location{
 				{
-					Path:      "/path-1",
-					ProxyPass: "http://test_foo_80",
-				},
-				{
-					Path:      "/path-2",
+					Path:      "/get_exact_route0",
+					Internal:  true,
 					ProxyPass: "http://test_foo_80",
 				},
 				{
-					Path: "/",
-					Return: &http.Return{
-						Code: http.StatusNotFound,
-					},
+					Path: "= /get",
+					HTTPMatchVar: expectedMatchString([]httpMatch{
+						{Method: v1beta1.HTTPMethodGet, RedirectPath: "/get_exact_route0"},
+					}),
 				},
 			},
 		},
 		{
-			name:      "path rules with a root path should not generate a default 404 root path",
-			pathRules: getPathRules(hrWithRootPathRule, true),
+			name:     "POST only",
+			pathRule: createPathRule("/post", 1),
 			expLocations: []http.Location{
 				{
-					Path:      "/path-1",
-					ProxyPass: "http://test_foo_80",
-				},
-				{
-					Path:      "/path-2",
+					Path:      "/post_exact_route0",
+					Internal:  true,
 					ProxyPass: "http://test_foo_80",
 				},
 				{
-					Path:      "/",
-					ProxyPass: "http://test_foo_80",
+					Path: "= /post",
+					HTTPMatchVar: expectedMatchString([]httpMatch{
+						{Method: v1beta1.HTTPMethodPost, RedirectPath: "/post_exact_route0"},
+					}),
 				},
 			},
 		},
 		{
-			name:      "nil path rules should generate a default 404 root path",
-			pathRules: nil,
+			name:     "no method restriction",
+			pathRule: createPathRule("/any", 2),
 			expLocations: []http.Location{
 				{
-					Path: "/",
-					Return: &http.Return{
-						Code: http.StatusNotFound,
-					},
+					Path:      "= /any",
+					ProxyPass: "http://test_foo_80",
 				},
 			},
 		},
 	}
 
 	for _, test := range tests {
-		locs := createLocations(test.pathRules, 80)
-		g.Expect(locs).To(Equal(test.expLocations), fmt.Sprintf("test case: %s", test.name))
+		// The last location is the default root location.
+		locs := createLocations([]dataplane.PathRule{test.pathRule}, 80)
+		g.Expect(locs[:len(locs)-1]).To(Equal(test.expLocations), fmt.Sprintf("test case: %s", test.name))
 	}
 }
 
@@ -1346,7 +2851,7 @@ func TestCreateReturnValForRedirectFilter(t *testing.T) {
 			filter: &v1beta1.HTTPRequestRedirectFilter{
 				Scheme:     helpers.GetPointer("https"),
 				Hostname:   helpers.GetPointer(v1beta1.PreciseHostname("foo.example.com")),
-				Port:       (*v1beta1.PortNumber)(helpers.GetInt32Pointer(2022)),
+				Port:       (*v1beta1.PortNumber)(helpers.GetPointer[int32](2022)),
 				StatusCode: helpers.GetPointer(301),
 			},
 			listenerPort: listenerPortCustom,
@@ -1411,7 +2916,7 @@ func TestCreateReturnValForRedirectFilter(t *testing.T) {
 			filter: &v1beta1.HTTPRequestRedirectFilter{
 				Scheme:     helpers.GetPointer("http"),
 				Hostname:   helpers.GetPointer(v1beta1.PreciseHostname("foo.example.com")),
-				Port:       (*v1beta1.PortNumber)(helpers.GetInt32Pointer(80)),
+				Port:       (*v1beta1.PortNumber)(helpers.GetPointer[int32](80)),
 				StatusCode: helpers.GetPointer(301),
 			},
 			listenerPort: listenerPortCustom,
@@ -1425,7 +2930,7 @@ func TestCreateReturnValForRedirectFilter(t *testing.T) {
 			filter: &v1beta1.HTTPRequestRedirectFilter{
 				Scheme:     helpers.GetPointer("https"),
 				Hostname:   helpers.GetPointer(v1beta1.PreciseHostname("foo.example.com")),
-				Port:       (*v1beta1.PortNumber)(helpers.GetInt32Pointer(443)),
+				Port:       (*v1beta1.PortNumber)(helpers.GetPointer[int32](443)),
 				StatusCode: helpers.GetPointer(301),
 			},
 			listenerPort: listenerPortCustom,
@@ -1448,27 +2953,27 @@ func TestCreateReturnValForRedirectFilter(t *testing.T) {
 func TestCreateHTTPMatch(t *testing.T) {
 	testPath := "/internal_loc"
 
-	testPathMatch := v1beta1.HTTPPathMatch{Value: helpers.GetStringPointer("/")}
-	testMethodMatch := helpers.GetHTTPMethodPointer(v1beta1.HTTPMethodPut)
+	testPathMatch := v1beta1.HTTPPathMatch{Value: helpers.GetPointer("/")}
+	testMethodMatch := helpers.GetPointer[v1beta1.HTTPMethod](v1beta1.HTTPMethodPut)
 	testHeaderMatches := []v1beta1.HTTPHeaderMatch{
 		{
-			Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
+			Type:  helpers.GetPointer[v1beta1.HeaderMatchType](v1beta1.HeaderMatchExact),
 			Name:  "header-1",
 			Value: "val-1",
 		},
 		{
-			Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
+			Type:  helpers.GetPointer[v1beta1.HeaderMatchType](v1beta1.HeaderMatchExact),
 			Name:  "header-2",
 			Value: "val-2",
 		},
 		{
 			// regex type is not supported. This should not be added to the httpMatch headers.
-			Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchRegularExpression),
+			Type:  helpers.GetPointer[v1beta1.HeaderMatchType](v1beta1.HeaderMatchRegularExpression),
 			Name:  "ignore-this-header",
 			Value: "val",
 		},
 		{
-			Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
+			Type:  helpers.GetPointer[v1beta1.HeaderMatchType](v1beta1.HeaderMatchExact),
 			Name:  "header-3",
 			Value: "val-3",
 		},
@@ -1476,7 +2981,7 @@ func TestCreateHTTPMatch(t *testing.T) {
 
 	testDuplicateHeaders := make([]v1beta1.HTTPHeaderMatch, 0, 5)
 	duplicateHeaderMatch := v1beta1.HTTPHeaderMatch{
-		Type:  helpers.GetHeaderMatchTypePointer(v1beta1.HeaderMatchExact),
+		Type:  helpers.GetPointer[v1beta1.HeaderMatchType](v1beta1.HeaderMatchExact),
 		Name:  "HEADER-2", // header names are case-insensitive
 		Value: "val-2",
 	}
@@ -1485,23 +2990,23 @@ func TestCreateHTTPMatch(t *testing.T) {
 
 	testQueryParamMatches := []v1beta1.HTTPQueryParamMatch{
 		{
-			Type:  helpers.GetQueryParamMatchTypePointer(v1beta1.QueryParamMatchExact),
+			Type:  helpers.GetPointer[v1beta1.QueryParamMatchType](v1beta1.QueryParamMatchExact),
 			Name:  "arg1",
 			Value: "val1",
 		},
 		{
-			Type:  helpers.GetQueryParamMatchTypePointer(v1beta1.QueryParamMatchExact),
+			Type:  helpers.GetPointer[v1beta1.QueryParamMatchType](v1beta1.QueryParamMatchExact),
 			Name:  "arg2",
 			Value: "val2=another-val",
 		},
 		{
-			// regex type is not supported. This should not be added to the httpMatch args
-			Type:  helpers.GetQueryParamMatchTypePointer(v1beta1.QueryParamMatchRegularExpression),
-			Name:  "ignore-this-arg",
-			Value: "val",
+			// regex type is matched by a map. Only the map variable should be added to the httpMatch.
+			Type:  helpers.GetPointer[v1beta1.QueryParamMatchType](v1beta1.QueryParamMatchRegularExpression),
+			Name:  "version",
+			Value: "^v[0-9]+$",
 		},
 		{
-			Type:  helpers.GetQueryParamMatchTypePointer(v1beta1.QueryParamMatchExact),
+			Type:  helpers.GetPointer[v1beta1.QueryParamMatchType](v1beta1.QueryParamMatchExact),
 			Name:  "arg3",
 			Value: "==val3",
 		},
@@ -1509,6 +3014,7 @@ func TestCreateHTTPMatch(t *testing.T) {
 
 	expectedHeaders := []string{"header-1:val-1", "header-2:val-2", "header-3:val-3"}
 	expectedArgs := []string{"arg1=val1", "arg2=val2=another-val", "arg3===val3"}
+	expectedRegexArgs := []string{"arg_version_regex_9b630460"}
 
 	tests := []struct {
 		match    v1beta1.HTTPRouteMatch
@@ -1551,8 +3057,9 @@ func TestCreateHTTPMatch(t *testing.T) {
 				QueryParams: testQueryParamMatches,
 			},
 			expected: httpMatch{
-				QueryParams:  expectedArgs,
-				RedirectPath: testPath,
+				QueryParams:      expectedArgs,
+				RegexQueryParams: expectedRegexArgs,
+				RedirectPath:     testPath,
 			},
 			msg: "query params only match",
 		},
@@ -1562,9 +3069,10 @@ func TestCreateHTTPMatch(t *testing.T) {
 				QueryParams: testQueryParamMatches,
 			},
 			expected: httpMatch{
-				Method:       "PUT",
-				QueryParams:  expectedArgs,
-				RedirectPath: testPath,
+				Method:           "PUT",
+				QueryParams:      expectedArgs,
+				RegexQueryParams: expectedRegexArgs,
+				RedirectPath:     testPath,
 			},
 			msg: "method and query params match",
 		},
@@ -1586,9 +3094,10 @@ func TestCreateHTTPMatch(t *testing.T) {
 				Headers:     testHeaderMatches,
 			},
 			expected: httpMatch{
-				QueryParams:  expectedArgs,
-				Headers:      expectedHeaders,
-				RedirectPath: testPath,
+				QueryParams:      expectedArgs,
+				RegexQueryParams: expectedRegexArgs,
+				Headers:          expectedHeaders,
+				RedirectPath:     testPath,
 			},
 			msg: "query params and headers match",
 		},
@@ -1599,10 +3108,11 @@ func TestCreateHTTPMatch(t *testing.T) {
 				Method:      testMethodMatch,
 			},
 			expected: httpMatch{
-				Method:       "PUT",
-				Headers:      expectedHeaders,
-				QueryParams:  expectedArgs,
-				RedirectPath: testPath,
+				Method:           "PUT",
+				Headers:          expectedHeaders,
+				QueryParams:      expectedArgs,
+				RegexQueryParams: expectedRegexArgs,
+				RedirectPath:     testPath,
 			},
 			msg: "method, headers, and query params match",
 		},
@@ -1675,7 +3185,7 @@ func TestIsPathOnlyMatch(t *testing.T) {
 		{
 			match: v1beta1.HTTPRouteMatch{
 				Path: &v1beta1.HTTPPathMatch{
-					Value: helpers.GetStringPointer("/path"),
+					Value: helpers.GetPointer("/path"),
 				},
 			},
 			expected: true,
@@ -1684,9 +3194,9 @@ func TestIsPathOnlyMatch(t *testing.T) {
 		{
 			match: v1beta1.HTTPRouteMatch{
 				Path: &v1beta1.HTTPPathMatch{
-					Value: helpers.GetStringPointer("/path"),
+					Value: helpers.GetPointer("/path"),
 				},
-				Method: helpers.GetHTTPMethodPointer(v1beta1.HTTPMethodGet),
+				Method: helpers.GetPointer[v1beta1.HTTPMethod](v1beta1.HTTPMethodGet),
 			},
 			expected: false,
 			msg:      "method defined in match",
@@ -1694,7 +3204,7 @@ func TestIsPathOnlyMatch(t *testing.T) {
 		{
 			match: v1beta1.HTTPRouteMatch{
 				Path: &v1beta1.HTTPPathMatch{
-					Value: helpers.GetStringPointer("/path"),
+					Value: helpers.GetPointer("/path"),
 				},
 				Headers: []v1beta1.HTTPHeaderMatch{
 					{
@@ -1709,7 +3219,7 @@ func TestIsPathOnlyMatch(t *testing.T) {
 		{
 			match: v1beta1.HTTPRouteMatch{
 				Path: &v1beta1.HTTPPathMatch{
-					Value: helpers.GetStringPointer("/path"),
+					Value: helpers.GetPointer("/path"),
 				},
 				QueryParams: []v1beta1.HTTPQueryParamMatch{
 					{
@@ -1777,6 +3287,56 @@ func TestCreateProxyPass(t *testing.T) {
 	}
 }
 
+func TestGetBackendGroupDNSAddress(t *testing.T) {
+	dnsBackend := dataplane.Backend{
+		UpstreamName: "test_external_80",
+		DNSAddress:   "example.com:80",
+		Valid:        true,
+		Weight:       1,
+	}
+
+	tests := []struct {
+		name     string
+		expected string
+		grp      dataplane.BackendGroup
+	}{
+		{
+			name:     "DNS backend",
+			expected: "example.com:80",
+			grp:      dataplane.BackendGroup{Backends: []dataplane.Backend{dnsBackend}},
+		},
+		{
+			name:     "invalid DNS backend",
+			expected: "",
+			grp: dataplane.BackendGroup{
+				Backends: []dataplane.Backend{
+					{UpstreamName: "test_external_80", DNSAddress: "example.com:80", Weight: 1},
+				},
+			},
+		},
+		{
+			name:     "backend without DNS name",
+			expected: "",
+			grp: dataplane.BackendGroup{
+				Backends: []dataplane.Backend{{UpstreamName: "test_svc_80", Valid: true, Weight: 1}},
+			},
+		},
+		{
+			name:     "split backends",
+			expected: "",
+			grp:      dataplane.BackendGroup{Backends: []dataplane.Backend{dnsBackend, dnsBackend}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			g.Expect(getBackendGroupDNSAddress(test.grp)).To(Equal(test.expected))
+		})
+	}
+}
+
 func TestCreateMatchLocation(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -1813,6 +3373,13 @@ func TestCreatePathForMatch(t *testing.T) {
 	}
 }
 
+func TestCreatePathForRegexMatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	result := createPathForRegexMatch(2, 1)
+	g.Expect(result).To(Equal("/_regex2_route1"))
+}
+
 func TestGenerateProxySetHeaders(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -1849,3 +3416,19 @@ func TestGenerateProxySetHeaders(t *testing.T) {
 	headers := generateProxySetHeaders(&filters)
 	g.Expect(headers).To(Equal(expectedHeaders))
 }
+
+func TestCreateSSLSessionTickets(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(createSSLSessionTickets(nil)).To(BeEmpty())
+	g.Expect(createSSLSessionTickets(helpers.GetPointer(true))).To(Equal("on"))
+	g.Expect(createSSLSessionTickets(helpers.GetPointer(false))).To(Equal("off"))
+}
+
+func TestCreateSSLProtocols(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(createSSLProtocols("")).To(BeEmpty())
+	g.Expect(createSSLProtocols("TLSv1.2")).To(Equal("TLSv1.2 TLSv1.3"))
+	g.Expect(createSSLProtocols("TLSv1.3")).To(Equal("TLSv1.3"))
+}
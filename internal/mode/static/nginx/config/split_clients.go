@@ -101,10 +101,14 @@ func createSplitClientDistributions(group dataplane.BackendGroup) []http.SplitCl
 }
 
 func getSplitClientValue(b dataplane.Backend) string {
-	if b.Valid {
-		return b.UpstreamName
+	if !b.Valid {
+		return invalidBackendRef
+	}
+	// proxy_pass with the variable resolves the DNS address at runtime, because no upstream has such a name.
+	if b.DNSAddress != "" {
+		return b.DNSAddress
 	}
-	return invalidBackendRef
+	return b.UpstreamName
 }
 
 // percentOf returns the percentage of a weight out of a totalWeight.
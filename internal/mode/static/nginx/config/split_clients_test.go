@@ -424,6 +424,24 @@ func TestGetSplitClientValue(t *testing.T) {
 			},
 			expValue: invalidBackendRef,
 		},
+		{
+			msg: "valid DNS backend",
+			backend: dataplane.Backend{
+				UpstreamName: "dns",
+				DNSAddress:   "example.com:80",
+				Valid:        true,
+			},
+			expValue: "example.com:80",
+		},
+		{
+			msg: "invalid DNS backend",
+			backend: dataplane.Backend{
+				UpstreamName: "dns",
+				DNSAddress:   "example.com:80",
+				Valid:        false,
+			},
+			expValue: invalidBackendRef,
+		},
 	}
 
 	for _, test := range tests {
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"strconv"
 	gotemplate "text/template"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/config/http"
@@ -17,15 +18,19 @@ const (
 	nginx500Server = "unix:/var/lib/nginx/nginx-500-server.sock"
 	// invalidBackendRef is used as an upstream name for invalid backend references.
 	invalidBackendRef = "invalid-backend-ref"
+	// FIXME(kate-osborn): Dynamically calculate upstream zone size based on the number of upstreams.
+	// 512k will support up to 648 upstream servers.
+	// https://github.com/nginxinc/nginx-kubernetes-gateway/issues/483
+	defaultUpstreamZoneSize = "512k"
 )
 
 func executeUpstreams(conf dataplane.Configuration) []byte {
-	upstreams := createUpstreams(conf.Upstreams)
+	upstreams := createUpstreams(conf.Upstreams, createUpstreamZoneSize(conf.UpstreamZoneSize))
 
 	return execute(upstreamsTemplate, upstreams)
 }
 
-func createUpstreams(upstreams []dataplane.Upstream) []http.Upstream {
+func createUpstreams(upstreams []dataplane.Upstream, zoneSize string) []http.Upstream {
 	// capacity is the number of upstreams + 1 for the invalid backend ref upstream
 	ups := make([]http.Upstream, 0, len(upstreams)+1)
 
@@ -35,9 +40,33 @@ func createUpstreams(upstreams []dataplane.Upstream) []http.Upstream {
 
 	ups = append(ups, createInvalidBackendRefUpstream())
 
+	for i := range ups {
+		ups[i].ZoneSize = zoneSize
+	}
+
 	return ups
 }
 
+// createUpstreamZoneSize returns the size of the shared memory zone of the upstreams for the size in bytes.
+// It returns the default size if the size is 0.
+func createUpstreamZoneSize(size int64) string {
+	const (
+		kilobyte = 1024
+		megabyte = 1024 * kilobyte
+	)
+
+	switch {
+	case size == 0:
+		return defaultUpstreamZoneSize
+	case size%megabyte == 0:
+		return fmt.Sprintf("%dm", size/megabyte)
+	case size%kilobyte == 0:
+		return fmt.Sprintf("%dk", size/kilobyte)
+	default:
+		return strconv.FormatInt(size, 10)
+	}
+}
+
 func createUpstream(up dataplane.Upstream) http.Upstream {
 	if len(up.Endpoints) == 0 {
 		return http.Upstream{
@@ -54,6 +83,7 @@ func createUpstream(up dataplane.Upstream) http.Upstream {
 	for idx, ep := range up.Endpoints {
 		upstreamServers[idx] = http.UpstreamServer{
 			Address: fmt.Sprintf("%s:%d", ep.Address, ep.Port),
+			Zone:    ep.Zone,
 		}
 	}
 
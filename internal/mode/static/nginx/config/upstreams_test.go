@@ -31,6 +31,20 @@ func TestExecuteUpstreams(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "up-zones",
+			Endpoints: []resolver.Endpoint{
+				{
+					Address: "12.0.0.0",
+					Port:    80,
+					Zone:    "us-east-1a",
+				},
+				{
+					Address: "12.0.0.1",
+					Port:    80,
+				},
+			},
+		},
 		{
 			Name:      "up3",
 			Endpoints: []resolver.Endpoint{},
@@ -44,7 +58,11 @@ func TestExecuteUpstreams(t *testing.T) {
 		"upstream invalid-backend-ref",
 		"server 10.0.0.0:80;",
 		"server 11.0.0.0:80;",
+		"server 12.0.0.0:80; # zone=us-east-1a",
+		"server 12.0.0.1:80;",
 		"server unix:/var/lib/nginx/nginx-502-server.sock;",
+		"zone up1 512k;",
+		"zone invalid-backend-ref 512k;",
 	}
 
 	upstreams := string(executeUpstreams(dataplane.Configuration{Upstreams: stateUpstreams}))
@@ -57,6 +75,11 @@ func TestExecuteUpstreams(t *testing.T) {
 			)
 		}
 	}
+
+	// only the endpoint with a zone gets the comment
+	if count := strings.Count(upstreams, "# zone="); count != 1 {
+		t.Errorf("executeUpstreams() generated %d zone comments, expected 1, got %q", count, upstreams)
+	}
 }
 
 func TestCreateUpstreams(t *testing.T) {
@@ -95,7 +118,8 @@ func TestCreateUpstreams(t *testing.T) {
 
 	expUpstreams := []http.Upstream{
 		{
-			Name: "up1",
+			Name:     "up1",
+			ZoneSize: "64k",
 			Servers: []http.UpstreamServer{
 				{
 					Address: "10.0.0.0:80",
@@ -109,7 +133,8 @@ func TestCreateUpstreams(t *testing.T) {
 			},
 		},
 		{
-			Name: "up2",
+			Name:     "up2",
+			ZoneSize: "64k",
 			Servers: []http.UpstreamServer{
 				{
 					Address: "11.0.0.0:80",
@@ -117,7 +142,8 @@ func TestCreateUpstreams(t *testing.T) {
 			},
 		},
 		{
-			Name: "up3",
+			Name:     "up3",
+			ZoneSize: "64k",
 			Servers: []http.UpstreamServer{
 				{
 					Address: nginx502Server,
@@ -125,7 +151,8 @@ func TestCreateUpstreams(t *testing.T) {
 			},
 		},
 		{
-			Name: invalidBackendRef,
+			Name:     invalidBackendRef,
+			ZoneSize: "64k",
 			Servers: []http.UpstreamServer{
 				{
 					Address: nginx500Server,
@@ -134,7 +161,7 @@ func TestCreateUpstreams(t *testing.T) {
 		},
 	}
 
-	result := createUpstreams(stateUpstreams)
+	result := createUpstreams(stateUpstreams, "64k")
 	if diff := cmp.Diff(expUpstreams, result); diff != "" {
 		t.Errorf("createUpstreams() mismatch (-want +got):\n%s", diff)
 	}
@@ -210,6 +237,35 @@ func TestCreateUpstream(t *testing.T) {
 			},
 			msg: "multiple endpoints",
 		},
+		{
+			stateUpstream: dataplane.Upstream{
+				Name: "endpoints-with-zones",
+				Endpoints: []resolver.Endpoint{
+					{
+						Address: "10.0.0.1",
+						Port:    80,
+						Zone:    "us-east-1a",
+					},
+					{
+						Address: "10.0.0.2",
+						Port:    80,
+					},
+				},
+			},
+			expectedUpstream: http.Upstream{
+				Name: "endpoints-with-zones",
+				Servers: []http.UpstreamServer{
+					{
+						Address: "10.0.0.1:80",
+						Zone:    "us-east-1a",
+					},
+					{
+						Address: "10.0.0.2:80",
+					},
+				},
+			},
+			msg: "endpoints with and without zones",
+		},
 	}
 
 	for _, test := range tests {
@@ -219,3 +275,62 @@ func TestCreateUpstream(t *testing.T) {
 		}
 	}
 }
+
+func TestExecuteUpstreamsZoneSize(t *testing.T) {
+	conf := dataplane.Configuration{
+		Upstreams: []dataplane.Upstream{
+			{
+				Name: "up1",
+			},
+		},
+		UpstreamZoneSize: 64 * 1024,
+	}
+
+	upstreams := string(executeUpstreams(conf))
+
+	for _, expSubString := range []string{"zone up1 64k;", "zone invalid-backend-ref 64k;"} {
+		if !strings.Contains(upstreams, expSubString) {
+			t.Errorf(
+				"executeUpstreams() did not generate upstreams with expected substring %q, got %q",
+				expSubString,
+				upstreams,
+			)
+		}
+	}
+}
+
+func TestCreateUpstreamZoneSize(t *testing.T) {
+	tests := []struct {
+		msg      string
+		expected string
+		size     int64
+	}{
+		{
+			size:     0,
+			expected: "512k",
+			msg:      "default",
+		},
+		{
+			size:     64 * 1024,
+			expected: "64k",
+			msg:      "kilobytes",
+		},
+		{
+			size:     2 * 1024 * 1024,
+			expected: "2m",
+			msg:      "megabytes",
+		},
+		{
+			size:     40000,
+			expected: "40000",
+			msg:      "bytes",
+		},
+	}
+
+	for _, test := range tests {
+		result := createUpstreamZoneSize(test.size)
+		if result != test.expected {
+			t.Errorf("createUpstreamZoneSize() %q returned %q but expected %q", test.msg, result, test.expected)
+		}
+	}
+}
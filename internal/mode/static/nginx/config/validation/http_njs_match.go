@@ -22,8 +22,9 @@ const (
 )
 
 var (
-	pathRegexp   = regexp.MustCompile("^" + pathFmt + "$")
-	pathExamples = []string{"/", "/path", "/path/subpath-123"}
+	pathRegexp        = regexp.MustCompile("^" + pathFmt + "$")
+	pathExamples      = []string{"/", "/path", "/path/subpath-123"}
+	pathRegexExamples = []string{"/path/[0-9]+", `^/path/\w+$`, `\.(jpg|png)$`}
 )
 
 // ValidatePathInMatch a path used in the location directive.
@@ -45,6 +46,21 @@ func (HTTPNJSMatchValidator) ValidatePathInMatch(path string) error {
 	return validateCommonNJSMatchPart(path)
 }
 
+// ValidatePathRegexInMatch validates a regular expression of a path used in the regex location directive.
+// Unlike a regular path, the regex is not propagated to NJS.
+func (HTTPNJSMatchValidator) ValidatePathRegexInMatch(regex string) error {
+	if regex == "" {
+		return errors.New("cannot be empty")
+	}
+
+	if _, err := regexp.Compile(regex); err != nil {
+		return fmt.Errorf("must be a valid regular expression: %w", err)
+	}
+
+	// the regex is surrounded by " in the location directive
+	return validateEscapedString(regex, pathRegexExamples)
+}
+
 func (HTTPNJSMatchValidator) ValidateHeaderNameInMatch(name string) error {
 	return validateNJSHeaderPart(name)
 }
@@ -70,6 +86,48 @@ func (HTTPNJSMatchValidator) ValidateQueryParamValueInMatch(value string) error
 	return validateCommonNJSMatchPart(value)
 }
 
+const (
+	queryParamNameInRegexMatchFmt    = `[A-Za-z0-9_]+`
+	queryParamNameInRegexMatchErrMsg = "must contain only alphanumeric characters or '_'"
+)
+
+var (
+	queryParamNameInRegexMatchRegexp   = regexp.MustCompile("^" + queryParamNameInRegexMatchFmt + "$")
+	queryParamNameInRegexMatchExamples = []string{"param", "param_1"}
+	queryParamRegexExamples            = []string{"^v[0-9]+$", "(foo|bar)"}
+)
+
+// ValidateQueryParamNameInRegexMatch validates a name of a query parameter matched by a regex.
+// Unlike in the Exact match, the name is not propagated to NJS: it becomes a part of the $arg_<name> variable
+// in a map, so it must be a valid part of a variable name.
+func (HTTPNJSMatchValidator) ValidateQueryParamNameInRegexMatch(name string) error {
+	if !queryParamNameInRegexMatchRegexp.MatchString(name) {
+		msg := k8svalidation.RegexError(
+			queryParamNameInRegexMatchErrMsg,
+			queryParamNameInRegexMatchFmt,
+			queryParamNameInRegexMatchExamples...,
+		)
+		return errors.New(msg)
+	}
+
+	return nil
+}
+
+// ValidateQueryParamRegexInMatch validates a regular expression of a query parameter value.
+// The regex is used in a map, which tests the $arg_<name> variable.
+func (HTTPNJSMatchValidator) ValidateQueryParamRegexInMatch(regex string) error {
+	if regex == "" {
+		return errors.New("cannot be empty")
+	}
+
+	if _, err := regexp.Compile(regex); err != nil {
+		return fmt.Errorf("must be a valid regular expression: %w", err)
+	}
+
+	// the regex is surrounded by " in the map
+	return validateEscapedString(regex, queryParamRegexExamples)
+}
+
 // validateCommonNJSMatchPart validates a string value used in NJS-based matching.
 func validateCommonNJSMatchPart(value string) error {
 	// empty values do not make sense, so we don't allow them.
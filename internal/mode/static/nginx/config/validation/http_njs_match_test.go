@@ -21,6 +21,22 @@ func TestValidatePathInMatch(t *testing.T) {
 		"/path$")
 }
 
+func TestValidatePathRegexInMatch(t *testing.T) {
+	validator := HTTPNJSMatchValidator{}
+
+	testValidValuesForSimpleValidator(t, validator.ValidatePathRegexInMatch,
+		"/path/[0-9]+",
+		`^/path/\w+$`,
+		`\.(jpg|png)$`,
+		`/path/\"quoted\"`)
+	testInvalidValuesForSimpleValidator(t, validator.ValidatePathRegexInMatch,
+		"",
+		"/path/[0-9",
+		"/path/(a",
+		`/path/"quoted"`,
+		`/path\`)
+}
+
 func TestValidateHeaderNameInMatch(t *testing.T) {
 	validator := HTTPNJSMatchValidator{}
 
@@ -59,6 +75,32 @@ func TestValidateQueryParamValueInMatch(t *testing.T) {
 		"")
 }
 
+func TestValidateQueryParamNameInRegexMatch(t *testing.T) {
+	validator := HTTPNJSMatchValidator{}
+
+	testValidValuesForSimpleValidator(t, validator.ValidateQueryParamNameInRegexMatch,
+		"param",
+		"Param_1")
+	testInvalidValuesForSimpleValidator(t, validator.ValidateQueryParamNameInRegexMatch,
+		"",
+		"param-1",
+		"param.1",
+		"param$")
+}
+
+func TestValidateQueryParamRegexInMatch(t *testing.T) {
+	validator := HTTPNJSMatchValidator{}
+
+	testValidValuesForSimpleValidator(t, validator.ValidateQueryParamRegexInMatch,
+		"^v[0-9]+$",
+		"(foo|bar)",
+		`\"quoted\"`)
+	testInvalidValuesForSimpleValidator(t, validator.ValidateQueryParamRegexInMatch,
+		"",
+		"[0-9",
+		`"quoted"`)
+}
+
 func TestValidateMethodInMatch(t *testing.T) {
 	validator := HTTPNJSMatchValidator{}
 
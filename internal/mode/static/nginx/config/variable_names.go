@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+	"hash/fnv"
 	"strings"
 )
 
@@ -19,3 +21,13 @@ func convertStringToSafeVariableName(s string) string {
 func generateAddHeaderMapVariableName(name string) string {
 	return strings.ToLower(convertStringToSafeVariableName(name)) + "_header_var"
 }
+
+// generateQueryParamRegexMapVariableName generates the variable name for a map that tests a query parameter against
+// a regex. The regex can't be a part of a variable name, so we use its hash instead.
+func generateQueryParamRegexMapVariableName(name, regex string) string {
+	h := fnv.New32a()
+	// Write never returns an error
+	_, _ = h.Write([]byte(regex))
+
+	return fmt.Sprintf("arg_%s_regex_%08x", strings.ToLower(name), h.Sum32())
+}
@@ -58,3 +58,11 @@ func TestGenerateAddHeaderMapVariableName(t *testing.T) {
 		g.Expect(actual).To(Equal(tc.expected))
 	}
 }
+
+func TestGenerateQueryParamRegexMapVariableName(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(generateQueryParamRegexMapVariableName("Version", "^v[0-9]+$")).To(Equal("arg_version_regex_9b630460"))
+	g.Expect(generateQueryParamRegexMapVariableName("version", "^v[0-9]+$")).To(Equal("arg_version_regex_9b630460"))
+	g.Expect(generateQueryParamRegexMapVariableName("version", "^v[0-9]$")).ToNot(Equal("arg_version_regex_9b630460"))
+}
@@ -0,0 +1,234 @@
+package file
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// diffContextLines is the number of unchanged lines around the changed lines in a unified diff.
+	diffContextLines = 3
+	// maxDiffCells limits the memory used to diff two files: the diff needs a table of (old lines + 1) x
+	// (new lines + 1) cells.
+	maxDiffCells = 4 << 20
+)
+
+// FileDiff is the difference of a file between two configurations.
+//
+//nolint:revive // Diff alone would be ambiguous next to the unified diff of a file.
+type FileDiff struct {
+	// Path is the path of the file.
+	Path string
+	// Added indicates that the file is only in the new configuration.
+	Added bool
+	// Removed indicates that the file is only in the old configuration.
+	Removed bool
+	// Changed indicates that the file is in both configurations with different content.
+	Changed bool
+}
+
+// diffConfig returns the differences between the old and the new configuration, where the key is the path of
+// a file and the value is its content. The unchanged files are not included. The differences are sorted by path.
+func diffConfig(oldFiles, newFiles map[string][]byte) []FileDiff {
+	var diffs []FileDiff
+
+	for path, newContent := range newFiles {
+		oldContent, exists := oldFiles[path]
+
+		switch {
+		case !exists:
+			diffs = append(diffs, FileDiff{Path: path, Added: true})
+		case !bytes.Equal(oldContent, newContent):
+			diffs = append(diffs, FileDiff{Path: path, Changed: true})
+		}
+	}
+
+	for path := range oldFiles {
+		if _, exists := newFiles[path]; !exists {
+			diffs = append(diffs, FileDiff{Path: path, Removed: true})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+
+	return diffs
+}
+
+// contentForDiff returns the content of the file to keep for diffing. The secret files are replaced with their
+// hash, so that the private keys are neither kept in memory nor logged.
+func contentForDiff(file File) []byte {
+	if file.Type == TypeSecret {
+		hash := sha256.Sum256(file.Content)
+		return hash[:]
+	}
+
+	return file.Content
+}
+
+// unifiedDiff returns the unified diff of the old and the new content of the file.
+func unifiedDiff(path string, oldContent, newContent []byte) string {
+	oldLines := splitLines(oldContent)
+	newLines := splitLines(newContent)
+
+	if (len(oldLines)+1)*(len(newLines)+1) > maxDiffCells {
+		return fmt.Sprintf("--- %s\n+++ %s\n(the file is too large to diff)\n", path, path)
+	}
+
+	edits := diffLines(oldLines, newLines)
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", path, path)
+
+	for start := 0; start < len(edits); {
+		// find the next changed line
+		for start < len(edits) && edits[start].op == ' ' {
+			start++
+		}
+		if start == len(edits) {
+			break
+		}
+
+		// extend the hunk until there are more than 2*diffContextLines unchanged lines in a row
+		end := start
+		for end < len(edits) {
+			if edits[end].op != ' ' {
+				end++
+				continue
+			}
+
+			unchanged := 0
+			for end+unchanged < len(edits) && edits[end+unchanged].op == ' ' {
+				unchanged++
+			}
+
+			if end+unchanged == len(edits) || unchanged > 2*diffContextLines {
+				break
+			}
+
+			end += unchanged
+		}
+
+		hunkStart := maxInt(start-diffContextLines, 0)
+		hunkEnd := minInt(end+diffContextLines, len(edits))
+
+		writeHunk(&sb, edits[hunkStart:hunkEnd])
+
+		start = hunkEnd
+	}
+
+	return sb.String()
+}
+
+// edit is a line of a diff. The op is ' ' for an unchanged line, '-' for a removed line and '+' for an added line.
+// oldNum and newNum are the 1-based numbers of the line in the old and the new content.
+type edit struct {
+	line           string
+	oldNum, newNum int
+	op             byte
+}
+
+func writeHunk(sb *strings.Builder, hunk []edit) {
+	var oldStart, newStart, oldCount, newCount int
+
+	for _, e := range hunk {
+		if e.op != '+' {
+			if oldCount == 0 {
+				oldStart = e.oldNum
+			}
+			oldCount++
+		}
+		if e.op != '-' {
+			if newCount == 0 {
+				newStart = e.newNum
+			}
+			newCount++
+		}
+	}
+
+	// An empty range starts at the line before it.
+	if oldCount == 0 {
+		oldStart = hunk[0].oldNum
+	}
+	if newCount == 0 {
+		newStart = hunk[0].newNum
+	}
+
+	fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+
+	for _, e := range hunk {
+		sb.WriteByte(e.op)
+		sb.WriteString(e.line)
+		sb.WriteByte('\n')
+	}
+}
+
+// diffLines returns the edits that turn the old lines into the new lines. It uses the longest common subsequence
+// of the lines.
+func diffLines(oldLines, newLines []string) []edit {
+	n, m := len(oldLines), len(newLines)
+
+	// lcs[i][j] is the length of the longest common subsequence of oldLines[i:] and newLines[j:].
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = maxInt(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	edits := make([]edit, 0, n+m)
+
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && oldLines[i] == newLines[j]:
+			edits = append(edits, edit{op: ' ', line: oldLines[i], oldNum: i + 1, newNum: j + 1})
+			i++
+			j++
+		case i < n && (j == m || lcs[i+1][j] >= lcs[i][j+1]):
+			// the removed lines go before the added lines, like in the diff tool
+			edits = append(edits, edit{op: '-', line: oldLines[i], oldNum: i + 1, newNum: j})
+			i++
+		default:
+			edits = append(edits, edit{op: '+', line: newLines[j], oldNum: i, newNum: j + 1})
+			j++
+		}
+	}
+
+	return edits
+}
+
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,155 @@
+package file
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDiffConfig(t *testing.T) {
+	oldFiles := map[string][]byte{
+		"/etc/nginx/conf.d/http.conf":                          []byte("upstream foo {}\n"),
+		"/etc/nginx/conf.d/servers/foo.example.com_80.conf":    []byte("server {\n    server_name foo.example.com;\n}\n"),
+		"/etc/nginx/conf.d/servers/bar.example.com_80.conf":    []byte("server {\n    server_name bar.example.com;\n}\n"),
+		"/etc/nginx/conf.d/servers/_default_80.conf":           []byte("server {\n    listen 80 default_server;\n}\n"),
+		"/etc/nginx/secrets/ssl_keypair_test_secret.pem":       []byte("key"),
+		"/etc/nginx/conf.d/servers/unchanged.example.com.conf": []byte("server {}\n"),
+	}
+
+	newFiles := map[string][]byte{
+		"/etc/nginx/conf.d/http.conf": []byte("upstream foo {}\n"),
+		"/etc/nginx/conf.d/servers/foo.example.com_80.conf": []byte(
+			"server {\n    server_name foo.example.com;\n    location / {}\n}\n",
+		),
+		"/etc/nginx/conf.d/servers/baz.example.com_80.conf":    []byte("server {\n    server_name baz.example.com;\n}\n"),
+		"/etc/nginx/conf.d/servers/_default_80.conf":           []byte("server {\n    listen 80 default_server;\n}\n"),
+		"/etc/nginx/secrets/ssl_keypair_test_secret.pem":       []byte("key"),
+		"/etc/nginx/conf.d/servers/unchanged.example.com.conf": []byte("server {}\n"),
+	}
+
+	expected := []FileDiff{
+		{Path: "/etc/nginx/conf.d/servers/bar.example.com_80.conf", Removed: true},
+		{Path: "/etc/nginx/conf.d/servers/baz.example.com_80.conf", Added: true},
+		{Path: "/etc/nginx/conf.d/servers/foo.example.com_80.conf", Changed: true},
+	}
+
+	g := NewGomegaWithT(t)
+
+	g.Expect(diffConfig(oldFiles, newFiles)).To(Equal(expected))
+	g.Expect(diffConfig(newFiles, newFiles)).To(BeEmpty())
+	g.Expect(diffConfig(nil, map[string][]byte{"a.conf": nil})).To(Equal([]FileDiff{{Path: "a.conf", Added: true}}))
+	g.Expect(diffConfig(map[string][]byte{"a.conf": nil}, nil)).To(Equal([]FileDiff{{Path: "a.conf", Removed: true}}))
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		msg        string
+		oldContent string
+		newContent string
+		expected   string
+	}{
+		{
+			msg:        "line added",
+			oldContent: "server {\n    server_name foo.example.com;\n}\n",
+			newContent: "server {\n    server_name foo.example.com;\n    location / {}\n}\n",
+			expected: `--- test.conf
++++ test.conf
+@@ -1,3 +1,4 @@
+ server {
+     server_name foo.example.com;
++    location / {}
+ }
+`,
+		},
+		{
+			msg:        "line changed",
+			oldContent: "a\nb\nc\n",
+			newContent: "a\nB\nc\n",
+			expected: `--- test.conf
++++ test.conf
+@@ -1,3 +1,3 @@
+ a
+-b
++B
+ c
+`,
+		},
+		{
+			msg:        "file emptied",
+			oldContent: "a\nb\n",
+			newContent: "",
+			expected: `--- test.conf
++++ test.conf
+@@ -1,2 +0,0 @@
+-a
+-b
+`,
+		},
+		{
+			msg:        "changes far apart make separate hunks",
+			oldContent: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			newContent: "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			expected: `--- test.conf
++++ test.conf
+@@ -1,4 +1,4 @@
+-1
++one
+ 2
+ 3
+ 4
+@@ -9,4 +9,4 @@
+ 9
+ 10
+ 11
+-12
++twelve
+`,
+		},
+		{
+			msg:        "changes close together make one hunk",
+			oldContent: "1\n2\n3\n4\n5\n6\n7\n",
+			newContent: "one\n2\n3\n4\n5\n6\nseven\n",
+			expected: `--- test.conf
++++ test.conf
+@@ -1,7 +1,7 @@
+-1
++one
+ 2
+ 3
+ 4
+ 5
+ 6
+-7
++seven
+`,
+		},
+		{
+			msg:        "no changes",
+			oldContent: "a\n",
+			newContent: "a\n",
+			expected: `--- test.conf
++++ test.conf
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			result := unifiedDiff("test.conf", []byte(test.oldContent), []byte(test.newContent))
+			g.Expect(result).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestContentForDiff(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	regular := File{Type: TypeRegular, Content: []byte("server {}")}
+	g.Expect(contentForDiff(regular)).To(Equal([]byte("server {}")))
+
+	secret := File{Type: TypeSecret, Content: []byte("private key")}
+	g.Expect(contentForDiff(secret)).ToNot(ContainSubstring("private key"))
+	g.Expect(contentForDiff(secret)).To(HaveLen(32))
+}
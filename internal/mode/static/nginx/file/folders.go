@@ -20,6 +20,7 @@ type ClearFoldersOSFileManager interface {
 }
 
 // ClearFolders removes all files in the given folders and returns the removed files' full paths.
+// It doesn't remove the subfolders. To clear a subfolder, include it in the paths before its parent folder.
 func ClearFolders(fileMgr ClearFoldersOSFileManager, paths []string) (removedFiles []string, e error) {
 	for _, path := range paths {
 		entries, err := fileMgr.ReadDir(path)
@@ -28,6 +29,10 @@ func ClearFolders(fileMgr ClearFoldersOSFileManager, paths []string) (removedFil
 		}
 
 		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+
 			path := filepath.Join(path, entry.Name())
 			if err := fileMgr.Remove(path); err != nil {
 				return removedFiles, fmt.Errorf("failed to remove %q: %w", path, err)
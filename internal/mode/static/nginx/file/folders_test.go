@@ -41,6 +41,31 @@ func TestClearFoldersRemoves(t *testing.T) {
 	g.Expect(entries).To(BeEmpty())
 }
 
+func TestClearFoldersKeepsSubfolders(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	tempDir := t.TempDir()
+
+	subDir := filepath.Join(tempDir, "sub")
+	g.Expect(os.Mkdir(subDir, 0o750)).To(Succeed())
+
+	path1 := filepath.Join(tempDir, "path1")
+	writeFile(t, path1, []byte("test"))
+	path2 := filepath.Join(subDir, "path2")
+	writeFile(t, path2, []byte("test"))
+
+	removedFiles, err := file.ClearFolders(file.NewStdLibOSFileManager(), []string{subDir, tempDir})
+
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(removedFiles).To(ConsistOf(path2, path1))
+
+	entries, err := os.ReadDir(tempDir)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entries).To(HaveLen(1))
+	g.Expect(entries[0].Name()).To(Equal("sub"))
+	g.Expect(entries[0].IsDir()).To(BeTrue())
+}
+
 func TestClearFoldersFails(t *testing.T) {
 	files := []string{"file"}
 
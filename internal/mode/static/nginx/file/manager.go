@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"runtime"
+	"sync"
 
 	"github.com/go-logr/logr"
 )
@@ -12,6 +14,8 @@ import (
 const (
 	// secretFileMode defines the default file mode for files with secrets.
 	secretFileMode = 0o600
+	// DefaultParallelWriteThreshold is the default number of files above which ManagerImpl writes files in parallel.
+	DefaultParallelWriteThreshold = 10
 )
 
 // Type is the type of File.
@@ -70,17 +74,37 @@ type Manager interface {
 // ManagerImpl is an implementation of Manager.
 // Note: It is not thread safe.
 type ManagerImpl struct {
-	logger           logr.Logger
-	osFileManager    OSFileManager
-	lastWrittenPaths []string
+	logger        logr.Logger
+	osFileManager OSFileManager
+	// lastWrittenContents holds the contents of the last written files for diffing, where the key is the path.
+	lastWrittenContents    map[string][]byte
+	lastWrittenPaths       []string
+	parallelWriteThreshold int
+}
+
+// ManagerOption is an option for ManagerImpl.
+type ManagerOption func(*ManagerImpl)
+
+// WithParallelWriteThreshold sets the number of files above which ManagerImpl writes files in parallel.
+func WithParallelWriteThreshold(threshold int) ManagerOption {
+	return func(m *ManagerImpl) {
+		m.parallelWriteThreshold = threshold
+	}
 }
 
 // NewManagerImpl creates a new NewManagerImpl.
-func NewManagerImpl(logger logr.Logger, osFileManager OSFileManager) *ManagerImpl {
-	return &ManagerImpl{
-		logger:        logger,
-		osFileManager: osFileManager,
+func NewManagerImpl(logger logr.Logger, osFileManager OSFileManager, options ...ManagerOption) *ManagerImpl {
+	m := &ManagerImpl{
+		logger:                 logger,
+		osFileManager:          osFileManager,
+		parallelWriteThreshold: DefaultParallelWriteThreshold,
 	}
+
+	for _, opt := range options {
+		opt(m)
+	}
+
+	return m
 }
 
 // ReplaceFiles replaces the files on the file system with the given files removing any previous files.
@@ -100,6 +124,22 @@ func (m *ManagerImpl) ReplaceFiles(files []File) error {
 
 	m.lastWrittenPaths = make([]string, 0, len(files))
 
+	if err := m.writeFiles(files); err != nil {
+		// The files on the file system are now unknown, so all files are reported as added next time.
+		m.lastWrittenContents = nil
+		return err
+	}
+
+	m.logChanges(files)
+
+	return nil
+}
+
+func (m *ManagerImpl) writeFiles(files []File) error {
+	if len(files) > m.parallelWriteThreshold {
+		return m.writeFilesParallel(files)
+	}
+
 	for _, file := range files {
 		if err := writeFile(m.osFileManager, file); err != nil {
 			return fmt.Errorf("failed to write file %q of type %v: %w", file.Path, file.Type, err)
@@ -112,6 +152,103 @@ func (m *ManagerImpl) ReplaceFiles(files []File) error {
 	return nil
 }
 
+// logChanges logs the summary of the changes of the files since the last write and, at the debug level,
+// the unified diff of every changed regular file.
+func (m *ManagerImpl) logChanges(files []File) {
+	contents := make(map[string][]byte, len(files))
+	types := make(map[string]Type, len(files))
+
+	for _, file := range files {
+		contents[file.Path] = contentForDiff(file)
+		types[file.Path] = file.Type
+	}
+
+	diffs := diffConfig(m.lastWrittenContents, contents)
+
+	var added, removed, changed []string
+
+	for _, d := range diffs {
+		switch {
+		case d.Added:
+			added = append(added, d.Path)
+		case d.Removed:
+			removed = append(removed, d.Path)
+		case d.Changed:
+			changed = append(changed, d.Path)
+
+			if types[d.Path] == TypeRegular {
+				m.logger.V(1).Info(
+					"changed file",
+					"path", d.Path,
+					"diff", unifiedDiff(d.Path, m.lastWrittenContents[d.Path], contents[d.Path]),
+				)
+			}
+		}
+	}
+
+	if len(diffs) > 0 {
+		m.logger.Info("configuration files changed", "added", added, "removed", removed, "changed", changed)
+	}
+
+	m.lastWrittenContents = contents
+}
+
+// writeFilesParallel writes the files concurrently using a pool of workers bounded by the number of CPUs.
+// Unlike the sequential write, it doesn't stop at the first failure: it writes all files it can and returns
+// the combined error for the files it failed to write.
+func (m *ManagerImpl) writeFilesParallel(files []File) error {
+	// ensure the types before writing any files, so that we panic in the caller's goroutine.
+	for _, file := range files {
+		ensureType(file.Type)
+	}
+
+	workers := runtime.NumCPU()
+	if workers > len(files) {
+		workers = len(files)
+	}
+
+	errs := make([]error, len(files))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			// each worker writes only to its own elements of errs, so no locking is needed.
+			for idx := range indexes {
+				errs[idx] = writeFile(m.osFileManager, files[idx])
+			}
+		}()
+	}
+
+	for idx := range files {
+		indexes <- idx
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	var resultErr error
+
+	for idx, file := range files {
+		if errs[idx] != nil {
+			resultErr = errors.Join(
+				resultErr,
+				fmt.Errorf("failed to write file %q of type %v: %w", file.Path, file.Type, errs[idx]),
+			)
+			continue
+		}
+
+		m.lastWrittenPaths = append(m.lastWrittenPaths, file.Path)
+		m.logger.Info("wrote file", "path", file.Path)
+	}
+
+	return resultErr
+}
+
 func writeFile(fileMgr OSFileManager, file File) error {
 	ensureType(file.Type)
 
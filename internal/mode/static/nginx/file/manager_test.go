@@ -1,12 +1,17 @@
 package file_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zapcore"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/nginx/file"
@@ -116,6 +121,185 @@ var _ = Describe("EventHandler", func() {
 		})
 	})
 
+	Describe("Replace files in parallel", func() {
+		var (
+			mgr    *file.ManagerImpl
+			tmpDir string
+			files  []file.File
+		)
+
+		BeforeEach(func() {
+			mgr = file.NewManagerImpl(
+				zap.New(),
+				file.NewStdLibOSFileManager(),
+				file.WithParallelWriteThreshold(1),
+			)
+			tmpDir = GinkgoT().TempDir()
+
+			files = make([]file.File, 0, 20)
+			for i := 0; i < 20; i++ {
+				fileType := file.TypeRegular
+				if i%2 == 0 {
+					fileType = file.TypeSecret
+				}
+
+				files = append(files, file.File{
+					Type:    fileType,
+					Path:    filepath.Join(tmpDir, fmt.Sprintf("file-%d.conf", i)),
+					Content: []byte(fmt.Sprintf("content-%d", i)),
+				})
+			}
+		})
+
+		ensureFileContent := func(files ...file.File) {
+			for _, f := range files {
+				bytes, err := os.ReadFile(f.Path)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(bytes).To(Equal(f.Content))
+			}
+		}
+
+		It("should write all files", func() {
+			err := mgr.ReplaceFiles(files)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			ensureFileContent(files...)
+
+			entries, err := os.ReadDir(tmpDir)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(entries).To(HaveLen(len(files)))
+		})
+
+		It("should report a single failure and write the other files", func() {
+			failingFile := file.File{
+				Type:    file.TypeRegular,
+				Path:    filepath.Join(tmpDir, "does-not-exist", "failing.conf"),
+				Content: []byte("failing"),
+			}
+
+			err := mgr.ReplaceFiles(append([]file.File{failingFile}, files...))
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(failingFile.Path))
+
+			ensureFileContent(files...)
+
+			// the next replacement removes only the files that were written.
+			err = mgr.ReplaceFiles(nil)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			entries, err := os.ReadDir(tmpDir)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(entries).To(BeEmpty())
+		})
+
+		It("should panic in the caller goroutine if file type is not supported", func() {
+			replace := func() {
+				_ = mgr.ReplaceFiles(append(files, file.File{Type: 123, Path: "unsupported.conf"}))
+			}
+
+			Expect(replace).Should(Panic())
+		})
+	})
+
+	Describe("Log changes", func() {
+		var (
+			mgr    *file.ManagerImpl
+			logs   *bytes.Buffer
+			tmpDir string
+		)
+
+		// logEntries returns the log entries with the message.
+		logEntries := func(msg string) []map[string]interface{} {
+			var entries []map[string]interface{}
+
+			for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+				var entry map[string]interface{}
+				Expect(json.Unmarshal([]byte(line), &entry)).To(Succeed())
+
+				if entry["msg"] == msg {
+					entries = append(entries, entry)
+				}
+			}
+
+			return entries
+		}
+
+		BeforeEach(func() {
+			logs = &bytes.Buffer{}
+			mgr = file.NewManagerImpl(
+				zap.New(zap.WriteTo(logs), zap.JSONEncoder(), zap.Level(zapcore.DebugLevel)),
+				file.NewStdLibOSFileManager(),
+			)
+			tmpDir = GinkgoT().TempDir()
+		})
+
+		It("should log the summary of the changed files and the diffs of the regular files", func() {
+			foo := file.File{
+				Type:    file.TypeRegular,
+				Path:    filepath.Join(tmpDir, "foo.conf"),
+				Content: []byte("server {\n    server_name foo.example.com;\n}\n"),
+			}
+			bar := file.File{
+				Type:    file.TypeRegular,
+				Path:    filepath.Join(tmpDir, "bar.conf"),
+				Content: []byte("server {\n    server_name bar.example.com;\n}\n"),
+			}
+			secret := file.File{
+				Type:    file.TypeSecret,
+				Path:    filepath.Join(tmpDir, "secret.pem"),
+				Content: []byte("private key 1"),
+			}
+
+			Expect(mgr.ReplaceFiles([]file.File{foo, bar, secret})).To(Succeed())
+
+			summaries := logEntries("configuration files changed")
+			Expect(summaries).To(HaveLen(1))
+			Expect(summaries[0]["added"]).To(ConsistOf(foo.Path, bar.Path, secret.Path))
+
+			logs.Reset()
+
+			baz := file.File{
+				Type:    file.TypeRegular,
+				Path:    filepath.Join(tmpDir, "baz.conf"),
+				Content: []byte("server {\n    server_name baz.example.com;\n}\n"),
+			}
+
+			foo.Content = []byte("server {\n    server_name foo.example.com;\n    location / {}\n}\n")
+			secret.Content = []byte("private key 2")
+
+			Expect(mgr.ReplaceFiles([]file.File{foo, baz, secret})).To(Succeed())
+
+			Expect(logs.String()).ToNot(ContainSubstring("private key"))
+
+			diffs := logEntries("changed file")
+			Expect(diffs).To(HaveLen(1))
+			Expect(diffs[0]).To(HaveKeyWithValue("path", foo.Path))
+			Expect(diffs[0]).To(HaveKeyWithValue("diff", ContainSubstring("+    location / {}\n")))
+
+			summaries = logEntries("configuration files changed")
+			Expect(summaries).To(HaveLen(1))
+			Expect(summaries[0]["added"]).To(ConsistOf(baz.Path))
+			Expect(summaries[0]["removed"]).To(ConsistOf(bar.Path))
+			Expect(summaries[0]["changed"]).To(ConsistOf(foo.Path, secret.Path))
+		})
+
+		It("should not log the summary if the files are unchanged", func() {
+			files := []file.File{
+				{
+					Type:    file.TypeRegular,
+					Path:    filepath.Join(tmpDir, "foo.conf"),
+					Content: []byte("server {}"),
+				},
+			}
+
+			Expect(mgr.ReplaceFiles(files)).To(Succeed())
+			logs.Reset()
+
+			Expect(mgr.ReplaceFiles(files)).To(Succeed())
+			Expect(logEntries("configuration files changed")).To(BeEmpty())
+		})
+	})
+
 	When("file type is not supported", func() {
 		It("should panic", func() {
 			mgr := file.NewManagerImpl(zap.New(), nil)
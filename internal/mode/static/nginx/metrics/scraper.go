@@ -0,0 +1,157 @@
+// Package metrics collects the metrics of NGINX and exposes them as Prometheus metrics.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	metricsNamespace = "nginx_kubernetes_gateway"
+	metricsSubsystem = "nginx"
+
+	// maxResponseSize limits the size of a stub_status response. A valid response is about 100 bytes.
+	maxResponseSize = 1024
+)
+
+// StatusScraperConfig holds configuration parameters for StatusScraper.
+type StatusScraperConfig struct {
+	// Registerer registers the gauges of the StatusScraper.
+	Registerer prometheus.Registerer
+	// HTTPClient fetches the stub_status responses.
+	HTTPClient *http.Client
+	// Logger is the logger of the StatusScraper.
+	Logger logr.Logger
+	// URL is the URL of the NGINX stub_status endpoint.
+	URL string
+	// Interval is the interval between two scrapes.
+	Interval time.Duration
+}
+
+// StatusScraper periodically scrapes the NGINX stub_status endpoint and updates the corresponding Prometheus gauges.
+// It implements the manager.Runnable interface.
+type StatusScraper struct {
+	httpClient *http.Client
+	gauges     statusGauges
+	logger     logr.Logger
+	url        string
+	interval   time.Duration
+}
+
+type statusGauges struct {
+	active   prometheus.Gauge
+	accepts  prometheus.Gauge
+	handled  prometheus.Gauge
+	requests prometheus.Gauge
+	reading  prometheus.Gauge
+	writing  prometheus.Gauge
+	waiting  prometheus.Gauge
+}
+
+// NewStatusScraper creates a new StatusScraper and registers its gauges.
+func NewStatusScraper(cfg StatusScraperConfig) (*StatusScraper, error) {
+	newGauge := func(name, help string) prometheus.Gauge {
+		return prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      name,
+			Help:      help,
+		})
+	}
+
+	gauges := statusGauges{
+		active:   newGauge("connections_active", "Active client connections including waiting connections"),
+		accepts:  newGauge("connections_accepted", "Accepted client connections"),
+		handled:  newGauge("connections_handled", "Handled client connections"),
+		requests: newGauge("http_requests_total", "Total HTTP requests"),
+		reading:  newGauge("connections_reading", "Connections where NGINX is reading the request header"),
+		writing:  newGauge("connections_writing", "Connections where NGINX is writing the response back to the client"),
+		waiting:  newGauge("connections_waiting", "Idle client connections waiting for a request"),
+	}
+
+	for _, g := range []prometheus.Gauge{
+		gauges.active,
+		gauges.accepts,
+		gauges.handled,
+		gauges.requests,
+		gauges.reading,
+		gauges.writing,
+		gauges.waiting,
+	} {
+		if err := cfg.Registerer.Register(g); err != nil {
+			return nil, fmt.Errorf("failed to register gauge: %w", err)
+		}
+	}
+
+	return &StatusScraper{
+		httpClient: cfg.HTTPClient,
+		gauges:     gauges,
+		logger:     cfg.Logger,
+		url:        cfg.URL,
+		interval:   cfg.Interval,
+	}, nil
+}
+
+// Start starts the StatusScraper.
+// This method will block until the StatusScraper stops, which will happen after the ctx is closed.
+func (s *StatusScraper) Start(ctx context.Context) error {
+	s.logger.Info("Starting NGINX status scraper", "url", s.url, "interval", s.interval)
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := s.scrape(ctx); err != nil {
+			// NGINX might not be running yet or might be restarting, so we don't stop scraping.
+			s.logger.Error(err, "failed to scrape NGINX status")
+		}
+	}, s.interval)
+
+	return nil
+}
+
+// NeedLeaderElection returns false, because every replica must report the metrics of its own NGINX.
+func (s *StatusScraper) NeedLeaderElection() bool {
+	return false
+}
+
+func (s *StatusScraper) scrape(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", s.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get %s: unexpected status code %d", s.url, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	status, err := parseStubStatus(string(body))
+	if err != nil {
+		return fmt.Errorf("failed to parse stub_status response: %w", err)
+	}
+
+	s.gauges.active.Set(float64(status.Active))
+	s.gauges.accepts.Set(float64(status.Accepts))
+	s.gauges.handled.Set(float64(status.Handled))
+	s.gauges.requests.Set(float64(status.Requests))
+	s.gauges.reading.Set(float64(status.Reading))
+	s.gauges.writing.Set(float64(status.Writing))
+	s.gauges.waiting.Set(float64(status.Waiting))
+
+	return nil
+}
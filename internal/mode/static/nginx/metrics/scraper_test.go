@@ -0,0 +1,161 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+const (
+	stubStatusResponse1 = `Active connections: 291 
+server accepts handled requests
+ 16630948 16630947 31070465 
+Reading: 6 Writing: 179 Waiting: 106 
+`
+	stubStatusResponse2 = `Active connections: 3 
+server accepts handled requests
+ 16630950 16630949 31070470 
+Reading: 0 Writing: 1 Waiting: 2 
+`
+)
+
+func newTestScraper(g *WithT, url string) *StatusScraper {
+	scraper, err := NewStatusScraper(StatusScraperConfig{
+		Registerer: prometheus.NewRegistry(),
+		HTTPClient: &http.Client{},
+		Logger:     logr.Discard(),
+		URL:        url,
+		Interval:   10 * time.Millisecond,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	return scraper
+}
+
+func gaugeValues(s *StatusScraper) StubStatus {
+	return StubStatus{
+		Active:   int64(testutil.ToFloat64(s.gauges.active)),
+		Accepts:  int64(testutil.ToFloat64(s.gauges.accepts)),
+		Handled:  int64(testutil.ToFloat64(s.gauges.handled)),
+		Requests: int64(testutil.ToFloat64(s.gauges.requests)),
+		Reading:  int64(testutil.ToFloat64(s.gauges.reading)),
+		Writing:  int64(testutil.ToFloat64(s.gauges.writing)),
+		Waiting:  int64(testutil.ToFloat64(s.gauges.waiting)),
+	}
+}
+
+func TestStatusScraperScrape(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	response := stubStatusResponse1
+	statusCode := http.StatusOK
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+		fmt.Fprint(w, response)
+	}))
+	defer server.Close()
+
+	scraper := newTestScraper(g, server.URL+"/nginx_status")
+
+	expectedStatus1 := StubStatus{
+		Active:   291,
+		Accepts:  16630948,
+		Handled:  16630947,
+		Requests: 31070465,
+		Reading:  6,
+		Writing:  179,
+		Waiting:  106,
+	}
+
+	g.Expect(scraper.scrape(context.Background())).To(Succeed())
+	g.Expect(gaugeValues(scraper)).To(Equal(expectedStatus1))
+
+	// the gauges are updated on the next scrape
+	response = stubStatusResponse2
+
+	g.Expect(scraper.scrape(context.Background())).To(Succeed())
+	g.Expect(gaugeValues(scraper)).To(Equal(StubStatus{
+		Active:   3,
+		Accepts:  16630950,
+		Handled:  16630949,
+		Requests: 31070470,
+		Reading:  0,
+		Writing:  1,
+		Waiting:  2,
+	}))
+
+	// the gauges keep their values if a scrape fails
+	response = "invalid"
+
+	g.Expect(scraper.scrape(context.Background())).ToNot(Succeed())
+	g.Expect(gaugeValues(scraper).Active).To(Equal(int64(3)))
+
+	response = stubStatusResponse1
+	statusCode = http.StatusInternalServerError
+
+	g.Expect(scraper.scrape(context.Background())).ToNot(Succeed())
+	g.Expect(gaugeValues(scraper).Active).To(Equal(int64(3)))
+}
+
+func TestStatusScraperScrapeUnreachable(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	scraper := newTestScraper(g, url)
+
+	g.Expect(scraper.scrape(context.Background())).ToNot(Succeed())
+}
+
+func TestStatusScraperStart(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, stubStatusResponse1)
+	}))
+	defer server.Close()
+
+	scraper := newTestScraper(g, server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+
+	go func() {
+		errCh <- scraper.Start(ctx)
+	}()
+
+	g.Eventually(func() float64 {
+		return testutil.ToFloat64(scraper.gauges.active)
+	}).Should(Equal(float64(291)))
+
+	cancel()
+	g.Expect(<-errCh).To(Succeed())
+}
+
+func TestNewStatusScraperRegistersGauges(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	registry := prometheus.NewRegistry()
+
+	_, err := NewStatusScraper(StatusScraperConfig{Registerer: registry})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	count, err := testutil.GatherAndCount(registry)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(count).To(Equal(7))
+
+	// registering the same gauges twice fails
+	_, err = NewStatusScraper(StatusScraperConfig{Registerer: registry})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StubStatus holds the data reported by the NGINX stub_status module.
+// See https://nginx.org/en/docs/http/ngx_http_stub_status_module.html
+type StubStatus struct {
+	// Active is the current number of active client connections including the waiting connections.
+	Active int64
+	// Accepts is the total number of accepted client connections.
+	Accepts int64
+	// Handled is the total number of handled connections.
+	Handled int64
+	// Requests is the total number of client requests.
+	Requests int64
+	// Reading is the current number of connections where NGINX is reading the request header.
+	Reading int64
+	// Writing is the current number of connections where NGINX is writing the response back to the client.
+	Writing int64
+	// Waiting is the current number of idle client connections waiting for a request.
+	Waiting int64
+}
+
+// parseStubStatus parses the output of the stub_status module, which looks like this:
+//
+//	Active connections: 291
+//	server accepts handled requests
+//	 16630948 16630948 31070465
+//	Reading: 6 Writing: 179 Waiting: 106
+func parseStubStatus(data string) (StubStatus, error) {
+	lines := strings.Split(strings.TrimSpace(data), "\n")
+	if len(lines) != 4 {
+		return StubStatus{}, fmt.Errorf("expected 4 lines, got %d", len(lines))
+	}
+
+	var status StubStatus
+
+	active, err := parseLabeledValues(lines[0], "Active connections:")
+	if err != nil {
+		return StubStatus{}, fmt.Errorf("invalid active connections line: %w", err)
+	}
+	status.Active = active[0]
+
+	counters, err := parseValues(strings.Fields(lines[2]), 3)
+	if err != nil {
+		return StubStatus{}, fmt.Errorf("invalid server accepts handled requests line: %w", err)
+	}
+	status.Accepts, status.Handled, status.Requests = counters[0], counters[1], counters[2]
+
+	connections, err := parseLabeledValues(lines[3], "Reading:", "Writing:", "Waiting:")
+	if err != nil {
+		return StubStatus{}, fmt.Errorf("invalid reading writing waiting line: %w", err)
+	}
+	status.Reading, status.Writing, status.Waiting = connections[0], connections[1], connections[2]
+
+	return status, nil
+}
+
+// parseLabeledValues parses a line of the form "label1 value1 label2 value2 ...",
+// where each label may consist of multiple words.
+func parseLabeledValues(line string, labels ...string) ([]int64, error) {
+	rest := strings.TrimSpace(line)
+	rawValues := make([]string, 0, len(labels))
+
+	for _, label := range labels {
+		if !strings.HasPrefix(rest, label) {
+			return nil, fmt.Errorf("expected %q", label)
+		}
+
+		fields := strings.Fields(strings.TrimPrefix(rest, label))
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("missing value for %q", label)
+		}
+
+		rawValues = append(rawValues, fields[0])
+		rest = strings.Join(fields[1:], " ")
+	}
+
+	if rest != "" {
+		return nil, errors.New("unexpected trailing data")
+	}
+
+	return parseValues(rawValues, len(labels))
+}
+
+func parseValues(rawValues []string, expected int) ([]int64, error) {
+	if len(rawValues) != expected {
+		return nil, fmt.Errorf("expected %d values, got %d", expected, len(rawValues))
+	}
+
+	values := make([]int64, 0, len(rawValues))
+
+	for _, raw := range rawValues {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseStubStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected StubStatus
+		expErr   bool
+	}{
+		{
+			name: "valid",
+			data: "Active connections: 291 \n" +
+				"server accepts handled requests\n" +
+				" 16630948 16630947 31070465 \n" +
+				"Reading: 6 Writing: 179 Waiting: 106 \n",
+			expected: StubStatus{
+				Active:   291,
+				Accepts:  16630948,
+				Handled:  16630947,
+				Requests: 31070465,
+				Reading:  6,
+				Writing:  179,
+				Waiting:  106,
+			},
+		},
+		{
+			name: "valid zero values",
+			data: "Active connections: 0\n" +
+				"server accepts handled requests\n" +
+				" 0 0 0\n" +
+				"Reading: 0 Writing: 0 Waiting: 0\n",
+			expected: StubStatus{},
+		},
+		{
+			name:   "empty",
+			data:   "",
+			expErr: true,
+		},
+		{
+			name: "missing line",
+			data: "Active connections: 1\n" +
+				"server accepts handled requests\n" +
+				" 1 1 1\n",
+			expErr: true,
+		},
+		{
+			name: "invalid active connections",
+			data: "Active connections: many\n" +
+				"server accepts handled requests\n" +
+				" 1 1 1\n" +
+				"Reading: 0 Writing: 1 Waiting: 0\n",
+			expErr: true,
+		},
+		{
+			name: "missing request counter",
+			data: "Active connections: 1\n" +
+				"server accepts handled requests\n" +
+				" 1 1\n" +
+				"Reading: 0 Writing: 1 Waiting: 0\n",
+			expErr: true,
+		},
+		{
+			name: "missing waiting",
+			data: "Active connections: 1\n" +
+				"server accepts handled requests\n" +
+				" 1 1 1\n" +
+				"Reading: 0 Writing: 1\n",
+			expErr: true,
+		},
+		{
+			name: "unexpected label",
+			data: "Active connections: 1\n" +
+				"server accepts handled requests\n" +
+				" 1 1 1\n" +
+				"Reading: 0 Sending: 1 Waiting: 0\n",
+			expErr: true,
+		},
+		{
+			name: "trailing data",
+			data: "Active connections: 1 2\n" +
+				"server accepts handled requests\n" +
+				" 1 1 1\n" +
+				"Reading: 0 Writing: 1 Waiting: 0\n",
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			status, err := parseStubStatus(test.data)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(status).To(Equal(test.expected))
+		})
+	}
+}
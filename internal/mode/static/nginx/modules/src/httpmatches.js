@@ -104,7 +104,7 @@ function testMatch(r, match) {
   // check headers
   if (match.headers) {
     try {
-      let found = headersMatch(r.headersIn, match.headers);
+      let found = headersMatch(r.headersIn, match.headers, match.caseInsensitiveHeaders);
       if (!found) {
         return false;
       }
@@ -125,11 +125,16 @@ function testMatch(r, match) {
     }
   }
 
+  // check regex params
+  if (match.regexParams && !regexParamsMatch(r.variables, match.regexParams)) {
+    return false;
+  }
+
   // all match conditions are satisfied so return true
   return true;
 }
 
-function headersMatch(requestHeaders, headers) {
+function headersMatch(requestHeaders, headers, caseInsensitive) {
   for (let i = 0; i < headers.length; i++) {
     const h = headers[i];
     const kv = h.split(':');
@@ -146,9 +151,17 @@ function headersMatch(requestHeaders, headers) {
       return false;
     }
 
+    // Header values are compared in a case-sensitive manner, unless the match is case-insensitive.
+    // In a case-insensitive match, header value "BAR" is equivalent to "bar".
     // split on comma because nginx uses commas to delimit multiple header values
-    const values = val.split(',');
-    if (!values.includes(kv[1])) {
+    let values = val.split(',');
+    let expected = kv[1];
+    if (caseInsensitive) {
+      values = values.map((v) => v.toLowerCase());
+      expected = expected.toLowerCase();
+    }
+
+    if (!values.includes(expected)) {
       return false;
     }
   }
@@ -195,12 +208,25 @@ function paramsMatch(requestParams, params) {
   return true;
 }
 
+// Query parameters are matched against regexes in NGINX maps, because NJS regexes are not compatible with the
+// regexes NGINX uses. Every map sets its variable to "1" if the first value of the query parameter matches the regex.
+function regexParamsMatch(requestVariables, regexParams) {
+  for (let i = 0; i < regexParams.length; i++) {
+    if (requestVariables[regexParams[i]] !== '1') {
+      return false;
+    }
+  }
+
+  return true;
+}
+
 export default {
   redirect,
   testMatch,
   findWinningMatch,
   headersMatch,
   paramsMatch,
+  regexParamsMatch,
   extractMatchesFromRequest,
   HTTP_CODES,
   MATCHES_VARIABLE,
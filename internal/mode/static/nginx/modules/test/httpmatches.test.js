@@ -3,7 +3,13 @@ import { expect } from 'chai';
 
 // Creates a NGINX HTTP Request Object for testing.
 // See documentation for all properties available: http://nginx.org/en/docs/njs/reference.html
-function createRequest({ method = '', headers = {}, params = {}, matches = '' } = {}) {
+function createRequest({
+  method = '',
+  headers = {},
+  params = {},
+  matches = '',
+  variables = {},
+} = {}) {
   let r = {
     // Test mocks
     return(statusCode) {
@@ -15,7 +21,7 @@ function createRequest({ method = '', headers = {}, params = {}, matches = '' }
     error(msg) {
       console.log('\tngx_error:', msg);
     },
-    variables: {},
+    variables: { ...variables },
   };
 
   if (method) {
@@ -100,6 +106,18 @@ describe('testMatch', () => {
       request: createRequest({ headers: { header: 'value' } }),
       expected: true,
     },
+    {
+      name: 'returns false if the header value case does not match',
+      match: { headers: ['header:VALUE'] },
+      request: createRequest({ headers: { header: 'value' } }),
+      expected: false,
+    },
+    {
+      name: 'returns true if the header value case does not match in a case-insensitive match',
+      match: { headers: ['header:VALUE'], caseInsensitiveHeaders: true },
+      request: createRequest({ headers: { header: 'value' } }),
+      expected: true,
+    },
     {
       name: 'returns true if query parameters match and no other conditions are set',
       match: { params: ['key=value'] },
@@ -134,6 +152,21 @@ describe('testMatch', () => {
       request: createRequest({ method: 'GET', headers: { header: 'value' } }), // no params set on request
       expected: false,
     },
+    {
+      name: 'returns true if regex query parameters match',
+      match: { regexParams: ['arg_key_regex_1', 'arg_other_regex_2'] },
+      request: createRequest({ variables: { arg_key_regex_1: '1', arg_other_regex_2: '1' } }),
+      expected: true,
+    },
+    {
+      name: 'returns false if one of regex query parameters does not match',
+      match: { method: 'GET', regexParams: ['arg_key_regex_1', 'arg_other_regex_2'] },
+      request: createRequest({
+        method: 'GET',
+        variables: { arg_key_regex_1: '1', arg_other_regex_2: '0' },
+      }),
+      expected: false,
+    },
     {
       name: 'throws if headers are malformed',
       match: { headers: ['malformedheader'] },
@@ -246,12 +279,48 @@ describe('headersMatch', () => {
       name: 'returns true if all headers match',
       headers: multipleHeaders,
       requestHeaders: {
-        header1: 'VALUE1', // this value is not the correct case
+        header1: 'VALUE1',
         header2: 'value2',
         header3: 'value3',
       },
       expected: true,
     },
+    {
+      name: 'returns true if the header value matches in lowercase in a case-insensitive match',
+      headers: ['X-Foo:Bar'],
+      caseInsensitive: true,
+      requestHeaders: {
+        'X-Foo': 'bar',
+      },
+      expected: true,
+    },
+    {
+      name: 'returns true if the header value matches in uppercase in a case-insensitive match',
+      headers: ['X-Foo:Bar'],
+      caseInsensitive: true,
+      requestHeaders: {
+        'X-Foo': 'BAR',
+      },
+      expected: true,
+    },
+    {
+      name: 'returns true if one of multiple header values matches in a different case in a case-insensitive match',
+      headers: ['multiValueHeader:Val3'],
+      caseInsensitive: true,
+      requestHeaders: {
+        multiValueHeader: 'val1,VAL3',
+      },
+      expected: true,
+    },
+    {
+      name: 'returns false if the header value does not match in a case-insensitive match',
+      headers: ['X-Foo:Bar'],
+      caseInsensitive: true,
+      requestHeaders: {
+        'X-Foo': 'baz',
+      },
+      expected: false,
+    },
     {
       name: 'returns true if request has multiple values for a header name and one value matches ',
       headers: ['multiValueHeader:val3'],
@@ -265,11 +334,13 @@ describe('headersMatch', () => {
   tests.forEach((test) => {
     it(test.name, () => {
       if (test.expectThrow) {
-        expect(() => hm.headersMatch(test.requestHeaders, test.headers)).to.throw(
-          'invalid header match',
-        );
+        expect(() =>
+          hm.headersMatch(test.requestHeaders, test.headers, test.caseInsensitive),
+        ).to.throw('invalid header match');
       } else {
-        expect(hm.headersMatch(test.requestHeaders, test.headers)).to.equal(test.expected);
+        expect(hm.headersMatch(test.requestHeaders, test.headers, test.caseInsensitive)).to.equal(
+          test.expected,
+        );
       }
     });
   });
@@ -389,6 +460,35 @@ describe('paramsMatch', () => {
   });
 });
 
+describe('regexParamsMatch', () => {
+  const tests = [
+    {
+      name: 'returns true if all regex params match',
+      regexParams: ['var1', 'var2'],
+      requestVariables: { var1: '1', var2: '1' },
+      expected: true,
+    },
+    {
+      name: 'returns false if one of regex params does not match',
+      regexParams: ['var1', 'var2'],
+      requestVariables: { var1: '1', var2: '0' },
+      expected: false,
+    },
+    {
+      name: 'returns false if the variable of a regex param is not set',
+      regexParams: ['var1'],
+      requestVariables: {},
+      expected: false,
+    },
+  ];
+
+  tests.forEach((test) => {
+    it(test.name, () => {
+      expect(hm.regexParamsMatch(test.requestVariables, test.regexParams)).to.equal(test.expected);
+    });
+  });
+});
+
 describe('redirect', () => {
   const testAnyMatch = { any: true, redirectPath: '/any' };
   const testHeaderMatches = {
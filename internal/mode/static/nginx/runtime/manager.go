@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"strconv"
@@ -17,11 +18,28 @@ import (
 const (
 	pidFile        = "/etc/nginx/nginx.pid"
 	pidFileTimeout = 10 * time.Second
+	// errorLogFile is the log, to which NGINX writes the emerg messages, including the errors of a configuration
+	// rejected during a reload. See the nginx-conf ConfigMap.
+	errorLogFile = "/var/lib/nginx/emerg.log"
+	// DefaultReloadTimeout is the default time to wait for NGINX to start new worker processes after a reload.
+	// A rejected configuration is detected through the error log, so the timeout only applies if NGINX neither
+	// starts new workers nor logs an error.
+	DefaultReloadTimeout = 5 * time.Second
+	// DefaultQuitTimeout is the default time to wait for NGINX to exit after a graceful shutdown starts.
+	// It matches the default termination grace period of a Pod, after which Kubernetes kills NGINX anyway.
+	DefaultQuitTimeout = 30 * time.Second
+	// workersPollInterval is the interval between two checks of the worker processes during a reload.
+	workersPollInterval = 100 * time.Millisecond
+	// exitPollInterval is the interval between two checks of the NGINX main process during a quit.
+	exitPollInterval = 500 * time.Millisecond
 )
 
 type (
-	readFileFunc  func(string) ([]byte, error)
-	checkFileFunc func(string) (fs.FileInfo, error)
+	readFileFunc       func(string) ([]byte, error)
+	checkFileFunc      func(string) (fs.FileInfo, error)
+	processRunningFunc func(pid int) (bool, error)
+	// newErrorsFunc returns the errors that NGINX logged since the reload started.
+	newErrorsFunc func() (string, error)
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . Manager
@@ -30,14 +48,57 @@ type (
 type Manager interface {
 	// Reload reloads NGINX configuration. It is a blocking operation.
 	Reload(ctx context.Context) error
+	// Quit gracefully shuts down NGINX. It blocks until NGINX finishes handling the open connections and exits,
+	// until the quit timeout expires, or until the ctx is canceled.
+	Quit(ctx context.Context) error
 }
 
 // ManagerImpl implements Manager.
-type ManagerImpl struct{}
+type ManagerImpl struct {
+	processChecker ProcessChecker
+	errorLogFile   string
+	reloadTimeout  time.Duration
+	quitTimeout    time.Duration
+}
+
+// ManagerOption is an option for ManagerImpl.
+type ManagerOption func(*ManagerImpl)
+
+// WithReloadTimeout sets the time to wait for NGINX to start new worker processes after a reload.
+func WithReloadTimeout(timeout time.Duration) ManagerOption {
+	return func(m *ManagerImpl) {
+		m.reloadTimeout = timeout
+	}
+}
+
+// WithQuitTimeout sets the time to wait for NGINX to exit after a graceful shutdown starts.
+func WithQuitTimeout(timeout time.Duration) ManagerOption {
+	return func(m *ManagerImpl) {
+		m.quitTimeout = timeout
+	}
+}
+
+// WithProcessChecker sets the ProcessChecker used to verify the reloads.
+func WithProcessChecker(checker ProcessChecker) ManagerOption {
+	return func(m *ManagerImpl) {
+		m.processChecker = checker
+	}
+}
 
 // NewManagerImpl creates a new ManagerImpl.
-func NewManagerImpl() *ManagerImpl {
-	return &ManagerImpl{}
+func NewManagerImpl(options ...ManagerOption) *ManagerImpl {
+	m := &ManagerImpl{
+		processChecker: NewProcFSProcessChecker(),
+		errorLogFile:   errorLogFile,
+		reloadTimeout:  DefaultReloadTimeout,
+		quitTimeout:    DefaultQuitTimeout,
+	}
+
+	for _, opt := range options {
+		opt(m)
+	}
+
+	return m
 }
 
 func (m *ManagerImpl) Reload(ctx context.Context) error {
@@ -47,6 +108,20 @@ func (m *ManagerImpl) Reload(ctx context.Context) error {
 		return fmt.Errorf("failed to find NGINX main process: %w", err)
 	}
 
+	previousWorkers, err := m.processChecker.GetWorkerPIDs(pid)
+	if err != nil {
+		return fmt.Errorf("failed to get NGINX worker processes: %w", err)
+	}
+
+	errorLogOffset, err := getFileSize(m.errorLogFile)
+	if err != nil {
+		return fmt.Errorf("failed to get the size of the NGINX error log: %w", err)
+	}
+
+	newErrors := func() (string, error) {
+		return readFileFrom(m.errorLogFile, errorLogOffset)
+	}
+
 	// send HUP signal to the NGINX main process reload configuration
 	// See https://nginx.org/en/docs/control.html
 	err = syscall.Kill(pid, syscall.SIGHUP)
@@ -54,22 +129,212 @@ func (m *ManagerImpl) Reload(ctx context.Context) error {
 		return fmt.Errorf("failed to send the HUP signal to NGINX main: %w", err)
 	}
 
-	// FIXME(pleshakov)
-	// (1) ensure the reload actually happens.
-	// https://github.com/nginxinc/nginx-kubernetes-gateway/issues/664
+	// The signal doesn't tell if NGINX reloaded. NGINX starts new worker processes after it successfully applies
+	// the new configuration, so we wait for them. If NGINX rejects the configuration, it keeps the previous workers
+	// and logs the error, so we stop waiting. Waiting also prevents a subsequent reload from starting before
+	// the in-flight reload finishes.
+	err = waitForNewWorkers(ctx, m.processChecker, pid, previousWorkers, newErrors, m.reloadTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to verify NGINX reload: %w", err)
+	}
+
+	return nil
+}
+
+func (m *ManagerImpl) Quit(ctx context.Context) error {
+	pid, err := findMainProcess(ctx, os.Stat, os.ReadFile, pidFileTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to find NGINX main process: %w", err)
+	}
+
+	// send QUIT signal to the NGINX main process for a graceful shutdown, same as nginx -s quit
+	// See https://nginx.org/en/docs/control.html
+	err = syscall.Kill(pid, syscall.SIGQUIT)
+	if err != nil {
+		return fmt.Errorf("failed to send the QUIT signal to NGINX main: %w", err)
+	}
+
+	if err := waitForProcessExit(ctx, isProcessRunning, pid, m.quitTimeout); err != nil {
+		return fmt.Errorf("failed to wait for NGINX to exit: %w", err)
+	}
+
+	return nil
+}
+
+// WaitForExit waits until the NGINX main process exits or the timeout expires. It returns immediately if NGINX
+// is not running. The NGINX processes must be visible to the process, for example, because the containers of the Pod
+// share the process namespace.
+func WaitForExit(ctx context.Context, timeout time.Duration) error {
+	return waitForMainProcessExit(ctx, os.ReadFile, isProcessRunning, timeout)
+}
+
+func waitForMainProcessExit(
+	ctx context.Context,
+	readFile readFileFunc,
+	isRunning processRunningFunc,
+	timeout time.Duration,
+) error {
+	content, err := readFile(pidFile)
+	if err != nil {
+		// NGINX removes the pid file when it exits.
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read the NGINX pid file: %w", err)
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		return fmt.Errorf("invalid pid file content %q: %w", content, err)
+	}
+
+	return waitForProcessExit(ctx, isRunning, pid, timeout)
+}
+
+// isProcessRunning returns true if the process with the pid exists.
+func isProcessRunning(pid int) (bool, error) {
+	// signal 0 doesn't send a signal but checks that the process exists
+	err := syscall.Kill(pid, 0)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, syscall.ESRCH) {
+		return false, nil
+	}
+
+	return false, err
+}
+
+// waitForProcessExit waits until the process with the pid exits. NGINX takes as long as its longest connection
+// to drain, so the wait is bounded by the timeout.
+func waitForProcessExit(ctx context.Context, isRunning processRunningFunc, pid int, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	// for now, to prevent a subsequent reload starting before the in-flight reload finishes, we simply sleep.
-	// Fixing (1) will make the sleep unnecessary.
+	err := wait.PollUntilContextCancel(
+		ctx,
+		exitPollInterval,
+		true, /* poll immediately */
+		func(ctx context.Context) (bool, error) {
+			running, err := isRunning(pid)
+			if err != nil {
+				return false, err
+			}
 
-	select {
-	case <-ctx.Done():
-		return nil
-	case <-time.After(1 * time.Second):
+			return !running, nil
+		})
+	if err != nil {
+		return fmt.Errorf("NGINX main process didn't exit within %v: %w", timeout, err)
 	}
 
 	return nil
 }
 
+// waitForNewWorkers waits until the NGINX main process has a worker process that is not one of the previous
+// workers. The previous workers can keep running after a reload until they finish handling the connections.
+// It returns an error without waiting for the timeout if NGINX logs an error, because NGINX doesn't start new
+// workers after it rejects the configuration.
+func waitForNewWorkers(
+	ctx context.Context,
+	checker ProcessChecker,
+	mainPID int,
+	previousWorkers []int,
+	newErrors newErrorsFunc,
+	timeout time.Duration,
+) error {
+	previous := make(map[int]struct{}, len(previousWorkers))
+	for _, pid := range previousWorkers {
+		previous[pid] = struct{}{}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := wait.PollUntilContextCancel(
+		ctx,
+		workersPollInterval,
+		true, /* poll immediately */
+		func(ctx context.Context) (bool, error) {
+			workers, err := checker.GetWorkerPIDs(mainPID)
+			if err != nil {
+				return false, err
+			}
+
+			for _, pid := range workers {
+				if _, exists := previous[pid]; !exists {
+					return true, nil
+				}
+			}
+
+			errs, err := newErrors()
+			if err != nil {
+				return false, fmt.Errorf("failed to read the NGINX error log: %w", err)
+			}
+			if errs != "" {
+				return false, &rejectedConfigError{msg: errs}
+			}
+
+			return false, nil
+		})
+
+	var rejectedErr *rejectedConfigError
+	if errors.As(err, &rejectedErr) {
+		return rejectedErr
+	}
+	if err != nil {
+		return fmt.Errorf("new NGINX worker processes didn't start within %v: %w", timeout, err)
+	}
+
+	return nil
+}
+
+// rejectedConfigError is returned when NGINX rejects the configuration during a reload.
+type rejectedConfigError struct {
+	msg string
+}
+
+func (e *rejectedConfigError) Error() string {
+	return "NGINX rejected the configuration: " + e.msg
+}
+
+// getFileSize returns the size of the file. It returns 0 if the file doesn't exist: NGINX creates the error log
+// when it starts, so the file only doesn't exist if NGINX runs with a different nginx.conf.
+func getFileSize(name string) (int64, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return info.Size(), nil
+}
+
+// readFileFrom reads the content of the file from the offset and returns it without the surrounding whitespace.
+// It returns an empty string if the file doesn't exist.
+func readFileFrom(name string, offset int64) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
 func findMainProcess(
 	ctx context.Context,
 	checkFile checkFileFunc,
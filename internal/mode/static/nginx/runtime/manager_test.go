@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"io/fs"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -113,3 +115,339 @@ func TestFindMainProcess(t *testing.T) {
 		}
 	}
 }
+
+// processCheckerFunc is a ProcessChecker that returns the workers from the function.
+type processCheckerFunc func(mainPID int) ([]int, error)
+
+func (f processCheckerFunc) GetWorkerPIDs(mainPID int) ([]int, error) {
+	return f(mainPID)
+}
+
+func TestWaitForNewWorkers(t *testing.T) {
+	// newCheckerGen creates a ProcessChecker that returns the previous workers for the first calls and then
+	// the new workers.
+	newCheckerGen := func(callsBeforeReload int) ProcessChecker {
+		calls := 0
+
+		return processCheckerFunc(func(mainPID int) ([]int, error) {
+			if mainPID != 1 {
+				return nil, errors.New("unexpected main pid")
+			}
+
+			calls++
+			if calls <= callsBeforeReload {
+				return []int{2, 3}, nil
+			}
+
+			// The previous workers can still be running.
+			return []int{2, 4}, nil
+		})
+	}
+	checkerError := processCheckerFunc(func(int) ([]int, error) {
+		return nil, errors.New("error")
+	})
+
+	// newErrorsGen creates a newErrorsFunc that returns the errors after the first calls.
+	newErrorsGen := func(callsBeforeErrors int) newErrorsFunc {
+		calls := 0
+
+		return func() (string, error) {
+			calls++
+			if calls <= callsBeforeErrors {
+				return "", nil
+			}
+
+			return `[emerg] 1#1: unknown directive "invalid" in /etc/nginx/conf.d/http.conf:1`, nil
+		}
+	}
+	noErrors := newErrorsGen(1000)
+	newErrorsError := func() (string, error) {
+		return "", errors.New("error")
+	}
+
+	tests := []struct {
+		checker     ProcessChecker
+		newErrors   newErrorsFunc
+		msg         string
+		timeout     time.Duration
+		expectError bool
+	}{
+		{
+			checker:     newCheckerGen(0),
+			newErrors:   noErrors,
+			timeout:     500 * time.Millisecond,
+			expectError: false,
+			msg:         "new workers start immediately",
+		},
+		{
+			checker:     newCheckerGen(2),
+			newErrors:   noErrors,
+			timeout:     500 * time.Millisecond,
+			expectError: false,
+			msg:         "new workers start after a while",
+		},
+		{
+			checker:     newCheckerGen(1000),
+			newErrors:   noErrors,
+			timeout:     500 * time.Millisecond,
+			expectError: true,
+			msg:         "new workers don't start before the timeout",
+		},
+		{
+			checker:     checkerError,
+			newErrors:   noErrors,
+			timeout:     500 * time.Millisecond,
+			expectError: true,
+			msg:         "checker fails",
+		},
+		{
+			checker:     newCheckerGen(1000),
+			newErrors:   newErrorsGen(2),
+			timeout:     time.Minute,
+			expectError: true,
+			msg:         "configuration is rejected",
+		},
+		{
+			checker:     newCheckerGen(1000),
+			newErrors:   newErrorsError,
+			timeout:     time.Minute,
+			expectError: true,
+			msg:         "reading the errors fails",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			err := waitForNewWorkers(ctx, test.checker, 1, []int{2, 3}, test.newErrors, test.timeout)
+
+			if test.expectError && err == nil {
+				t.Errorf("waitForNewWorkers() didn't return error")
+			}
+			if !test.expectError && err != nil {
+				t.Errorf("waitForNewWorkers() returned unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestWaitForNewWorkersRejectedConfig(t *testing.T) {
+	checker := processCheckerFunc(func(int) ([]int, error) {
+		return []int{2, 3}, nil
+	})
+	newErrors := func() (string, error) {
+		return "[emerg] 1#1: unexpected end of file", nil
+	}
+
+	start := time.Now()
+	err := waitForNewWorkers(context.Background(), checker, 1, []int{2, 3}, newErrors, time.Minute)
+
+	var rejectedErr *rejectedConfigError
+	if !errors.As(err, &rejectedErr) {
+		t.Fatalf("waitForNewWorkers() returned %v, expected the rejected configuration error", err)
+	}
+	if expected := "NGINX rejected the configuration: [emerg] 1#1: unexpected end of file"; err.Error() != expected {
+		t.Errorf("waitForNewWorkers() returned error %q, expected %q", err, expected)
+	}
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("waitForNewWorkers() returned after %v, expected to return before the timeout", elapsed)
+	}
+}
+
+func TestWaitForMainProcessExit(t *testing.T) {
+	readFileGen := func(content string) readFileFunc {
+		return func(name string) ([]byte, error) {
+			if name != pidFile {
+				return nil, errors.New("unexpected file")
+			}
+			return []byte(content), nil
+		}
+	}
+	readFileNotExist := func(string) ([]byte, error) {
+		return nil, fs.ErrNotExist
+	}
+	readFileError := func(string) ([]byte, error) {
+		return nil, errors.New("error")
+	}
+
+	exited := func(pid int) (bool, error) {
+		if pid != 1 {
+			return false, errors.New("unexpected pid")
+		}
+		return false, nil
+	}
+	running := func(int) (bool, error) {
+		return true, nil
+	}
+
+	tests := []struct {
+		readFile    readFileFunc
+		isRunning   processRunningFunc
+		msg         string
+		expectError bool
+	}{
+		{
+			readFile:    readFileGen("1\n"),
+			isRunning:   exited,
+			expectError: false,
+			msg:         "process exits",
+		},
+		{
+			readFile:    readFileNotExist,
+			isRunning:   running,
+			expectError: false,
+			msg:         "NGINX is not running",
+		},
+		{
+			readFile:    readFileGen("1\n"),
+			isRunning:   running,
+			expectError: true,
+			msg:         "process doesn't exit before the timeout",
+		},
+		{
+			readFile:    readFileGen("not a number"),
+			isRunning:   exited,
+			expectError: true,
+			msg:         "bad file content",
+		},
+		{
+			readFile:    readFileError,
+			isRunning:   exited,
+			expectError: true,
+			msg:         "cannot read file",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			err := waitForMainProcessExit(context.Background(), test.readFile, test.isRunning, time.Second)
+
+			if test.expectError && err == nil {
+				t.Errorf("waitForMainProcessExit() didn't return error")
+			}
+			if !test.expectError && err != nil {
+				t.Errorf("waitForMainProcessExit() returned unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestReadFileFrom(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "emerg.log")
+
+	size, err := getFileSize(name)
+	if err != nil || size != 0 {
+		t.Fatalf("getFileSize() for a missing file returned %d, %v, expected 0, nil", size, err)
+	}
+
+	content, err := readFileFrom(name, 0)
+	if err != nil || content != "" {
+		t.Fatalf("readFileFrom() for a missing file returned %q, %v, expected empty content", content, err)
+	}
+
+	if err := os.WriteFile(name, []byte("[emerg] previous error\n"), 0o600); err != nil {
+		t.Fatalf("failed to write the file: %v", err)
+	}
+
+	offset, err := getFileSize(name)
+	if err != nil {
+		t.Fatalf("getFileSize() returned unexpected error %v", err)
+	}
+
+	content, err = readFileFrom(name, offset)
+	if err != nil || content != "" {
+		t.Fatalf("readFileFrom() without new content returned %q, %v, expected empty content", content, err)
+	}
+
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("failed to open the file: %v", err)
+	}
+	if _, err := f.WriteString("[emerg] new error\n"); err != nil {
+		t.Fatalf("failed to append to the file: %v", err)
+	}
+	f.Close()
+
+	content, err = readFileFrom(name, offset)
+	if err != nil {
+		t.Fatalf("readFileFrom() returned unexpected error %v", err)
+	}
+	if content != "[emerg] new error" {
+		t.Errorf("readFileFrom() returned %q, expected %q", content, "[emerg] new error")
+	}
+}
+
+func TestWaitForProcessExit(t *testing.T) {
+	// isRunningGen creates a processRunningFunc that reports the process as running for the first calls.
+	isRunningGen := func(callsBeforeExit int) processRunningFunc {
+		calls := 0
+
+		return func(pid int) (bool, error) {
+			if pid != 1 {
+				return false, errors.New("unexpected pid")
+			}
+
+			calls++
+			return calls <= callsBeforeExit, nil
+		}
+	}
+	isRunningError := func(int) (bool, error) {
+		return false, errors.New("error")
+	}
+
+	tests := []struct {
+		isRunning   processRunningFunc
+		msg         string
+		timeout     time.Duration
+		expectError bool
+	}{
+		{
+			isRunning:   isRunningGen(0),
+			timeout:     time.Minute,
+			expectError: false,
+			msg:         "process exits immediately",
+		},
+		{
+			isRunning:   isRunningGen(2),
+			timeout:     time.Minute,
+			expectError: false,
+			msg:         "process exits after a while",
+		},
+		{
+			isRunning:   isRunningGen(1000),
+			timeout:     time.Second,
+			expectError: true,
+			msg:         "process doesn't exit before the timeout",
+		},
+		{
+			isRunning:   isRunningGen(1000),
+			timeout:     time.Minute,
+			expectError: true,
+			msg:         "process doesn't exit before the ctx is canceled",
+		},
+		{
+			isRunning:   isRunningError,
+			timeout:     time.Minute,
+			expectError: true,
+			msg:         "check fails",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			err := waitForProcessExit(ctx, test.isRunning, 1, test.timeout)
+
+			if test.expectError && err == nil {
+				t.Errorf("waitForProcessExit() didn't return error")
+			}
+			if !test.expectError && err != nil {
+				t.Errorf("waitForProcessExit() returned unexpected error %v", err)
+			}
+		})
+	}
+}
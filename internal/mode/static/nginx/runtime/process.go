@@ -0,0 +1,90 @@
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . ProcessChecker
+
+// ProcessChecker checks the processes of NGINX.
+type ProcessChecker interface {
+	// GetWorkerPIDs returns the PIDs of the worker processes of the NGINX main process.
+	GetWorkerPIDs(mainPID int) ([]int, error)
+}
+
+// ProcFSProcessChecker implements ProcessChecker using the /proc file system.
+// The NGINX processes must be visible to the process, for example, because the containers of the Pod share
+// the process namespace.
+type ProcFSProcessChecker struct {
+	procFolder string
+}
+
+// NewProcFSProcessChecker creates a new ProcFSProcessChecker.
+func NewProcFSProcessChecker() *ProcFSProcessChecker {
+	return &ProcFSProcessChecker{
+		procFolder: "/proc",
+	}
+}
+
+// GetWorkerPIDs returns the PIDs of the child processes of the NGINX main process.
+// Besides the workers, NGINX can have the cache manager and cache loader child processes, but it doesn't matter
+// for checking a reload, because NGINX replaces them during a reload too.
+func (c *ProcFSProcessChecker) GetWorkerPIDs(mainPID int) ([]int, error) {
+	entries, err := os.ReadDir(c.procFolder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", c.procFolder, err)
+	}
+
+	var pids []int
+
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			// not a process
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(c.procFolder, entry.Name(), "stat"))
+		if err != nil {
+			// the process has exited
+			continue
+		}
+
+		ppid, err := parseParentPID(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the stat of process %d: %w", pid, err)
+		}
+
+		if ppid == mainPID {
+			pids = append(pids, pid)
+		}
+	}
+
+	return pids, nil
+}
+
+// parseParentPID parses the parent PID from the content of /proc/[pid]/stat.
+// The content starts with "pid (comm) state ppid". The comm can include spaces and parentheses, so the fields
+// are counted from the last closing parenthesis.
+func parseParentPID(stat []byte) (int, error) {
+	idx := bytes.LastIndexByte(stat, ')')
+	if idx == -1 {
+		return 0, fmt.Errorf("invalid stat %q", stat)
+	}
+
+	fields := bytes.Fields(stat[idx+1:])
+	if len(fields) < 2 {
+		return 0, fmt.Errorf("invalid stat %q", stat)
+	}
+
+	ppid, err := strconv.Atoi(string(fields[1]))
+	if err != nil {
+		return 0, fmt.Errorf("invalid parent pid in stat %q: %w", stat, err)
+	}
+
+	return ppid, nil
+}
@@ -0,0 +1,84 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestProcFSProcessCheckerGetWorkerPIDs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	procFolder := t.TempDir()
+
+	writeStat := func(pid, content string) {
+		g.Expect(os.Mkdir(filepath.Join(procFolder, pid), 0o750)).To(Succeed())
+		g.Expect(os.WriteFile(filepath.Join(procFolder, pid, "stat"), []byte(content), 0o600)).To(Succeed())
+	}
+
+	writeStat("1", "1 (nginx) S 0 1 1 0 -1")
+	writeStat("7", "7 (nginx) S 1 1 1 0 -1")
+	writeStat("8", "8 (nginx) S 1 1 1 0 -1")
+	writeStat("9", "9 (gateway) S 0 9 9 0 -1")
+	g.Expect(os.Mkdir(filepath.Join(procFolder, "self"), 0o750)).To(Succeed())
+
+	checker := &ProcFSProcessChecker{procFolder: procFolder}
+
+	pids, err := checker.GetWorkerPIDs(1)
+
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pids).To(ConsistOf(7, 8))
+}
+
+func TestParseParentPID(t *testing.T) {
+	tests := []struct {
+		name        string
+		stat        string
+		expected    int
+		expectError bool
+	}{
+		{
+			name:     "normal",
+			stat:     "7 (nginx) S 1 1 1 0 -1",
+			expected: 1,
+		},
+		{
+			name:     "command with spaces and parentheses",
+			stat:     "7 (nginx: worker (x) process) S 1 1 1 0 -1",
+			expected: 1,
+		},
+		{
+			name:        "no command",
+			stat:        "7 S 1",
+			expectError: true,
+		},
+		{
+			name:        "missing parent pid",
+			stat:        "7 (nginx) S",
+			expectError: true,
+		},
+		{
+			name:        "invalid parent pid",
+			stat:        "7 (nginx) S x",
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			ppid, err := parseParentPID([]byte(test.stat))
+
+			if test.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ppid).To(Equal(test.expected))
+		})
+	}
+}
@@ -9,6 +9,17 @@ import (
 )
 
 type FakeManager struct {
+	QuitStub        func(context.Context) error
+	quitMutex       sync.RWMutex
+	quitArgsForCall []struct {
+		arg1 context.Context
+	}
+	quitReturns struct {
+		result1 error
+	}
+	quitReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ReloadStub        func(context.Context) error
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct {
@@ -24,6 +35,67 @@ type FakeManager struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeManager) Quit(arg1 context.Context) error {
+	fake.quitMutex.Lock()
+	ret, specificReturn := fake.quitReturnsOnCall[len(fake.quitArgsForCall)]
+	fake.quitArgsForCall = append(fake.quitArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.QuitStub
+	fakeReturns := fake.quitReturns
+	fake.recordInvocation("Quit", []interface{}{arg1})
+	fake.quitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeManager) QuitCallCount() int {
+	fake.quitMutex.RLock()
+	defer fake.quitMutex.RUnlock()
+	return len(fake.quitArgsForCall)
+}
+
+func (fake *FakeManager) QuitCalls(stub func(context.Context) error) {
+	fake.quitMutex.Lock()
+	defer fake.quitMutex.Unlock()
+	fake.QuitStub = stub
+}
+
+func (fake *FakeManager) QuitArgsForCall(i int) context.Context {
+	fake.quitMutex.RLock()
+	defer fake.quitMutex.RUnlock()
+	argsForCall := fake.quitArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeManager) QuitReturns(result1 error) {
+	fake.quitMutex.Lock()
+	defer fake.quitMutex.Unlock()
+	fake.QuitStub = nil
+	fake.quitReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeManager) QuitReturnsOnCall(i int, result1 error) {
+	fake.quitMutex.Lock()
+	defer fake.quitMutex.Unlock()
+	fake.QuitStub = nil
+	if fake.quitReturnsOnCall == nil {
+		fake.quitReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.quitReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeManager) Reload(arg1 context.Context) error {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -88,6 +160,8 @@ func (fake *FakeManager) ReloadReturnsOnCall(i int, result1 error) {
 func (fake *FakeManager) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.quitMutex.RLock()
+	defer fake.quitMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,66 @@
+package static
+
+import (
+	"encoding/hex"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/status"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+)
+
+// configSnapshot is the state of the control plane after it handled an event batch that changed the
+// configuration. The admin server exports it, so that tools like GitOps controllers can compare the state of
+// the cluster with the desired state.
+type configSnapshot struct {
+	// GatewayClassStatuses holds the statuses of GatewayClasses where the key is the name of a GatewayClass.
+	GatewayClassStatuses map[string]status.GatewayClassStatus
+	// GatewayStatuses holds the statuses of Gateways where the key is the namespaced name of a Gateway.
+	GatewayStatuses map[string]status.GatewayStatus
+	// HTTPRouteStatuses holds the statuses of HTTPRoutes where the key is the namespaced name of an HTTPRoute.
+	HTTPRouteStatuses map[string]status.HTTPRouteStatus
+	// ConfigHash is the hex-encoded hash of the Configuration.
+	ConfigHash string
+	// Configuration is the dataplane configuration. The private keys of the SSL key pairs are removed.
+	Configuration dataplane.Configuration
+	// Applied indicates whether the Configuration was successfully applied to NGINX.
+	Applied bool
+}
+
+func newConfigSnapshot(
+	conf dataplane.Configuration,
+	confHash [32]byte,
+	statuses status.Statuses,
+	applied bool,
+) *configSnapshot {
+	snapshot := &configSnapshot{
+		GatewayClassStatuses: make(map[string]status.GatewayClassStatus, len(statuses.GatewayClassStatuses)),
+		GatewayStatuses:      make(map[string]status.GatewayStatus, len(statuses.GatewayStatuses)),
+		HTTPRouteStatuses:    make(map[string]status.HTTPRouteStatus, len(statuses.HTTPRouteStatuses)),
+		ConfigHash:           hex.EncodeToString(confHash[:]),
+		Configuration:        conf,
+		Applied:              applied,
+	}
+
+	// The keys are converted to strings, because JSON doesn't support struct keys.
+	for nsname, s := range statuses.GatewayClassStatuses {
+		snapshot.GatewayClassStatuses[nsname.Name] = s
+	}
+	for nsname, s := range statuses.GatewayStatuses {
+		snapshot.GatewayStatuses[nsname.String()] = s
+	}
+	for nsname, s := range statuses.HTTPRouteStatuses {
+		snapshot.HTTPRouteStatuses[nsname.String()] = s
+	}
+
+	if len(conf.SSLKeyPairs) > 0 {
+		pairs := make(map[dataplane.SSLKeyPairID]dataplane.SSLKeyPair, len(conf.SSLKeyPairs))
+		for id, pair := range conf.SSLKeyPairs {
+			// Never export the private keys.
+			pair.Key = nil
+			pairs[id] = pair
+		}
+
+		snapshot.Configuration.SSLKeyPairs = pairs
+	}
+
+	return snapshot
+}
@@ -0,0 +1,69 @@
+package static
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/status"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/dataplane"
+)
+
+func TestNewConfigSnapshot(t *testing.T) {
+	conf := dataplane.Configuration{
+		SSLKeyPairs: map[dataplane.SSLKeyPairID]dataplane.SSLKeyPair{
+			"ssl_keypair_test_secret": {
+				Cert:   []byte("cert"),
+				Key:    []byte("key"),
+				CACert: []byte("ca"),
+			},
+		},
+		Upstreams: []dataplane.Upstream{{Name: "test_svc_80"}},
+	}
+
+	statuses := status.Statuses{
+		GatewayClassStatuses: status.GatewayClassStatuses{
+			{Name: "nginx"}: {ObservedGeneration: 1},
+		},
+		GatewayStatuses: status.GatewayStatuses{
+			{Namespace: "test", Name: "gateway"}: {ObservedGeneration: 2},
+		},
+		HTTPRouteStatuses: status.HTTPRouteStatuses{
+			{Namespace: "test", Name: "route"}: {ObservedGeneration: 3},
+		},
+	}
+
+	confHash := [32]byte{0xab, 0xcd}
+
+	expected := &configSnapshot{
+		GatewayClassStatuses: map[string]status.GatewayClassStatus{
+			"nginx": {ObservedGeneration: 1},
+		},
+		GatewayStatuses: map[string]status.GatewayStatus{
+			"test/gateway": {ObservedGeneration: 2},
+		},
+		HTTPRouteStatuses: map[string]status.HTTPRouteStatus{
+			"test/route": {ObservedGeneration: 3},
+		},
+		ConfigHash: "abcd000000000000000000000000000000000000000000000000000000000000",
+		Configuration: dataplane.Configuration{
+			SSLKeyPairs: map[dataplane.SSLKeyPairID]dataplane.SSLKeyPair{
+				"ssl_keypair_test_secret": {
+					Cert:   []byte("cert"),
+					CACert: []byte("ca"),
+				},
+			},
+			Upstreams: []dataplane.Upstream{{Name: "test_svc_80"}},
+		},
+		Applied: true,
+	}
+
+	g := NewGomegaWithT(t)
+
+	result := newConfigSnapshot(conf, confHash, statuses, true /* applied */)
+	g.Expect(helpers.Diff(expected, result)).To(BeEmpty())
+
+	// The configuration of the handler must keep the private key.
+	g.Expect(conf.SSLKeyPairs["ssl_keypair_test_secret"].Key).To(Equal([]byte("key")))
+}
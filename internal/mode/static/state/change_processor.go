@@ -63,6 +63,8 @@ type ChangeProcessorConfig struct {
 	GatewayCtlrName string
 	// GatewayClassName is the name of the GatewayClass resource.
 	GatewayClassName string
+	// EnableSnippets allows the annotations that add NGINX configuration snippets.
+	EnableSnippets bool
 }
 
 // ChangeProcessorImpl is an implementation of ChangeProcessor.
@@ -76,7 +78,9 @@ type ChangeProcessorImpl struct {
 	// getAndResetClusterStateChanged tells if the cluster state has changed.
 	getAndResetClusterStateChanged func() bool
 
-	cfg  ChangeProcessorConfig
+	cfg ChangeProcessorConfig
+	// lock serializes the changes to the clusterState with building the graph from it, so that the maps of
+	// the clusterState are never read and written concurrently.
 	lock sync.Mutex
 }
 
@@ -90,6 +94,7 @@ func NewChangeProcessorImpl(cfg ChangeProcessorConfig) *ChangeProcessorImpl {
 		Namespaces:      make(map[types.NamespacedName]*apiv1.Namespace),
 		ReferenceGrants: make(map[types.NamespacedName]*v1beta1.ReferenceGrant),
 		Secrets:         make(map[types.NamespacedName]*apiv1.Secret),
+		ConfigMaps:      make(map[types.NamespacedName]*apiv1.ConfigMap),
 	}
 
 	extractGVK := func(obj client.Object) schema.GroupVersionKind {
@@ -154,6 +159,13 @@ func NewChangeProcessorImpl(cfg ChangeProcessorConfig) *ChangeProcessorImpl {
 				store:             newObjectStoreMapAdapter(clusterStore.Secrets),
 				trackUpsertDelete: false,
 			},
+			{
+				// NKG only watches the ConfigMap with the NGINX configuration snippets, so every change to
+				// a ConfigMap changes the NGINX configuration.
+				gvk:               extractGVK(&apiv1.ConfigMap{}),
+				store:             newObjectStoreMapAdapter(clusterStore.ConfigMaps),
+				trackUpsertDelete: true,
+			},
 		},
 	)
 
@@ -227,6 +239,7 @@ func (c *ChangeProcessorImpl) Process() (bool, *graph.Graph) {
 		c.cfg.GatewayCtlrName,
 		c.cfg.GatewayClassName,
 		c.cfg.Validators,
+		c.cfg.EnableSnippets,
 	)
 
 	return true, c.latestGraph
@@ -1,6 +1,9 @@
 package state_test
 
 import (
+	"fmt"
+	"sync"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/format"
@@ -129,6 +132,15 @@ func createGatewayWithTLSListener(name string, tlsSecret *apiv1.Secret) *v1beta1
 	return gw
 }
 
+// setNoMatchingParent sets the attachments of the route's ParentRefs to reference a Gateway that doesn't exist.
+func setNoMatchingParent(route *graph.Route) {
+	for i := range route.ParentRefs {
+		route.ParentRefs[i].Attachment = &graph.ParentRefAttachmentStatus{
+			FailedCondition: staticConds.NewRouteNoMatchingParent(),
+		}
+	}
+}
+
 func createRouteWithMultipleRules(
 	name, gateway, hostname string,
 	rules []v1beta1.HTTPRouteRule,
@@ -771,7 +783,8 @@ var _ = Describe("ChangeProcessor", func() {
 					delete(expGraph.Gateway.Listeners["listener-443-1"].Routes, hr1Name)
 					expGraph.Gateway.Listeners["listener-80-1"].Routes[hr2Name] = expRouteHR2
 					expGraph.Gateway.Listeners["listener-443-1"].Routes[hr2Name] = expRouteHR2
-					delete(expGraph.Routes, hr1Name)
+					// route 1 references the deleted gateway 1
+					setNoMatchingParent(expGraph.Routes[hr1Name])
 					expGraph.Routes[hr2Name] = expRouteHR2
 					sameNsTLSSecretRef := helpers.GetPointer(client.ObjectKeyFromObject(sameNsTLSSecret))
 					expGraph.Gateway.Listeners["listener-443-1"].ResolvedSecret = sameNsTLSSecretRef
@@ -792,13 +805,13 @@ var _ = Describe("ChangeProcessor", func() {
 					)
 
 					// gateway 2 still in charge;
-					// no routes remain
+					// only route 1, which references the deleted gateway 1, remains
 					expGraph.Gateway.Source = gw2
 					expGraph.Gateway.Listeners["listener-80-1"].Source = gw2.Spec.Listeners[0]
 					expGraph.Gateway.Listeners["listener-443-1"].Source = gw2.Spec.Listeners[1]
 					delete(expGraph.Gateway.Listeners["listener-80-1"].Routes, hr1Name)
 					delete(expGraph.Gateway.Listeners["listener-443-1"].Routes, hr1Name)
-					expGraph.Routes = map[types.NamespacedName]*graph.Route{}
+					setNoMatchingParent(expGraph.Routes[hr1Name])
 					sameNsTLSSecretRef := helpers.GetPointer(client.ObjectKeyFromObject(sameNsTLSSecret))
 					expGraph.Gateway.Listeners["listener-443-1"].ResolvedSecret = sameNsTLSSecretRef
 					expGraph.ReferencedSecrets[client.ObjectKeyFromObject(sameNsTLSSecret)] = &graph.Secret{
@@ -822,7 +835,7 @@ var _ = Describe("ChangeProcessor", func() {
 						Source:     gw2,
 						Conditions: staticConds.NewGatewayInvalid("GatewayClass doesn't exist"),
 					}
-					expGraph.Routes = map[types.NamespacedName]*graph.Route{}
+					setNoMatchingParent(expGraph.Routes[hr1Name])
 					expGraph.ReferencedSecrets = nil
 
 					changed, graphCfg := processor.Process()
@@ -1187,6 +1200,55 @@ var _ = Describe("ChangeProcessor", func() {
 				})
 			})
 		})
+		Describe("NGINX ConfigMap changes", Ordered, func() {
+			var configMap *apiv1.ConfigMap
+
+			BeforeAll(func() {
+				configMap = &apiv1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "nginx-gateway",
+						Name:      "nginx-config",
+					},
+					Data: map[string]string{
+						"http-snippet": "gzip on;",
+					},
+				}
+			})
+
+			When("the ConfigMap is upserted", func() {
+				It("returns the graph with the snippet", func() {
+					processor.CaptureUpsertChange(configMap)
+
+					changed, graphCfg := processor.Process()
+					Expect(changed).To(BeTrue())
+					Expect(graphCfg.HTTPSnippet).To(Equal("gzip on;"))
+				})
+			})
+
+			When("the ConfigMap is updated", func() {
+				It("returns the graph with the updated snippet", func() {
+					updated := configMap.DeepCopy()
+					updated.Data["http-snippet"] = "gzip off;"
+
+					processor.CaptureUpsertChange(updated)
+
+					changed, graphCfg := processor.Process()
+					Expect(changed).To(BeTrue())
+					Expect(graphCfg.HTTPSnippet).To(Equal("gzip off;"))
+				})
+			})
+
+			When("the ConfigMap is deleted", func() {
+				It("returns the graph without the snippet", func() {
+					processor.CaptureDeleteChange(&apiv1.ConfigMap{}, client.ObjectKeyFromObject(configMap))
+
+					changed, graphCfg := processor.Process()
+					Expect(changed).To(BeTrue())
+					Expect(graphCfg.HTTPSnippet).To(BeEmpty())
+				})
+			})
+		})
+
 		Describe("namespace changes", func() {
 			When("namespace is linked via label selectors", func() {
 				It("triggers an update when labels are removed", func() {
@@ -1909,6 +1971,120 @@ var _ = Describe("ChangeProcessor", func() {
 		})
 	})
 
+	Describe("Concurrent changes", func() {
+		It("should process changes captured concurrently with building the graph", func() {
+			processor := state.NewChangeProcessorImpl(state.ChangeProcessorConfig{
+				GatewayCtlrName:      controllerName,
+				GatewayClassName:     gcName,
+				RelationshipCapturer: relationship.NewCapturerImpl(),
+				Logger:               zap.New(),
+				Validators:           createAlwaysValidValidators(),
+				Scheme:               createScheme(),
+			})
+
+			const (
+				writers    = 5
+				iterations = 100
+			)
+
+			var wg sync.WaitGroup
+
+			for i := 0; i < writers; i++ {
+				wg.Add(1)
+
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					gc := &v1beta1.GatewayClass{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: fmt.Sprintf("gc-%d", i),
+						},
+						Spec: v1beta1.GatewayClassSpec{
+							ControllerName: controllerName,
+						},
+					}
+
+					for j := 0; j < iterations; j++ {
+						gc.Generation = int64(j + 1)
+						processor.CaptureUpsertChange(gc.DeepCopy())
+						processor.CaptureDeleteChange(&v1beta1.GatewayClass{}, client.ObjectKeyFromObject(gc))
+					}
+				}(i)
+			}
+
+			wg.Add(1)
+
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				for j := 0; j < writers*iterations; j++ {
+					processor.Process()
+				}
+			}()
+
+			wg.Wait()
+
+			processor.CaptureUpsertChange(&v1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       gcName,
+					Generation: 1,
+				},
+				Spec: v1beta1.GatewayClassSpec{
+					ControllerName: controllerName,
+				},
+			})
+
+			changed, g := processor.Process()
+			Expect(changed).To(BeTrue())
+			Expect(g.GatewayClass).ToNot(BeNil())
+			Expect(g.IgnoredGatewayClasses).To(BeEmpty())
+		})
+	})
+
+	Describe("Annotation changes", func() {
+		It("should report a change of the client max body size annotation without a generation change", func() {
+			processor := state.NewChangeProcessorImpl(state.ChangeProcessorConfig{
+				GatewayCtlrName:      controllerName,
+				GatewayClassName:     gcName,
+				RelationshipCapturer: relationship.NewCapturerImpl(),
+				Logger:               zap.New(),
+				Validators:           createAlwaysValidValidators(),
+				Scheme:               createScheme(),
+			})
+
+			hr := &v1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:  "test",
+					Name:       "hr",
+					Generation: 1,
+				},
+			}
+
+			processor.CaptureUpsertChange(hr)
+
+			changed, _ := processor.Process()
+			Expect(changed).To(BeTrue())
+
+			hrWithAnnotation := hr.DeepCopy()
+			hrWithAnnotation.Annotations = map[string]string{graph.ClientMaxBodySizeAnnotation: "10M"}
+
+			processor.CaptureUpsertChange(hrWithAnnotation)
+
+			changed, _ = processor.Process()
+			Expect(changed).To(BeTrue())
+
+			hrWithOtherAnnotation := hrWithAnnotation.DeepCopy()
+			hrWithOtherAnnotation.Annotations["other"] = "value"
+
+			processor.CaptureUpsertChange(hrWithOtherAnnotation)
+
+			changed, _ = processor.Process()
+			Expect(changed).To(BeFalse())
+		})
+	})
+
 	Describe("Edge cases with panic", func() {
 		var (
 			processor                state.ChangeProcessor
@@ -2,6 +2,7 @@ package conditions
 
 import (
 	"fmt"
+	"regexp"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
@@ -23,6 +24,10 @@ const (
 	// Route rules has a backendRef with an unsupported value.
 	RouteReasonBackendRefUnsupportedValue = "UnsupportedValue"
 
+	// RouteReasonBackendRefPortRequired is used with the "ResolvedRefs" condition when one of the
+	// Route rules has a backendRef without a port to a Service with multiple ports.
+	RouteReasonBackendRefPortRequired v1beta1.RouteConditionReason = "BackendRefPortRequired"
+
 	// RouteReasonInvalidGateway is used with the "Accepted" (false) condition when the Gateway the Route
 	// references is invalid.
 	RouteReasonInvalidGateway = "InvalidGateway"
@@ -58,6 +63,53 @@ const (
 		"is programmed again"
 )
 
+// The codes of the conditions that report a problem. The message of such a condition starts with its code.
+// Each condition constructor has its own code, which never changes, so that automation can match a problem by the code
+// without parsing the rest of the message. The code can't be part of the reason, because different problems can share
+// the same reason, and the reason of a Kubernetes condition doesn't allow the '-' character.
+const (
+	RouteNotAllowedByListenersCode          = "NKGE-1001"
+	RouteNoMatchingListenerHostnameCode     = "NKGE-1002"
+	RouteUnsupportedValueCode               = "NKGE-1003"
+	RouteInvalidListenerCode                = "NKGE-1004"
+	RouteBackendRefInvalidKindCode          = "NKGE-1005"
+	RouteBackendRefRefNotPermittedCode      = "NKGE-1006"
+	RouteBackendRefBackendNotFoundCode      = "NKGE-1007"
+	RouteBackendRefPortRequiredCode         = "NKGE-1008"
+	RouteInvalidGatewayCode                 = "NKGE-1009"
+	RouteNoMatchingParentCode               = "NKGE-1010"
+	RouteGatewayNotProgrammedCode           = "NKGE-1011"
+	ListenerNotProgrammedInvalidCode        = "NKGE-1012"
+	ListenerInvalidCertificateRefCode       = "NKGE-1013"
+	ListenerInvalidRouteKindsCode           = "NKGE-1014"
+	ListenerProtocolConflictCode            = "NKGE-1015"
+	ListenerUnsupportedProtocolCode         = "NKGE-1016"
+	GatewayClassInvalidParametersCode       = "NKGE-1017"
+	GatewayConflictCode                     = "NKGE-1018"
+	GatewayAcceptedListenersNotValidCode    = "NKGE-1019"
+	RouteBackendRefUnsupportedValueCode     = "NKGE-1020"
+	ListenerUnsupportedValueCode            = "NKGE-1021"
+	ListenerRefNotPermittedCode             = "NKGE-1022"
+	GatewayNotAcceptedListenersNotValidCode = "NKGE-1023"
+	GatewayInvalidCode                      = "NKGE-1024"
+	GatewayUnsupportedValueCode             = "NKGE-1025"
+	GatewayNotProgrammedInvalidCode         = "NKGE-1026"
+	GatewayConflictNotProgrammedCode        = "NKGE-1027"
+)
+
+// codePattern matches the code at the beginning of a condition message.
+var codePattern = regexp.MustCompile(`^NKGE-\d{4}: `)
+
+// withCode prepends the code to the message.
+func withCode(code, msg string) string {
+	return code + ": " + msg
+}
+
+// TrimCode returns the message of a condition without its code.
+func TrimCode(msg string) string {
+	return codePattern.ReplaceAllString(msg, "")
+}
+
 // DeduplicateConditions removes duplicate conditions based on the condition type.
 // The last condition wins. The order of conditions is preserved.
 func DeduplicateConditions(conds []conditions.Condition) []conditions.Condition {
@@ -115,7 +167,7 @@ func NewRouteNotAllowedByListeners() conditions.Condition {
 		Type:    string(v1beta1.RouteConditionAccepted),
 		Status:  metav1.ConditionFalse,
 		Reason:  string(v1beta1.RouteReasonNotAllowedByListeners),
-		Message: "HTTPRoute is not allowed by any listener",
+		Message: withCode(RouteNotAllowedByListenersCode, "HTTPRoute is not allowed by any listener"),
 	}
 }
 
@@ -126,7 +178,7 @@ func NewRouteNoMatchingListenerHostname() conditions.Condition {
 		Type:    string(v1beta1.RouteConditionAccepted),
 		Status:  metav1.ConditionFalse,
 		Reason:  string(v1beta1.RouteReasonNoMatchingListenerHostname),
-		Message: "Listener hostname does not match the HTTPRoute hostnames",
+		Message: withCode(RouteNoMatchingListenerHostnameCode, "Listener hostname does not match the HTTPRoute hostnames"),
 	}
 }
 
@@ -146,7 +198,7 @@ func NewRouteUnsupportedValue(msg string) conditions.Condition {
 		Type:    string(v1beta1.RouteConditionAccepted),
 		Status:  metav1.ConditionFalse,
 		Reason:  string(v1beta1.RouteReasonUnsupportedValue),
-		Message: msg,
+		Message: withCode(RouteUnsupportedValueCode, msg),
 	}
 }
 
@@ -157,7 +209,7 @@ func NewRouteInvalidListener() conditions.Condition {
 		Type:    string(v1beta1.RouteConditionAccepted),
 		Status:  metav1.ConditionFalse,
 		Reason:  string(RouteReasonInvalidListener),
-		Message: "Listener is invalid for this parent ref",
+		Message: withCode(RouteInvalidListenerCode, "Listener is invalid for this parent ref"),
 	}
 }
 
@@ -178,7 +230,7 @@ func NewRouteBackendRefInvalidKind(msg string) conditions.Condition {
 		Type:    string(v1beta1.RouteConditionResolvedRefs),
 		Status:  metav1.ConditionFalse,
 		Reason:  string(v1beta1.RouteReasonInvalidKind),
-		Message: msg,
+		Message: withCode(RouteBackendRefInvalidKindCode, msg),
 	}
 }
 
@@ -189,7 +241,7 @@ func NewRouteBackendRefRefNotPermitted(msg string) conditions.Condition {
 		Type:    string(v1beta1.RouteConditionResolvedRefs),
 		Status:  metav1.ConditionFalse,
 		Reason:  string(v1beta1.RouteReasonRefNotPermitted),
-		Message: msg,
+		Message: withCode(RouteBackendRefRefNotPermittedCode, msg),
 	}
 }
 
@@ -200,7 +252,7 @@ func NewRouteBackendRefRefBackendNotFound(msg string) conditions.Condition {
 		Type:    string(v1beta1.RouteConditionResolvedRefs),
 		Status:  metav1.ConditionFalse,
 		Reason:  string(v1beta1.RouteReasonBackendNotFound),
-		Message: msg,
+		Message: withCode(RouteBackendRefBackendNotFoundCode, msg),
 	}
 }
 
@@ -211,7 +263,18 @@ func NewRouteBackendRefUnsupportedValue(msg string) conditions.Condition {
 		Type:    string(v1beta1.RouteConditionResolvedRefs),
 		Status:  metav1.ConditionFalse,
 		Reason:  RouteReasonBackendRefUnsupportedValue,
-		Message: msg,
+		Message: withCode(RouteBackendRefUnsupportedValueCode, msg),
+	}
+}
+
+// NewRouteBackendRefPortRequired returns a Condition that indicates that the Route has a backendRef without a port
+// to a Service with multiple ports.
+func NewRouteBackendRefPortRequired(msg string) conditions.Condition {
+	return conditions.Condition{
+		Type:    string(v1beta1.RouteConditionResolvedRefs),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(RouteReasonBackendRefPortRequired),
+		Message: withCode(RouteBackendRefPortRequiredCode, msg),
 	}
 }
 
@@ -222,7 +285,7 @@ func NewRouteInvalidGateway() conditions.Condition {
 		Type:    string(v1beta1.RouteConditionAccepted),
 		Status:  metav1.ConditionFalse,
 		Reason:  RouteReasonInvalidGateway,
-		Message: "Gateway is invalid",
+		Message: withCode(RouteInvalidGatewayCode, "Gateway is invalid"),
 	}
 }
 
@@ -233,7 +296,7 @@ func NewRouteNoMatchingParent() conditions.Condition {
 		Type:    string(v1beta1.RouteConditionAccepted),
 		Status:  metav1.ConditionFalse,
 		Reason:  string(v1beta1.RouteReasonNoMatchingParent),
-		Message: "Listener is not found for this parent ref",
+		Message: withCode(RouteNoMatchingParentCode, "Listener is not found for this parent ref"),
 	}
 }
 
@@ -244,7 +307,7 @@ func NewRouteGatewayNotProgrammed(msg string) conditions.Condition {
 		Type:    string(v1beta1.RouteConditionAccepted),
 		Status:  metav1.ConditionFalse,
 		Reason:  string(RouteReasonGatewayNotProgrammed),
-		Message: msg,
+		Message: withCode(RouteGatewayNotProgrammedCode, msg),
 	}
 }
 
@@ -268,6 +331,17 @@ func NewListenerAccepted() conditions.Condition {
 	}
 }
 
+// NewListenerAcceptedWithWarning returns a Condition that indicates that the Listener is accepted, but a part of its
+// configuration is ignored. The provided message contains the details of the ignored configuration.
+func NewListenerAcceptedWithWarning(msg string) conditions.Condition {
+	return conditions.Condition{
+		Type:    string(v1beta1.ListenerConditionAccepted),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(v1beta1.ListenerReasonAccepted),
+		Message: "Listener is accepted with a warning: " + msg,
+	}
+}
+
 // NewListenerProgrammed returns a Condition that indicates the Listener is programmed.
 func NewListenerProgrammed() conditions.Condition {
 	return conditions.Condition{
@@ -305,7 +379,7 @@ func NewListenerNotProgrammedInvalid(msg string) conditions.Condition {
 		Type:    string(v1beta1.ListenerConditionProgrammed),
 		Status:  metav1.ConditionFalse,
 		Reason:  string(v1beta1.ListenerReasonInvalid),
-		Message: msg,
+		Message: withCode(ListenerNotProgrammedInvalidCode, msg),
 	}
 }
 
@@ -317,7 +391,7 @@ func NewListenerUnsupportedValue(msg string) []conditions.Condition {
 			Type:    string(v1beta1.ListenerConditionAccepted),
 			Status:  metav1.ConditionFalse,
 			Reason:  string(ListenerReasonUnsupportedValue),
-			Message: msg,
+			Message: withCode(ListenerUnsupportedValueCode, msg),
 		},
 		NewListenerNotProgrammedInvalid(msg),
 	}
@@ -330,13 +404,13 @@ func NewListenerInvalidCertificateRef(msg string) []conditions.Condition {
 			Type:    string(v1beta1.ListenerConditionAccepted),
 			Status:  metav1.ConditionFalse,
 			Reason:  string(v1beta1.ListenerReasonInvalidCertificateRef),
-			Message: msg,
+			Message: withCode(ListenerInvalidCertificateRefCode, msg),
 		},
 		{
 			Type:    string(v1beta1.ListenerReasonResolvedRefs),
 			Status:  metav1.ConditionFalse,
 			Reason:  string(v1beta1.ListenerReasonInvalidCertificateRef),
-			Message: msg,
+			Message: withCode(ListenerInvalidCertificateRefCode, msg),
 		},
 		NewListenerNotProgrammedInvalid(msg),
 	}
@@ -350,7 +424,7 @@ func NewListenerInvalidRouteKinds(msg string) []conditions.Condition {
 			Type:    string(v1beta1.ListenerReasonResolvedRefs),
 			Status:  metav1.ConditionFalse,
 			Reason:  string(v1beta1.ListenerReasonInvalidRouteKinds),
-			Message: msg,
+			Message: withCode(ListenerInvalidRouteKindsCode, msg),
 		},
 		NewListenerNotProgrammedInvalid(msg),
 	}
@@ -364,13 +438,13 @@ func NewListenerProtocolConflict(msg string) []conditions.Condition {
 			Type:    string(v1beta1.ListenerConditionAccepted),
 			Status:  metav1.ConditionFalse,
 			Reason:  string(v1beta1.ListenerReasonProtocolConflict),
-			Message: msg,
+			Message: withCode(ListenerProtocolConflictCode, msg),
 		},
 		{
 			Type:    string(v1beta1.ListenerConditionConflicted),
 			Status:  metav1.ConditionTrue,
 			Reason:  string(v1beta1.ListenerReasonProtocolConflict),
-			Message: msg,
+			Message: withCode(ListenerProtocolConflictCode, msg),
 		},
 		NewListenerNotProgrammedInvalid(msg),
 	}
@@ -383,7 +457,7 @@ func NewListenerUnsupportedProtocol(msg string) []conditions.Condition {
 			Type:    string(v1beta1.ListenerConditionAccepted),
 			Status:  metav1.ConditionFalse,
 			Reason:  string(v1beta1.ListenerReasonUnsupportedProtocol),
-			Message: msg,
+			Message: withCode(ListenerUnsupportedProtocolCode, msg),
 		},
 		NewListenerNotProgrammedInvalid(msg),
 	}
@@ -397,13 +471,13 @@ func NewListenerRefNotPermitted(msg string) []conditions.Condition {
 			Type:    string(v1beta1.ListenerConditionAccepted),
 			Status:  metav1.ConditionFalse,
 			Reason:  string(v1beta1.ListenerReasonRefNotPermitted),
-			Message: msg,
+			Message: withCode(ListenerRefNotPermittedCode, msg),
 		},
 		{
 			Type:    string(v1beta1.ListenerReasonResolvedRefs),
 			Status:  metav1.ConditionFalse,
 			Reason:  string(v1beta1.ListenerReasonRefNotPermitted),
-			Message: msg,
+			Message: withCode(ListenerRefNotPermittedCode, msg),
 		},
 		NewListenerNotProgrammedInvalid(msg),
 	}
@@ -415,7 +489,7 @@ func NewGatewayClassInvalidParameters(msg string) conditions.Condition {
 		Type:    string(v1beta1.GatewayClassConditionStatusAccepted),
 		Status:  metav1.ConditionFalse,
 		Reason:  string(v1beta1.GatewayClassReasonInvalidParameters),
-		Message: msg,
+		Message: withCode(GatewayClassInvalidParametersCode, msg),
 	}
 }
 
@@ -444,7 +518,7 @@ func NewGatewayConflict() []conditions.Condition {
 			Type:    string(v1beta1.GatewayConditionAccepted),
 			Status:  metav1.ConditionFalse,
 			Reason:  string(GatewayReasonGatewayConflict),
-			Message: GatewayMessageGatewayConflict,
+			Message: withCode(GatewayConflictCode, GatewayMessageGatewayConflict),
 		},
 		NewGatewayConflictNotProgrammed(),
 	}
@@ -457,7 +531,7 @@ func NewGatewayAcceptedListenersNotValid() conditions.Condition {
 		Type:    string(v1beta1.GatewayConditionAccepted),
 		Status:  metav1.ConditionTrue,
 		Reason:  string(v1beta1.GatewayReasonListenersNotValid),
-		Message: "Gateway has at least one valid listener",
+		Message: withCode(GatewayAcceptedListenersNotValidCode, "Gateway has at least one valid listener"),
 	}
 }
 
@@ -470,7 +544,7 @@ func NewGatewayNotAcceptedListenersNotValid() []conditions.Condition {
 			Type:    string(v1beta1.GatewayConditionAccepted),
 			Status:  metav1.ConditionFalse,
 			Reason:  string(v1beta1.GatewayReasonListenersNotValid),
-			Message: msg,
+			Message: withCode(GatewayNotAcceptedListenersNotValidCode, msg),
 		},
 		NewGatewayNotProgrammedInvalid(msg),
 	}
@@ -484,7 +558,7 @@ func NewGatewayInvalid(msg string) []conditions.Condition {
 			Type:    string(v1beta1.GatewayConditionAccepted),
 			Status:  metav1.ConditionFalse,
 			Reason:  string(v1beta1.GatewayReasonInvalid),
-			Message: msg,
+			Message: withCode(GatewayInvalidCode, msg),
 		},
 		NewGatewayNotProgrammedInvalid(msg),
 	}
@@ -498,13 +572,13 @@ func NewGatewayUnsupportedValue(msg string) []conditions.Condition {
 			Type:    string(v1beta1.GatewayConditionAccepted),
 			Status:  metav1.ConditionFalse,
 			Reason:  string(GatewayReasonUnsupportedValue),
-			Message: msg,
+			Message: withCode(GatewayUnsupportedValueCode, msg),
 		},
 		{
 			Type:    string(v1beta1.GatewayConditionProgrammed),
 			Status:  metav1.ConditionFalse,
 			Reason:  string(GatewayReasonUnsupportedValue),
-			Message: msg,
+			Message: withCode(GatewayUnsupportedValueCode, msg),
 		},
 	}
 }
@@ -527,7 +601,7 @@ func NewGatewayNotProgrammedInvalid(msg string) conditions.Condition {
 		Type:    string(v1beta1.GatewayConditionProgrammed),
 		Status:  metav1.ConditionFalse,
 		Reason:  string(v1beta1.GatewayReasonInvalid),
-		Message: msg,
+		Message: withCode(GatewayNotProgrammedInvalidCode, msg),
 	}
 }
 
@@ -538,6 +612,6 @@ func NewGatewayConflictNotProgrammed() conditions.Condition {
 		Type:    string(v1beta1.GatewayConditionProgrammed),
 		Status:  metav1.ConditionFalse,
 		Reason:  string(GatewayReasonGatewayConflict),
-		Message: GatewayMessageGatewayConflict,
+		Message: withCode(GatewayConflictNotProgrammedCode, GatewayMessageGatewayConflict),
 	}
 }
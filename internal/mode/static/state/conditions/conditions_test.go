@@ -61,3 +61,194 @@ func TestDeduplicateConditions(t *testing.T) {
 	result := DeduplicateConditions(conds)
 	g.Expect(result).Should(Equal(expected))
 }
+
+func TestConditionsIncludeCode(t *testing.T) {
+	const msg = "test"
+
+	tests := []struct {
+		name  string
+		code  string
+		conds []conditions.Condition
+	}{
+		{
+			name:  "NewRouteNotAllowedByListeners",
+			code:  RouteNotAllowedByListenersCode,
+			conds: []conditions.Condition{NewRouteNotAllowedByListeners()},
+		},
+		{
+			name:  "NewRouteNoMatchingListenerHostname",
+			code:  RouteNoMatchingListenerHostnameCode,
+			conds: []conditions.Condition{NewRouteNoMatchingListenerHostname()},
+		},
+		{
+			name:  "NewRouteUnsupportedValue",
+			code:  RouteUnsupportedValueCode,
+			conds: []conditions.Condition{NewRouteUnsupportedValue(msg)},
+		},
+		{
+			name:  "NewRouteInvalidListener",
+			code:  RouteInvalidListenerCode,
+			conds: []conditions.Condition{NewRouteInvalidListener()},
+		},
+		{
+			name:  "NewRouteBackendRefInvalidKind",
+			code:  RouteBackendRefInvalidKindCode,
+			conds: []conditions.Condition{NewRouteBackendRefInvalidKind(msg)},
+		},
+		{
+			name:  "NewRouteBackendRefRefNotPermitted",
+			code:  RouteBackendRefRefNotPermittedCode,
+			conds: []conditions.Condition{NewRouteBackendRefRefNotPermitted(msg)},
+		},
+		{
+			name:  "NewRouteBackendRefRefBackendNotFound",
+			code:  RouteBackendRefBackendNotFoundCode,
+			conds: []conditions.Condition{NewRouteBackendRefRefBackendNotFound(msg)},
+		},
+		{
+			name:  "NewRouteBackendRefUnsupportedValue",
+			code:  RouteBackendRefUnsupportedValueCode,
+			conds: []conditions.Condition{NewRouteBackendRefUnsupportedValue(msg)},
+		},
+		{
+			name:  "NewRouteBackendRefPortRequired",
+			code:  RouteBackendRefPortRequiredCode,
+			conds: []conditions.Condition{NewRouteBackendRefPortRequired(msg)},
+		},
+		{
+			name:  "NewRouteInvalidGateway",
+			code:  RouteInvalidGatewayCode,
+			conds: []conditions.Condition{NewRouteInvalidGateway()},
+		},
+		{
+			name:  "NewRouteNoMatchingParent",
+			code:  RouteNoMatchingParentCode,
+			conds: []conditions.Condition{NewRouteNoMatchingParent()},
+		},
+		{
+			name:  "NewRouteGatewayNotProgrammed",
+			code:  RouteGatewayNotProgrammedCode,
+			conds: []conditions.Condition{NewRouteGatewayNotProgrammed(msg)},
+		},
+		{
+			name:  "NewListenerNotProgrammedInvalid",
+			code:  ListenerNotProgrammedInvalidCode,
+			conds: []conditions.Condition{NewListenerNotProgrammedInvalid(msg)},
+		},
+		{
+			name:  "NewListenerUnsupportedValue",
+			code:  ListenerUnsupportedValueCode,
+			conds: NewListenerUnsupportedValue(msg),
+		},
+		{
+			name:  "NewListenerInvalidCertificateRef",
+			code:  ListenerInvalidCertificateRefCode,
+			conds: NewListenerInvalidCertificateRef(msg),
+		},
+		{
+			name:  "NewListenerInvalidRouteKinds",
+			code:  ListenerInvalidRouteKindsCode,
+			conds: NewListenerInvalidRouteKinds(msg),
+		},
+		{
+			name:  "NewListenerProtocolConflict",
+			code:  ListenerProtocolConflictCode,
+			conds: NewListenerProtocolConflict(msg),
+		},
+		{
+			name:  "NewListenerUnsupportedProtocol",
+			code:  ListenerUnsupportedProtocolCode,
+			conds: NewListenerUnsupportedProtocol(msg),
+		},
+		{
+			name:  "NewListenerRefNotPermitted",
+			code:  ListenerRefNotPermittedCode,
+			conds: NewListenerRefNotPermitted(msg),
+		},
+		{
+			name:  "NewGatewayClassInvalidParameters",
+			code:  GatewayClassInvalidParametersCode,
+			conds: []conditions.Condition{NewGatewayClassInvalidParameters(msg)},
+		},
+		{
+			name:  "NewGatewayConflict",
+			code:  GatewayConflictCode,
+			conds: NewGatewayConflict(),
+		},
+		{
+			name:  "NewGatewayAcceptedListenersNotValid",
+			code:  GatewayAcceptedListenersNotValidCode,
+			conds: []conditions.Condition{NewGatewayAcceptedListenersNotValid()},
+		},
+		{
+			name:  "NewGatewayNotAcceptedListenersNotValid",
+			code:  GatewayNotAcceptedListenersNotValidCode,
+			conds: NewGatewayNotAcceptedListenersNotValid(),
+		},
+		{
+			name:  "NewGatewayInvalid",
+			code:  GatewayInvalidCode,
+			conds: NewGatewayInvalid(msg),
+		},
+		{
+			name:  "NewGatewayUnsupportedValue",
+			code:  GatewayUnsupportedValueCode,
+			conds: NewGatewayUnsupportedValue(msg),
+		},
+		{
+			name:  "NewGatewayNotProgrammedInvalid",
+			code:  GatewayNotProgrammedInvalidCode,
+			conds: []conditions.Condition{NewGatewayNotProgrammedInvalid(msg)},
+		},
+		{
+			name:  "NewGatewayConflictNotProgrammed",
+			code:  GatewayConflictNotProgrammedCode,
+			conds: []conditions.Condition{NewGatewayConflictNotProgrammed()},
+		},
+	}
+
+	constructorsByCode := make(map[string]string, len(tests))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			g.Expect(test.code).To(MatchRegexp(`^NKGE-\d{4}$`))
+			g.Expect(constructorsByCode).ToNot(HaveKey(test.code), "code %s is not unique", test.code)
+			constructorsByCode[test.code] = test.name
+
+			// The other conditions can come from the constructors that the constructor reuses, which have
+			// their own codes.
+			g.Expect(test.conds[0].Message).To(HavePrefix(test.code + ": "))
+
+			for _, cond := range test.conds {
+				g.Expect(cond.Message).To(MatchRegexp(`^NKGE-\d{4}: `))
+				g.Expect(TrimCode(cond.Message)).ToNot(ContainSubstring("NKGE-"))
+			}
+		})
+	}
+}
+
+func TestConditionsWithoutCode(t *testing.T) {
+	var conds []conditions.Condition
+
+	conds = append(conds, NewDefaultRouteConditions()...)
+	conds = append(conds, NewDefaultListenerConditions()...)
+	conds = append(conds, NewDefaultGatewayConditions()...)
+
+	for _, cond := range conds {
+		t.Run(cond.Type+"/"+cond.Reason, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			g.Expect(cond.Message).ToNot(ContainSubstring("NKGE-"))
+		})
+	}
+}
+
+func TestTrimCode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(TrimCode(NewRouteUnsupportedValue("test").Message)).To(Equal("test"))
+	g.Expect(TrimCode("test")).To(Equal("test"))
+	g.Expect(TrimCode("test NKGE-1003: test")).To(Equal("test NKGE-1003: test"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"time"
 
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -16,15 +17,18 @@ import (
 type PathType string
 
 const (
-	wildcardHostname          = "~^"
-	PathTypePrefix   PathType = "prefix"
-	PathTypeExact    PathType = "exact"
+	wildcardHostname                   = "~^"
+	PathTypePrefix            PathType = "prefix"
+	PathTypeExact             PathType = "exact"
+	PathTypeRegularExpression PathType = "regex"
 )
 
 // Configuration is an intermediate representation of dataplane configuration.
 type Configuration struct {
 	// SSLKeyPairs holds all unique SSLKeyPairs.
 	SSLKeyPairs map[SSLKeyPairID]SSLKeyPair
+	// DHParams holds all unique PEM-encoded Diffie-Hellman parameters.
+	DHParams map[DHParamsID][]byte
 	// HTTPServers holds all HTTPServers.
 	HTTPServers []VirtualServer
 	// SSLServers holds all SSLServers.
@@ -33,23 +37,66 @@ type Configuration struct {
 	Upstreams []Upstream
 	// BackendGroups holds all unique BackendGroups.
 	BackendGroups []BackendGroup
+	// TrustedProxies holds the CIDRs of the trusted proxies, from which NGINX takes the client address from the
+	// X-Forwarded-For header.
+	TrustedProxies []string
+	// DNSResolvers holds the IP addresses of the DNS servers that NGINX uses to resolve the DNS names of
+	// the backends. It is only set if at least one backend has a DNS name.
+	DNSResolvers []string
+	// HTTPSnippet is the snippet of NGINX configuration for the http context from the NGINX ConfigMap.
+	HTTPSnippet string
+	// Gzip holds the settings of the gzip compression of the responses. If nil, the responses are not compressed.
+	Gzip *Gzip
+	// OpenFileCache is the cache of the open file descriptors. If nil, the cache is disabled.
+	OpenFileCache *OpenFileCache
+	// UpstreamZoneSize is the size in bytes of the shared memory zone of each upstream.
+	// If 0, the default size applies.
+	UpstreamZoneSize int64
+	// WorkerProcesses is the number of the NGINX worker processes: a positive integer or "auto".
+	// If empty, the NGINX default applies.
+	WorkerProcesses string
+	// WorkerShutdownTimeout is the time, for which NGINX waits for the active connections to complete during
+	// a graceful shutdown. If 0, the default timeout applies.
+	WorkerShutdownTimeout time.Duration
+	// WorkerConnections is the maximum number of simultaneous connections of an NGINX worker process.
+	// If 0, the NGINX default applies.
+	WorkerConnections int32
+	// ShowServerVersion allows NGINX to show its version in the Server response header and on the error pages.
+	ShowServerVersion bool
 }
 
 // SSLKeyPairID is a unique identifier for a SSLKeyPair.
 // The ID is safe to use as a file name.
 type SSLKeyPairID string
 
+// DHParamsID is a unique identifier for Diffie-Hellman parameters.
+// The ID is safe to use as a file name.
+type DHParamsID string
+
 // SSLKeyPair is an SSL private/public key pair.
 type SSLKeyPair struct {
 	Cert, Key []byte
+	// CACert is the CA certificate, which NGINX uses to verify OCSP responses.
+	// It is only set if OCSP stapling is enabled for a server with the key pair.
+	CACert []byte
 }
 
 // VirtualServer is a virtual server.
 type VirtualServer struct {
 	// SSL holds the SSL configuration for the server.
 	SSL *SSL
+	// ClientMaxBodySize is the maximum allowed size of the client request body in bytes.
+	// If nil, the NGINX default applies.
+	ClientMaxBodySize *int64
 	// Hostname is the hostname of the server.
 	Hostname string
+	// ErrorPage is the custom error page of the server. If nil, NGINX responds with its default error pages.
+	ErrorPage *ErrorPage
+	// RequestIDHeader is the name of the header that carries the ID of the request to the backends and in the
+	// response. If empty, the header is not set.
+	RequestIDHeader string
+	// ServerSnippet holds the NGINX directives that are appended to the server block. If empty, nothing is appended.
+	ServerSnippet string
 	// PathRules is a collection of routing rules.
 	PathRules []PathRule
 	// IsDefault indicates whether the server is the default server.
@@ -58,6 +105,37 @@ type VirtualServer struct {
 	Port int32
 }
 
+// ErrorPage is a custom error page, which NGINX returns instead of its default error page for the responses with
+// the specified status codes.
+type ErrorPage struct {
+	// Body is the body of the error page.
+	Body string
+	// ContentType is the content type of the error page.
+	ContentType string
+	// StatusCodes are the status codes of the responses to replace with the error page.
+	StatusCodes []int
+}
+
+// Gzip holds the settings of the gzip compression of the responses.
+type Gzip struct {
+	// Types are the MIME types of the responses to compress in addition to text/html. "*" matches any type.
+	Types []string
+	// MinLength is the minimum length in bytes of the responses to compress.
+	MinLength int32
+	// Level is the compression level from 1 to 9.
+	Level int32
+}
+
+// OpenFileCache is the NGINX cache of the open file descriptors.
+type OpenFileCache struct {
+	// MaxFiles is the maximum number of the cached files.
+	MaxFiles int32
+	// Inactive is the time after which a file, which is not accessed, is removed from the cache.
+	Inactive time.Duration
+	// Valid is the time after which NGINX checks that a cached file is still valid.
+	Valid time.Duration
+}
+
 // Upstream is a pool of endpoints to be load balanced.
 type Upstream struct {
 	// Name is the name of the Upstream. Will be unique for each service/port combination.
@@ -70,14 +148,27 @@ type Upstream struct {
 
 // SSL is the SSL configuration for a server.
 type SSL struct {
+	// SessionCacheSize is the size in bytes of the shared cache of TLS sessions. If nil, the cache is not enabled.
+	SessionCacheSize *int64
+	// SessionTickets tells if the TLS session tickets are enabled. If nil, the NGINX default applies.
+	SessionTickets *bool
+	// KeyPairID is the ID of the SSLKeyPair of the server.
 	KeyPairID SSLKeyPairID
+	// MinTLSVersion is the minimum TLS version accepted from clients. If empty, the NGINX default applies.
+	MinTLSVersion string
+	// DHParamsID is the ID of the Diffie-Hellman parameters. If empty, the NGINX default applies.
+	DHParamsID DHParamsID
+	// Ciphers are the enabled ciphers for TLSv1.2. If empty, the NGINX default applies.
+	Ciphers []string
+	// Stapling tells if OCSP stapling is enabled. If true, the SSLKeyPair includes the CA certificate.
+	Stapling bool
 }
 
 // PathRule represents routing rules that share a common path.
 type PathRule struct {
 	// Path is a path. For example, '/hello'.
 	Path string
-	// PathType is simplified path type. For example, prefix or exact.
+	// PathType is simplified path type. For example, prefix, exact or regex.
 	PathType PathType
 	// MatchRules holds routing rules.
 	MatchRules []MatchRule
@@ -113,12 +204,37 @@ type MatchRule struct {
 	Filters Filters
 	// Source is the corresponding HTTPRoute resource.
 	Source *v1beta1.HTTPRoute
+	// ClientMaxBodySize is the maximum allowed size of the client request body in bytes.
+	// If nil, the size of the VirtualServer applies.
+	ClientMaxBodySize *int64
+	// NextUpstream holds the settings of passing a request to the next backend server.
+	// If nil, the NGINX defaults apply.
+	NextUpstream *NextUpstream
+	// BackendErrorPage is the custom error page, which replaces the error responses of the backends.
+	// If nil, the error responses of the backends pass through.
+	BackendErrorPage *ErrorPage
+	// LocationSnippet holds the NGINX directives that are appended to the locations of the rule.
+	// If empty, nothing is appended.
+	LocationSnippet string
 	// BackendGroup is the group of Backends that the rule routes to.
 	BackendGroup BackendGroup
 	// MatchIdx is the index of the rule in the Rule.Matches.
 	MatchIdx int
 	// RuleIdx is the index of the corresponding rule in the HTTPRoute.
 	RuleIdx int
+	// StreamingProxy tells if NGINX must not buffer the responses.
+	StreamingProxy bool
+	// CaseInsensitiveHeaderValues tells if the header matches compare the header values in a case-insensitive manner.
+	CaseInsensitiveHeaderValues bool
+}
+
+// NextUpstream holds the settings of passing a request to the next backend server when the current one fails.
+type NextUpstream struct {
+	// Conditions are the conditions in which a request is passed to the next server.
+	// If empty, the NGINX default conditions apply.
+	Conditions []string
+	// Tries is the maximum number of tries to pass a request to the next server. If 0, the number is not limited.
+	Tries int32
 }
 
 // BackendGroup represents a group of Backends for a routing rule in an HTTPRoute.
@@ -144,6 +260,9 @@ func (bg *BackendGroup) Name() string {
 type Backend struct {
 	// UpstreamName is the name of the upstream for this backend.
 	UpstreamName string
+	// DNSAddress is the DNS name and port of the backend, which NGINX resolves at runtime. If set, the backend
+	// doesn't have an upstream.
+	DNSAddress string
 	// Weight is the weight of the BackendRef.
 	// The possible values of weight are 0-1,000,000.
 	// If weight is 0, no traffic should be forwarded for this entry.
@@ -154,7 +273,32 @@ type Backend struct {
 
 // GetMatch returns the HTTPRouteMatch of the Route .
 func (r *MatchRule) GetMatch() v1beta1.HTTPRouteMatch {
-	return r.Source.Spec.Rules[r.RuleIdx].Matches[r.MatchIdx]
+	return getRuleMatches(r.Source.Spec.Rules[r.RuleIdx])[r.MatchIdx]
+}
+
+// isCatchAll returns true if the MatchRule belongs to an HTTPRoute rule without matches.
+func (r *MatchRule) isCatchAll() bool {
+	return len(r.Source.Spec.Rules[r.RuleIdx].Matches) == 0
+}
+
+// getRuleMatches returns the matches of the HTTPRoute rule. If the rule doesn't have any matches, it returns
+// a catch-all match, which matches all requests to the hostnames of the HTTPRoute.
+func getRuleMatches(rule v1beta1.HTTPRouteRule) []v1beta1.HTTPRouteMatch {
+	if len(rule.Matches) > 0 {
+		return rule.Matches
+	}
+
+	pathType := v1beta1.PathMatchPathPrefix
+	pathValue := "/"
+
+	return []v1beta1.HTTPRouteMatch{
+		{
+			Path: &v1beta1.HTTPPathMatch{
+				Type:  &pathType,
+				Value: &pathValue,
+			},
+		},
+	}
 }
 
 // BuildConfiguration builds the Configuration from the Graph.
@@ -168,16 +312,32 @@ func BuildConfiguration(ctx context.Context, g *graph.Graph, resolver resolver.S
 	}
 
 	upstreams := buildUpstreams(ctx, g.Gateway.Listeners, resolver)
-	httpServers, sslServers := buildServers(g.Gateway.Listeners)
+	httpServers, sslServers := buildServers(g.Gateway)
 	backendGroups := buildBackendGroups(append(httpServers, sslServers...))
 	keyPairs := buildSSLKeyPairs(g.ReferencedSecrets, g.Gateway.Listeners)
+	dhParams := buildDHParams(g.ReferencedSecrets, g.Gateway.Listeners)
 
 	config := Configuration{
-		HTTPServers:   httpServers,
-		SSLServers:    sslServers,
-		Upstreams:     upstreams,
-		BackendGroups: backendGroups,
-		SSLKeyPairs:   keyPairs,
+		HTTPServers:           httpServers,
+		SSLServers:            sslServers,
+		Upstreams:             upstreams,
+		BackendGroups:         backendGroups,
+		SSLKeyPairs:           keyPairs,
+		DHParams:              dhParams,
+		TrustedProxies:        g.Gateway.TrustedProxies,
+		HTTPSnippet:           g.HTTPSnippet,
+		Gzip:                  buildGzip(g.Gateway.Gzip),
+		OpenFileCache:         buildOpenFileCache(g.Gateway.OpenFileCache),
+		UpstreamZoneSize:      g.Gateway.UpstreamZoneSize,
+		WorkerProcesses:       g.Gateway.WorkerProcesses,
+		WorkerConnections:     g.Gateway.WorkerConnections,
+		WorkerShutdownTimeout: g.Gateway.WorkerShutdownTimeout,
+		ShowServerVersion:     g.Gateway.ShowServerVersion,
+	}
+
+	// NGINX only needs the DNS resolvers for the backends with DNS names.
+	if hasDNSBackends(backendGroups) {
+		config.DNSResolvers = g.Gateway.DNSResolvers
 	}
 
 	return config
@@ -197,16 +357,58 @@ func buildSSLKeyPairs(
 			secret := secrets[*l.ResolvedSecret]
 			// The Data map keys are guaranteed to exist by the graph package.
 			// the Source field is guaranteed to be non-nil by the graph package.
-			keyPairs[id] = SSLKeyPair{
+			pair := SSLKeyPair{
 				Cert: secret.Source.Data[apiv1.TLSCertKey],
 				Key:  secret.Source.Data[apiv1.TLSPrivateKeyKey],
 			}
+
+			// Multiple listeners can reference the same Secret, so we keep the CA certificate if any of them
+			// enables OCSP stapling.
+			if l.TLSSettings.Stapling {
+				pair.CACert = secret.Source.Data[graph.CACertKey]
+			} else {
+				pair.CACert = keyPairs[id].CACert
+			}
+
+			keyPairs[id] = pair
 		}
 	}
 
 	return keyPairs
 }
 
+// buildDHParams builds the Diffie-Hellman parameters from the Secrets. Like buildSSLKeyPairs, it will only include
+// Secrets that are referenced by valid listeners.
+func buildDHParams(
+	secrets map[types.NamespacedName]*graph.Secret,
+	listeners map[string]*graph.Listener,
+) map[DHParamsID][]byte {
+	dhParams := make(map[DHParamsID][]byte)
+
+	for _, l := range listeners {
+		if l.Valid && l.ResolvedDHParamsSecret != nil {
+			id := generateDHParamsID(*l.ResolvedDHParamsSecret)
+			secret := secrets[*l.ResolvedDHParamsSecret]
+			// The Data map key is guaranteed to exist by the graph package.
+			dhParams[id] = secret.Source.Data[graph.DHParamsKey]
+		}
+	}
+
+	return dhParams
+}
+
+func hasDNSBackends(groups []BackendGroup) bool {
+	for _, group := range groups {
+		for _, b := range group.Backends {
+			if b.Valid && b.DNSAddress != "" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func buildBackendGroups(servers []VirtualServer) []BackendGroup {
 	type key struct {
 		nsname  types.NamespacedName
@@ -255,6 +457,7 @@ func newBackendGroup(refs []graph.BackendRef, sourceNsName types.NamespacedName,
 	for _, ref := range refs {
 		backends = append(backends, Backend{
 			UpstreamName: ref.ServicePortReference(),
+			DNSAddress:   ref.DNSAddress(),
 			Weight:       ref.Weight,
 			Valid:        ref.Valid,
 		})
@@ -267,17 +470,17 @@ func newBackendGroup(refs []graph.BackendRef, sourceNsName types.NamespacedName,
 	}
 }
 
-func buildServers(listeners map[string]*graph.Listener) (http, ssl []VirtualServer) {
+func buildServers(gateway *graph.Gateway) (http, ssl []VirtualServer) {
 	rulesForProtocol := map[v1beta1.ProtocolType]portPathRules{
 		v1beta1.HTTPProtocolType:  make(portPathRules),
 		v1beta1.HTTPSProtocolType: make(portPathRules),
 	}
 
-	for _, l := range listeners {
+	for _, l := range gateway.Listeners {
 		if l.Valid {
 			rules := rulesForProtocol[l.Source.Protocol][l.Source.Port]
 			if rules == nil {
-				rules = newHostPathRules()
+				rules = newHostPathRules(gateway)
 				rulesForProtocol[l.Source.Protocol][l.Source.Port] = rules
 			}
 
@@ -315,18 +518,26 @@ type pathAndType struct {
 }
 
 type hostPathRules struct {
-	rulesPerHost     map[string]map[pathAndType]PathRule
-	listenersForHost map[string]*graph.Listener
-	httpsListeners   []*graph.Listener
-	listenersExist   bool
-	port             int32
-}
-
-func newHostPathRules() *hostPathRules {
+	rulesPerHost      map[string]map[pathAndType]PathRule
+	listenersForHost  map[string]*graph.Listener
+	clientMaxBodySize *int64
+	errorPage         *ErrorPage
+	backendErrorPage  *ErrorPage
+	requestIDHeader   string
+	httpsListeners    []*graph.Listener
+	listenersExist    bool
+	port              int32
+}
+
+func newHostPathRules(gateway *graph.Gateway) *hostPathRules {
 	return &hostPathRules{
-		rulesPerHost:     make(map[string]map[pathAndType]PathRule),
-		listenersForHost: make(map[string]*graph.Listener),
-		httpsListeners:   make([]*graph.Listener, 0),
+		rulesPerHost:      make(map[string]map[pathAndType]PathRule),
+		listenersForHost:  make(map[string]*graph.Listener),
+		clientMaxBodySize: gateway.ClientMaxBodySize,
+		requestIDHeader:   gateway.RequestIDHeader,
+		errorPage:         buildErrorPage(gateway.ErrorPage),
+		backendErrorPage:  buildErrorPage(gateway.BackendErrorPage),
+		httpsListeners:    make([]*graph.Listener, 0),
 	}
 }
 
@@ -361,6 +572,12 @@ func (hpr *hostPathRules) upsertListener(l *graph.Listener) {
 			}
 		}
 
+		// The page of the route overrides the page of the Gateway.
+		backendErrorPage := hpr.backendErrorPage
+		if r.BackendErrorPage != nil {
+			backendErrorPage = buildErrorPage(r.BackendErrorPage)
+		}
+
 		for i, rule := range r.Source.Spec.Rules {
 			if !r.Rules[i].ValidMatches {
 				continue
@@ -376,7 +593,7 @@ func (hpr *hostPathRules) upsertListener(l *graph.Listener) {
 			}
 
 			for _, h := range hostnames {
-				for j, m := range rule.Matches {
+				for j, m := range getRuleMatches(rule) {
 					path := getPath(m.Path)
 
 					key := pathAndType{
@@ -391,11 +608,17 @@ func (hpr *hostPathRules) upsertListener(l *graph.Listener) {
 					}
 
 					rule.MatchRules = append(rule.MatchRules, MatchRule{
-						MatchIdx:     j,
-						RuleIdx:      i,
-						Source:       r.Source,
-						BackendGroup: newBackendGroup(r.Rules[i].BackendRefs, routeNsName, i),
-						Filters:      filters,
+						MatchIdx:                    j,
+						RuleIdx:                     i,
+						Source:                      r.Source,
+						ClientMaxBodySize:           r.ClientMaxBodySize,
+						BackendGroup:                newBackendGroup(r.Rules[i].BackendRefs, routeNsName, i),
+						Filters:                     filters,
+						StreamingProxy:              r.StreamingProxy,
+						CaseInsensitiveHeaderValues: r.CaseInsensitiveHeaderValues,
+						LocationSnippet:             r.LocationSnippet,
+						NextUpstream:                buildNextUpstream(r.NextUpstream),
+						BackendErrorPage:            backendErrorPage,
 					})
 
 					hpr.rulesPerHost[h][key] = rule
@@ -405,14 +628,87 @@ func (hpr *hostPathRules) upsertListener(l *graph.Listener) {
 	}
 }
 
+func buildErrorPage(errorPage *graph.ErrorPage) *ErrorPage {
+	if errorPage == nil {
+		return nil
+	}
+
+	return &ErrorPage{
+		Body:        errorPage.Body,
+		ContentType: errorPage.ContentType,
+		StatusCodes: errorPage.StatusCodes,
+	}
+}
+
+func buildGzip(gzip *graph.Gzip) *Gzip {
+	if gzip == nil {
+		return nil
+	}
+
+	return &Gzip{
+		Types:     gzip.Types,
+		MinLength: gzip.MinLength,
+		Level:     gzip.Level,
+	}
+}
+
+func buildOpenFileCache(cache *graph.OpenFileCache) *OpenFileCache {
+	if cache == nil {
+		return nil
+	}
+
+	return &OpenFileCache{
+		MaxFiles: cache.MaxFiles,
+		Inactive: cache.Inactive,
+		Valid:    cache.Valid,
+	}
+}
+
+func buildNextUpstream(nextUpstream *graph.NextUpstream) *NextUpstream {
+	if nextUpstream == nil {
+		return nil
+	}
+
+	return &NextUpstream{
+		Conditions: nextUpstream.Conditions,
+		Tries:      nextUpstream.Tries,
+	}
+}
+
+// buildSSL builds the SSL configuration of the servers of the listener.
+// It returns nil if the listener doesn't have a resolved Secret.
+func buildSSL(l *graph.Listener) *SSL {
+	if l.ResolvedSecret == nil {
+		return nil
+	}
+
+	var dhParamsID DHParamsID
+	if l.ResolvedDHParamsSecret != nil {
+		dhParamsID = generateDHParamsID(*l.ResolvedDHParamsSecret)
+	}
+
+	return &SSL{
+		KeyPairID:        generateSSLKeyPairID(*l.ResolvedSecret),
+		DHParamsID:       dhParamsID,
+		SessionCacheSize: l.TLSSettings.SessionCacheSize,
+		SessionTickets:   l.TLSSettings.SessionTickets,
+		MinTLSVersion:    l.TLSSettings.MinTLSVersion,
+		Ciphers:          l.TLSSettings.Ciphers,
+		Stapling:         l.TLSSettings.Stapling,
+	}
+}
+
 func (hpr *hostPathRules) buildServers() []VirtualServer {
 	servers := make([]VirtualServer, 0, len(hpr.rulesPerHost)+len(hpr.httpsListeners))
 
 	for h, rules := range hpr.rulesPerHost {
 		s := VirtualServer{
-			Hostname:  h,
-			PathRules: make([]PathRule, 0, len(rules)),
-			Port:      hpr.port,
+			Hostname:          h,
+			PathRules:         make([]PathRule, 0, len(rules)),
+			Port:              hpr.port,
+			ClientMaxBodySize: hpr.clientMaxBodySize,
+			RequestIDHeader:   hpr.requestIDHeader,
+			ErrorPage:         hpr.errorPage,
 		}
 
 		l, ok := hpr.listenersForHost[h]
@@ -420,11 +716,8 @@ func (hpr *hostPathRules) buildServers() []VirtualServer {
 			panic(fmt.Sprintf("no listener found for hostname: %s", h))
 		}
 
-		if l.ResolvedSecret != nil {
-			s.SSL = &SSL{
-				KeyPairID: generateSSLKeyPairID(*l.ResolvedSecret),
-			}
-		}
+		s.SSL = buildSSL(l)
+		s.ServerSnippet = l.ServerSnippet
 
 		for _, r := range rules {
 			sortMatchRules(r.MatchRules)
@@ -433,7 +726,14 @@ func (hpr *hostPathRules) buildServers() []VirtualServer {
 		}
 
 		// We sort the path rules so the order is preserved after reconfiguration.
+		// Regex path rules go last: NGINX checks regex locations in the order they appear in the config.
 		sort.Slice(s.PathRules, func(i, j int) bool {
+			iRegex := s.PathRules[i].PathType == PathTypeRegularExpression
+			jRegex := s.PathRules[j].PathType == PathTypeRegularExpression
+			if iRegex != jRegex {
+				return jRegex
+			}
+
 			if s.PathRules[i].Path != s.PathRules[j].Path {
 				return s.PathRules[i].Path < s.PathRules[j].Path
 			}
@@ -450,15 +750,12 @@ func (hpr *hostPathRules) buildServers() []VirtualServer {
 		// This server overrides the default ssl server.
 		if len(l.Routes) == 0 || hostname == wildcardHostname {
 			s := VirtualServer{
-				Hostname: hostname,
-				Port:     hpr.port,
+				Hostname:      hostname,
+				Port:          hpr.port,
+				ServerSnippet: l.ServerSnippet,
 			}
 
-			if l.ResolvedSecret != nil {
-				s.SSL = &SSL{
-					KeyPairID: generateSSLKeyPairID(*l.ResolvedSecret),
-				}
-			}
+			s.SSL = buildSSL(l)
 
 			servers = append(servers, s)
 		}
@@ -511,7 +808,8 @@ func buildUpstreams(
 					continue
 				}
 				for _, br := range rule.BackendRefs {
-					if br.Valid {
+					// NGINX resolves the backends with DNS names at runtime, so they don't need upstreams.
+					if br.Valid && br.DNSAddress() == "" {
 						upstreamName := br.ServicePortReference()
 						_, exist := uniqueUpstreams[upstreamName]
 
@@ -605,6 +903,8 @@ func convertPathType(pathType v1beta1.PathMatchType) PathType {
 		return PathTypePrefix
 	case v1beta1.PathMatchExact:
 		return PathTypeExact
+	case v1beta1.PathMatchRegularExpression:
+		return PathTypeRegularExpression
 	default:
 		panic(fmt.Sprintf("unsupported path type: %s", pathType))
 	}
@@ -630,3 +930,10 @@ func listenerHostnameMoreSpecific(host1, host2 *v1beta1.Hostname) bool {
 func generateSSLKeyPairID(secret types.NamespacedName) SSLKeyPairID {
 	return SSLKeyPairID(fmt.Sprintf("ssl_keypair_%s_%s", secret.Namespace, secret.Name))
 }
+
+// generateDHParamsID generates an ID for the Diffie-Hellman parameters based on the Secret namespaced name.
+// It is guaranteed to be unique per unique namespaced name and not to conflict with the IDs of the SSL key pairs.
+// The ID is safe to use as a file name.
+func generateDHParamsID(secret types.NamespacedName) DHParamsID {
+	return DHParamsID(fmt.Sprintf("ssl_dhparam_%s_%s", secret.Namespace, secret.Name))
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
@@ -33,7 +34,7 @@ func TestBuildConfiguration(t *testing.T) {
 				Matches: []v1beta1.HTTPRouteMatch{
 					{
 						Path: &v1beta1.HTTPPathMatch{
-							Value: helpers.GetStringPointer(p.path),
+							Value: helpers.GetPointer(p.path),
 							Type:  helpers.GetPointer(p.pathType),
 						},
 					},
@@ -49,9 +50,9 @@ func TestBuildConfiguration(t *testing.T) {
 				CommonRouteSpec: v1beta1.CommonRouteSpec{
 					ParentRefs: []v1beta1.ParentReference{
 						{
-							Namespace:   (*v1beta1.Namespace)(helpers.GetStringPointer("test")),
+							Namespace:   (*v1beta1.Namespace)(helpers.GetPointer("test")),
 							Name:        "gateway",
-							SectionName: (*v1beta1.SectionName)(helpers.GetStringPointer(listenerName)),
+							SectionName: (*v1beta1.SectionName)(helpers.GetPointer(listenerName)),
 						},
 					},
 				},
@@ -219,7 +220,7 @@ func TestBuildConfiguration(t *testing.T) {
 	redirect := v1beta1.HTTPRouteFilter{
 		Type: v1beta1.HTTPRouteFilterRequestRedirect,
 		RequestRedirect: &v1beta1.HTTPRequestRedirectFilter{
-			Hostname: (*v1beta1.PreciseHostname)(helpers.GetStringPointer("foo.example.com")),
+			Hostname: (*v1beta1.PreciseHostname)(helpers.GetPointer("foo.example.com")),
 		},
 	}
 	addFilters(hr5, []v1beta1.HTTPRouteFilter{redirect})
@@ -325,6 +326,34 @@ func TestBuildConfiguration(t *testing.T) {
 		},
 	}
 
+	secretWithCANsName := types.NamespacedName{Namespace: "test", Name: "secret-with-ca"}
+	secretWithCA := &graph.Secret{
+		Source: &apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretWithCANsName.Name,
+				Namespace: secretWithCANsName.Namespace,
+			},
+			Data: map[string][]byte{
+				apiv1.TLSCertKey:       []byte("cert-ca"),
+				apiv1.TLSPrivateKeyKey: []byte("privateKey-ca"),
+				graph.CACertKey:        []byte("ca-cert"),
+			},
+		},
+	}
+
+	dhParamsNsName := types.NamespacedName{Namespace: "test", Name: "dhparams"}
+	dhParamsSecret := &graph.Secret{
+		Source: &apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      dhParamsNsName.Name,
+				Namespace: dhParamsNsName.Namespace,
+			},
+			Data: map[string][]byte{
+				graph.DHParamsKey: []byte("dhparams"),
+			},
+		},
+	}
+
 	listener80 := v1beta1.Listener{
 		Name:     "listener-80-1",
 		Hostname: nil,
@@ -345,10 +374,10 @@ func TestBuildConfiguration(t *testing.T) {
 		Port:     443,
 		Protocol: v1beta1.HTTPSProtocolType,
 		TLS: &v1beta1.GatewayTLSConfig{
-			Mode: helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
+			Mode: helpers.GetPointer[v1beta1.TLSModeType](v1beta1.TLSModeTerminate),
 			CertificateRefs: []v1beta1.SecretObjectReference{
 				{
-					Kind:      (*v1beta1.Kind)(helpers.GetStringPointer("Secret")),
+					Kind:      (*v1beta1.Kind)(helpers.GetPointer("Secret")),
 					Namespace: helpers.GetPointer(v1beta1.Namespace(secret1NsName.Namespace)),
 					Name:      v1beta1.ObjectName(secret1NsName.Name),
 				},
@@ -362,10 +391,10 @@ func TestBuildConfiguration(t *testing.T) {
 		Port:     8443,
 		Protocol: v1beta1.HTTPSProtocolType,
 		TLS: &v1beta1.GatewayTLSConfig{
-			Mode: helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
+			Mode: helpers.GetPointer[v1beta1.TLSModeType](v1beta1.TLSModeTerminate),
 			CertificateRefs: []v1beta1.SecretObjectReference{
 				{
-					Kind:      (*v1beta1.Kind)(helpers.GetStringPointer("Secret")),
+					Kind:      (*v1beta1.Kind)(helpers.GetPointer("Secret")),
 					Namespace: helpers.GetPointer(v1beta1.Namespace(secret2NsName.Namespace)),
 					Name:      v1beta1.ObjectName(secret2NsName.Name),
 				},
@@ -381,10 +410,10 @@ func TestBuildConfiguration(t *testing.T) {
 		Port:     443,
 		Protocol: v1beta1.HTTPSProtocolType,
 		TLS: &v1beta1.GatewayTLSConfig{
-			Mode: helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
+			Mode: helpers.GetPointer[v1beta1.TLSModeType](v1beta1.TLSModeTerminate),
 			CertificateRefs: []v1beta1.SecretObjectReference{
 				{
-					Kind:      (*v1beta1.Kind)(helpers.GetStringPointer("Secret")),
+					Kind:      (*v1beta1.Kind)(helpers.GetPointer("Secret")),
 					Namespace: helpers.GetPointer(v1beta1.Namespace(secret2NsName.Namespace)),
 					Name:      v1beta1.ObjectName(secret2NsName.Name),
 				},
@@ -409,6 +438,33 @@ func TestBuildConfiguration(t *testing.T) {
 		},
 	}
 
+	routeHR2WithClientMaxBodySize := &graph.Route{}
+	*routeHR2WithClientMaxBodySize = *routeHR2
+	routeHR2WithClientMaxBodySize.ClientMaxBodySize = helpers.GetPointer[int64](10000000)
+
+	routeHR2WithStreamingProxy := &graph.Route{}
+	*routeHR2WithStreamingProxy = *routeHR2
+	routeHR2WithStreamingProxy.StreamingProxy = true
+
+	routeHR2WithLocationSnippet := &graph.Route{}
+	*routeHR2WithLocationSnippet = *routeHR2
+	routeHR2WithLocationSnippet.LocationSnippet = "proxy_set_header X-Route hr-2;"
+
+	routeHR2WithBackendErrorPage := &graph.Route{}
+	*routeHR2WithBackendErrorPage = *routeHR2
+	routeHR2WithBackendErrorPage.BackendErrorPage = &graph.ErrorPage{
+		Body:        "Route error",
+		ContentType: "text/plain",
+		StatusCodes: []int{502},
+	}
+
+	routeHR2WithNextUpstream := &graph.Route{}
+	*routeHR2WithNextUpstream = *routeHR2
+	routeHR2WithNextUpstream.NextUpstream = &graph.NextUpstream{
+		Conditions: []string{"error", "http_502"},
+		Tries:      2,
+	}
+
 	tests := []struct {
 		graph   *graph.Graph
 		msg     string
@@ -463,6 +519,190 @@ func TestBuildConfiguration(t *testing.T) {
 			},
 			msg: "http listener with no routes",
 		},
+		{
+			graph: &graph.Graph{
+				GatewayClass: &graph.GatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateway: &graph.Gateway{
+					Source: &v1beta1.Gateway{},
+					Listeners: map[string]*graph.Listener{
+						"listener-80-1": {
+							Source: listener80,
+							Valid:  true,
+							Routes: map[types.NamespacedName]*graph.Route{},
+						},
+					},
+					TrustedProxies: []string{"10.0.0.0/8"},
+				},
+				Routes: map[types.NamespacedName]*graph.Route{},
+			},
+			expConf: Configuration{
+				HTTPServers: []VirtualServer{
+					{
+						IsDefault: true,
+						Port:      80,
+					},
+				},
+				SSLServers:     []VirtualServer{},
+				SSLKeyPairs:    map[SSLKeyPairID]SSLKeyPair{},
+				TrustedProxies: []string{"10.0.0.0/8"},
+			},
+			msg: "trusted proxies of the gateway",
+		},
+		{
+			graph: &graph.Graph{
+				GatewayClass: &graph.GatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateway: &graph.Gateway{
+					Source: &v1beta1.Gateway{},
+					Listeners: map[string]*graph.Listener{
+						"listener-80-1": {
+							Source: listener80,
+							Valid:  true,
+							Routes: map[types.NamespacedName]*graph.Route{},
+						},
+					},
+					UpstreamZoneSize:      64 * 1024,
+					WorkerProcesses:       "auto",
+					WorkerConnections:     4096,
+					ShowServerVersion:     true,
+					WorkerShutdownTimeout: 20 * time.Second,
+				},
+				Routes: map[types.NamespacedName]*graph.Route{},
+			},
+			expConf: Configuration{
+				HTTPServers: []VirtualServer{
+					{
+						IsDefault: true,
+						Port:      80,
+					},
+				},
+				SSLServers:            []VirtualServer{},
+				SSLKeyPairs:           map[SSLKeyPairID]SSLKeyPair{},
+				UpstreamZoneSize:      64 * 1024,
+				WorkerProcesses:       "auto",
+				WorkerConnections:     4096,
+				ShowServerVersion:     true,
+				WorkerShutdownTimeout: 20 * time.Second,
+			},
+			msg: "global nginx settings of the gateway",
+		},
+		{
+			graph: &graph.Graph{
+				GatewayClass: &graph.GatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateway: &graph.Gateway{
+					Source: &v1beta1.Gateway{},
+					Listeners: map[string]*graph.Listener{
+						"listener-80-1": {
+							Source: listener80,
+							Valid:  true,
+							Routes: map[types.NamespacedName]*graph.Route{},
+						},
+					},
+					OpenFileCache: &graph.OpenFileCache{
+						MaxFiles: 1000,
+						Inactive: 20 * time.Second,
+						Valid:    30 * time.Second,
+					},
+				},
+				Routes: map[types.NamespacedName]*graph.Route{},
+			},
+			expConf: Configuration{
+				HTTPServers: []VirtualServer{
+					{
+						IsDefault: true,
+						Port:      80,
+					},
+				},
+				SSLServers:  []VirtualServer{},
+				SSLKeyPairs: map[SSLKeyPairID]SSLKeyPair{},
+				OpenFileCache: &OpenFileCache{
+					MaxFiles: 1000,
+					Inactive: 20 * time.Second,
+					Valid:    30 * time.Second,
+				},
+			},
+			msg: "open file cache of the gateway",
+		},
+		{
+			graph: &graph.Graph{
+				GatewayClass: &graph.GatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateway: &graph.Gateway{
+					Source: &v1beta1.Gateway{},
+					Listeners: map[string]*graph.Listener{
+						"listener-80-1": {
+							Source: listener80,
+							Valid:  true,
+							Routes: map[types.NamespacedName]*graph.Route{},
+						},
+					},
+					Gzip: &graph.Gzip{
+						Types:     []string{"application/json"},
+						MinLength: 1000,
+						Level:     5,
+					},
+				},
+				Routes: map[types.NamespacedName]*graph.Route{},
+			},
+			expConf: Configuration{
+				HTTPServers: []VirtualServer{
+					{
+						IsDefault: true,
+						Port:      80,
+					},
+				},
+				SSLServers:  []VirtualServer{},
+				SSLKeyPairs: map[SSLKeyPairID]SSLKeyPair{},
+				Gzip: &Gzip{
+					Types:     []string{"application/json"},
+					MinLength: 1000,
+					Level:     5,
+				},
+			},
+			msg: "gzip of the gateway",
+		},
+		{
+			graph: &graph.Graph{
+				GatewayClass: &graph.GatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateway: &graph.Gateway{
+					Source: &v1beta1.Gateway{},
+					Listeners: map[string]*graph.Listener{
+						"listener-80-1": {
+							Source: listener80,
+							Valid:  true,
+							Routes: map[types.NamespacedName]*graph.Route{},
+						},
+					},
+				},
+				Routes:      map[types.NamespacedName]*graph.Route{},
+				HTTPSnippet: "gzip on;",
+			},
+			expConf: Configuration{
+				HTTPServers: []VirtualServer{
+					{
+						IsDefault: true,
+						Port:      80,
+					},
+				},
+				SSLServers:  []VirtualServer{},
+				SSLKeyPairs: map[SSLKeyPairID]SSLKeyPair{},
+				HTTPSnippet: "gzip on;",
+			},
+			msg: "http snippet",
+		},
 		{
 			graph: &graph.Graph{
 				GatewayClass: &graph.GatewayClass{
@@ -532,27 +772,682 @@ func TestBuildConfiguration(t *testing.T) {
 				Gateway: &graph.Gateway{
 					Source: &v1beta1.Gateway{},
 					Listeners: map[string]*graph.Listener{
-						"invalid-listener": {
-							Source:         invalidListener,
-							Valid:          false,
+						"listener-443-1": {
+							Source:         listener443,
+							Valid:          true,
+							Routes:         map[types.NamespacedName]*graph.Route{},
 							ResolvedSecret: &secret1NsName,
+							TLSSettings: graph.TLSSettings{
+								SessionCacheSize: helpers.GetPointer[int64](10 * 1024 * 1024),
+								SessionTickets:   helpers.GetPointer(false),
+								MinTLSVersion:    "TLSv1.3",
+								Ciphers:          []string{"HIGH", "!aNULL"},
+							},
 						},
 					},
 				},
-				Routes: map[types.NamespacedName]*graph.Route{
-					{Namespace: "test", Name: "https-hr-1"}: httpsRouteHR1,
-					{Namespace: "test", Name: "https-hr-2"}: httpsRouteHR2,
-				},
+				Routes: map[types.NamespacedName]*graph.Route{},
 				ReferencedSecrets: map[types.NamespacedName]*graph.Secret{
 					secret1NsName: secret1,
 				},
 			},
-			expConf: Configuration{
-				HTTPServers: []VirtualServer{},
-				SSLServers:  []VirtualServer{},
-				SSLKeyPairs: map[SSLKeyPairID]SSLKeyPair{},
-			},
-			msg: "invalid https listener with resolved secret",
+			expConf: Configuration{
+				HTTPServers: []VirtualServer{},
+				SSLServers: []VirtualServer{
+					{
+						IsDefault: true,
+						Port:      443,
+					},
+					{
+						Hostname: wildcardHostname,
+						SSL: &SSL{
+							KeyPairID:        "ssl_keypair_test_secret-1",
+							SessionCacheSize: helpers.GetPointer[int64](10 * 1024 * 1024),
+							SessionTickets:   helpers.GetPointer(false),
+							MinTLSVersion:    "TLSv1.3",
+							Ciphers:          []string{"HIGH", "!aNULL"},
+						},
+						Port: 443,
+					},
+				},
+				SSLKeyPairs: map[SSLKeyPairID]SSLKeyPair{
+					"ssl_keypair_test_secret-1": {
+						Cert: []byte("cert-1"),
+						Key:  []byte("privateKey-1"),
+					},
+				},
+			},
+			msg: "https listener with TLS settings",
+		},
+		{
+			graph: &graph.Graph{
+				GatewayClass: &graph.GatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateway: &graph.Gateway{
+					Source: &v1beta1.Gateway{},
+					Listeners: map[string]*graph.Listener{
+						"listener-443-1": {
+							Source:         listener443,
+							Valid:          true,
+							Routes:         map[types.NamespacedName]*graph.Route{},
+							ResolvedSecret: &secretWithCANsName,
+							TLSSettings: graph.TLSSettings{
+								Stapling: true,
+							},
+						},
+						"listener-443-with-hostname": {
+							Source:         listener443WithHostname,
+							Valid:          true,
+							Routes:         map[types.NamespacedName]*graph.Route{},
+							ResolvedSecret: &secretWithCANsName,
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*graph.Route{},
+				ReferencedSecrets: map[types.NamespacedName]*graph.Secret{
+					secretWithCANsName: secretWithCA,
+				},
+			},
+			expConf: Configuration{
+				HTTPServers: []VirtualServer{},
+				SSLServers: []VirtualServer{
+					{
+						IsDefault: true,
+						Port:      443,
+					},
+					{
+						Hostname: string(hostname),
+						SSL: &SSL{
+							KeyPairID: "ssl_keypair_test_secret-with-ca",
+						},
+						Port: 443,
+					},
+					{
+						Hostname: wildcardHostname,
+						SSL: &SSL{
+							KeyPairID: "ssl_keypair_test_secret-with-ca",
+							Stapling:  true,
+						},
+						Port: 443,
+					},
+				},
+				SSLKeyPairs: map[SSLKeyPairID]SSLKeyPair{
+					"ssl_keypair_test_secret-with-ca": {
+						Cert:   []byte("cert-ca"),
+						Key:    []byte("privateKey-ca"),
+						CACert: []byte("ca-cert"),
+					},
+				},
+			},
+			msg: "https listeners with OCSP stapling",
+		},
+		{
+			graph: &graph.Graph{
+				GatewayClass: &graph.GatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateway: &graph.Gateway{
+					Source: &v1beta1.Gateway{},
+					Listeners: map[string]*graph.Listener{
+						"listener-443-1": {
+							Source:                 listener443,
+							Valid:                  true,
+							Routes:                 map[types.NamespacedName]*graph.Route{},
+							ResolvedSecret:         &secret1NsName,
+							ResolvedDHParamsSecret: &dhParamsNsName,
+							TLSSettings: graph.TLSSettings{
+								DHParamSecret: dhParamsNsName.Name,
+							},
+						},
+						"invalid-listener": {
+							Source:                 invalidListener,
+							Valid:                  false,
+							ResolvedSecret:         &secret2NsName,
+							ResolvedDHParamsSecret: &dhParamsNsName,
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*graph.Route{},
+				ReferencedSecrets: map[types.NamespacedName]*graph.Secret{
+					secret1NsName:  secret1,
+					secret2NsName:  secret2,
+					dhParamsNsName: dhParamsSecret,
+				},
+			},
+			expConf: Configuration{
+				HTTPServers: []VirtualServer{},
+				SSLServers: []VirtualServer{
+					{
+						IsDefault: true,
+						Port:      443,
+					},
+					{
+						Hostname: wildcardHostname,
+						SSL: &SSL{
+							KeyPairID:  "ssl_keypair_test_secret-1",
+							DHParamsID: "ssl_dhparam_test_dhparams",
+						},
+						Port: 443,
+					},
+				},
+				SSLKeyPairs: map[SSLKeyPairID]SSLKeyPair{
+					"ssl_keypair_test_secret-1": {
+						Cert: []byte("cert-1"),
+						Key:  []byte("privateKey-1"),
+					},
+				},
+				DHParams: map[DHParamsID][]byte{
+					"ssl_dhparam_test_dhparams": []byte("dhparams"),
+				},
+			},
+			msg: "https listener with DH parameters",
+		},
+		{
+			graph: &graph.Graph{
+				GatewayClass: &graph.GatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateway: &graph.Gateway{
+					Source: &v1beta1.Gateway{},
+					Listeners: map[string]*graph.Listener{
+						"invalid-listener": {
+							Source:         invalidListener,
+							Valid:          false,
+							ResolvedSecret: &secret1NsName,
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*graph.Route{
+					{Namespace: "test", Name: "https-hr-1"}: httpsRouteHR1,
+					{Namespace: "test", Name: "https-hr-2"}: httpsRouteHR2,
+				},
+				ReferencedSecrets: map[types.NamespacedName]*graph.Secret{
+					secret1NsName: secret1,
+				},
+			},
+			expConf: Configuration{
+				HTTPServers: []VirtualServer{},
+				SSLServers:  []VirtualServer{},
+				SSLKeyPairs: map[SSLKeyPairID]SSLKeyPair{},
+			},
+			msg: "invalid https listener with resolved secret",
+		},
+		{
+			graph: &graph.Graph{
+				GatewayClass: &graph.GatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateway: &graph.Gateway{
+					Source: &v1beta1.Gateway{},
+					Listeners: map[string]*graph.Listener{
+						"listener-80-1": {
+							Source: listener80,
+							Valid:  true,
+							Routes: map[types.NamespacedName]*graph.Route{
+								{Namespace: "test", Name: "hr-1"}: routeHR1,
+								{Namespace: "test", Name: "hr-2"}: routeHR2,
+							},
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*graph.Route{
+					{Namespace: "test", Name: "hr-1"}: routeHR1,
+					{Namespace: "test", Name: "hr-2"}: routeHR2,
+				},
+			},
+			expConf: Configuration{
+				HTTPServers: []VirtualServer{
+					{
+						IsDefault: true,
+						Port:      80,
+					},
+					{
+						Hostname: "bar.example.com",
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx:     0,
+										RuleIdx:      0,
+										BackendGroup: expHR2Groups[0],
+										Source:       hr2,
+									},
+								},
+							},
+						},
+						Port: 80,
+					},
+					{
+						Hostname: "foo.example.com",
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx:     0,
+										RuleIdx:      0,
+										BackendGroup: expHR1Groups[0],
+										Source:       hr1,
+									},
+								},
+							},
+						},
+						Port: 80,
+					},
+				},
+				SSLServers:    []VirtualServer{},
+				Upstreams:     []Upstream{fooUpstream},
+				BackendGroups: []BackendGroup{expHR1Groups[0], expHR2Groups[0]},
+				SSLKeyPairs:   map[SSLKeyPairID]SSLKeyPair{},
+			},
+			msg: "one http listener with two routes for different hostnames",
+		},
+		{
+			graph: &graph.Graph{
+				GatewayClass: &graph.GatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateway: &graph.Gateway{
+					Source: &v1beta1.Gateway{},
+					Listeners: map[string]*graph.Listener{
+						"listener-80-1": {
+							Source: listener80,
+							Valid:  true,
+							Routes: map[types.NamespacedName]*graph.Route{
+								{Namespace: "test", Name: "hr-1"}: routeHR1,
+								{Namespace: "test", Name: "hr-2"}: routeHR2WithClientMaxBodySize,
+							},
+						},
+					},
+					ClientMaxBodySize: helpers.GetPointer[int64](1000000000),
+				},
+				Routes: map[types.NamespacedName]*graph.Route{
+					{Namespace: "test", Name: "hr-1"}: routeHR1,
+					{Namespace: "test", Name: "hr-2"}: routeHR2WithClientMaxBodySize,
+				},
+			},
+			expConf: Configuration{
+				HTTPServers: []VirtualServer{
+					{
+						IsDefault: true,
+						Port:      80,
+					},
+					{
+						Hostname: "bar.example.com",
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx:          0,
+										RuleIdx:           0,
+										BackendGroup:      expHR2Groups[0],
+										Source:            hr2,
+										ClientMaxBodySize: helpers.GetPointer[int64](10000000),
+									},
+								},
+							},
+						},
+						ClientMaxBodySize: helpers.GetPointer[int64](1000000000),
+						Port:              80,
+					},
+					{
+						Hostname: "foo.example.com",
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx:     0,
+										RuleIdx:      0,
+										BackendGroup: expHR1Groups[0],
+										Source:       hr1,
+									},
+								},
+							},
+						},
+						ClientMaxBodySize: helpers.GetPointer[int64](1000000000),
+						Port:              80,
+					},
+				},
+				SSLServers:    []VirtualServer{},
+				Upstreams:     []Upstream{fooUpstream},
+				BackendGroups: []BackendGroup{expHR1Groups[0], expHR2Groups[0]},
+				SSLKeyPairs:   map[SSLKeyPairID]SSLKeyPair{},
+			},
+			msg: "client max body size of the gateway and a route",
+		},
+		{
+			graph: &graph.Graph{
+				GatewayClass: &graph.GatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateway: &graph.Gateway{
+					Source: &v1beta1.Gateway{},
+					Listeners: map[string]*graph.Listener{
+						"listener-80-1": {
+							Source: listener80,
+							Valid:  true,
+							Routes: map[types.NamespacedName]*graph.Route{
+								{Namespace: "test", Name: "hr-1"}: routeHR1,
+							},
+							ServerSnippet: "add_header X-Gateway nginx;",
+						},
+						"listener-443-1": {
+							Source:         listener443,
+							Valid:          true,
+							Routes:         map[types.NamespacedName]*graph.Route{},
+							ResolvedSecret: &secret1NsName,
+							ServerSnippet:  "add_header X-Gateway nginx;",
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*graph.Route{
+					{Namespace: "test", Name: "hr-1"}: routeHR1,
+				},
+				ReferencedSecrets: map[types.NamespacedName]*graph.Secret{
+					secret1NsName: secret1,
+				},
+			},
+			expConf: Configuration{
+				HTTPServers: []VirtualServer{
+					{
+						IsDefault: true,
+						Port:      80,
+					},
+					{
+						Hostname: "foo.example.com",
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx:     0,
+										RuleIdx:      0,
+										BackendGroup: expHR1Groups[0],
+										Source:       hr1,
+									},
+								},
+							},
+						},
+						ServerSnippet: "add_header X-Gateway nginx;",
+						Port:          80,
+					},
+				},
+				SSLServers: []VirtualServer{
+					{
+						IsDefault: true,
+						Port:      443,
+					},
+					{
+						Hostname:      wildcardHostname,
+						SSL:           &SSL{KeyPairID: "ssl_keypair_test_secret-1"},
+						ServerSnippet: "add_header X-Gateway nginx;",
+						Port:          443,
+					},
+				},
+				Upstreams:     []Upstream{fooUpstream},
+				BackendGroups: []BackendGroup{expHR1Groups[0]},
+				SSLKeyPairs: map[SSLKeyPairID]SSLKeyPair{
+					"ssl_keypair_test_secret-1": {
+						Cert: []byte("cert-1"),
+						Key:  []byte("privateKey-1"),
+					},
+				},
+			},
+			msg: "server snippet of the listeners",
+		},
+		{
+			graph: &graph.Graph{
+				GatewayClass: &graph.GatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateway: &graph.Gateway{
+					Source: &v1beta1.Gateway{},
+					Listeners: map[string]*graph.Listener{
+						"listener-80-1": {
+							Source: listener80,
+							Valid:  true,
+							Routes: map[types.NamespacedName]*graph.Route{
+								{Namespace: "test", Name: "hr-1"}: routeHR1,
+								{Namespace: "test", Name: "hr-2"}: routeHR2WithBackendErrorPage,
+							},
+						},
+					},
+					BackendErrorPage: &graph.ErrorPage{
+						Body:        "Gateway error",
+						ContentType: "text/html",
+						StatusCodes: []int{500, 503},
+					},
+				},
+				Routes: map[types.NamespacedName]*graph.Route{
+					{Namespace: "test", Name: "hr-1"}: routeHR1,
+					{Namespace: "test", Name: "hr-2"}: routeHR2WithBackendErrorPage,
+				},
+			},
+			expConf: Configuration{
+				HTTPServers: []VirtualServer{
+					{
+						IsDefault: true,
+						Port:      80,
+					},
+					{
+						Hostname: "bar.example.com",
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx:     0,
+										RuleIdx:      0,
+										BackendGroup: expHR2Groups[0],
+										Source:       hr2,
+										BackendErrorPage: &ErrorPage{
+											Body:        "Route error",
+											ContentType: "text/plain",
+											StatusCodes: []int{502},
+										},
+									},
+								},
+							},
+						},
+						Port: 80,
+					},
+					{
+						Hostname: "foo.example.com",
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx:     0,
+										RuleIdx:      0,
+										BackendGroup: expHR1Groups[0],
+										Source:       hr1,
+										BackendErrorPage: &ErrorPage{
+											Body:        "Gateway error",
+											ContentType: "text/html",
+											StatusCodes: []int{500, 503},
+										},
+									},
+								},
+							},
+						},
+						Port: 80,
+					},
+				},
+				SSLServers:    []VirtualServer{},
+				Upstreams:     []Upstream{fooUpstream},
+				BackendGroups: []BackendGroup{expHR1Groups[0], expHR2Groups[0]},
+				SSLKeyPairs:   map[SSLKeyPairID]SSLKeyPair{},
+			},
+			msg: "backend error page of the gateway and a route",
+		},
+		{
+			graph: &graph.Graph{
+				GatewayClass: &graph.GatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateway: &graph.Gateway{
+					Source: &v1beta1.Gateway{},
+					Listeners: map[string]*graph.Listener{
+						"listener-80-1": {
+							Source: listener80,
+							Valid:  true,
+							Routes: map[types.NamespacedName]*graph.Route{
+								{Namespace: "test", Name: "hr-1"}: routeHR1,
+								{Namespace: "test", Name: "hr-2"}: routeHR2WithStreamingProxy,
+							},
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*graph.Route{
+					{Namespace: "test", Name: "hr-1"}: routeHR1,
+					{Namespace: "test", Name: "hr-2"}: routeHR2WithStreamingProxy,
+				},
+			},
+			expConf: Configuration{
+				HTTPServers: []VirtualServer{
+					{
+						IsDefault: true,
+						Port:      80,
+					},
+					{
+						Hostname: "bar.example.com",
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx:       0,
+										RuleIdx:        0,
+										BackendGroup:   expHR2Groups[0],
+										Source:         hr2,
+										StreamingProxy: true,
+									},
+								},
+							},
+						},
+						Port: 80,
+					},
+					{
+						Hostname: "foo.example.com",
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx:     0,
+										RuleIdx:      0,
+										BackendGroup: expHR1Groups[0],
+										Source:       hr1,
+									},
+								},
+							},
+						},
+						Port: 80,
+					},
+				},
+				SSLServers:    []VirtualServer{},
+				Upstreams:     []Upstream{fooUpstream},
+				BackendGroups: []BackendGroup{expHR1Groups[0], expHR2Groups[0]},
+				SSLKeyPairs:   map[SSLKeyPairID]SSLKeyPair{},
+			},
+			msg: "streaming proxy of a route",
+		},
+		{
+			graph: &graph.Graph{
+				GatewayClass: &graph.GatewayClass{
+					Source: &v1beta1.GatewayClass{},
+					Valid:  true,
+				},
+				Gateway: &graph.Gateway{
+					Source: &v1beta1.Gateway{},
+					Listeners: map[string]*graph.Listener{
+						"listener-80-1": {
+							Source: listener80,
+							Valid:  true,
+							Routes: map[types.NamespacedName]*graph.Route{
+								{Namespace: "test", Name: "hr-1"}: routeHR1,
+								{Namespace: "test", Name: "hr-2"}: routeHR2WithLocationSnippet,
+							},
+						},
+					},
+				},
+				Routes: map[types.NamespacedName]*graph.Route{
+					{Namespace: "test", Name: "hr-1"}: routeHR1,
+					{Namespace: "test", Name: "hr-2"}: routeHR2WithLocationSnippet,
+				},
+			},
+			expConf: Configuration{
+				HTTPServers: []VirtualServer{
+					{
+						IsDefault: true,
+						Port:      80,
+					},
+					{
+						Hostname: "bar.example.com",
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx:        0,
+										RuleIdx:         0,
+										BackendGroup:    expHR2Groups[0],
+										Source:          hr2,
+										LocationSnippet: "proxy_set_header X-Route hr-2;",
+									},
+								},
+							},
+						},
+						Port: 80,
+					},
+					{
+						Hostname: "foo.example.com",
+						PathRules: []PathRule{
+							{
+								Path:     "/",
+								PathType: PathTypePrefix,
+								MatchRules: []MatchRule{
+									{
+										MatchIdx:     0,
+										RuleIdx:      0,
+										BackendGroup: expHR1Groups[0],
+										Source:       hr1,
+									},
+								},
+							},
+						},
+						Port: 80,
+					},
+				},
+				SSLServers:    []VirtualServer{},
+				Upstreams:     []Upstream{fooUpstream},
+				BackendGroups: []BackendGroup{expHR1Groups[0], expHR2Groups[0]},
+				SSLKeyPairs:   map[SSLKeyPairID]SSLKeyPair{},
+			},
+			msg: "location snippet of a route",
 		},
 		{
 			graph: &graph.Graph{
@@ -568,14 +1463,14 @@ func TestBuildConfiguration(t *testing.T) {
 							Valid:  true,
 							Routes: map[types.NamespacedName]*graph.Route{
 								{Namespace: "test", Name: "hr-1"}: routeHR1,
-								{Namespace: "test", Name: "hr-2"}: routeHR2,
+								{Namespace: "test", Name: "hr-2"}: routeHR2WithNextUpstream,
 							},
 						},
 					},
 				},
 				Routes: map[types.NamespacedName]*graph.Route{
 					{Namespace: "test", Name: "hr-1"}: routeHR1,
-					{Namespace: "test", Name: "hr-2"}: routeHR2,
+					{Namespace: "test", Name: "hr-2"}: routeHR2WithNextUpstream,
 				},
 			},
 			expConf: Configuration{
@@ -596,6 +1491,10 @@ func TestBuildConfiguration(t *testing.T) {
 										RuleIdx:      0,
 										BackendGroup: expHR2Groups[0],
 										Source:       hr2,
+										NextUpstream: &NextUpstream{
+											Conditions: []string{"error", "http_502"},
+											Tries:      2,
+										},
 									},
 								},
 							},
@@ -626,7 +1525,7 @@ func TestBuildConfiguration(t *testing.T) {
 				BackendGroups: []BackendGroup{expHR1Groups[0], expHR2Groups[0]},
 				SSLKeyPairs:   map[SSLKeyPairID]SSLKeyPair{},
 			},
-			msg: "one http listener with two routes for different hostnames",
+			msg: "next upstream of a route",
 		},
 		{
 			graph: &graph.Graph{
@@ -1562,7 +2461,7 @@ func TestGetPath(t *testing.T) {
 		msg      string
 	}{
 		{
-			path:     &v1beta1.HTTPPathMatch{Value: helpers.GetStringPointer("/abc")},
+			path:     &v1beta1.HTTPPathMatch{Value: helpers.GetPointer("/abc")},
 			expected: "/abc",
 			msg:      "normal case",
 		},
@@ -1577,7 +2476,7 @@ func TestGetPath(t *testing.T) {
 			msg:      "nil value",
 		},
 		{
-			path:     &v1beta1.HTTPPathMatch{Value: helpers.GetStringPointer("")},
+			path:     &v1beta1.HTTPPathMatch{Value: helpers.GetPointer("")},
 			expected: "/",
 			msg:      "empty value",
 		},
@@ -1595,13 +2494,13 @@ func TestCreateFilters(t *testing.T) {
 	redirect1 := v1beta1.HTTPRouteFilter{
 		Type: v1beta1.HTTPRouteFilterRequestRedirect,
 		RequestRedirect: &v1beta1.HTTPRequestRedirectFilter{
-			Hostname: (*v1beta1.PreciseHostname)(helpers.GetStringPointer("foo.example.com")),
+			Hostname: (*v1beta1.PreciseHostname)(helpers.GetPointer("foo.example.com")),
 		},
 	}
 	redirect2 := v1beta1.HTTPRouteFilter{
 		Type: v1beta1.HTTPRouteFilterRequestRedirect,
 		RequestRedirect: &v1beta1.HTTPRequestRedirectFilter{
-			Hostname: (*v1beta1.PreciseHostname)(helpers.GetStringPointer("bar.example.com")),
+			Hostname: (*v1beta1.PreciseHostname)(helpers.GetPointer("bar.example.com")),
 		},
 	}
 	requestHeaderModifiers1 := v1beta1.HTTPRouteFilter{
@@ -1699,12 +2598,12 @@ func TestMatchRuleGetMatch(t *testing.T) {
 					Matches: []v1beta1.HTTPRouteMatch{
 						{
 							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetStringPointer("/path-1"),
+								Value: helpers.GetPointer("/path-1"),
 							},
 						},
 						{
 							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetStringPointer("/path-2"),
+								Value: helpers.GetPointer("/path-2"),
 							},
 						},
 					},
@@ -1713,12 +2612,12 @@ func TestMatchRuleGetMatch(t *testing.T) {
 					Matches: []v1beta1.HTTPRouteMatch{
 						{
 							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetStringPointer("/path-3"),
+								Value: helpers.GetPointer("/path-3"),
 							},
 						},
 						{
 							Path: &v1beta1.HTTPPathMatch{
-								Value: helpers.GetStringPointer("/path-4"),
+								Value: helpers.GetPointer("/path-4"),
 							},
 						},
 					},
@@ -1892,6 +2791,20 @@ func TestBuildUpstreams(t *testing.T) {
 
 	invalidRefs := createBackendRefs("invalid")
 
+	externalRefs := []graph.BackendRef{
+		{
+			Svc: &apiv1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "external"},
+				Spec: apiv1.ServiceSpec{
+					Type:         apiv1.ServiceTypeExternalName,
+					ExternalName: "example.com",
+				},
+			},
+			Port:  80,
+			Valid: true,
+		},
+	} // shouldn't have an upstream, because NGINX resolves it at runtime
+
 	routes := map[types.NamespacedName]*graph.Route{
 		{Name: "hr1", Namespace: "test"}: {
 			Rules: refsToValidRules(hr1Refs0, hr1Refs1),
@@ -1902,6 +2815,9 @@ func TestBuildUpstreams(t *testing.T) {
 		{Name: "hr3", Namespace: "test"}: {
 			Rules: refsToValidRules(hr3Refs0),
 		},
+		{Name: "external", Namespace: "test"}: {
+			Rules: refsToValidRules(externalRefs),
+		},
 	}
 
 	routes2 := map[types.NamespacedName]*graph.Route{
@@ -1989,6 +2905,197 @@ func TestBuildUpstreams(t *testing.T) {
 	g.Expect(upstreams).To(ConsistOf(expUpstreams))
 }
 
+func TestBuildUpstreamsSharedBackends(t *testing.T) {
+	svc := &apiv1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "foo"}}
+
+	createRoute := func(port int32) *graph.Route {
+		return &graph.Route{
+			Rules: refsToValidRules([]graph.BackendRef{
+				{
+					Svc:   svc,
+					Port:  port,
+					Valid: true,
+				},
+			}),
+		}
+	}
+
+	tests := []struct {
+		routes            map[types.NamespacedName]*graph.Route
+		name              string
+		expUpstreamNames  []string
+		expResolveCallCnt int
+	}{
+		{
+			routes: map[types.NamespacedName]*graph.Route{
+				{Namespace: "test", Name: "hr1"}: createRoute(80),
+				{Namespace: "test", Name: "hr2"}: createRoute(80),
+			},
+			name:              "routes with the same service and port share the upstream",
+			expUpstreamNames:  []string{"test_foo_80"},
+			expResolveCallCnt: 1,
+		},
+		{
+			routes: map[types.NamespacedName]*graph.Route{
+				{Namespace: "test", Name: "hr1"}: createRoute(80),
+				{Namespace: "test", Name: "hr2"}: createRoute(8080),
+			},
+			name:              "routes with the same service and different ports have separate upstreams",
+			expUpstreamNames:  []string{"test_foo_80", "test_foo_8080"},
+			expResolveCallCnt: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			listeners := map[string]*graph.Listener{
+				"listener-1": {
+					Valid:  true,
+					Routes: test.routes,
+				},
+			}
+
+			fakeResolver := &resolverfakes.FakeServiceResolver{}
+
+			upstreams := buildUpstreams(context.TODO(), listeners, fakeResolver)
+
+			names := make([]string, 0, len(upstreams))
+			for _, up := range upstreams {
+				names = append(names, up.Name)
+			}
+
+			g.Expect(names).To(ConsistOf(test.expUpstreamNames))
+			g.Expect(fakeResolver.ResolveCallCount()).To(Equal(test.expResolveCallCnt))
+		})
+	}
+}
+
+func TestBuildConfigurationDNSResolvers(t *testing.T) {
+	const listenerName = "listener-80"
+
+	createGraph := func(svc *apiv1.Service) *graph.Graph {
+		hr := &v1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr"},
+			Spec: v1beta1.HTTPRouteSpec{
+				Rules: []v1beta1.HTTPRouteRule{
+					{
+						Matches: []v1beta1.HTTPRouteMatch{
+							{
+								Path: &v1beta1.HTTPPathMatch{
+									Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+									Value: helpers.GetPointer("/"),
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		route := &graph.Route{
+			Source: hr,
+			Rules: []graph.Rule{
+				{
+					ValidMatches: true,
+					ValidFilters: true,
+					BackendRefs: []graph.BackendRef{
+						{Svc: svc, Port: 80, Valid: true, Weight: 1},
+					},
+				},
+			},
+			ParentRefs: []graph.ParentRef{
+				{
+					Attachment: &graph.ParentRefAttachmentStatus{
+						AcceptedHostnames: map[string][]string{listenerName: {"foo.example.com"}},
+					},
+				},
+			},
+		}
+
+		return &graph.Graph{
+			GatewayClass: &graph.GatewayClass{
+				Source: &v1beta1.GatewayClass{},
+				Valid:  true,
+			},
+			Gateway: &graph.Gateway{
+				Source: &v1beta1.Gateway{},
+				Listeners: map[string]*graph.Listener{
+					listenerName: {
+						Source: v1beta1.Listener{Name: listenerName, Port: 80, Protocol: v1beta1.HTTPProtocolType},
+						Valid:  true,
+						Routes: map[types.NamespacedName]*graph.Route{
+							client.ObjectKeyFromObject(hr): route,
+						},
+					},
+				},
+				DNSResolvers: []string{"10.96.0.10"},
+			},
+			Routes: map[types.NamespacedName]*graph.Route{
+				client.ObjectKeyFromObject(hr): route,
+			},
+		}
+	}
+
+	externalSvc := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "external"},
+		Spec: apiv1.ServiceSpec{
+			Type:         apiv1.ServiceTypeExternalName,
+			ExternalName: "example.com",
+		},
+	}
+	svc := &apiv1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "svc"}}
+
+	tests := []struct {
+		graph           *graph.Graph
+		name            string
+		expResolvers    []string
+		expBackend      Backend
+		expUpstreamsLen int
+	}{
+		{
+			graph:        createGraph(externalSvc),
+			expResolvers: []string{"10.96.0.10"},
+			expBackend: Backend{
+				UpstreamName: "test_external_80",
+				DNSAddress:   "example.com:80",
+				Weight:       1,
+				Valid:        true,
+			},
+			expUpstreamsLen: 0,
+			name:            "DNS backend",
+		},
+		{
+			graph:        createGraph(svc),
+			expResolvers: nil,
+			expBackend: Backend{
+				UpstreamName: "test_svc_80",
+				Weight:       1,
+				Valid:        true,
+			},
+			expUpstreamsLen: 1,
+			name:            "no DNS backends",
+		},
+	}
+
+	fakeResolver := &resolverfakes.FakeServiceResolver{}
+	fakeResolver.ResolveReturns([]resolver.Endpoint{{Address: "10.0.0.0", Port: 8080}}, nil)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			conf := BuildConfiguration(context.TODO(), test.graph, fakeResolver)
+
+			g.Expect(conf.DNSResolvers).To(Equal(test.expResolvers))
+			g.Expect(conf.Upstreams).To(HaveLen(test.expUpstreamsLen))
+			g.Expect(conf.BackendGroups).To(HaveLen(1))
+			g.Expect(conf.BackendGroups[0].Backends).To(Equal([]Backend{test.expBackend}))
+		})
+	}
+}
+
 func TestBuildBackendGroups(t *testing.T) {
 	createBackendGroup := func(name string, ruleIdx int, backendNames ...string) BackendGroup {
 		backends := make([]Backend, len(backendNames))
@@ -2061,6 +3168,276 @@ func TestBuildBackendGroups(t *testing.T) {
 	g.Expect(result).To(ConsistOf(expGroups))
 }
 
+func TestBuildConfigurationRegexPathOrder(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	const listenerName = "listener-80"
+
+	createMatch := func(pathType v1beta1.PathMatchType, path string) v1beta1.HTTPRouteMatch {
+		return v1beta1.HTTPRouteMatch{
+			Path: &v1beta1.HTTPPathMatch{
+				Type:  helpers.GetPointer(pathType),
+				Value: helpers.GetPointer(path),
+			},
+		}
+	}
+
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr"},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
+				{
+					Matches: []v1beta1.HTTPRouteMatch{
+						createMatch(v1beta1.PathMatchRegularExpression, "/a/[0-9]+"),
+						createMatch(v1beta1.PathMatchPathPrefix, "/b"),
+						createMatch(v1beta1.PathMatchExact, "/c"),
+					},
+				},
+			},
+		},
+	}
+
+	route := &graph.Route{
+		Source: hr,
+		Rules: []graph.Rule{
+			{
+				ValidMatches: true,
+				ValidFilters: true,
+			},
+		},
+		ParentRefs: []graph.ParentRef{
+			{
+				Attachment: &graph.ParentRefAttachmentStatus{
+					AcceptedHostnames: map[string][]string{listenerName: {"foo.example.com"}},
+				},
+			},
+		},
+	}
+
+	gr := &graph.Graph{
+		GatewayClass: &graph.GatewayClass{
+			Source: &v1beta1.GatewayClass{},
+			Valid:  true,
+		},
+		Gateway: &graph.Gateway{
+			Source: &v1beta1.Gateway{},
+			Listeners: map[string]*graph.Listener{
+				listenerName: {
+					Source: v1beta1.Listener{Name: listenerName, Port: 80, Protocol: v1beta1.HTTPProtocolType},
+					Valid:  true,
+					Routes: map[types.NamespacedName]*graph.Route{
+						client.ObjectKeyFromObject(hr): route,
+					},
+				},
+			},
+		},
+		Routes: map[types.NamespacedName]*graph.Route{
+			client.ObjectKeyFromObject(hr): route,
+		},
+	}
+
+	conf := BuildConfiguration(context.TODO(), gr, &resolverfakes.FakeServiceResolver{})
+
+	g.Expect(conf.HTTPServers).To(HaveLen(2))
+
+	server := conf.HTTPServers[1]
+	g.Expect(server.Hostname).To(Equal("foo.example.com"))
+
+	paths := make([]string, 0, len(server.PathRules))
+	for _, r := range server.PathRules {
+		paths = append(paths, r.Path)
+	}
+	g.Expect(paths).To(Equal([]string{"/b", "/c", "/a/[0-9]+"}))
+	g.Expect(server.PathRules[2].PathType).To(Equal(PathTypeRegularExpression))
+}
+
+func TestBuildConfigurationCatchAll(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	const listenerName = "listener-80"
+
+	catchAllHR := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "catch-all"},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
+				{}, // no matches
+			},
+		},
+	}
+
+	explicitHR := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "explicit"},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
+				{
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+								Value: helpers.GetPointer("/coffee"),
+							},
+						},
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+								Value: helpers.GetPointer("/"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	createRoute := func(hr *v1beta1.HTTPRoute) *graph.Route {
+		return &graph.Route{
+			Source: hr,
+			Rules: []graph.Rule{
+				{
+					ValidMatches: true,
+					ValidFilters: true,
+				},
+			},
+			ParentRefs: []graph.ParentRef{
+				{
+					Attachment: &graph.ParentRefAttachmentStatus{
+						AcceptedHostnames: map[string][]string{listenerName: {"foo.example.com"}},
+					},
+				},
+			},
+		}
+	}
+
+	routes := map[types.NamespacedName]*graph.Route{
+		client.ObjectKeyFromObject(catchAllHR): createRoute(catchAllHR),
+		client.ObjectKeyFromObject(explicitHR): createRoute(explicitHR),
+	}
+
+	gr := &graph.Graph{
+		GatewayClass: &graph.GatewayClass{
+			Source: &v1beta1.GatewayClass{},
+			Valid:  true,
+		},
+		Gateway: &graph.Gateway{
+			Source: &v1beta1.Gateway{},
+			Listeners: map[string]*graph.Listener{
+				listenerName: {
+					Source: v1beta1.Listener{Name: listenerName, Port: 80, Protocol: v1beta1.HTTPProtocolType},
+					Valid:  true,
+					Routes: routes,
+				},
+			},
+		},
+		Routes: routes,
+	}
+
+	conf := BuildConfiguration(context.TODO(), gr, &resolverfakes.FakeServiceResolver{})
+
+	g.Expect(conf.HTTPServers).To(HaveLen(2))
+
+	server := conf.HTTPServers[1]
+	g.Expect(server.PathRules).To(HaveLen(2))
+
+	rootRule := server.PathRules[0]
+	g.Expect(rootRule.Path).To(Equal("/"))
+	g.Expect(rootRule.PathType).To(Equal(PathTypePrefix))
+
+	// The catch-all rule has the lowest priority, even though its route comes first in alphabetical order.
+	g.Expect(rootRule.MatchRules).To(HaveLen(2))
+	g.Expect(rootRule.MatchRules[0].Source).To(Equal(explicitHR))
+	g.Expect(rootRule.MatchRules[0].MatchIdx).To(Equal(1))
+	g.Expect(rootRule.MatchRules[1].Source).To(Equal(catchAllHR))
+	g.Expect(rootRule.MatchRules[1].GetMatch()).To(Equal(v1beta1.HTTPRouteMatch{
+		Path: &v1beta1.HTTPPathMatch{
+			Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+			Value: helpers.GetPointer("/"),
+		},
+	}))
+
+	g.Expect(server.PathRules[1].Path).To(Equal("/coffee"))
+}
+
+func TestBuildConfigurationMultipleHostnames(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	const listenerName = "listener-80"
+
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr"},
+		Spec: v1beta1.HTTPRouteSpec{
+			Hostnames: []v1beta1.Hostname{"foo.example.com", "bar.example.com"},
+			Rules: []v1beta1.HTTPRouteRule{
+				{
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+								Value: helpers.GetPointer("/coffee"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	route := &graph.Route{
+		Source: hr,
+		Valid:  true,
+		Rules: []graph.Rule{
+			{
+				ValidMatches: true,
+				ValidFilters: true,
+			},
+		},
+		ParentRefs: []graph.ParentRef{
+			{
+				Attachment: &graph.ParentRefAttachmentStatus{
+					AcceptedHostnames: map[string][]string{listenerName: {"foo.example.com", "bar.example.com"}},
+				},
+			},
+		},
+	}
+
+	routes := map[types.NamespacedName]*graph.Route{
+		client.ObjectKeyFromObject(hr): route,
+	}
+
+	gr := &graph.Graph{
+		GatewayClass: &graph.GatewayClass{
+			Source: &v1beta1.GatewayClass{},
+			Valid:  true,
+		},
+		Gateway: &graph.Gateway{
+			Source: &v1beta1.Gateway{},
+			Listeners: map[string]*graph.Listener{
+				listenerName: {
+					Source: v1beta1.Listener{Name: listenerName, Port: 80, Protocol: v1beta1.HTTPProtocolType},
+					Valid:  true,
+					Routes: routes,
+				},
+			},
+		},
+		Routes: routes,
+	}
+
+	conf := BuildConfiguration(context.TODO(), gr, &resolverfakes.FakeServiceResolver{})
+
+	// Every hostname of the route gets its own server, in addition to the default server.
+	g.Expect(conf.HTTPServers).To(HaveLen(3))
+	g.Expect(conf.HTTPServers[0].IsDefault).To(BeTrue())
+
+	barServer := conf.HTTPServers[1]
+	fooServer := conf.HTTPServers[2]
+
+	g.Expect(barServer.Hostname).To(Equal("bar.example.com"))
+	g.Expect(fooServer.Hostname).To(Equal("foo.example.com"))
+
+	g.Expect(fooServer.PathRules).To(HaveLen(1))
+	g.Expect(fooServer.PathRules[0].Path).To(Equal("/coffee"))
+	g.Expect(barServer.PathRules).To(Equal(fooServer.PathRules))
+}
+
 func TestConvertPathType(t *testing.T) {
 	g := NewGomegaWithT(t)
 
@@ -2078,7 +3455,11 @@ func TestConvertPathType(t *testing.T) {
 			pathType: v1beta1.PathMatchExact,
 		},
 		{
+			expected: PathTypeRegularExpression,
 			pathType: v1beta1.PathMatchRegularExpression,
+		},
+		{
+			pathType: "Unknown",
 			panic:    true,
 		},
 	}
@@ -0,0 +1,128 @@
+package dataplane
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/resolver"
+)
+
+// ConfigHash returns the SHA-256 hash of the Configuration.
+// Two Configurations that result in the same NGINX configuration have the same hash. To achieve that, the hash
+// doesn't depend on the order of Upstreams, their Endpoints and BackendGroups, which is not deterministic, and on
+// the fields of
+// the source HTTPRoutes that are not used in the NGINX configuration, like the status or the resource version.
+func (c Configuration) ConfigHash() [32]byte {
+	h := sha256.New()
+
+	// Configuration consists only of types that can be encoded, so encoding never fails.
+	if err := json.NewEncoder(h).Encode(c.normalize()); err != nil {
+		panic(fmt.Errorf("failed to encode configuration: %w", err))
+	}
+
+	var hash [32]byte
+	copy(hash[:], h.Sum(nil))
+
+	return hash
+}
+
+// normalize returns a copy of the Configuration suitable for hashing.
+func (c Configuration) normalize() Configuration {
+	normalized := c
+
+	normalized.HTTPServers = normalizeServers(c.HTTPServers)
+	normalized.SSLServers = normalizeServers(c.SSLServers)
+
+	if c.Upstreams != nil {
+		normalized.Upstreams = make([]Upstream, 0, len(c.Upstreams))
+
+		for _, u := range c.Upstreams {
+			if u.Endpoints != nil {
+				endpoints := make([]resolver.Endpoint, len(u.Endpoints))
+				copy(endpoints, u.Endpoints)
+
+				sort.Slice(endpoints, func(i, j int) bool {
+					if endpoints[i].Address != endpoints[j].Address {
+						return endpoints[i].Address < endpoints[j].Address
+					}
+					return endpoints[i].Port < endpoints[j].Port
+				})
+
+				u.Endpoints = endpoints
+			}
+
+			normalized.Upstreams = append(normalized.Upstreams, u)
+		}
+
+		sort.Slice(normalized.Upstreams, func(i, j int) bool {
+			return normalized.Upstreams[i].Name < normalized.Upstreams[j].Name
+		})
+	}
+
+	if c.BackendGroups != nil {
+		normalized.BackendGroups = make([]BackendGroup, len(c.BackendGroups))
+		copy(normalized.BackendGroups, c.BackendGroups)
+
+		sort.Slice(normalized.BackendGroups, func(i, j int) bool {
+			gi, gj := normalized.BackendGroups[i], normalized.BackendGroups[j]
+			if gi.Source != gj.Source {
+				return gi.Source.String() < gj.Source.String()
+			}
+			return gi.RuleIdx < gj.RuleIdx
+		})
+	}
+
+	return normalized
+}
+
+func normalizeServers(servers []VirtualServer) []VirtualServer {
+	if servers == nil {
+		return nil
+	}
+
+	normalized := make([]VirtualServer, 0, len(servers))
+
+	for _, s := range servers {
+		if s.PathRules != nil {
+			pathRules := make([]PathRule, 0, len(s.PathRules))
+
+			for _, pr := range s.PathRules {
+				matchRules := make([]MatchRule, 0, len(pr.MatchRules))
+
+				for _, mr := range pr.MatchRules {
+					mr.Source = normalizeSource(mr.Source)
+					matchRules = append(matchRules, mr)
+				}
+
+				pr.MatchRules = matchRules
+				pathRules = append(pathRules, pr)
+			}
+
+			s.PathRules = pathRules
+		}
+
+		normalized = append(normalized, s)
+	}
+
+	return normalized
+}
+
+// normalizeSource keeps only the fields of the HTTPRoute that the NGINX configuration depends on.
+func normalizeSource(hr *v1beta1.HTTPRoute) *v1beta1.HTTPRoute {
+	if hr == nil {
+		return nil
+	}
+
+	return &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: hr.Namespace,
+			Name:      hr.Name,
+		},
+		Spec: hr.Spec,
+	}
+}
@@ -0,0 +1,169 @@
+package dataplane
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/resolver"
+)
+
+func TestConfigHash(t *testing.T) {
+	createConfig := func(modify func(conf *Configuration)) Configuration {
+		hr := &v1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "test",
+				Name:            "hr",
+				ResourceVersion: "1",
+			},
+			Spec: v1beta1.HTTPRouteSpec{
+				Hostnames: []v1beta1.Hostname{"foo.example.com"},
+			},
+		}
+
+		conf := Configuration{
+			HTTPServers: []VirtualServer{
+				{
+					Hostname: "foo.example.com",
+					PathRules: []PathRule{
+						{
+							Path:     "/",
+							PathType: PathTypePrefix,
+							MatchRules: []MatchRule{
+								{
+									Source: hr,
+								},
+							},
+						},
+					},
+					Port: 80,
+				},
+			},
+			Upstreams: []Upstream{
+				{
+					Name: "test_foo_80",
+					Endpoints: []resolver.Endpoint{
+						{Address: "10.0.0.1", Port: 8080},
+						{Address: "10.0.0.2", Port: 8080},
+					},
+				},
+				{
+					Name: "test_bar_80",
+				},
+			},
+			BackendGroups: []BackendGroup{
+				{
+					Source:  types.NamespacedName{Namespace: "test", Name: "hr"},
+					RuleIdx: 0,
+				},
+				{
+					Source:  types.NamespacedName{Namespace: "test", Name: "hr"},
+					RuleIdx: 1,
+				},
+			},
+			SSLKeyPairs: map[SSLKeyPairID]SSLKeyPair{
+				"test-keypair": {
+					Cert: []byte("cert"),
+					Key:  []byte("key"),
+				},
+			},
+		}
+
+		if modify != nil {
+			modify(&conf)
+		}
+
+		return conf
+	}
+
+	tests := []struct {
+		modify    func(conf *Configuration)
+		name      string
+		expChange bool
+	}{
+		{
+			name:      "same configuration",
+			modify:    nil,
+			expChange: false,
+		},
+		{
+			name: "different order of upstreams, endpoints and backend groups",
+			modify: func(conf *Configuration) {
+				conf.Upstreams[0], conf.Upstreams[1] = conf.Upstreams[1], conf.Upstreams[0]
+				conf.BackendGroups[0], conf.BackendGroups[1] = conf.BackendGroups[1], conf.BackendGroups[0]
+				eps := conf.Upstreams[1].Endpoints
+				eps[0], eps[1] = eps[1], eps[0]
+			},
+			expChange: false,
+		},
+		{
+			name: "different source fields not used in NGINX configuration",
+			modify: func(conf *Configuration) {
+				hr := conf.HTTPServers[0].PathRules[0].MatchRules[0].Source
+				hr.ResourceVersion = "2"
+				hr.Status.Parents = []v1beta1.RouteParentStatus{{ControllerName: "my.controller"}}
+			},
+			expChange: false,
+		},
+		{
+			name: "different source spec",
+			modify: func(conf *Configuration) {
+				hr := conf.HTTPServers[0].PathRules[0].MatchRules[0].Source
+				hr.Spec.Hostnames = []v1beta1.Hostname{"bar.example.com"}
+			},
+			expChange: true,
+		},
+		{
+			name: "different endpoint",
+			modify: func(conf *Configuration) {
+				conf.Upstreams[0].Endpoints[0].Address = "10.0.0.3"
+			},
+			expChange: true,
+		},
+		{
+			name: "different certificate",
+			modify: func(conf *Configuration) {
+				conf.SSLKeyPairs["test-keypair"] = SSLKeyPair{Cert: []byte("new-cert"), Key: []byte("key")}
+			},
+			expChange: true,
+		},
+	}
+
+	expected := createConfig(nil).ConfigHash()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf := createConfig(test.modify)
+
+			changed := conf.ConfigHash() != expected
+			if changed != test.expChange {
+				t.Errorf("ConfigHash() changed = %v, expected %v", changed, test.expChange)
+			}
+		})
+	}
+}
+
+func TestConfigHashDoesNotModifyConfiguration(t *testing.T) {
+	conf := Configuration{
+		Upstreams: []Upstream{
+			{
+				Name: "b",
+				Endpoints: []resolver.Endpoint{
+					{Address: "10.0.0.2", Port: 80},
+					{Address: "10.0.0.1", Port: 80},
+				},
+			},
+			{
+				Name: "a",
+			},
+		},
+	}
+
+	_ = conf.ConfigHash()
+
+	if conf.Upstreams[0].Name != "b" || conf.Upstreams[0].Endpoints[0].Address != "10.0.0.2" {
+		t.Errorf("ConfigHash() modified the configuration: %+v", conf.Upstreams)
+	}
+}
@@ -37,9 +37,16 @@ If ties still exist within the Route that has been given precedence,
 matching precedence MUST be granted to the first matching rule meeting the above criteria.
 
 higherPriority will determine precedence by comparing len(headers), len(query parameters), creation timestamp,
-and namespace name. The other criteria are handled by NGINX.
+and namespace name. The other criteria are handled by NGINX. Additionally, a rule without matches always has
+the lowest priority.
 */
 func higherPriority(rule1, rule2 MatchRule) bool {
+	// A catch-all rule (a rule without matches) has the lowest priority, so that it only gets the requests
+	// that no other rule matches.
+	if catchAll1, catchAll2 := rule1.isCatchAll(), rule2.isCatchAll(); catchAll1 != catchAll2 {
+		return catchAll2
+	}
+
 	// Get the matches from the rules
 	match1 := rule1.GetMatch()
 	match2 := rule2.GetMatch()
@@ -1,10 +1,15 @@
 package dataplane
 
 import (
+	"fmt"
+	"math/rand"
+	"reflect"
 	"testing"
+	"testing/quick"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
@@ -19,12 +24,12 @@ func TestSort(t *testing.T) {
 	// matches
 	pathOnlyMatch := v1beta1.HTTPRouteMatch{
 		Path: &v1beta1.HTTPPathMatch{
-			Value: helpers.GetStringPointer("/path"), // path match only (low priority)
+			Value: helpers.GetPointer("/path"), // path match only (low priority)
 		},
 	}
 	twoHeaderMatch := v1beta1.HTTPRouteMatch{
 		Path: &v1beta1.HTTPPathMatch{
-			Value: helpers.GetStringPointer("/path"),
+			Value: helpers.GetPointer("/path"),
 		},
 		Headers: []v1beta1.HTTPHeaderMatch{
 			{
@@ -39,7 +44,7 @@ func TestSort(t *testing.T) {
 	}
 	threeHeaderMatch := v1beta1.HTTPRouteMatch{
 		Path: &v1beta1.HTTPPathMatch{
-			Value: helpers.GetStringPointer("/path"),
+			Value: helpers.GetPointer("/path"),
 		},
 		Headers: []v1beta1.HTTPHeaderMatch{
 			{
@@ -58,7 +63,7 @@ func TestSort(t *testing.T) {
 	}
 	twoHeaderOneParamMatch := v1beta1.HTTPRouteMatch{
 		Path: &v1beta1.HTTPPathMatch{
-			Value: helpers.GetStringPointer("/path"),
+			Value: helpers.GetPointer("/path"),
 		},
 		Headers: []v1beta1.HTTPHeaderMatch{
 			{
@@ -79,7 +84,7 @@ func TestSort(t *testing.T) {
 	}
 	methodMatch := v1beta1.HTTPRouteMatch{
 		Path: &v1beta1.HTTPPathMatch{
-			Value: helpers.GetStringPointer("/path"),
+			Value: helpers.GetPointer("/path"),
 		},
 		Method: helpers.GetPointer(v1beta1.HTTPMethodPost),
 	}
@@ -221,3 +226,287 @@ func TestSort(t *testing.T) {
 		t.Errorf("sortMatchRules() mismatch (-want +got):\n%s", diff)
 	}
 }
+
+// matchRulesInput is a random list of MatchRules, generated for the property-based tests of sortMatchRules.
+// Every MatchRule in the list is unique.
+type matchRulesInput []MatchRule
+
+// Generate implements quick.Generator.
+// To cause a lot of ties, the generated values come from small sets: the routes share names, namespaces
+// and creation timestamps, and the matches have a small number of headers and query params.
+func (matchRulesInput) Generate(rand *rand.Rand, _ int) reflect.Value {
+	timestamps := []metav1.Time{
+		metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+		metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 1, 0, time.UTC)),
+	}
+	namespaces := []string{"test", "a-test"}
+	names := []string{"hr1", "hr2"}
+
+	var matchRules []MatchRule
+
+	for routeIdx, numRoutes := 0, rand.Intn(4)+1; routeIdx < numRoutes; routeIdx++ {
+		hr := &v1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              names[rand.Intn(len(names))],
+				Namespace:         namespaces[rand.Intn(len(namespaces))],
+				CreationTimestamp: timestamps[rand.Intn(len(timestamps))],
+			},
+		}
+
+		numRules := rand.Intn(3) + 1
+		hr.Spec.Rules = make([]v1beta1.HTTPRouteRule, numRules)
+
+		for ruleIdx := range hr.Spec.Rules {
+			// a rule without matches is a catch-all rule
+			if rand.Intn(5) == 0 {
+				matchRules = append(matchRules, MatchRule{
+					Source:  hr,
+					RuleIdx: ruleIdx,
+				})
+				continue
+			}
+
+			numMatches := rand.Intn(3) + 1
+			hr.Spec.Rules[ruleIdx].Matches = make([]v1beta1.HTTPRouteMatch, numMatches)
+
+			for matchIdx := range hr.Spec.Rules[ruleIdx].Matches {
+				hr.Spec.Rules[ruleIdx].Matches[matchIdx] = generateMatch(rand)
+				matchRules = append(matchRules, MatchRule{
+					Source:   hr,
+					RuleIdx:  ruleIdx,
+					MatchIdx: matchIdx,
+				})
+			}
+		}
+	}
+
+	rand.Shuffle(len(matchRules), func(i, j int) {
+		matchRules[i], matchRules[j] = matchRules[j], matchRules[i]
+	})
+
+	return reflect.ValueOf(matchRulesInput(matchRules))
+}
+
+func generateMatch(rand *rand.Rand) v1beta1.HTTPRouteMatch {
+	match := v1beta1.HTTPRouteMatch{
+		Path: &v1beta1.HTTPPathMatch{
+			Value: helpers.GetPointer("/path"),
+		},
+	}
+
+	if rand.Intn(2) == 0 {
+		match.Method = helpers.GetPointer(v1beta1.HTTPMethodGet)
+	}
+
+	for i, n := 0, rand.Intn(3); i < n; i++ {
+		match.Headers = append(match.Headers, v1beta1.HTTPHeaderMatch{
+			Name:  v1beta1.HTTPHeaderName(fmt.Sprintf("header%d", i)),
+			Value: "value",
+		})
+	}
+
+	for i, n := 0, rand.Intn(3); i < n; i++ {
+		match.QueryParams = append(match.QueryParams, v1beta1.HTTPQueryParamMatch{
+			Name:  v1beta1.HTTPHeaderName(fmt.Sprintf("param%d", i)),
+			Value: "value",
+		})
+	}
+
+	return match
+}
+
+func TestSortCatchAll(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	earlier := metav1.Now()
+	later := metav1.NewTime(earlier.Add(1 * time.Second))
+
+	catchAllRoute := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "test",
+			Name:              "catch-all",
+			CreationTimestamp: earlier,
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
+				{}, // no matches
+			},
+		},
+	}
+	otherCatchAllRoute := catchAllRoute.DeepCopy()
+	otherCatchAllRoute.Name = "other-catch-all"
+	otherCatchAllRoute.CreationTimestamp = later
+
+	rootRoute := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "test",
+			Name:              "root",
+			CreationTimestamp: later,
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			Rules: []v1beta1.HTTPRouteRule{
+				{
+					Matches: []v1beta1.HTTPRouteMatch{
+						{
+							Path: &v1beta1.HTTPPathMatch{
+								Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+								Value: helpers.GetPointer("/"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	rules := []MatchRule{
+		{Source: otherCatchAllRoute},
+		{Source: catchAllRoute},
+		{Source: rootRoute},
+	}
+
+	sortMatchRules(rules)
+
+	// The explicit match wins even though its route is newer. The catch-all rules are sorted by their routes.
+	g.Expect(rules).To(Equal([]MatchRule{
+		{Source: rootRoute},
+		{Source: catchAllRoute},
+		{Source: otherCatchAllRoute},
+	}))
+}
+
+// matchRuleKey uniquely identifies a MatchRule in matchRulesInput.
+type matchRuleKey struct {
+	source   *v1beta1.HTTPRoute
+	ruleIdx  int
+	matchIdx int
+}
+
+func getMatchRuleKey(r MatchRule) matchRuleKey {
+	return matchRuleKey{source: r.Source, ruleIdx: r.RuleIdx, matchIdx: r.MatchIdx}
+}
+
+func sortedCopy(input matchRulesInput) []MatchRule {
+	sorted := make([]MatchRule, len(input))
+	copy(sorted, input)
+
+	sortMatchRules(sorted)
+
+	return sorted
+}
+
+func TestHigherPriorityIsStrictWeakOrdering(t *testing.T) {
+	// sort.SliceStable requires the less function to be a strict weak ordering.
+	// Otherwise, the result of sorting is undefined.
+	property := func(input matchRulesInput) bool {
+		for _, a := range input {
+			// irreflexivity
+			if higherPriority(a, a) {
+				t.Logf("higherPriority(a, a) is true for %+v", getMatchRuleKey(a))
+				return false
+			}
+
+			for _, b := range input {
+				// asymmetry
+				if higherPriority(a, b) && higherPriority(b, a) {
+					t.Logf(
+						"higherPriority is true for both (a, b) and (b, a) for %+v and %+v",
+						getMatchRuleKey(a),
+						getMatchRuleKey(b),
+					)
+					return false
+				}
+
+				for _, c := range input {
+					// transitivity
+					if higherPriority(a, b) && higherPriority(b, c) && !higherPriority(a, c) {
+						t.Logf("higherPriority is not transitive for %+v, %+v and %+v",
+							getMatchRuleKey(a),
+							getMatchRuleKey(b),
+							getMatchRuleKey(c),
+						)
+						return false
+					}
+				}
+			}
+		}
+
+		return true
+	}
+
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSortMatchRulesIsSorted(t *testing.T) {
+	property := func(input matchRulesInput) bool {
+		sorted := sortedCopy(input)
+
+		for i := 1; i < len(sorted); i++ {
+			if higherPriority(sorted[i], sorted[i-1]) {
+				t.Logf("%+v has a higher priority than the preceding %+v",
+					getMatchRuleKey(sorted[i]),
+					getMatchRuleKey(sorted[i-1]),
+				)
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSortMatchRulesIsStable(t *testing.T) {
+	property := func(input matchRulesInput) bool {
+		originalPositions := make(map[matchRuleKey]int, len(input))
+		for i, r := range input {
+			originalPositions[getMatchRuleKey(r)] = i
+		}
+
+		sorted := sortedCopy(input)
+
+		for i := range sorted {
+			for j := i + 1; j < len(sorted); j++ {
+				equalPriority := !higherPriority(sorted[i], sorted[j]) && !higherPriority(sorted[j], sorted[i])
+				if !equalPriority {
+					continue
+				}
+
+				keyI, keyJ := getMatchRuleKey(sorted[i]), getMatchRuleKey(sorted[j])
+				if originalPositions[keyI] > originalPositions[keyJ] {
+					t.Logf("the relative order of %+v and %+v with equal priority is not preserved", keyI, keyJ)
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSortMatchRulesIsIdempotent(t *testing.T) {
+	property := func(input matchRulesInput) bool {
+		sorted := sortedCopy(input)
+		sortedTwice := sortedCopy(sorted)
+
+		if diff := cmp.Diff(sorted, sortedTwice); diff != "" {
+			t.Logf("sortMatchRules() is not idempotent (-once +twice):\n%s", diff)
+			return false
+		}
+
+		return true
+	}
+
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
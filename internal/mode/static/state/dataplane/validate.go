@@ -0,0 +1,408 @@
+package dataplane
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/graph"
+	staticValidation "github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/validation"
+)
+
+// fileSafeIDRegexp matches the IDs that are safe to use as file names.
+// The names of the upstreams, which the generator also puts into the NGINX configuration, must match it too.
+var fileSafeIDRegexp = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+const fileSafeIDMsg = "must only include letters, digits, '_', '.' and '-'"
+
+// Validate validates a Configuration that was not built from the cluster state, for example, the one imported
+// from a snapshot. It checks that the Configuration is consistent, so that the generator can turn it into
+// NGINX configuration: the IDs are safe to use as file names and all servers and rules reference existing
+// key pairs, Diffie-Hellman parameters, upstreams and HTTPRoute rules.
+//
+// Because the generator puts the values into the NGINX configuration verbatim, Validate also runs the same checks
+// as building the Graph from the cluster state: the validator validates the HTTPRoute fields, such as the paths,
+// and the parsers of the annotations validate the annotation values, such as the hostnames and the error pages.
+// The snippets are only valid if enableSnippets is true.
+func (c Configuration) Validate(validator staticValidation.HTTPFieldsValidator, enableSnippets bool) error {
+	var allErrs field.ErrorList
+
+	keyPairsPath := field.NewPath("SSLKeyPairs")
+	for id, pair := range c.SSLKeyPairs {
+		if !fileSafeIDRegexp.MatchString(string(id)) {
+			allErrs = append(allErrs, field.Invalid(keyPairsPath.Key(string(id)), id, "must be a valid file name"))
+		}
+		if len(pair.Cert) == 0 || len(pair.Key) == 0 {
+			allErrs = append(allErrs, field.Required(keyPairsPath.Key(string(id)), "cert and key are required"))
+		}
+	}
+
+	dhParamsPath := field.NewPath("DHParams")
+	for id := range c.DHParams {
+		if !fileSafeIDRegexp.MatchString(string(id)) {
+			allErrs = append(allErrs, field.Invalid(dhParamsPath.Key(string(id)), id, "must be a valid file name"))
+		}
+	}
+
+	upstreams := make(map[string]struct{}, len(c.Upstreams))
+	for i, u := range c.Upstreams {
+		upstreams[u.Name] = struct{}{}
+		allErrs = append(allErrs, validateUpstream(field.NewPath("Upstreams").Index(i), u)...)
+	}
+
+	for i, g := range c.BackendGroups {
+		allErrs = append(allErrs, validateBackendGroup(field.NewPath("BackendGroups").Index(i), g, upstreams)...)
+	}
+
+	allErrs = append(allErrs, c.validateHTTPSettings(enableSnippets)...)
+
+	sv := serverValidator{
+		validator:      validator,
+		upstreams:      upstreams,
+		enableSnippets: enableSnippets,
+	}
+
+	allErrs = append(allErrs, c.validateServers(field.NewPath("HTTPServers"), c.HTTPServers, sv)...)
+	allErrs = append(allErrs, c.validateServers(field.NewPath("SSLServers"), c.SSLServers, sv)...)
+
+	return allErrs.ToAggregate()
+}
+
+// validateHTTPSettings validates the settings of the http and main contexts.
+func (c Configuration) validateHTTPSettings(enableSnippets bool) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if c.HTTPSnippet != "" {
+		if err := graph.ValidateSnippet(c.HTTPSnippet, enableSnippets); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("HTTPSnippet"), c.HTTPSnippet, err.Error()))
+		}
+	}
+
+	if err := graph.ValidateTrustedProxies(c.TrustedProxies); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("TrustedProxies"), c.TrustedProxies, err.Error()))
+	}
+
+	if err := graph.ValidateDNSResolvers(c.DNSResolvers); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("DNSResolvers"), c.DNSResolvers, err.Error()))
+	}
+
+	if c.WorkerProcesses != "" {
+		if err := graph.ValidateWorkerProcesses(c.WorkerProcesses); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("WorkerProcesses"), c.WorkerProcesses, err.Error()))
+		}
+	}
+
+	if c.Gzip != nil {
+		if err := graph.ValidateGzip(c.Gzip.MinLength, c.Gzip.Level, c.Gzip.Types); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("Gzip"), *c.Gzip, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// serverValidator holds what the validation of the servers needs besides the Configuration.
+type serverValidator struct {
+	validator      staticValidation.HTTPFieldsValidator
+	upstreams      map[string]struct{}
+	enableSnippets bool
+}
+
+func (c Configuration) validateServers(
+	path *field.Path,
+	servers []VirtualServer,
+	sv serverValidator,
+) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, s := range servers {
+		serverPath := path.Index(i)
+
+		if s.SSL != nil {
+			allErrs = append(allErrs, c.validateSSL(serverPath.Child("SSL"), *s.SSL)...)
+		}
+
+		// The default server doesn't have a hostname and the servers of the Listeners without a hostname have
+		// the wildcard hostname.
+		defaultHostname := s.IsDefault && s.Hostname == ""
+		if !defaultHostname && s.Hostname != wildcardHostname {
+			if err := graph.ValidateHostname(s.Hostname); err != nil {
+				allErrs = append(allErrs, field.Invalid(serverPath.Child("Hostname"), s.Hostname, err.Error()))
+			}
+		}
+
+		if s.RequestIDHeader != "" {
+			if err := graph.ValidateRequestIDHeader(s.RequestIDHeader); err != nil {
+				allErrs = append(allErrs, field.Invalid(
+					serverPath.Child("RequestIDHeader"),
+					s.RequestIDHeader,
+					err.Error(),
+				))
+			}
+		}
+
+		if s.ServerSnippet != "" {
+			if err := graph.ValidateSnippet(s.ServerSnippet, sv.enableSnippets); err != nil {
+				allErrs = append(allErrs, field.Invalid(serverPath.Child("ServerSnippet"), s.ServerSnippet, err.Error()))
+			}
+		}
+
+		if s.ErrorPage != nil {
+			allErrs = append(allErrs, validateErrorPage(serverPath.Child("ErrorPage"), *s.ErrorPage)...)
+		}
+
+		for j, pathRule := range s.PathRules {
+			allErrs = append(allErrs, validatePathRule(serverPath.Child("PathRules").Index(j), pathRule, sv)...)
+		}
+	}
+
+	return allErrs
+}
+
+func (c Configuration) validateSSL(path *field.Path, ssl SSL) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if _, exists := c.SSLKeyPairs[ssl.KeyPairID]; !exists {
+		allErrs = append(allErrs, field.NotFound(path.Child("KeyPairID"), ssl.KeyPairID))
+	}
+
+	if ssl.DHParamsID != "" {
+		if _, exists := c.DHParams[ssl.DHParamsID]; !exists {
+			allErrs = append(allErrs, field.NotFound(path.Child("DHParamsID"), ssl.DHParamsID))
+		}
+	}
+
+	if ssl.MinTLSVersion != "" {
+		if err := graph.ValidateMinTLSVersion(ssl.MinTLSVersion); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("MinTLSVersion"), ssl.MinTLSVersion, err.Error()))
+		}
+	}
+
+	if len(ssl.Ciphers) > 0 {
+		if err := graph.ValidateCiphers(ssl.Ciphers); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("Ciphers"), ssl.Ciphers, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+func validatePathRule(path *field.Path, pathRule PathRule, sv serverValidator) field.ErrorList {
+	var allErrs field.ErrorList
+
+	var validatePath func(string) error
+
+	switch pathRule.PathType {
+	case PathTypePrefix, PathTypeExact:
+		validatePath = sv.validator.ValidatePathInMatch
+	case PathTypeRegularExpression:
+		validatePath = sv.validator.ValidatePathRegexInMatch
+	default:
+		allErrs = append(allErrs, field.NotSupported(
+			path.Child("PathType"),
+			pathRule.PathType,
+			[]string{string(PathTypePrefix), string(PathTypeExact), string(PathTypeRegularExpression)},
+		))
+	}
+
+	if validatePath != nil {
+		if err := validatePath(pathRule.Path); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("Path"), pathRule.Path, err.Error()))
+		}
+	}
+
+	for i, rule := range pathRule.MatchRules {
+		allErrs = append(allErrs, validateMatchRule(path.Child("MatchRules").Index(i), rule, sv)...)
+	}
+
+	return allErrs
+}
+
+func validateMatchRule(path *field.Path, rule MatchRule, sv serverValidator) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// The generator reads the match of the rule from the HTTPRoute.
+	switch {
+	case rule.Source == nil:
+		allErrs = append(allErrs, field.Required(path.Child("Source"), "the HTTPRoute is required"))
+	case rule.RuleIdx < 0 || rule.RuleIdx >= len(rule.Source.Spec.Rules):
+		allErrs = append(allErrs, field.Invalid(
+			path.Child("RuleIdx"),
+			rule.RuleIdx,
+			fmt.Sprintf("must be less than the number of the HTTPRoute rules %d", len(rule.Source.Spec.Rules)),
+		))
+	default:
+		matches := getRuleMatches(rule.Source.Spec.Rules[rule.RuleIdx])
+		if rule.MatchIdx < 0 || rule.MatchIdx >= len(matches) {
+			allErrs = append(allErrs, field.Invalid(
+				path.Child("MatchIdx"),
+				rule.MatchIdx,
+				fmt.Sprintf("must be less than the number of the HTTPRoute rule matches %d", len(matches)),
+			))
+		} else {
+			matchPath := path.Child("Source", "spec", "rules").Index(rule.RuleIdx).Child("matches").Index(rule.MatchIdx)
+			allErrs = append(allErrs, graph.ValidateRouteMatch(sv.validator, matches[rule.MatchIdx], matchPath)...)
+		}
+	}
+
+	allErrs = append(allErrs, validateFilters(path.Child("Filters"), rule.Filters, sv.validator)...)
+
+	if rule.LocationSnippet != "" {
+		if err := graph.ValidateLocationSnippet(rule.LocationSnippet, sv.enableSnippets); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("LocationSnippet"), rule.LocationSnippet, err.Error()))
+		}
+	}
+
+	if rule.BackendErrorPage != nil {
+		allErrs = append(allErrs, validateErrorPage(path.Child("BackendErrorPage"), *rule.BackendErrorPage)...)
+	}
+
+	if rule.NextUpstream != nil {
+		if err := graph.ValidateNextUpstream(rule.NextUpstream.Conditions, rule.NextUpstream.Tries); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("NextUpstream"), *rule.NextUpstream, err.Error()))
+		}
+	}
+
+	allErrs = append(allErrs, validateBackendGroup(path.Child("BackendGroup"), rule.BackendGroup, sv.upstreams)...)
+
+	return allErrs
+}
+
+func validateFilters(
+	path *field.Path,
+	filters Filters,
+	validator staticValidation.HTTPFieldsValidator,
+) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if filters.RequestRedirect != nil {
+		allErrs = append(allErrs, graph.ValidateRequestRedirect(validator, filters.RequestRedirect, path)...)
+	}
+
+	if modifier := filters.RequestHeaderModifiers; modifier != nil {
+		headerFilter := &v1beta1.HTTPHeaderFilter{
+			Set:    convertHTTPHeaders(modifier.Set),
+			Add:    convertHTTPHeaders(modifier.Add),
+			Remove: modifier.Remove,
+		}
+
+		allErrs = append(allErrs, graph.ValidateRequestHeaderModifier(validator, headerFilter, path)...)
+	}
+
+	return allErrs
+}
+
+func convertHTTPHeaders(headers []HTTPHeader) []v1beta1.HTTPHeader {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	converted := make([]v1beta1.HTTPHeader, 0, len(headers))
+	for _, h := range headers {
+		converted = append(converted, v1beta1.HTTPHeader{
+			Name:  v1beta1.HTTPHeaderName(h.Name),
+			Value: h.Value,
+		})
+	}
+
+	return converted
+}
+
+func validateErrorPage(path *field.Path, page ErrorPage) field.ErrorList {
+	if err := graph.ValidateErrorPage(page.StatusCodes, page.Body, page.ContentType); err != nil {
+		return field.ErrorList{field.Invalid(path, page, err.Error())}
+	}
+
+	return nil
+}
+
+// validateBackendGroup validates the backend group. The generator puts the name of a group with backends, which
+// includes the namespace and the name of the HTTPRoute, into the NGINX configuration.
+func validateBackendGroup(path *field.Path, group BackendGroup, upstreams map[string]struct{}) field.ErrorList {
+	if len(group.Backends) == 0 {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	if msgs := validation.IsDNS1123Label(group.Source.Namespace); len(msgs) > 0 {
+		allErrs = append(allErrs, field.Invalid(
+			path.Child("Source", "Namespace"),
+			group.Source.Namespace,
+			strings.Join(msgs, ", "),
+		))
+	}
+
+	if msgs := validation.IsDNS1123Subdomain(group.Source.Name); len(msgs) > 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("Source", "Name"), group.Source.Name, strings.Join(msgs, ", ")))
+	}
+
+	for i, b := range group.Backends {
+		backendPath := path.Child("Backends").Index(i)
+
+		switch {
+		case !b.Valid:
+		case b.DNSAddress != "":
+			if err := validateDNSAddress(b.DNSAddress); err != nil {
+				allErrs = append(allErrs, field.Invalid(backendPath.Child("DNSAddress"), b.DNSAddress, err.Error()))
+			}
+		default:
+			if _, exists := upstreams[b.UpstreamName]; !exists {
+				allErrs = append(allErrs, field.NotFound(backendPath.Child("UpstreamName"), b.UpstreamName))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateDNSAddress validates the DNS name and the port of an ExternalName Service.
+func validateDNSAddress(address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if msgs := validation.IsDNS1123Subdomain(host); len(msgs) > 0 {
+		return fmt.Errorf("invalid DNS name: %s", strings.Join(msgs, ", "))
+	}
+
+	p, err := strconv.Atoi(port)
+	if err != nil || len(validation.IsValidPortNum(p)) > 0 {
+		return fmt.Errorf("invalid port %q", port)
+	}
+
+	return nil
+}
+
+func validateUpstream(path *field.Path, u Upstream) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if !fileSafeIDRegexp.MatchString(u.Name) {
+		allErrs = append(allErrs, field.Invalid(path.Child("Name"), u.Name, fileSafeIDMsg))
+	}
+
+	for i, ep := range u.Endpoints {
+		endpointPath := path.Child("Endpoints").Index(i)
+
+		if net.ParseIP(ep.Address) == nil {
+			allErrs = append(allErrs, field.Invalid(endpointPath.Child("Address"), ep.Address, "must be an IP address"))
+		}
+
+		if msgs := validation.IsValidPortNum(int(ep.Port)); len(msgs) > 0 {
+			allErrs = append(allErrs, field.Invalid(endpointPath.Child("Port"), ep.Port, strings.Join(msgs, ", ")))
+		}
+
+		if msgs := validation.IsValidLabelValue(ep.Zone); len(msgs) > 0 {
+			allErrs = append(allErrs, field.Invalid(endpointPath.Child("Zone"), ep.Zone, strings.Join(msgs, ", ")))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,438 @@
+package dataplane
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/resolver"
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/validation/validationfakes"
+)
+
+func TestConfigurationValidate(t *testing.T) {
+	createConfig := func(modify func(conf *Configuration)) Configuration {
+		hr := &v1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "hr"},
+			Spec: v1beta1.HTTPRouteSpec{
+				Rules: []v1beta1.HTTPRouteRule{
+					{
+						Matches: []v1beta1.HTTPRouteMatch{{}, {}},
+					},
+				},
+			},
+		}
+
+		// The servers don't share the path rules, so that the tests can modify the rules of one server.
+		createPathRules := func() []PathRule {
+			return []PathRule{
+				{
+					Path:     "/",
+					PathType: PathTypePrefix,
+					MatchRules: []MatchRule{
+						{
+							Source:   hr,
+							RuleIdx:  0,
+							MatchIdx: 1,
+							BackendGroup: BackendGroup{
+								Source: types.NamespacedName{Namespace: "test", Name: "hr"},
+								Backends: []Backend{
+									{UpstreamName: "test_foo_80", Valid: true, Weight: 1},
+									{DNSAddress: "example.com:80", Valid: true, Weight: 1},
+									{UpstreamName: "invalid", Valid: false, Weight: 1},
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		conf := Configuration{
+			SSLKeyPairs: map[SSLKeyPairID]SSLKeyPair{
+				"ssl_keypair_test_secret": {Cert: []byte("cert"), Key: []byte("key")},
+			},
+			DHParams: map[DHParamsID][]byte{
+				"dhparams_test_secret": []byte("params"),
+			},
+			HTTPServers: []VirtualServer{
+				{
+					Hostname:  "foo.example.com",
+					PathRules: createPathRules(),
+					Port:      80,
+				},
+			},
+			SSLServers: []VirtualServer{
+				{
+					Hostname: "foo.example.com",
+					SSL: &SSL{
+						KeyPairID:  "ssl_keypair_test_secret",
+						DHParamsID: "dhparams_test_secret",
+					},
+					PathRules: createPathRules(),
+					Port:      443,
+				},
+			},
+			Upstreams: []Upstream{{Name: "test_foo_80"}},
+		}
+
+		if modify != nil {
+			modify(&conf)
+		}
+
+		return conf
+	}
+
+	invalidPathValidator := &validationfakes.FakeHTTPFieldsValidator{}
+	invalidPathValidator.ValidatePathInMatchCalls(func(path string) error {
+		if path != "/" {
+			return errors.New("invalid path")
+		}
+		return nil
+	})
+
+	tests := []struct {
+		validator      *validationfakes.FakeHTTPFieldsValidator
+		conf           Configuration
+		msg            string
+		expectedErr    string
+		enableSnippets bool
+	}{
+		{
+			conf: createConfig(nil),
+			msg:  "valid",
+		},
+		{
+			conf: Configuration{},
+			msg:  "empty",
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.SSLKeyPairs["../secret"] = SSLKeyPair{Cert: []byte("cert"), Key: []byte("key")}
+				conf.DHParams["../dhparams"] = []byte("params")
+			}),
+			msg: "IDs are not valid file names",
+			expectedErr: `[SSLKeyPairs[../secret]: Invalid value: "../secret": must be a valid file name, ` +
+				`DHParams[../dhparams]: Invalid value: "../dhparams": must be a valid file name]`,
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.SSLKeyPairs["ssl_keypair_test_secret"] = SSLKeyPair{Cert: []byte("cert")}
+			}),
+			msg:         "key pair without key",
+			expectedErr: "SSLKeyPairs[ssl_keypair_test_secret]: Required value: cert and key are required",
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				delete(conf.SSLKeyPairs, "ssl_keypair_test_secret")
+				delete(conf.DHParams, "dhparams_test_secret")
+			}),
+			msg: "SSL server references non-existing key pair and DH params",
+			expectedErr: `[SSLServers[0].SSL.KeyPairID: Not found: "ssl_keypair_test_secret", ` +
+				`SSLServers[0].SSL.DHParamsID: Not found: "dhparams_test_secret"]`,
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.Upstreams = nil
+			}),
+			msg: "backend references non-existing upstream",
+			expectedErr: `[HTTPServers[0].PathRules[0].MatchRules[0].BackendGroup.Backends[0].UpstreamName: ` +
+				`Not found: "test_foo_80", ` +
+				`SSLServers[0].PathRules[0].MatchRules[0].BackendGroup.Backends[0].UpstreamName: ` +
+				`Not found: "test_foo_80"]`,
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.HTTPServers[0].PathRules = []PathRule{{Path: "/", PathType: "unknown"}}
+			}),
+			msg: "unsupported path type",
+			expectedErr: `HTTPServers[0].PathRules[0].PathType: Unsupported value: "unknown": ` +
+				`supported values: "prefix", "exact", "regex"`,
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.HTTPServers[0].PathRules = []PathRule{
+					{
+						Path:       "/",
+						PathType:   PathTypeExact,
+						MatchRules: []MatchRule{{}},
+					},
+				}
+			}),
+			msg:         "match rule without HTTPRoute",
+			expectedErr: "HTTPServers[0].PathRules[0].MatchRules[0].Source: Required value: the HTTPRoute is required",
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.HTTPServers[0].PathRules = []PathRule{
+					{
+						Path:     "/",
+						PathType: PathTypeExact,
+						MatchRules: []MatchRule{
+							{
+								Source:  &v1beta1.HTTPRoute{},
+								RuleIdx: 1,
+							},
+						},
+					},
+				}
+			}),
+			msg: "rule index out of range",
+			expectedErr: "HTTPServers[0].PathRules[0].MatchRules[0].RuleIdx: Invalid value: 1: " +
+				"must be less than the number of the HTTPRoute rules 0",
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.HTTPServers[0].PathRules = []PathRule{
+					{
+						Path:     "/",
+						PathType: PathTypeExact,
+						MatchRules: []MatchRule{
+							{
+								Source: &v1beta1.HTTPRoute{
+									Spec: v1beta1.HTTPRouteSpec{
+										// The rule without matches has the catch-all match.
+										Rules: []v1beta1.HTTPRouteRule{{}},
+									},
+								},
+								MatchIdx: 1,
+							},
+						},
+					},
+				}
+			}),
+			msg: "match index out of range",
+			expectedErr: "HTTPServers[0].PathRules[0].MatchRules[0].MatchIdx: Invalid value: 1: " +
+				"must be less than the number of the HTTPRoute rule matches 1",
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.HTTPServers[0].PathRules[0].Path = "/foo;\n    proxy_pass http://example.com"
+			}),
+			validator: invalidPathValidator,
+			msg:       "injected path",
+			expectedErr: `HTTPServers[0].PathRules[0].Path: Invalid value: "/foo;\n    proxy_pass http://example.com": ` +
+				`invalid path`,
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.HTTPServers[0].PathRules = []PathRule{
+					{
+						Path:     "/",
+						PathType: PathTypePrefix,
+						MatchRules: []MatchRule{
+							{
+								Source: &v1beta1.HTTPRoute{
+									Spec: v1beta1.HTTPRouteSpec{
+										Rules: []v1beta1.HTTPRouteRule{
+											{
+												Matches: []v1beta1.HTTPRouteMatch{{Path: &v1beta1.HTTPPathMatch{}}},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			}),
+			msg: "match without path type and value",
+			expectedErr: "[HTTPServers[0].PathRules[0].MatchRules[0].Source.spec.rules[0].matches[0].path.type: " +
+				"Required value: cannot be empty, " +
+				"HTTPServers[0].PathRules[0].MatchRules[0].Source.spec.rules[0].matches[0].path.value: " +
+				"Required value: cannot be empty]",
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.HTTPServers[0].Hostname = "foo.example.com *"
+				conf.HTTPServers = append(
+					conf.HTTPServers,
+					VirtualServer{Hostname: wildcardHostname, Port: 80},
+					VirtualServer{IsDefault: true, Port: 80},
+				)
+			}),
+			msg: "invalid hostname",
+			expectedErr: `HTTPServers[0].Hostname: Invalid value: "foo.example.com *": a lowercase RFC 1123 ` +
+				`subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end ` +
+				`with an alphanumeric character (e.g. 'example.com', regex used for validation is ` +
+				`'[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`,
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.HTTPSnippet = "keepalive_timeout 30s;"
+				conf.HTTPServers[0].ServerSnippet = "return 200;"
+				conf.HTTPServers[0].PathRules[0].MatchRules[0].LocationSnippet = "add_header X-Foo bar;"
+			}),
+			msg: "snippets are disabled",
+			expectedErr: `[HTTPSnippet: Invalid value: "keepalive_timeout 30s;": snippets are disabled; ` +
+				`NKG must run with --enable-snippets to allow them, ` +
+				`HTTPServers[0].ServerSnippet: Invalid value: "return 200;": snippets are disabled; ` +
+				`NKG must run with --enable-snippets to allow them, ` +
+				`HTTPServers[0].PathRules[0].MatchRules[0].LocationSnippet: Invalid value: "add_header X-Foo bar;": ` +
+				`snippets are disabled; NKG must run with --enable-snippets to allow them]`,
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.HTTPSnippet = "keepalive_timeout 30s;"
+				conf.HTTPServers[0].ServerSnippet = "return 200;"
+				conf.HTTPServers[0].PathRules[0].MatchRules[0].LocationSnippet = "add_header X-Foo bar;"
+			}),
+			enableSnippets: true,
+			msg:            "snippets are enabled",
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.HTTPServers[0].ServerSnippet = "return 200;\n}\nserver {\n    listen 8080;"
+			}),
+			enableSnippets: true,
+			msg:            "injected server snippet",
+			expectedErr: `HTTPServers[0].ServerSnippet: Invalid value: "return 200;\n}\nserver {\n    listen 8080;": ` +
+				`unexpected }`,
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.HTTPServers[0].PathRules[0].MatchRules[0].LocationSnippet = "proxy_pass http://example.com;"
+			}),
+			enableSnippets: true,
+			msg:            "location snippet with proxy_pass",
+			expectedErr: `HTTPServers[0].PathRules[0].MatchRules[0].LocationSnippet: ` +
+				`Invalid value: "proxy_pass http://example.com;": must not include the proxy_pass directive`,
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.HTTPServers[0].RequestIDHeader = "X-Request-ID"
+				conf.HTTPServers[0].ErrorPage = &ErrorPage{
+					StatusCodes: []int{502, 503},
+					Body:        "error",
+					ContentType: "text/plain",
+				}
+				conf.TrustedProxies = []string{"10.0.0.0/8"}
+				conf.DNSResolvers = []string{"10.0.0.10"}
+				conf.WorkerProcesses = "auto"
+				conf.Gzip = &Gzip{Types: []string{"application/json"}, MinLength: 20, Level: 1}
+				conf.HTTPServers[0].PathRules[0].MatchRules[0].NextUpstream = &NextUpstream{
+					Conditions: []string{"error", "timeout"},
+					Tries:      3,
+				}
+				conf.SSLServers[0].SSL.MinTLSVersion = "TLSv1.2"
+				conf.SSLServers[0].SSL.Ciphers = []string{"ECDHE-RSA-AES128-GCM-SHA256", "!aNULL"}
+			}),
+			msg: "valid annotation values",
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.HTTPServers[0].RequestIDHeader = "X-Request-ID: foo"
+				conf.HTTPServers[0].ErrorPage = &ErrorPage{
+					StatusCodes: []int{502},
+					Body:        "$remote_addr",
+					ContentType: "text/plain",
+				}
+				conf.TrustedProxies = []string{" 10.0.0.0/8"}
+				conf.DNSResolvers = []string{"10.0.0.10,10.0.0.11"}
+				conf.WorkerProcesses = "auto; daemon off"
+				conf.Gzip = &Gzip{Types: []string{"application/json; text/plain"}, MinLength: 20, Level: 1}
+				conf.HTTPServers[0].PathRules[0].MatchRules[0].NextUpstream = &NextUpstream{
+					Conditions: []string{"error timeout"},
+				}
+				conf.SSLServers[0].SSL.MinTLSVersion = "TLSv1.2; ssl_protocols TLSv1"
+				conf.SSLServers[0].SSL.Ciphers = []string{"HIGH:!aNULL"}
+			}),
+			msg: "invalid annotation values",
+			expectedErr: `[TrustedProxies: Invalid value: []string{" 10.0.0.0/8"}: ` +
+				`" 10.0.0.0/8" must not include whitespace, ` +
+				`DNSResolvers: Invalid value: []string{"10.0.0.10,10.0.0.11"}: ` +
+				`values must not be empty or include separators, ` +
+				`WorkerProcesses: Invalid value: "auto; daemon off": must be a positive integer or "auto", ` +
+				`Gzip: Invalid value: dataplane.Gzip{Types:[]string{"application/json; text/plain"}, ` +
+				`MinLength:20, Level:1}: "application/json; text/plain" is not a valid MIME type, ` +
+				`HTTPServers[0].RequestIDHeader: Invalid value: "X-Request-ID: foo": ` +
+				`a valid HTTP header must consist of alphanumeric characters or '-' ` +
+				`(e.g. 'X-Header-Name', regex used for validation is '[-A-Za-z0-9]+'), ` +
+				`HTTPServers[0].ErrorPage: Invalid value: dataplane.ErrorPage{Body:"$remote_addr", ` +
+				`ContentType:"text/plain", StatusCodes:[]int{502}}: must not contain $, ` +
+				`HTTPServers[0].PathRules[0].MatchRules[0].NextUpstream: Invalid value: ` +
+				`dataplane.NextUpstream{Conditions:[]string{"error timeout"}, Tries:0}: ` +
+				`"error timeout" is not a supported condition, ` +
+				`SSLServers[0].SSL.MinTLSVersion: Invalid value: "TLSv1.2; ssl_protocols TLSv1": ` +
+				`supported values: "TLSv1.2", "TLSv1.3", ` +
+				`SSLServers[0].SSL.Ciphers: Invalid value: []string{"HIGH:!aNULL"}: ` +
+				`values must not be empty or include separators]`,
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.Upstreams = []Upstream{
+					{
+						Name: "test_foo_80 { server 10.0.0.1; }",
+						Endpoints: []resolver.Endpoint{
+							{Address: "10.0.0.1", Port: 8080, Zone: "us-east-1a"},
+							{Address: "10.0.0.2;", Port: 0, Zone: "us east"},
+						},
+					},
+					{Name: "test_foo_80"},
+				}
+				conf.BackendGroups = []BackendGroup{
+					{
+						Source: types.NamespacedName{Namespace: "test", Name: "hr;"},
+						Backends: []Backend{
+							{DNSAddress: "example.com;:80", Valid: true, Weight: 1},
+						},
+					},
+				}
+			}),
+			msg: "invalid upstreams and backend groups",
+			expectedErr: `[Upstreams[0].Name: Invalid value: "test_foo_80 { server 10.0.0.1; }": ` +
+				`must only include letters, digits, '_', '.' and '-', ` +
+				`Upstreams[0].Endpoints[1].Address: Invalid value: "10.0.0.2;": must be an IP address, ` +
+				`Upstreams[0].Endpoints[1].Port: Invalid value: 0: must be between 1 and 65535, inclusive, ` +
+				`Upstreams[0].Endpoints[1].Zone: Invalid value: "us east": a valid label must be an empty string ` +
+				`or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric ` +
+				`character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is ` +
+				`'(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?'), ` +
+				`BackendGroups[0].Source.Name: Invalid value: "hr;": a lowercase RFC 1123 subdomain must consist ` +
+				`of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric ` +
+				`character (e.g. 'example.com', regex used for validation is ` +
+				`'[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*'), ` +
+				`BackendGroups[0].Backends[0].DNSAddress: Invalid value: "example.com;:80": ` +
+				`invalid DNS name: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric ` +
+				`characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', ` +
+				`regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')]`,
+		},
+		{
+			conf: createConfig(func(conf *Configuration) {
+				conf.HTTPServers[0].PathRules[0].MatchRules[0].Filters = Filters{
+					RequestRedirect: &v1beta1.HTTPRequestRedirectFilter{
+						Path: &v1beta1.HTTPPathModifier{},
+					},
+					RequestHeaderModifiers: &HTTPHeaderFilter{
+						Set: []HTTPHeader{{Name: "X-Foo", Value: "bar"}},
+					},
+				}
+			}),
+			msg: "invalid filters",
+			expectedErr: "HTTPServers[0].PathRules[0].MatchRules[0].Filters.requestRedirect.path: " +
+				"Forbidden: path is not supported",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			validator := test.validator
+			if validator == nil {
+				validator = &validationfakes.FakeHTTPFieldsValidator{}
+			}
+
+			err := test.conf.Validate(validator, test.enableSnippets)
+
+			if test.expectedErr == "" {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(test.expectedErr))
+			}
+		})
+	}
+}
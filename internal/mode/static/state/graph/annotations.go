@@ -0,0 +1,843 @@
+package graph
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ClientMaxBodySizeAnnotation is the annotation of the Gateway and HTTPRoute resources that sets the maximum allowed
+// size of the client request body. The value is a quantity, for example, "10M" or "1Gi". The value "0" disables
+// checking of the size. The annotation of an HTTPRoute overrides the annotation of the Gateway for the routes of
+// that HTTPRoute.
+const ClientMaxBodySizeAnnotation = "gateway.nginx.org/client-max-body-size"
+
+// StreamingProxyAnnotation is the annotation of the HTTPRoute resources that configures the routes of an HTTPRoute
+// for streaming responses, like Server-Sent Events: NGINX forwards the response to the client as soon as it receives
+// it from the backend instead of buffering it. The value is a boolean, for example, "true".
+const StreamingProxyAnnotation = "gateway.nginx.org/streaming-proxy"
+
+// CaseInsensitiveHeaderValuesAnnotation is the annotation of the HTTPRoute resources that makes the Exact header
+// matches of the HTTPRoute compare the header values in a case-insensitive manner. By default, the header values are
+// case-sensitive, as the Gateway API requires. The value is a boolean, for example, "true".
+const CaseInsensitiveHeaderValuesAnnotation = "gateway.nginx.org/case-insensitive-header-values"
+
+// RequestIDHeaderAnnotation is the annotation of the Gateway resources that sets the name of the header, for example,
+// "X-Request-ID", that carries the unique ID of a request to the backends and back to the client in the response.
+// The same ID is available to the NGINX access log as $request_id. The empty value disables the header.
+const RequestIDHeaderAnnotation = "gateway.nginx.org/request-id-header"
+
+// TrustedProxiesAnnotation is the annotation of the Gateway resources that sets the comma-separated list of
+// CIDRs of the trusted proxies in front of NGINX, for example, "10.0.0.0/8,2001:db8::/32". For the requests from
+// the trusted proxies, NGINX takes the client address from the X-Forwarded-For header.
+const TrustedProxiesAnnotation = "gateway.nginx.org/trusted-proxies"
+
+// DNSResolversAnnotation is the annotation of the Gateway resources that sets the comma-separated list of the IP
+// addresses of the DNS servers, for example, "10.96.0.10". NGINX uses the DNS servers to resolve the DNS names of
+// the backends at runtime. The backends of ExternalName Services require the DNS servers.
+const DNSResolversAnnotation = "gateway.nginx.org/dns-resolvers"
+
+// ErrorPageStatusCodesAnnotation is the annotation of the Gateway resources that sets the comma-separated list of
+// HTTP status codes, for example, "404,503", for which NGINX responds with the custom error page instead of its
+// default one. The codes must be in the range 400-599.
+const ErrorPageStatusCodesAnnotation = "gateway.nginx.org/error-page-status-codes"
+
+// ErrorPageBodyAnnotation is the annotation of the Gateway resources that sets the body of the custom error page.
+// It only takes effect together with the ErrorPageStatusCodesAnnotation.
+const ErrorPageBodyAnnotation = "gateway.nginx.org/error-page-body"
+
+// ErrorPageContentTypeAnnotation is the annotation of the Gateway resources that sets the content type of the custom
+// error page, for example, "application/json". The default is "text/html".
+const ErrorPageContentTypeAnnotation = "gateway.nginx.org/error-page-content-type"
+
+// BackendErrorStatusCodesAnnotation is the annotation of the Gateway and HTTPRoute resources that sets the
+// comma-separated list of HTTP status codes, for example, "500,502,503,504", of the backend responses, which NGINX
+// replaces with the custom backend error page. The codes must be in the range 400-599. The annotation of an HTTPRoute
+// overrides the annotation of the Gateway for the routes of that HTTPRoute.
+const BackendErrorStatusCodesAnnotation = "gateway.nginx.org/backend-error-status-codes"
+
+// BackendErrorBodyAnnotation is the annotation of the Gateway and HTTPRoute resources that sets the body of the
+// custom backend error page. It only takes effect together with the BackendErrorStatusCodesAnnotation.
+const BackendErrorBodyAnnotation = "gateway.nginx.org/backend-error-body"
+
+// BackendErrorContentTypeAnnotation is the annotation of the Gateway and HTTPRoute resources that sets the content
+// type of the custom backend error page, for example, "application/json". The default is "text/html".
+const BackendErrorContentTypeAnnotation = "gateway.nginx.org/backend-error-content-type"
+
+const defaultErrorPageContentType = "text/html"
+
+// errorPageAnnotations are the names of the annotations that configure a custom error page.
+type errorPageAnnotations struct {
+	statusCodes string
+	body        string
+	contentType string
+}
+
+var (
+	gatewayErrorPageAnnotations = errorPageAnnotations{
+		statusCodes: ErrorPageStatusCodesAnnotation,
+		body:        ErrorPageBodyAnnotation,
+		contentType: ErrorPageContentTypeAnnotation,
+	}
+	backendErrorPageAnnotations = errorPageAnnotations{
+		statusCodes: BackendErrorStatusCodesAnnotation,
+		body:        BackendErrorBodyAnnotation,
+		contentType: BackendErrorContentTypeAnnotation,
+	}
+)
+
+// ProxyNextUpstreamAnnotation is the annotation of the HTTPRoute resources that sets the comma-separated list of the
+// conditions, for example, "error,timeout,http_502", in which NGINX passes a request to the next backend server of
+// the routes of the HTTPRoute. The value "off" disables passing a request to the next server.
+const ProxyNextUpstreamAnnotation = "gateway.nginx.org/proxy-next-upstream"
+
+// ProxyNextUpstreamTriesAnnotation is the annotation of the HTTPRoute resources that limits the number of tries to
+// pass a request to the next backend server for the routes of the HTTPRoute. The value "0" disables passing a request
+// to the next server.
+const ProxyNextUpstreamTriesAnnotation = "gateway.nginx.org/proxy-next-upstream-tries"
+
+// WorkerProcessesAnnotation is the annotation of the Gateway resources that sets the number of the NGINX worker
+// processes. The value is a positive integer or "auto", which starts one worker process per CPU core. If not set,
+// NGINX starts one worker process.
+const WorkerProcessesAnnotation = "gateway.nginx.org/worker-processes"
+
+// WorkerConnectionsAnnotation is the annotation of the Gateway resources that sets the maximum number of simultaneous
+// connections of an NGINX worker process, including the connections to the backends. The value is a power of two
+// between 512 and 65536. If not set, the limit is 512.
+const WorkerConnectionsAnnotation = "gateway.nginx.org/worker-connections"
+
+// WorkerShutdownTimeoutAnnotation is the annotation of the Gateway resources that sets the time, for which NGINX waits
+// for the active connections to complete during a graceful shutdown, for example, when the Pod is terminated. After
+// the time, NGINX closes the remaining connections. The value is a duration, for example, "20s". The default is 10s.
+const WorkerShutdownTimeoutAnnotation = "gateway.nginx.org/worker-shutdown-timeout"
+
+// OpenFileCacheMaxFilesAnnotation is the annotation of the Gateway resources that enables the NGINX cache of
+// the open file descriptors and sets the maximum number of the cached files. The value is a positive integer.
+const OpenFileCacheMaxFilesAnnotation = "gateway.nginx.org/open-file-cache-max-files"
+
+// OpenFileCacheInactiveAnnotation is the annotation of the Gateway resources that sets the time after which a file,
+// which is not accessed, is removed from the open file cache. The value is a duration, for example, "20s".
+// The default is 60s. It only takes effect together with the OpenFileCacheMaxFilesAnnotation.
+const OpenFileCacheInactiveAnnotation = "gateway.nginx.org/open-file-cache-inactive"
+
+// OpenFileCacheValidAnnotation is the annotation of the Gateway resources that sets the time after which NGINX checks
+// that a file in the open file cache is still valid. The value is a duration, for example, "30s". The default is 60s.
+// It only takes effect together with the OpenFileCacheMaxFilesAnnotation.
+const OpenFileCacheValidAnnotation = "gateway.nginx.org/open-file-cache-valid"
+
+// defaultOpenFileCacheTime is the default inactive and valid time of the open file cache, the same as in NGINX.
+const defaultOpenFileCacheTime = 60 * time.Second
+
+// GzipAnnotation is the annotation of the Gateway resources that enables the gzip compression of the responses.
+// The value is a boolean, for example, "true".
+const GzipAnnotation = "gateway.nginx.org/gzip"
+
+// GzipMinLengthAnnotation is the annotation of the Gateway resources that sets the minimum length in bytes of
+// the responses to compress. The value is a non-negative integer. The default is 20. It only takes effect together with
+// the GzipAnnotation.
+const GzipMinLengthAnnotation = "gateway.nginx.org/gzip-min-length"
+
+// GzipTypesAnnotation is the annotation of the Gateway resources that sets the comma-separated list of the MIME types
+// of the responses to compress in addition to "text/html", for example, "application/json,text/css". The value "*"
+// compresses the responses of any type. It only takes effect together with the GzipAnnotation.
+const GzipTypesAnnotation = "gateway.nginx.org/gzip-types"
+
+// GzipLevelAnnotation is the annotation of the Gateway resources that sets the compression level from 1 (fastest) to
+// 9 (smallest). The default is 1. It only takes effect together with the GzipAnnotation.
+const GzipLevelAnnotation = "gateway.nginx.org/gzip-level"
+
+// The defaults of the gzip settings are the same as in NGINX.
+const (
+	defaultGzipMinLength = 20
+	defaultGzipLevel     = 1
+	minGzipLevel         = 1
+	maxGzipLevel         = 9
+	// anyGzipType is the value of the GzipTypesAnnotation that compresses the responses of any type.
+	anyGzipType = "*"
+)
+
+// HideServerVersionAnnotation is the annotation of the Gateway resources that hides the NGINX version in the Server
+// response header and on the error pages. The value is a boolean. The default is "true", because the version helps
+// attackers to find the known vulnerabilities.
+const HideServerVersionAnnotation = "gateway.nginx.org/hide-server-version"
+
+// UpstreamZoneSizeAnnotation is the annotation of the Gateway resources that sets the size of the shared memory zone
+// of each upstream, which holds the state of the backend servers of the upstream. The value is a quantity between
+// 32Ki and 512Mi, for example, "64Ki". The default is 512Ki, which fits about 650 backend servers.
+const UpstreamZoneSizeAnnotation = "gateway.nginx.org/upstream-zone-size"
+
+const (
+	minUpstreamZoneSize = 32 * 1024
+	maxUpstreamZoneSize = 512 * 1024 * 1024
+)
+
+// ServerSnippetAnnotation is the annotation of the Gateway resources that sets the base64-encoded NGINX directives,
+// which are appended verbatim to the server blocks of the listeners of the Gateway. NKG only checks that the braces
+// of the directives are balanced, so the invalid directives make the reload of NGINX fail.
+// The snippets can read any file of NGINX, including the private keys of other Gateways, so the annotation is only
+// allowed if the snippets are enabled.
+const ServerSnippetAnnotation = "gateway.nginx.org/server-snippet"
+
+// LocationSnippetAnnotation is the annotation of the HTTPRoute resources that sets the base64-encoded NGINX
+// directives, which are appended verbatim to the location blocks of the routes of the HTTPRoute. The directives must
+// not include proxy_pass, which NKG generates for the locations. NKG only checks that the braces of the directives are
+// balanced, so the invalid directives make the reload of NGINX fail.
+// As with the ServerSnippetAnnotation, the annotation is only allowed if the snippets are enabled.
+const LocationSnippetAnnotation = "gateway.nginx.org/location-snippet"
+
+// proxyPassDirectiveRegexp matches the proxy_pass directive at the beginning of a line or after another directive
+// or block.
+var proxyPassDirectiveRegexp = regexp.MustCompile(`(^|[;{}\n])\s*proxy_pass\s`)
+
+const (
+	snippetsDisabledMsg = "snippets are disabled; NKG must run with --enable-snippets to allow them"
+	proxyPassSnippetMsg = "must not include the proxy_pass directive"
+)
+
+// autoWorkerProcesses is the value of the WorkerProcessesAnnotation that starts one worker process per CPU core.
+const autoWorkerProcesses = "auto"
+
+const (
+	minWorkerConnections = 512
+	maxWorkerConnections = 65536
+)
+
+// nextUpstreamOff is the condition that disables passing a request to the next server.
+const nextUpstreamOff = "off"
+
+// nextUpstreamConditions are the supported conditions of the NGINX proxy_next_upstream directive.
+var nextUpstreamConditions = map[string]struct{}{
+	"error":          {},
+	"timeout":        {},
+	"invalid_header": {},
+	"http_500":       {},
+	"http_502":       {},
+	"http_503":       {},
+	"http_504":       {},
+	"http_403":       {},
+	"http_404":       {},
+	"http_429":       {},
+	"non_idempotent": {},
+	nextUpstreamOff:  {},
+}
+
+var annotationsPath = field.NewPath("metadata", "annotations")
+
+// getClientMaxBodySize returns the maximum allowed size of the client request body in bytes
+// from the ClientMaxBodySizeAnnotation. It returns nil if the annotation is not set.
+func getClientMaxBodySize(annotations map[string]string) (*int64, *field.Error) {
+	value, exists := annotations[ClientMaxBodySizeAnnotation]
+	if !exists {
+		return nil, nil
+	}
+
+	size, err := parseSize(value)
+	if err != nil {
+		return nil, field.Invalid(annotationsPath.Key(ClientMaxBodySizeAnnotation), value, err.Error())
+	}
+
+	return &size, nil
+}
+
+// parseSize parses a size in bytes from a quantity, for example, "10M" or "1Gi".
+func parseSize(value string) (int64, error) {
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if quantity.Sign() < 0 {
+		return 0, errors.New("must not be negative")
+	}
+
+	// Value rounds up the fractional part, so the quantity has fractional bytes if it doesn't equal the rounded value.
+	size := quantity.Value()
+	if resource.NewQuantity(size, resource.BinarySI).Cmp(quantity) != 0 {
+		return 0, errors.New("must be a whole number of bytes")
+	}
+
+	return size, nil
+}
+
+// getStreamingProxy returns whether the StreamingProxyAnnotation enables streaming of the responses.
+// It returns false if the annotation is not set.
+func getStreamingProxy(annotations map[string]string) (bool, *field.Error) {
+	value, exists := annotations[StreamingProxyAnnotation]
+	if !exists {
+		return false, nil
+	}
+
+	streaming, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, field.Invalid(annotationsPath.Key(StreamingProxyAnnotation), value, "must be a boolean")
+	}
+
+	return streaming, nil
+}
+
+// getCaseInsensitiveHeaderValues returns whether the CaseInsensitiveHeaderValuesAnnotation makes the header matches
+// case-insensitive.
+func getCaseInsensitiveHeaderValues(annotations map[string]string) (bool, *field.Error) {
+	value, exists := annotations[CaseInsensitiveHeaderValuesAnnotation]
+	if !exists {
+		return false, nil
+	}
+
+	caseInsensitive, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, field.Invalid(
+			annotationsPath.Key(CaseInsensitiveHeaderValuesAnnotation),
+			value,
+			"must be a boolean",
+		)
+	}
+
+	return caseInsensitive, nil
+}
+
+// getRequestIDHeader returns the name of the request ID header from the RequestIDHeaderAnnotation.
+// It returns an empty string if the annotation is not set or empty.
+func getRequestIDHeader(annotations map[string]string) (string, *field.Error) {
+	name := annotations[RequestIDHeaderAnnotation]
+	if name == "" {
+		return "", nil
+	}
+
+	path := annotationsPath.Key(RequestIDHeaderAnnotation)
+
+	if msgs := validation.IsHTTPHeaderName(name); len(msgs) > 0 {
+		return "", field.Invalid(path, name, strings.Join(msgs, ", "))
+	}
+
+	if strings.EqualFold(name, "host") {
+		return "", field.Invalid(path, name, "must not be the Host header")
+	}
+
+	return name, nil
+}
+
+// getTrustedProxies returns the CIDRs of the trusted proxies from the TrustedProxiesAnnotation.
+// It returns nil if the annotation is not set or empty.
+func getTrustedProxies(annotations map[string]string) ([]string, *field.Error) {
+	value := annotations[TrustedProxiesAnnotation]
+	if value == "" {
+		return nil, nil
+	}
+
+	path := annotationsPath.Key(TrustedProxiesAnnotation)
+
+	cidrs := strings.Split(value, ",")
+	for i := range cidrs {
+		cidrs[i] = strings.TrimSpace(cidrs[i])
+
+		if _, _, err := net.ParseCIDR(cidrs[i]); err != nil {
+			return nil, field.Invalid(path, value, fmt.Sprintf("%q is not a valid CIDR", cidrs[i]))
+		}
+	}
+
+	return cidrs, nil
+}
+
+// getDNSResolvers returns the IP addresses of the DNS servers from the DNSResolversAnnotation.
+// It returns nil if the annotation is not set or empty.
+func getDNSResolvers(annotations map[string]string) ([]string, *field.Error) {
+	value := annotations[DNSResolversAnnotation]
+	if value == "" {
+		return nil, nil
+	}
+
+	path := annotationsPath.Key(DNSResolversAnnotation)
+
+	ips := strings.Split(value, ",")
+	for i := range ips {
+		ips[i] = strings.TrimSpace(ips[i])
+
+		if net.ParseIP(ips[i]) == nil {
+			return nil, field.Invalid(path, value, fmt.Sprintf("%q is not a valid IP address", ips[i]))
+		}
+	}
+
+	return ips, nil
+}
+
+// getErrorPage returns the custom error page from the ErrorPageStatusCodesAnnotation, ErrorPageBodyAnnotation and
+// ErrorPageContentTypeAnnotation. It returns nil if the status codes annotation is not set or empty.
+func getErrorPage(annotations map[string]string) (*ErrorPage, *field.Error) {
+	return parseErrorPage(annotations, gatewayErrorPageAnnotations)
+}
+
+// getBackendErrorPage returns the custom backend error page from the BackendErrorStatusCodesAnnotation,
+// BackendErrorBodyAnnotation and BackendErrorContentTypeAnnotation. It returns nil if the status codes annotation
+// is not set or empty.
+func getBackendErrorPage(annotations map[string]string) (*ErrorPage, *field.Error) {
+	return parseErrorPage(annotations, backendErrorPageAnnotations)
+}
+
+// parseErrorPage parses a custom error page from the annotations with the specified names.
+func parseErrorPage(annotations map[string]string, names errorPageAnnotations) (*ErrorPage, *field.Error) {
+	value := annotations[names.statusCodes]
+	if value == "" {
+		return nil, nil
+	}
+
+	path := annotationsPath.Key(names.statusCodes)
+
+	codes := strings.Split(value, ",")
+	statusCodes := make([]int, 0, len(codes))
+	seen := make(map[int]struct{}, len(codes))
+
+	for _, c := range codes {
+		c = strings.TrimSpace(c)
+
+		code, err := strconv.Atoi(c)
+		if err != nil || code < 400 || code > 599 {
+			return nil, field.Invalid(path, value, fmt.Sprintf("%q is not a valid HTTP status code in range 400-599", c))
+		}
+
+		if _, exists := seen[code]; exists {
+			return nil, field.Invalid(path, value, fmt.Sprintf("%d is duplicated", code))
+		}
+		seen[code] = struct{}{}
+
+		statusCodes = append(statusCodes, code)
+	}
+
+	body := annotations[names.body]
+	// NGINX expands variables in the body
+	if strings.Contains(body, "$") {
+		return nil, field.Invalid(annotationsPath.Key(names.body), body, "must not contain $")
+	}
+
+	contentType := annotations[names.contentType]
+	if contentType == "" {
+		contentType = defaultErrorPageContentType
+	} else if !isValidMediaType(contentType) {
+		return nil, field.Invalid(
+			annotationsPath.Key(names.contentType),
+			contentType,
+			"must be a valid media type",
+		)
+	}
+
+	return &ErrorPage{
+		StatusCodes: statusCodes,
+		Body:        body,
+		ContentType: contentType,
+	}, nil
+}
+
+func isValidMediaType(value string) bool {
+	mediaType, _, err := mime.ParseMediaType(value)
+	if err != nil || !strings.Contains(mediaType, "/") {
+		return false
+	}
+
+	// the value is put in quotes in the NGINX config and NGINX expands variables in it
+	return !strings.ContainsAny(value, `"$\`)
+}
+
+// getNextUpstream returns the settings of passing a request to the next server from the ProxyNextUpstreamAnnotation
+// and ProxyNextUpstreamTriesAnnotation. It returns nil if neither annotation is set.
+func getNextUpstream(annotations map[string]string) (*NextUpstream, *field.Error) {
+	value, conditionsExist := annotations[ProxyNextUpstreamAnnotation]
+	triesValue, triesExist := annotations[ProxyNextUpstreamTriesAnnotation]
+
+	if !conditionsExist && !triesExist {
+		return nil, nil
+	}
+
+	var conditions []string
+
+	if conditionsExist {
+		path := annotationsPath.Key(ProxyNextUpstreamAnnotation)
+
+		if strings.TrimSpace(value) == "" {
+			return nil, field.Required(path, "must not be empty")
+		}
+
+		conds := strings.Split(value, ",")
+		conditions = make([]string, 0, len(conds))
+		seen := make(map[string]struct{}, len(conds))
+
+		for _, c := range conds {
+			c = strings.TrimSpace(c)
+
+			if _, valid := nextUpstreamConditions[c]; !valid {
+				return nil, field.Invalid(path, value, fmt.Sprintf("%q is not a supported condition", c))
+			}
+
+			if _, exists := seen[c]; exists {
+				return nil, field.Invalid(path, value, fmt.Sprintf("%q is duplicated", c))
+			}
+			seen[c] = struct{}{}
+
+			conditions = append(conditions, c)
+		}
+
+		if _, off := seen[nextUpstreamOff]; off && len(conditions) > 1 {
+			return nil, field.Invalid(path, value, fmt.Sprintf("%q must not be combined with other conditions", nextUpstreamOff))
+		}
+	}
+
+	var tries int32
+
+	if triesExist {
+		t, err := strconv.ParseInt(triesValue, 10, 32)
+		if err != nil || t < 0 {
+			return nil, field.Invalid(
+				annotationsPath.Key(ProxyNextUpstreamTriesAnnotation),
+				triesValue,
+				"must be a non-negative integer",
+			)
+		}
+
+		// For NGINX, 0 tries means an unlimited number of tries, so zero tries are configured as the "off" condition.
+		if t == 0 {
+			return &NextUpstream{Conditions: []string{nextUpstreamOff}}, nil
+		}
+
+		tries = int32(t)
+	}
+
+	return &NextUpstream{
+		Conditions: conditions,
+		Tries:      tries,
+	}, nil
+}
+
+// getWorkerProcesses returns the number of the NGINX worker processes from the WorkerProcessesAnnotation:
+// a positive integer or "auto". It returns an empty string if the annotation is not set.
+func getWorkerProcesses(annotations map[string]string) (string, *field.Error) {
+	value, exists := annotations[WorkerProcessesAnnotation]
+	if !exists {
+		return "", nil
+	}
+
+	if value == autoWorkerProcesses {
+		return value, nil
+	}
+
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || n <= 0 {
+		return "", field.Invalid(
+			annotationsPath.Key(WorkerProcessesAnnotation),
+			value,
+			fmt.Sprintf("must be a positive integer or %q", autoWorkerProcesses),
+		)
+	}
+
+	return strconv.FormatInt(n, 10), nil
+}
+
+// getWorkerConnections returns the maximum number of simultaneous connections of an NGINX worker process from
+// the WorkerConnectionsAnnotation. It returns 0 if the annotation is not set.
+func getWorkerConnections(annotations map[string]string) (int32, *field.Error) {
+	value, exists := annotations[WorkerConnectionsAnnotation]
+	if !exists {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseInt(value, 10, 32)
+	// n&(n-1) clears the lowest set bit, so it is zero only for the powers of two.
+	if err != nil || n < minWorkerConnections || n > maxWorkerConnections || n&(n-1) != 0 {
+		return 0, field.Invalid(
+			annotationsPath.Key(WorkerConnectionsAnnotation),
+			value,
+			fmt.Sprintf("must be a power of two between %d and %d", minWorkerConnections, maxWorkerConnections),
+		)
+	}
+
+	return int32(n), nil
+}
+
+// getWorkerShutdownTimeout returns the time, for which NGINX waits for the active connections to complete during
+// a graceful shutdown, from the WorkerShutdownTimeoutAnnotation. It returns 0 if the annotation is not set.
+func getWorkerShutdownTimeout(annotations map[string]string) (time.Duration, *field.Error) {
+	return getDuration(annotations, WorkerShutdownTimeoutAnnotation, 0)
+}
+
+// getOpenFileCache returns the open file cache from the OpenFileCacheMaxFilesAnnotation,
+// OpenFileCacheInactiveAnnotation and OpenFileCacheValidAnnotation. It returns nil if the max files annotation is not
+// set.
+func getOpenFileCache(annotations map[string]string) (*OpenFileCache, *field.Error) {
+	value, exists := annotations[OpenFileCacheMaxFilesAnnotation]
+	if !exists {
+		return nil, nil
+	}
+
+	maxFiles, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || maxFiles <= 0 {
+		return nil, field.Invalid(annotationsPath.Key(OpenFileCacheMaxFilesAnnotation), value, "must be a positive integer")
+	}
+
+	inactive, valErr := getDuration(annotations, OpenFileCacheInactiveAnnotation, defaultOpenFileCacheTime)
+	if valErr != nil {
+		return nil, valErr
+	}
+
+	valid, valErr := getDuration(annotations, OpenFileCacheValidAnnotation, defaultOpenFileCacheTime)
+	if valErr != nil {
+		return nil, valErr
+	}
+
+	return &OpenFileCache{
+		MaxFiles: int32(maxFiles),
+		Inactive: inactive,
+		Valid:    valid,
+	}, nil
+}
+
+// getGzip returns the gzip compression settings from the GzipAnnotation, GzipMinLengthAnnotation, GzipTypesAnnotation
+// and GzipLevelAnnotation. It returns nil if the compression is not enabled.
+func getGzip(annotations map[string]string) (*Gzip, *field.Error) {
+	value, exists := annotations[GzipAnnotation]
+	if !exists {
+		return nil, nil
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, field.Invalid(annotationsPath.Key(GzipAnnotation), value, "must be a boolean")
+	}
+
+	if !enabled {
+		return nil, nil
+	}
+
+	gzip := &Gzip{
+		MinLength: defaultGzipMinLength,
+		Level:     defaultGzipLevel,
+	}
+
+	if value, exists := annotations[GzipMinLengthAnnotation]; exists {
+		minLength, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || minLength < 0 {
+			return nil, field.Invalid(
+				annotationsPath.Key(GzipMinLengthAnnotation),
+				value,
+				"must be a non-negative integer",
+			)
+		}
+
+		gzip.MinLength = int32(minLength)
+	}
+
+	if value, exists := annotations[GzipLevelAnnotation]; exists {
+		level, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || level < minGzipLevel || level > maxGzipLevel {
+			return nil, field.Invalid(
+				annotationsPath.Key(GzipLevelAnnotation),
+				value,
+				fmt.Sprintf("must be an integer between %d and %d", minGzipLevel, maxGzipLevel),
+			)
+		}
+
+		gzip.Level = int32(level)
+	}
+
+	if value := annotations[GzipTypesAnnotation]; value != "" {
+		types := strings.Split(value, ",")
+		for i := range types {
+			types[i] = strings.TrimSpace(types[i])
+
+			if !isValidGzipType(types[i]) {
+				return nil, field.Invalid(
+					annotationsPath.Key(GzipTypesAnnotation),
+					value,
+					fmt.Sprintf("%q is not a valid MIME type", types[i]),
+				)
+			}
+		}
+
+		gzip.Types = types
+	}
+
+	return gzip, nil
+}
+
+// isValidGzipType checks that the value is a MIME type without parameters or the "*" value.
+func isValidGzipType(value string) bool {
+	if value == anyGzipType {
+		return true
+	}
+
+	mediaType, params, err := mime.ParseMediaType(value)
+	if err != nil || len(params) > 0 || !strings.Contains(mediaType, "/") {
+		return false
+	}
+
+	// ParseMediaType allows the optional whitespace and the trailing semicolon, which NGINX doesn't.
+	return mediaType == strings.ToLower(value)
+}
+
+// getShowServerVersion returns whether the HideServerVersionAnnotation allows NGINX to show its version.
+// It returns false if the annotation is not set.
+func getShowServerVersion(annotations map[string]string) (bool, *field.Error) {
+	value, exists := annotations[HideServerVersionAnnotation]
+	if !exists {
+		return false, nil
+	}
+
+	hide, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, field.Invalid(annotationsPath.Key(HideServerVersionAnnotation), value, "must be a boolean")
+	}
+
+	return !hide, nil
+}
+
+// getUpstreamZoneSize returns the size in bytes of the shared memory zone of the upstreams from
+// the UpstreamZoneSizeAnnotation. It returns 0 if the annotation is not set.
+func getUpstreamZoneSize(annotations map[string]string) (int64, *field.Error) {
+	value, exists := annotations[UpstreamZoneSizeAnnotation]
+	if !exists {
+		return 0, nil
+	}
+
+	size, err := parseSize(value)
+	if err != nil {
+		return 0, field.Invalid(annotationsPath.Key(UpstreamZoneSizeAnnotation), value, err.Error())
+	}
+
+	if size < minUpstreamZoneSize || size > maxUpstreamZoneSize {
+		return 0, field.Invalid(annotationsPath.Key(UpstreamZoneSizeAnnotation), value, "must be between 32Ki and 512Mi")
+	}
+
+	return size, nil
+}
+
+// getDuration returns the duration from the annotation with the name, or the default duration if the annotation
+// is not set. NGINX doesn't support the time units smaller than a millisecond, so the duration must be a positive
+// whole number of milliseconds.
+func getDuration(
+	annotations map[string]string,
+	name string,
+	defaultDuration time.Duration,
+) (time.Duration, *field.Error) {
+	value, exists := annotations[name]
+	if !exists {
+		return defaultDuration, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 || d%time.Millisecond != 0 {
+		return 0, field.Invalid(
+			annotationsPath.Key(name),
+			value,
+			"must be a positive duration in whole milliseconds, for example, 30s",
+		)
+	}
+
+	return d, nil
+}
+
+// getServerSnippet returns the decoded NGINX directives from the ServerSnippetAnnotation.
+// It returns an empty string if the annotation is not set or empty.
+func getServerSnippet(annotations map[string]string, enableSnippets bool) (string, *field.Error) {
+	return parseSnippet(annotations, ServerSnippetAnnotation, enableSnippets)
+}
+
+// getLocationSnippet returns the decoded NGINX directives from the LocationSnippetAnnotation.
+// It returns an empty string if the annotation is not set or empty.
+func getLocationSnippet(annotations map[string]string, enableSnippets bool) (string, *field.Error) {
+	snippet, err := parseSnippet(annotations, LocationSnippetAnnotation, enableSnippets)
+	if err != nil {
+		return "", err
+	}
+
+	if proxyPassDirectiveRegexp.MatchString(snippet) {
+		return "", field.Invalid(
+			annotationsPath.Key(LocationSnippetAnnotation),
+			annotations[LocationSnippetAnnotation],
+			proxyPassSnippetMsg,
+		)
+	}
+
+	return snippet, nil
+}
+
+// parseSnippet decodes and validates the base64-encoded NGINX directives from the annotation with the name.
+// If the snippets are not enabled, the annotation is forbidden.
+func parseSnippet(annotations map[string]string, name string, enableSnippets bool) (string, *field.Error) {
+	value := annotations[name]
+	if value == "" {
+		return "", nil
+	}
+
+	path := annotationsPath.Key(name)
+
+	if !enableSnippets {
+		return "", field.Forbidden(path, snippetsDisabledMsg)
+	}
+
+	snippet, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", field.Invalid(path, value, "must be base64-encoded")
+	}
+
+	if err := validateSnippetSyntax(string(snippet)); err != nil {
+		return "", field.Invalid(path, value, err.Error())
+	}
+
+	return string(snippet), nil
+}
+
+// validateSnippetSyntax checks that the NGINX directives of the snippet don't break the block they're inserted into:
+// the braces must be balanced and the quotes must be closed. The braces in the quoted strings and in the comments
+// are ignored.
+func validateSnippetSyntax(snippet string) error {
+	var (
+		depth   int
+		quote   rune
+		comment bool
+		escaped bool
+	)
+
+	for _, c := range snippet {
+		switch {
+		case comment:
+			if c == '\n' {
+				comment = false
+			}
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			comment = true
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth < 0 {
+				return errors.New("unexpected }")
+			}
+		}
+	}
+
+	if quote != 0 {
+		return fmt.Errorf("unclosed %c", quote)
+	}
+
+	if depth > 0 {
+		return errors.New("unclosed {")
+	}
+
+	return nil
+}
@@ -0,0 +1,1212 @@
+package graph
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
+)
+
+func TestGetClientMaxBodySize(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		expected    *int64
+		name        string
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{"other": "value"},
+			expected:    nil,
+		},
+		{
+			name:        "GB",
+			annotations: map[string]string{ClientMaxBodySizeAnnotation: "1G"},
+			expected:    helpers.GetPointer[int64](1000000000),
+		},
+		{
+			name:        "GiB",
+			annotations: map[string]string{ClientMaxBodySizeAnnotation: "1Gi"},
+			expected:    helpers.GetPointer[int64](1073741824),
+		},
+		{
+			name:        "MB",
+			annotations: map[string]string{ClientMaxBodySizeAnnotation: "10M"},
+			expected:    helpers.GetPointer[int64](10000000),
+		},
+		{
+			name:        "fractional MiB with whole bytes",
+			annotations: map[string]string{ClientMaxBodySizeAnnotation: "1.5Mi"},
+			expected:    helpers.GetPointer[int64](1572864),
+		},
+		{
+			name:        "zero (unlimited)",
+			annotations: map[string]string{ClientMaxBodySizeAnnotation: "0"},
+			expected:    helpers.GetPointer[int64](0),
+		},
+		{
+			name:        "fractional bytes",
+			annotations: map[string]string{ClientMaxBodySizeAnnotation: "0.5"},
+			expErr:      true,
+		},
+		{
+			name:        "milli bytes",
+			annotations: map[string]string{ClientMaxBodySizeAnnotation: "100m"},
+			expErr:      true,
+		},
+		{
+			name:        "negative",
+			annotations: map[string]string{ClientMaxBodySizeAnnotation: "-1M"},
+			expErr:      true,
+		},
+		{
+			name:        "not a quantity",
+			annotations: map[string]string{ClientMaxBodySizeAnnotation: "10MB"},
+			expErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			size, err := getClientMaxBodySize(test.annotations)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(size).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetStreamingProxy(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		name        string
+		expected    bool
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{"other": "true"},
+			expected:    false,
+		},
+		{
+			name:        "true",
+			annotations: map[string]string{StreamingProxyAnnotation: "true"},
+			expected:    true,
+		},
+		{
+			name:        "false",
+			annotations: map[string]string{StreamingProxyAnnotation: "false"},
+			expected:    false,
+		},
+		{
+			name:        "not a boolean",
+			annotations: map[string]string{StreamingProxyAnnotation: "on"},
+			expErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			streaming, err := getStreamingProxy(test.annotations)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(streaming).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetCaseInsensitiveHeaderValues(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		name        string
+		expected    bool
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{"other": "true"},
+			expected:    false,
+		},
+		{
+			name:        "true",
+			annotations: map[string]string{CaseInsensitiveHeaderValuesAnnotation: "true"},
+			expected:    true,
+		},
+		{
+			name:        "false",
+			annotations: map[string]string{CaseInsensitiveHeaderValuesAnnotation: "false"},
+			expected:    false,
+		},
+		{
+			name:        "not a boolean",
+			annotations: map[string]string{CaseInsensitiveHeaderValuesAnnotation: "yes"},
+			expErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			caseInsensitive, err := getCaseInsensitiveHeaderValues(test.annotations)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(caseInsensitive).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetRequestIDHeader(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		name        string
+		expected    string
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{"other": "X-Request-ID"},
+			expected:    "",
+		},
+		{
+			name:        "empty disables the header",
+			annotations: map[string]string{RequestIDHeaderAnnotation: ""},
+			expected:    "",
+		},
+		{
+			name:        "valid",
+			annotations: map[string]string{RequestIDHeaderAnnotation: "X-Request-ID"},
+			expected:    "X-Request-ID",
+		},
+		{
+			name:        "invalid header name",
+			annotations: map[string]string{RequestIDHeaderAnnotation: "X-Request ID;"},
+			expErr:      true,
+		},
+		{
+			name:        "host header",
+			annotations: map[string]string{RequestIDHeaderAnnotation: "host"},
+			expErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			header, err := getRequestIDHeader(test.annotations)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(header).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetTrustedProxies(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		name        string
+		expected    []string
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{"other": "10.0.0.0/8"},
+			expected:    nil,
+		},
+		{
+			name:        "empty",
+			annotations: map[string]string{TrustedProxiesAnnotation: ""},
+			expected:    nil,
+		},
+		{
+			name:        "IPv4 and IPv6",
+			annotations: map[string]string{TrustedProxiesAnnotation: "10.0.0.0/8, 192.168.1.1/32,2001:db8::/32"},
+			expected:    []string{"10.0.0.0/8", "192.168.1.1/32", "2001:db8::/32"},
+		},
+		{
+			name:        "invalid CIDR",
+			annotations: map[string]string{TrustedProxiesAnnotation: "10.0.0.0/8,10.0.0.1"},
+			expErr:      true,
+		},
+		{
+			name:        "empty CIDR",
+			annotations: map[string]string{TrustedProxiesAnnotation: "10.0.0.0/8,"},
+			expErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			cidrs, err := getTrustedProxies(test.annotations)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(cidrs).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetDNSResolvers(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		name        string
+		expected    []string
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{"other": "10.96.0.10"},
+			expected:    nil,
+		},
+		{
+			name:        "empty",
+			annotations: map[string]string{DNSResolversAnnotation: ""},
+			expected:    nil,
+		},
+		{
+			name:        "IPv4 and IPv6",
+			annotations: map[string]string{DNSResolversAnnotation: "10.96.0.10, 2001:db8::10"},
+			expected:    []string{"10.96.0.10", "2001:db8::10"},
+		},
+		{
+			name:        "invalid IP address",
+			annotations: map[string]string{DNSResolversAnnotation: "10.96.0.10,kube-dns"},
+			expErr:      true,
+		},
+		{
+			name:        "CIDR",
+			annotations: map[string]string{DNSResolversAnnotation: "10.96.0.0/12"},
+			expErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			ips, err := getDNSResolvers(test.annotations)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(ips).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetErrorPage(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		expected    *ErrorPage
+		name        string
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{ErrorPageBodyAnnotation: "Not found"},
+			expected:    nil,
+		},
+		{
+			name: "custom 404",
+			annotations: map[string]string{
+				ErrorPageStatusCodesAnnotation: "404",
+				ErrorPageBodyAnnotation:        "<h1>Not found</h1>",
+			},
+			expected: &ErrorPage{
+				Body:        "<h1>Not found</h1>",
+				ContentType: "text/html",
+				StatusCodes: []int{404},
+			},
+		},
+		{
+			name: "custom 503",
+			annotations: map[string]string{
+				ErrorPageStatusCodesAnnotation: "503",
+				ErrorPageBodyAnnotation:        `{"error": "unavailable"}`,
+				ErrorPageContentTypeAnnotation: "application/json",
+			},
+			expected: &ErrorPage{
+				Body:        `{"error": "unavailable"}`,
+				ContentType: "application/json",
+				StatusCodes: []int{503},
+			},
+		},
+		{
+			name: "404 and 503",
+			annotations: map[string]string{
+				ErrorPageStatusCodesAnnotation: "404, 503",
+				ErrorPageContentTypeAnnotation: "text/plain; charset=utf-8",
+			},
+			expected: &ErrorPage{
+				ContentType: "text/plain; charset=utf-8",
+				StatusCodes: []int{404, 503},
+			},
+		},
+		{
+			name:        "status code below 400",
+			annotations: map[string]string{ErrorPageStatusCodesAnnotation: "399"},
+			expErr:      true,
+		},
+		{
+			name:        "status code above 599",
+			annotations: map[string]string{ErrorPageStatusCodesAnnotation: "404,600"},
+			expErr:      true,
+		},
+		{
+			name:        "status code is not a number",
+			annotations: map[string]string{ErrorPageStatusCodesAnnotation: "not-found"},
+			expErr:      true,
+		},
+		{
+			name:        "duplicate status code",
+			annotations: map[string]string{ErrorPageStatusCodesAnnotation: "404,404"},
+			expErr:      true,
+		},
+		{
+			name: "body with a variable",
+			annotations: map[string]string{
+				ErrorPageStatusCodesAnnotation: "404",
+				ErrorPageBodyAnnotation:        "$request_uri not found",
+			},
+			expErr: true,
+		},
+		{
+			name: "invalid content type",
+			annotations: map[string]string{
+				ErrorPageStatusCodesAnnotation: "404",
+				ErrorPageContentTypeAnnotation: "html",
+			},
+			expErr: true,
+		},
+		{
+			name: "content type with a variable",
+			annotations: map[string]string{
+				ErrorPageStatusCodesAnnotation: "404",
+				ErrorPageContentTypeAnnotation: "text/html; charset=$charset",
+			},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			errorPage, err := getErrorPage(test.annotations)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(errorPage).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetNextUpstream(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		expected    *NextUpstream
+		name        string
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{"other": "error"},
+			expected:    nil,
+		},
+		{
+			name:        "single condition",
+			annotations: map[string]string{ProxyNextUpstreamAnnotation: "http_502"},
+			expected: &NextUpstream{
+				Conditions: []string{"http_502"},
+			},
+		},
+		{
+			name: "multiple conditions with tries",
+			annotations: map[string]string{
+				ProxyNextUpstreamAnnotation:      "error, timeout, http_502",
+				ProxyNextUpstreamTriesAnnotation: "2",
+			},
+			expected: &NextUpstream{
+				Conditions: []string{"error", "timeout", "http_502"},
+				Tries:      2,
+			},
+		},
+		{
+			name:        "tries only",
+			annotations: map[string]string{ProxyNextUpstreamTriesAnnotation: "3"},
+			expected: &NextUpstream{
+				Tries: 3,
+			},
+		},
+		{
+			name: "zero tries",
+			annotations: map[string]string{
+				ProxyNextUpstreamAnnotation:      "error",
+				ProxyNextUpstreamTriesAnnotation: "0",
+			},
+			expected: &NextUpstream{
+				Conditions: []string{"off"},
+			},
+		},
+		{
+			name:        "off",
+			annotations: map[string]string{ProxyNextUpstreamAnnotation: "off"},
+			expected: &NextUpstream{
+				Conditions: []string{"off"},
+			},
+		},
+		{
+			name:        "empty conditions",
+			annotations: map[string]string{ProxyNextUpstreamAnnotation: ""},
+			expErr:      true,
+		},
+		{
+			name:        "unsupported condition",
+			annotations: map[string]string{ProxyNextUpstreamAnnotation: "error,http_501"},
+			expErr:      true,
+		},
+		{
+			name:        "duplicate condition",
+			annotations: map[string]string{ProxyNextUpstreamAnnotation: "error,error"},
+			expErr:      true,
+		},
+		{
+			name:        "off with other conditions",
+			annotations: map[string]string{ProxyNextUpstreamAnnotation: "off,error"},
+			expErr:      true,
+		},
+		{
+			name:        "negative tries",
+			annotations: map[string]string{ProxyNextUpstreamTriesAnnotation: "-1"},
+			expErr:      true,
+		},
+		{
+			name:        "tries is not a number",
+			annotations: map[string]string{ProxyNextUpstreamTriesAnnotation: "many"},
+			expErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			nextUpstream, err := getNextUpstream(test.annotations)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(nextUpstream).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetBackendErrorPage(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		expected    *ErrorPage
+		name        string
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{ErrorPageStatusCodesAnnotation: "503"},
+			expected:    nil,
+		},
+		{
+			name: "5xx",
+			annotations: map[string]string{
+				BackendErrorStatusCodesAnnotation: "500,502,503,504",
+				BackendErrorBodyAnnotation:        `{"error": "try again later"}`,
+				BackendErrorContentTypeAnnotation: "application/json",
+			},
+			expected: &ErrorPage{
+				Body:        `{"error": "try again later"}`,
+				ContentType: "application/json",
+				StatusCodes: []int{500, 502, 503, 504},
+			},
+		},
+		{
+			name:        "default content type",
+			annotations: map[string]string{BackendErrorStatusCodesAnnotation: "503"},
+			expected: &ErrorPage{
+				ContentType: "text/html",
+				StatusCodes: []int{503},
+			},
+		},
+		{
+			name:        "successful status code",
+			annotations: map[string]string{BackendErrorStatusCodesAnnotation: "200"},
+			expErr:      true,
+		},
+		{
+			name: "invalid content type",
+			annotations: map[string]string{
+				BackendErrorStatusCodesAnnotation: "503",
+				BackendErrorContentTypeAnnotation: "json",
+			},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			errorPage, err := getBackendErrorPage(test.annotations)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(errorPage).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetWorkerProcesses(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		name        string
+		expected    string
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{"other": "4"},
+			expected:    "",
+		},
+		{
+			name:        "explicit count",
+			annotations: map[string]string{WorkerProcessesAnnotation: "4"},
+			expected:    "4",
+		},
+		{
+			name:        "auto",
+			annotations: map[string]string{WorkerProcessesAnnotation: "auto"},
+			expected:    "auto",
+		},
+		{
+			name:        "zero",
+			annotations: map[string]string{WorkerProcessesAnnotation: "0"},
+			expErr:      true,
+		},
+		{
+			name:        "invalid",
+			annotations: map[string]string{WorkerProcessesAnnotation: "Auto"},
+			expErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			processes, err := getWorkerProcesses(test.annotations)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(processes).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetWorkerConnections(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		name        string
+		expected    int32
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{"other": "1024"},
+			expected:    0,
+		},
+		{
+			name:        "minimum",
+			annotations: map[string]string{WorkerConnectionsAnnotation: "512"},
+			expected:    512,
+		},
+		{
+			name:        "maximum",
+			annotations: map[string]string{WorkerConnectionsAnnotation: "65536"},
+			expected:    65536,
+		},
+		{
+			name:        "not a power of two",
+			annotations: map[string]string{WorkerConnectionsAnnotation: "1000"},
+			expErr:      true,
+		},
+		{
+			name:        "too small",
+			annotations: map[string]string{WorkerConnectionsAnnotation: "256"},
+			expErr:      true,
+		},
+		{
+			name:        "too large",
+			annotations: map[string]string{WorkerConnectionsAnnotation: "131072"},
+			expErr:      true,
+		},
+		{
+			name:        "not a number",
+			annotations: map[string]string{WorkerConnectionsAnnotation: "many"},
+			expErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			connections, err := getWorkerConnections(test.annotations)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(connections).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetWorkerShutdownTimeout(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		name        string
+		expected    time.Duration
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{"other": "20s"},
+			expected:    0,
+		},
+		{
+			name:        "set",
+			annotations: map[string]string{WorkerShutdownTimeoutAnnotation: "1m30s"},
+			expected:    90 * time.Second,
+		},
+		{
+			name:        "negative",
+			annotations: map[string]string{WorkerShutdownTimeoutAnnotation: "-5s"},
+			expErr:      true,
+		},
+		{
+			name:        "zero",
+			annotations: map[string]string{WorkerShutdownTimeoutAnnotation: "0s"},
+			expErr:      true,
+		},
+		{
+			name:        "not a duration",
+			annotations: map[string]string{WorkerShutdownTimeoutAnnotation: "20"},
+			expErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			timeout, err := getWorkerShutdownTimeout(test.annotations)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(timeout).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetOpenFileCache(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		expected    *OpenFileCache
+		name        string
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{OpenFileCacheInactiveAnnotation: "20s"},
+			expected:    nil,
+		},
+		{
+			name:        "defaults",
+			annotations: map[string]string{OpenFileCacheMaxFilesAnnotation: "1000"},
+			expected: &OpenFileCache{
+				MaxFiles: 1000,
+				Inactive: 60 * time.Second,
+				Valid:    60 * time.Second,
+			},
+		},
+		{
+			name: "all set",
+			annotations: map[string]string{
+				OpenFileCacheMaxFilesAnnotation: "1000",
+				OpenFileCacheInactiveAnnotation: "20s",
+				OpenFileCacheValidAnnotation:    "1m30s",
+			},
+			expected: &OpenFileCache{
+				MaxFiles: 1000,
+				Inactive: 20 * time.Second,
+				Valid:    90 * time.Second,
+			},
+		},
+		{
+			name:        "invalid max files",
+			annotations: map[string]string{OpenFileCacheMaxFilesAnnotation: "0"},
+			expErr:      true,
+		},
+		{
+			name: "invalid inactive time",
+			annotations: map[string]string{
+				OpenFileCacheMaxFilesAnnotation: "1000",
+				OpenFileCacheInactiveAnnotation: "20",
+			},
+			expErr: true,
+		},
+		{
+			name: "negative valid time",
+			annotations: map[string]string{
+				OpenFileCacheMaxFilesAnnotation: "1000",
+				OpenFileCacheValidAnnotation:    "-30s",
+			},
+			expErr: true,
+		},
+		{
+			name: "valid time with microseconds",
+			annotations: map[string]string{
+				OpenFileCacheMaxFilesAnnotation: "1000",
+				OpenFileCacheValidAnnotation:    "1500us",
+			},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			cache, err := getOpenFileCache(test.annotations)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(cache).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetGzip(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		expected    *Gzip
+		name        string
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{GzipLevelAnnotation: "5"},
+			expected:    nil,
+		},
+		{
+			name: "disabled",
+			annotations: map[string]string{
+				GzipAnnotation:      "false",
+				GzipLevelAnnotation: "10",
+			},
+			expected: nil,
+		},
+		{
+			name:        "defaults",
+			annotations: map[string]string{GzipAnnotation: "true"},
+			expected: &Gzip{
+				MinLength: 20,
+				Level:     1,
+			},
+		},
+		{
+			name: "all set",
+			annotations: map[string]string{
+				GzipAnnotation:          "true",
+				GzipMinLengthAnnotation: "1000",
+				GzipTypesAnnotation:     "application/json, text/css",
+				GzipLevelAnnotation:     "9",
+			},
+			expected: &Gzip{
+				Types:     []string{"application/json", "text/css"},
+				MinLength: 1000,
+				Level:     9,
+			},
+		},
+		{
+			name: "any type",
+			annotations: map[string]string{
+				GzipAnnotation:      "true",
+				GzipTypesAnnotation: "*",
+			},
+			expected: &Gzip{
+				Types:     []string{"*"},
+				MinLength: 20,
+				Level:     1,
+			},
+		},
+		{
+			name:        "invalid boolean",
+			annotations: map[string]string{GzipAnnotation: "yes"},
+			expErr:      true,
+		},
+		{
+			name: "invalid compression level",
+			annotations: map[string]string{
+				GzipAnnotation:      "true",
+				GzipLevelAnnotation: "10",
+			},
+			expErr: true,
+		},
+		{
+			name: "negative min length",
+			annotations: map[string]string{
+				GzipAnnotation:          "true",
+				GzipMinLengthAnnotation: "-1",
+			},
+			expErr: true,
+		},
+		{
+			name: "type without subtype",
+			annotations: map[string]string{
+				GzipAnnotation:      "true",
+				GzipTypesAnnotation: "application",
+			},
+			expErr: true,
+		},
+		{
+			name: "type with parameters",
+			annotations: map[string]string{
+				GzipAnnotation:      "true",
+				GzipTypesAnnotation: "text/plain; charset=utf-8",
+			},
+			expErr: true,
+		},
+		{
+			name: "type with semicolon",
+			annotations: map[string]string{
+				GzipAnnotation:      "true",
+				GzipTypesAnnotation: "text/plain;",
+			},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			gzip, err := getGzip(test.annotations)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(gzip).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetShowServerVersion(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		name        string
+		expected    bool
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{"other": "false"},
+			expected:    false,
+		},
+		{
+			name:        "hidden",
+			annotations: map[string]string{HideServerVersionAnnotation: "true"},
+			expected:    false,
+		},
+		{
+			name:        "shown",
+			annotations: map[string]string{HideServerVersionAnnotation: "false"},
+			expected:    true,
+		},
+		{
+			name:        "invalid",
+			annotations: map[string]string{HideServerVersionAnnotation: "no"},
+			expErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			show, err := getShowServerVersion(test.annotations)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(show).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetUpstreamZoneSize(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		name        string
+		expected    int64
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{"other": "64Ki"},
+			expected:    0,
+		},
+		{
+			name:        "minimum",
+			annotations: map[string]string{UpstreamZoneSizeAnnotation: "32Ki"},
+			expected:    32 * 1024,
+		},
+		{
+			name:        "maximum",
+			annotations: map[string]string{UpstreamZoneSizeAnnotation: "512Mi"},
+			expected:    512 * 1024 * 1024,
+		},
+		{
+			name:        "too small",
+			annotations: map[string]string{UpstreamZoneSizeAnnotation: "16Ki"},
+			expErr:      true,
+		},
+		{
+			name:        "too large",
+			annotations: map[string]string{UpstreamZoneSizeAnnotation: "1Gi"},
+			expErr:      true,
+		},
+		{
+			name:        "invalid quantity",
+			annotations: map[string]string{UpstreamZoneSizeAnnotation: "large"},
+			expErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			size, err := getUpstreamZoneSize(test.annotations)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(size).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetServerSnippet(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		name        string
+		expected    string
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{"other": "value"},
+			expected:    "",
+		},
+		{
+			name:        "empty",
+			annotations: map[string]string{ServerSnippetAnnotation: ""},
+			expected:    "",
+		},
+		{
+			name: "valid",
+			// location /status { return 200 "{ok}"; } # }
+			annotations: map[string]string{ServerSnippetAnnotation: "bG9jYXRpb24gL3N0YXR1cyB7IHJldHVybiAyMDAgIntva30iOyB9ICMgfQ=="},
+			expected:    `location /status { return 200 "{ok}"; } # }`,
+		},
+		{
+			name:        "not base64-encoded",
+			annotations: map[string]string{ServerSnippetAnnotation: "location /status {}"},
+			expErr:      true,
+		},
+		{
+			name: "unclosed brace",
+			// location /status { return 200;
+			annotations: map[string]string{ServerSnippetAnnotation: "bG9jYXRpb24gL3N0YXR1cyB7IHJldHVybiAyMDA7"},
+			expErr:      true,
+		},
+		{
+			name: "unexpected brace",
+			// return 200; } server { return 404;
+			annotations: map[string]string{ServerSnippetAnnotation: "cmV0dXJuIDIwMDsgfSBzZXJ2ZXIgeyByZXR1cm4gNDA0Ow=="},
+			expErr:      true,
+		},
+		{
+			name: "unclosed quote",
+			// return 200 "ok;
+			annotations: map[string]string{ServerSnippetAnnotation: "cmV0dXJuIDIwMCAib2s7"},
+			expErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			snippet, err := getServerSnippet(test.annotations, true)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(snippet).To(Equal(test.expected))
+		})
+	}
+}
+
+func TestGetLocationSnippetDisabled(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// proxy_set_header X-Route hr; proxy_pass_header Server;
+	snippet, err := getLocationSnippet(
+		map[string]string{
+			LocationSnippetAnnotation: "cHJveHlfc2V0X2hlYWRlciBYLVJvdXRlIGhyOyBwcm94eV9wYXNzX2hlYWRlciBTZXJ2ZXI7",
+		},
+		false,
+	)
+	g.Expect(err).To(MatchError(ContainSubstring("snippets are disabled")))
+	g.Expect(snippet).To(BeEmpty())
+}
+
+func TestGetServerSnippetDisabled(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// add_header X-Gateway nginx;
+	snippet, err := getServerSnippet(
+		map[string]string{ServerSnippetAnnotation: "YWRkX2hlYWRlciBYLUdhdGV3YXkgbmdpbng7"},
+		false,
+	)
+	g.Expect(err).To(MatchError(ContainSubstring("snippets are disabled")))
+	g.Expect(snippet).To(BeEmpty())
+
+	snippet, err = getServerSnippet(map[string]string{ServerSnippetAnnotation: ""}, false)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(snippet).To(BeEmpty())
+}
+
+func TestGetLocationSnippet(t *testing.T) {
+	tests := []struct {
+		annotations map[string]string
+		name        string
+		expected    string
+		expErr      bool
+	}{
+		{
+			name:        "not set",
+			annotations: map[string]string{"other": "value"},
+			expected:    "",
+		},
+		{
+			name:        "empty",
+			annotations: map[string]string{LocationSnippetAnnotation: ""},
+			expected:    "",
+		},
+		{
+			name: "valid",
+			// proxy_set_header X-Route hr; proxy_pass_header Server;
+			annotations: map[string]string{LocationSnippetAnnotation: "cHJveHlfc2V0X2hlYWRlciBYLVJvdXRlIGhyOyBwcm94eV9wYXNzX2hlYWRlciBTZXJ2ZXI7"},
+			expected:    "proxy_set_header X-Route hr; proxy_pass_header Server;",
+		},
+		{
+			name: "proxy_pass",
+			// proxy_pass http://backend;
+			annotations: map[string]string{LocationSnippetAnnotation: "cHJveHlfcGFzcyBodHRwOi8vYmFja2VuZDs="},
+			expErr:      true,
+		},
+		{
+			name: "proxy_pass after another directive",
+			// proxy_buffering off;\n  proxy_pass http://backend;
+			annotations: map[string]string{LocationSnippetAnnotation: "cHJveHlfYnVmZmVyaW5nIG9mZjsKICBwcm94eV9wYXNzIGh0dHA6Ly9iYWNrZW5kOw=="},
+			expErr:      true,
+		},
+		{
+			name: "unclosed brace",
+			// if ($request_method = POST) {
+			annotations: map[string]string{LocationSnippetAnnotation: "aWYgKCRyZXF1ZXN0X21ldGhvZCA9IFBPU1QpIHs="},
+			expErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			snippet, err := getLocationSnippet(test.annotations, true)
+
+			if test.expErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(snippet).To(Equal(test.expected))
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package graph
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
@@ -12,6 +16,9 @@ import (
 	staticConds "github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/conditions"
 )
 
+// ErrAmbiguousServicePort is returned when a backendRef doesn't set the port, but the Service has multiple ports.
+var ErrAmbiguousServicePort = errors.New("port is required for a Service with multiple ports")
+
 // BackendRef is an internal representation of a backendRef in an HTTPRoute.
 type BackendRef struct {
 	// Svc is the service referenced by the backendRef.
@@ -32,28 +39,44 @@ func (b BackendRef) ServicePortReference() string {
 	return fmt.Sprintf("%s_%s_%d", b.Svc.Namespace, b.Svc.Name, b.Port)
 }
 
+// DNSAddress returns the DNS name and port of the backend, which NGINX resolves at runtime, for example,
+// "example.com:80". It returns an empty string if the backendRef doesn't reference an ExternalName Service.
+func (b BackendRef) DNSAddress() string {
+	if b.Svc == nil || b.Svc.Spec.Type != v1.ServiceTypeExternalName {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", b.Svc.Spec.ExternalName, b.Port)
+}
+
 func addBackendRefsToRouteRules(
 	routes map[types.NamespacedName]*Route,
 	refGrantResolver *referenceGrantResolver,
 	services map[types.NamespacedName]*v1.Service,
+	dnsResolvers []string,
 ) {
 	for _, r := range routes {
-		addBackendRefsToRules(r, refGrantResolver, services)
+		addBackendRefsToRules(r, refGrantResolver, services, dnsResolvers)
 	}
 }
 
 // addBackendRefsToRules iterates over the rules of a route and adds a list of BackendRef to each rule.
 // The route is modified in place.
 // If a reference in a rule is invalid, the function will add a condition to the rule.
+// The backendRefs that reference Services that don't exist are reported together in a single BackendNotFound
+// condition, so that the condition includes every missing Service.
+// The backendRefs that reference ExternalName Services are only valid if the DNS resolvers are configured.
 func addBackendRefsToRules(
 	route *Route,
 	refGrantResolver *referenceGrantResolver,
 	services map[types.NamespacedName]*v1.Service,
+	dnsResolvers []string,
 ) {
 	if !route.Valid {
 		return
 	}
 
+	var notFoundMsgs []string
+
 	for idx, rule := range route.Source.Spec.Rules {
 		if !route.Rules[idx].ValidMatches {
 			continue
@@ -72,16 +95,29 @@ func addBackendRefsToRules(
 		for refIdx, ref := range rule.BackendRefs {
 			refPath := field.NewPath("spec").Child("rules").Index(idx).Child("backendRefs").Index(refIdx)
 
-			ref, cond := createBackendRef(ref, route.Source.Namespace, refGrantResolver, services, refPath)
+			ref, cond := createBackendRef(ref, route.Source.Namespace, refGrantResolver, services, dnsResolvers, refPath)
 
 			backendRefs = append(backendRefs, ref)
-			if cond != nil {
+
+			if cond == nil {
+				continue
+			}
+
+			if cond.Reason == string(v1beta1.RouteReasonBackendNotFound) {
+				// The joined message gets the code once, when the combined condition is created.
+				notFoundMsgs = append(notFoundMsgs, staticConds.TrimCode(cond.Message))
+			} else {
 				route.Conditions = append(route.Conditions, *cond)
 			}
 		}
 
 		route.Rules[idx].BackendRefs = backendRefs
 	}
+
+	if len(notFoundMsgs) > 0 {
+		cond := staticConds.NewRouteBackendRefRefBackendNotFound(strings.Join(notFoundMsgs, "; "))
+		route.Conditions = append(route.Conditions, cond)
+	}
 }
 
 func createBackendRef(
@@ -89,6 +125,7 @@ func createBackendRef(
 	sourceNamespace string,
 	refGrantResolver *referenceGrantResolver,
 	services map[types.NamespacedName]*v1.Service,
+	dnsResolvers []string,
 	refPath *field.Path,
 ) (BackendRef, *conditions.Condition) {
 	// Data plane will handle invalid ref by responding with 500.
@@ -123,10 +160,28 @@ func createBackendRef(
 			Valid:  false,
 		}
 
-		cond := staticConds.NewRouteBackendRefRefBackendNotFound(err.Error())
+		var cond conditions.Condition
+		if errors.Is(err, ErrAmbiguousServicePort) {
+			cond = staticConds.NewRouteBackendRefPortRequired(err.Error())
+		} else {
+			cond = staticConds.NewRouteBackendRefRefBackendNotFound(err.Error())
+		}
+
 		return backendRef, &cond
 	}
 
+	if svc.Spec.Type == v1.ServiceTypeExternalName {
+		if err := validateExternalNameService(svc, dnsResolvers, refPath); err != nil {
+			backendRef = BackendRef{
+				Weight: weight,
+				Valid:  false,
+			}
+
+			cond := staticConds.NewRouteBackendRefUnsupportedValue(err.Error())
+			return backendRef, &cond
+		}
+	}
+
 	backendRef = BackendRef{
 		Svc:    svc,
 		Port:   port,
@@ -137,6 +192,28 @@ func createBackendRef(
 	return backendRef, nil
 }
 
+// validateExternalNameService validates that NGINX can resolve the DNS name of an ExternalName Service at runtime.
+func validateExternalNameService(svc *v1.Service, dnsResolvers []string, refPath *field.Path) error {
+	namePath := refPath.Child("name")
+
+	if len(dnsResolvers) == 0 {
+		msg := fmt.Sprintf(
+			"the ExternalName Service requires DNS resolvers, which are configured with the %s annotation "+
+				"of the Gateway",
+			DNSResolversAnnotation,
+		)
+		return field.Invalid(namePath, svc.Name, msg)
+	}
+
+	// Kubernetes allows the fully qualified DNS name with the trailing dot.
+	if msgs := validation.IsDNS1123Subdomain(strings.TrimSuffix(svc.Spec.ExternalName, ".")); len(msgs) > 0 {
+		msg := fmt.Sprintf("the external name %q of the Service is invalid: %s", svc.Spec.ExternalName, msgs[0])
+		return field.Invalid(namePath, svc.Name, msg)
+	}
+
+	return nil
+}
+
 func getServiceAndPortFromRef(
 	ref v1beta1.BackendRef,
 	routeNamespace string,
@@ -155,8 +232,27 @@ func getServiceAndPortFromRef(
 		return nil, 0, field.NotFound(refPath.Child("name"), ref.Name)
 	}
 
-	// safe to dereference port here because we already validated that the port is not nil.
-	return svc, int32(*ref.Port), nil
+	if ref.Port != nil && *ref.Port != 0 {
+		return svc, int32(*ref.Port), nil
+	}
+
+	// The port may only be omitted if the Service has a single port.
+	if len(svc.Spec.Ports) == 1 {
+		return svc, svc.Spec.Ports[0].Port, nil
+	}
+
+	ports := make([]string, 0, len(svc.Spec.Ports))
+	for _, p := range svc.Spec.Ports {
+		ports = append(ports, strconv.Itoa(int(p.Port)))
+	}
+
+	return nil, 0, fmt.Errorf(
+		"%s: %w: Service %s has ports [%s]",
+		refPath.Child("port"),
+		ErrAmbiguousServicePort,
+		svcNsName,
+		strings.Join(ports, ", "),
+	)
 }
 
 func validateHTTPBackendRef(
@@ -205,11 +301,7 @@ func validateBackendRef(
 		}
 	}
 
-	if ref.Port == nil {
-		panicForBrokenWebhookAssumption(fmt.Errorf("port is nil for ref %q", ref.Name))
-	}
-
-	// any value of port is OK
+	// any value of port is OK. If the port is not set, it is taken from the Service in getServiceAndPortFromRef.
 
 	if ref.Weight != nil {
 		if err := validateWeight(*ref.Weight); err != nil {
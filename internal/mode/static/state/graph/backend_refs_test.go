@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"errors"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -162,6 +163,14 @@ func TestValidateBackendRef(t *testing.T) {
 			},
 			expectedValid: true,
 		},
+		{
+			name: "normal case with nil port",
+			ref: getModifiedRef(func(backend v1beta1.BackendRef) v1beta1.BackendRef {
+				backend.Port = nil
+				return backend
+			}),
+			expectedValid: true,
+		},
 		{
 			name: "invalid group",
 			ref: getModifiedRef(func(backend v1beta1.BackendRef) v1beta1.BackendRef {
@@ -252,12 +261,33 @@ func TestGetServiceAndPortFromRef(t *testing.T) {
 		},
 	}
 
+	singlePortSvc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "single-port",
+			Namespace: "test",
+		},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{{Port: 8080}},
+		},
+	}
+
+	multiPortSvc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "multi-port",
+			Namespace: "test",
+		},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{{Port: 80}, {Port: 443}},
+		},
+	}
+
 	tests := []struct {
-		ref        v1beta1.BackendRef
-		expService *v1.Service
-		name       string
-		expPort    int32
-		expErr     bool
+		ref              v1beta1.BackendRef
+		expService       *v1.Service
+		name             string
+		expPort          int32
+		expErr           bool
+		expAmbiguousPort bool
 	}{
 		{
 			name:       "normal case",
@@ -273,11 +303,62 @@ func TestGetServiceAndPortFromRef(t *testing.T) {
 			}),
 			expErr: true,
 		},
+		{
+			name: "port omitted for single-port service",
+			ref: getModifiedRef(func(backend v1beta1.BackendRef) v1beta1.BackendRef {
+				backend.Name = "single-port"
+				backend.Port = nil
+				return backend
+			}),
+			expService: singlePortSvc,
+			expPort:    8080,
+		},
+		{
+			name: "port set for multi-port service",
+			ref: getModifiedRef(func(backend v1beta1.BackendRef) v1beta1.BackendRef {
+				backend.Name = "multi-port"
+				backend.Port = helpers.GetPointer[v1beta1.PortNumber](443)
+				return backend
+			}),
+			expService: multiPortSvc,
+			expPort:    443,
+		},
+		{
+			name: "port omitted for multi-port service",
+			ref: getModifiedRef(func(backend v1beta1.BackendRef) v1beta1.BackendRef {
+				backend.Name = "multi-port"
+				backend.Port = nil
+				return backend
+			}),
+			expErr:           true,
+			expAmbiguousPort: true,
+		},
+		{
+			name: "zero port for multi-port service",
+			ref: getModifiedRef(func(backend v1beta1.BackendRef) v1beta1.BackendRef {
+				backend.Name = "multi-port"
+				backend.Port = helpers.GetPointer[v1beta1.PortNumber](0)
+				return backend
+			}),
+			expErr:           true,
+			expAmbiguousPort: true,
+		},
+		{
+			name: "port omitted for service that does not exist",
+			ref: getModifiedRef(func(backend v1beta1.BackendRef) v1beta1.BackendRef {
+				backend.Name = "does-not-exist"
+				backend.Port = nil
+				return backend
+			}),
+			expErr: true,
+		},
 	}
 
 	services := map[types.NamespacedName]*v1.Service{
-		{Namespace: "test", Name: "service1"}: svc1,
-		{Namespace: "test", Name: "service2"}: svc2,
+		{Namespace: "test", Name: "service1"}:    svc1,
+		{Namespace: "test", Name: "service2"}:    svc2,
+		{Namespace: "test", Name: "single-port"}: singlePortSvc,
+		{Namespace: "test", Name: "multi-port"}:  multiPortSvc,
 	}
 
 	refPath := field.NewPath("test")
@@ -289,6 +370,7 @@ func TestGetServiceAndPortFromRef(t *testing.T) {
 			svc, port, err := getServiceAndPortFromRef(test.ref, "test", services, refPath)
 
 			g.Expect(err != nil).To(Equal(test.expErr))
+			g.Expect(errors.Is(err, ErrAmbiguousServicePort)).To(Equal(test.expAmbiguousPort))
 			g.Expect(svc).To(Equal(test.expService))
 			g.Expect(port).To(Equal(test.expPort))
 		})
@@ -367,11 +449,36 @@ func TestAddBackendRefsToRulesTest(t *testing.T) {
 	hrWithInvalidRule := createRoute("hr3", "NotService", 1, "svc1")
 	hrWithZeroBackendRefs := createRoute("hr4", "Service", 1, "svc1")
 	hrWithZeroBackendRefs.Spec.Rules[0].BackendRefs = nil
+	hrWithAllBackendsMissing := createRoute("hr5", "Service", 2, "svc-missing")
+	hrWithOneOfThreeBackendsMissing := createRoute("hr6", "Service", 1, "svc1", "svc-missing", "svc2")
+	hrWithOneOfThreeBackendsMissing.Spec.Rules = []v1beta1.HTTPRouteRule{
+		{
+			BackendRefs: []v1beta1.HTTPBackendRef{
+				hrWithOneOfThreeBackendsMissing.Spec.Rules[0].BackendRefs[0],
+				hrWithOneOfThreeBackendsMissing.Spec.Rules[1].BackendRefs[0],
+				hrWithOneOfThreeBackendsMissing.Spec.Rules[2].BackendRefs[0],
+			},
+		},
+	}
+	hrWithAllThreeBackendsFound := createRoute("hr7", "Service", 1, "svc1", "svc2", "svc3")
+	hrWithAllThreeBackendsFound.Spec.Rules = []v1beta1.HTTPRouteRule{
+		{
+			BackendRefs: []v1beta1.HTTPBackendRef{
+				hrWithAllThreeBackendsFound.Spec.Rules[0].BackendRefs[0],
+				hrWithAllThreeBackendsFound.Spec.Rules[1].BackendRefs[0],
+				hrWithAllThreeBackendsFound.Spec.Rules[2].BackendRefs[0],
+			},
+		},
+	}
 
 	svc1 := &v1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "svc1"}}
+	svc2 := &v1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "svc2"}}
+	svc3 := &v1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "svc3"}}
 
 	services := map[types.NamespacedName]*v1.Service{
 		{Namespace: "test", Name: "svc1"}: svc1,
+		{Namespace: "test", Name: "svc2"}: svc2,
+		{Namespace: "test", Name: "svc3"}: svc3,
 	}
 
 	tests := []struct {
@@ -484,13 +591,97 @@ func TestAddBackendRefsToRulesTest(t *testing.T) {
 			expectedConditions:  nil,
 			name:                "zero backendRefs",
 		},
+		{
+			route: &Route{
+				Source:     hrWithAllBackendsMissing,
+				ParentRefs: sectionNameRefs,
+				Valid:      true,
+				Rules:      createRules(hrWithAllBackendsMissing, allValid, allValid),
+			},
+			expectedBackendRefs: []BackendRef{
+				{
+					Weight: 1,
+				},
+				{
+					Weight: 5,
+				},
+			},
+			expectedConditions: []conditions.Condition{
+				staticConds.NewRouteBackendRefRefBackendNotFound(
+					`spec.rules[0].backendRefs[0].name: Not found: "svc-missing"; ` +
+						`spec.rules[0].backendRefs[1].name: Not found: "svc-missing"`,
+				),
+			},
+			name: "all backends missing",
+		},
+		{
+			route: &Route{
+				Source:     hrWithOneOfThreeBackendsMissing,
+				ParentRefs: sectionNameRefs,
+				Valid:      true,
+				Rules:      createRules(hrWithOneOfThreeBackendsMissing, allValid, allValid),
+			},
+			expectedBackendRefs: []BackendRef{
+				{
+					Svc:    svc1,
+					Port:   80,
+					Valid:  true,
+					Weight: 1,
+				},
+				{
+					Weight: 1,
+				},
+				{
+					Svc:    svc2,
+					Port:   80,
+					Valid:  true,
+					Weight: 1,
+				},
+			},
+			expectedConditions: []conditions.Condition{
+				staticConds.NewRouteBackendRefRefBackendNotFound(
+					`spec.rules[0].backendRefs[1].name: Not found: "svc-missing"`,
+				),
+			},
+			name: "one of three backends missing",
+		},
+		{
+			route: &Route{
+				Source:     hrWithAllThreeBackendsFound,
+				ParentRefs: sectionNameRefs,
+				Valid:      true,
+				Rules:      createRules(hrWithAllThreeBackendsFound, allValid, allValid),
+			},
+			expectedBackendRefs: []BackendRef{
+				{
+					Svc:    svc1,
+					Port:   80,
+					Valid:  true,
+					Weight: 1,
+				},
+				{
+					Svc:    svc2,
+					Port:   80,
+					Valid:  true,
+					Weight: 1,
+				},
+				{
+					Svc:    svc3,
+					Port:   80,
+					Valid:  true,
+					Weight: 1,
+				},
+			},
+			expectedConditions: nil,
+			name:               "all three backends found",
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			g := NewGomegaWithT(t)
 			resolver := newReferenceGrantResolver(nil)
-			addBackendRefsToRules(test.route, resolver, services)
+			addBackendRefsToRules(test.route, resolver, services, nil)
 
 			var actual []BackendRef
 			if test.route.Rules != nil {
@@ -505,11 +696,34 @@ func TestAddBackendRefsToRulesTest(t *testing.T) {
 
 func TestCreateBackend(t *testing.T) {
 	svc1 := &v1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "service1"}}
+	externalSvc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "external"},
+		Spec: v1.ServiceSpec{
+			Type:         v1.ServiceTypeExternalName,
+			ExternalName: "example.com",
+		},
+	}
+	invalidExternalSvc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "invalid-external"},
+		Spec: v1.ServiceSpec{
+			Type:         v1.ServiceTypeExternalName,
+			ExternalName: "example.com; return 200",
+		},
+	}
+	multiPortSvc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "multi-port"},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{{Port: 80}, {Port: 443}},
+		},
+	}
+	dnsResolvers := []string{"10.96.0.10"}
 
 	tests := []struct {
 		expectedCondition            *conditions.Condition
 		name                         string
 		expectedServicePortReference string
+		expectedDNSAddress           string
+		dnsResolvers                 []string
 		ref                          v1beta1.HTTPBackendRef
 		expectedBackend              BackendRef
 	}{
@@ -605,10 +819,99 @@ func TestCreateBackend(t *testing.T) {
 			),
 			name: "service doesn't exist",
 		},
+		{
+			ref: v1beta1.HTTPBackendRef{
+				BackendRef: getModifiedRef(func(backend v1beta1.BackendRef) v1beta1.BackendRef {
+					backend.Name = "multi-port"
+					backend.Port = nil
+					return backend
+				}),
+			},
+			expectedBackend: BackendRef{
+				Svc:    nil,
+				Port:   0,
+				Weight: 5,
+				Valid:  false,
+			},
+			expectedServicePortReference: "",
+			expectedCondition: helpers.GetPointer(
+				staticConds.NewRouteBackendRefPortRequired(
+					"test.port: port is required for a Service with multiple ports: " +
+						"Service test/multi-port has ports [80, 443]",
+				),
+			),
+			name: "port omitted for multi-port service",
+		},
+		{
+			ref: v1beta1.HTTPBackendRef{
+				BackendRef: getModifiedRef(func(backend v1beta1.BackendRef) v1beta1.BackendRef {
+					backend.Name = "external"
+					return backend
+				}),
+			},
+			dnsResolvers: dnsResolvers,
+			expectedBackend: BackendRef{
+				Svc:    externalSvc,
+				Port:   80,
+				Weight: 5,
+				Valid:  true,
+			},
+			expectedServicePortReference: "test_external_80",
+			expectedDNSAddress:           "example.com:80",
+			expectedCondition:            nil,
+			name:                         "ExternalName service",
+		},
+		{
+			ref: v1beta1.HTTPBackendRef{
+				BackendRef: getModifiedRef(func(backend v1beta1.BackendRef) v1beta1.BackendRef {
+					backend.Name = "external"
+					return backend
+				}),
+			},
+			expectedBackend: BackendRef{
+				Weight: 5,
+				Valid:  false,
+			},
+			expectedServicePortReference: "",
+			expectedCondition: helpers.GetPointer(
+				staticConds.NewRouteBackendRefUnsupportedValue(
+					`test.name: Invalid value: "external": the ExternalName Service requires DNS resolvers, ` +
+						"which are configured with the gateway.nginx.org/dns-resolvers annotation of the Gateway",
+				),
+			),
+			name: "ExternalName service without DNS resolvers",
+		},
+		{
+			ref: v1beta1.HTTPBackendRef{
+				BackendRef: getModifiedRef(func(backend v1beta1.BackendRef) v1beta1.BackendRef {
+					backend.Name = "invalid-external"
+					return backend
+				}),
+			},
+			dnsResolvers: dnsResolvers,
+			expectedBackend: BackendRef{
+				Weight: 5,
+				Valid:  false,
+			},
+			expectedServicePortReference: "",
+			expectedCondition: helpers.GetPointer(
+				staticConds.NewRouteBackendRefUnsupportedValue(
+					`test.name: Invalid value: "invalid-external": the external name "example.com; return 200" ` +
+						"of the Service is invalid: a lowercase RFC 1123 subdomain must consist of lower case " +
+						"alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character " +
+						"(e.g. 'example.com', regex used for validation is " +
+						`'[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`,
+				),
+			),
+			name: "ExternalName service with invalid external name",
+		},
 	}
 
 	services := map[types.NamespacedName]*v1.Service{
-		client.ObjectKeyFromObject(svc1): svc1,
+		client.ObjectKeyFromObject(svc1):               svc1,
+		client.ObjectKeyFromObject(externalSvc):        externalSvc,
+		client.ObjectKeyFromObject(invalidExternalSvc): invalidExternalSvc,
+		client.ObjectKeyFromObject(multiPortSvc):       multiPortSvc,
 	}
 	sourceNamespace := "test"
 
@@ -619,13 +922,14 @@ func TestCreateBackend(t *testing.T) {
 			g := NewGomegaWithT(t)
 
 			resolver := newReferenceGrantResolver(nil)
-			backend, cond := createBackendRef(test.ref, sourceNamespace, resolver, services, refPath)
+			backend, cond := createBackendRef(test.ref, sourceNamespace, resolver, services, test.dnsResolvers, refPath)
 
 			g.Expect(helpers.Diff(test.expectedBackend, backend)).To(BeEmpty())
 			g.Expect(cond).To(Equal(test.expectedCondition))
 
 			servicePortRef := backend.ServicePortReference()
 			g.Expect(servicePortRef).To(Equal(test.expectedServicePortReference))
+			g.Expect(backend.DNSAddress()).To(Equal(test.expectedDNSAddress))
 		})
 	}
 }
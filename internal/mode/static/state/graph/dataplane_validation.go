@@ -0,0 +1,242 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/validation"
+)
+
+// This file exports the validation of the Graph for the dataplane configuration that is not built from the Graph,
+// for example, the one imported from a snapshot. The functions run the same checks as building the Graph, so that
+// such a configuration can only include the values that a Graph can include.
+
+// ValidateHostname validates the hostname of a Listener or an HTTPRoute. The hostname can be a wildcard.
+func ValidateHostname(hostname string) error {
+	return validateHostname(hostname)
+}
+
+// ValidateRouteMatch validates the match of an HTTPRoute rule.
+func ValidateRouteMatch(
+	validator validation.HTTPFieldsValidator,
+	match v1beta1.HTTPRouteMatch,
+	matchPath *field.Path,
+) field.ErrorList {
+	// Unlike the HTTPRoutes from the cluster, the match wasn't validated by the webhook.
+	if match.Path != nil {
+		var allErrs field.ErrorList
+
+		if match.Path.Type == nil {
+			allErrs = append(allErrs, field.Required(matchPath.Child("path", "type"), "cannot be empty"))
+		}
+		if match.Path.Value == nil {
+			allErrs = append(allErrs, field.Required(matchPath.Child("path", "value"), "cannot be empty"))
+		}
+
+		if len(allErrs) > 0 {
+			return allErrs
+		}
+	}
+
+	return validateMatch(validator, match, matchPath)
+}
+
+// ValidateRequestRedirect validates the RequestRedirect filter of an HTTPRoute rule.
+func ValidateRequestRedirect(
+	validator validation.HTTPFieldsValidator,
+	redirect *v1beta1.HTTPRequestRedirectFilter,
+	filterPath *field.Path,
+) field.ErrorList {
+	filter := v1beta1.HTTPRouteFilter{
+		Type:            v1beta1.HTTPRouteFilterRequestRedirect,
+		RequestRedirect: redirect,
+	}
+
+	return validateFilterRedirect(validator, filter, filterPath)
+}
+
+// ValidateRequestHeaderModifier validates the RequestHeaderModifier filter of an HTTPRoute rule.
+func ValidateRequestHeaderModifier(
+	validator validation.HTTPFieldsValidator,
+	headerModifier *v1beta1.HTTPHeaderFilter,
+	filterPath *field.Path,
+) field.ErrorList {
+	return validateFilterHeaderModifierFields(validator, headerModifier, filterPath.Child("requestHeaderModifier"))
+}
+
+// ValidateSnippet validates the decoded NGINX directives of the ServerSnippetAnnotation or of the snippet for
+// the http context. If the snippets are not enabled, any snippet is invalid.
+func ValidateSnippet(snippet string, enableSnippets bool) error {
+	if !enableSnippets {
+		return errors.New(snippetsDisabledMsg)
+	}
+
+	return validateSnippetSyntax(snippet)
+}
+
+// ValidateLocationSnippet validates the decoded NGINX directives of the LocationSnippetAnnotation.
+// If the snippets are not enabled, any snippet is invalid.
+func ValidateLocationSnippet(snippet string, enableSnippets bool) error {
+	if err := ValidateSnippet(snippet, enableSnippets); err != nil {
+		return err
+	}
+
+	if proxyPassDirectiveRegexp.MatchString(snippet) {
+		return errors.New(proxyPassSnippetMsg)
+	}
+
+	return nil
+}
+
+// ValidateRequestIDHeader validates the name of the request ID header as the RequestIDHeaderAnnotation.
+func ValidateRequestIDHeader(name string) error {
+	_, err := getRequestIDHeader(map[string]string{RequestIDHeaderAnnotation: name})
+	return annotationErr(err)
+}
+
+// ValidateTrustedProxies validates the CIDRs of the trusted proxies as the TrustedProxiesAnnotation.
+func ValidateTrustedProxies(cidrs []string) error {
+	parsed, err := getTrustedProxies(map[string]string{TrustedProxiesAnnotation: strings.Join(cidrs, ",")})
+	if err != nil {
+		return annotationErr(err)
+	}
+
+	return validateParsedList(cidrs, parsed)
+}
+
+// ValidateDNSResolvers validates the IP addresses of the DNS servers as the DNSResolversAnnotation.
+func ValidateDNSResolvers(ips []string) error {
+	parsed, err := getDNSResolvers(map[string]string{DNSResolversAnnotation: strings.Join(ips, ",")})
+	if err != nil {
+		return annotationErr(err)
+	}
+
+	return validateParsedList(ips, parsed)
+}
+
+// ValidateErrorPage validates a custom error page as the ErrorPageStatusCodesAnnotation, ErrorPageBodyAnnotation
+// and ErrorPageContentTypeAnnotation.
+func ValidateErrorPage(statusCodes []int, body, contentType string) error {
+	if len(statusCodes) == 0 {
+		return errors.New("status codes are required")
+	}
+	if contentType == "" {
+		return errors.New("content type is required")
+	}
+
+	codes := make([]string, 0, len(statusCodes))
+	for _, c := range statusCodes {
+		codes = append(codes, strconv.Itoa(c))
+	}
+
+	_, err := getErrorPage(map[string]string{
+		ErrorPageStatusCodesAnnotation: strings.Join(codes, ","),
+		ErrorPageBodyAnnotation:        body,
+		ErrorPageContentTypeAnnotation: contentType,
+	})
+
+	return annotationErr(err)
+}
+
+// ValidateNextUpstream validates the settings of passing a request to the next backend server as
+// the ProxyNextUpstreamAnnotation and ProxyNextUpstreamTriesAnnotation.
+func ValidateNextUpstream(conditions []string, tries int32) error {
+	annotations := make(map[string]string, 2)
+	if len(conditions) > 0 {
+		annotations[ProxyNextUpstreamAnnotation] = strings.Join(conditions, ",")
+	}
+	// 0 tries is the default, which the annotation can't set.
+	if tries != 0 {
+		annotations[ProxyNextUpstreamTriesAnnotation] = strconv.FormatInt(int64(tries), 10)
+	}
+
+	nextUpstream, err := getNextUpstream(annotations)
+	if err != nil {
+		return annotationErr(err)
+	}
+
+	var parsed []string
+	if nextUpstream != nil {
+		parsed = nextUpstream.Conditions
+	}
+
+	return validateParsedList(conditions, parsed)
+}
+
+// ValidateWorkerProcesses validates the number of the NGINX worker processes as the WorkerProcessesAnnotation.
+func ValidateWorkerProcesses(value string) error {
+	_, err := getWorkerProcesses(map[string]string{WorkerProcessesAnnotation: value})
+	return annotationErr(err)
+}
+
+// ValidateGzip validates the gzip compression settings as the GzipAnnotation, GzipMinLengthAnnotation,
+// GzipTypesAnnotation and GzipLevelAnnotation.
+func ValidateGzip(minLength, level int32, types []string) error {
+	annotations := map[string]string{
+		GzipAnnotation:          "true",
+		GzipMinLengthAnnotation: strconv.FormatInt(int64(minLength), 10),
+		GzipLevelAnnotation:     strconv.FormatInt(int64(level), 10),
+	}
+	if len(types) > 0 {
+		annotations[GzipTypesAnnotation] = strings.Join(types, ",")
+	}
+
+	gzip, err := getGzip(annotations)
+	if err != nil {
+		return annotationErr(err)
+	}
+
+	return validateParsedList(types, gzip.Types)
+}
+
+// ValidateMinTLSVersion validates the minimum TLS version as the MinTLSVersionOption.
+func ValidateMinTLSVersion(version string) error {
+	_, errs := getTLSSettings(map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+		MinTLSVersionOption: v1beta1.AnnotationValue(version),
+	})
+	if len(errs) > 0 {
+		return errors.New(errs[0].Detail)
+	}
+
+	return nil
+}
+
+// ValidateCiphers validates the enabled ciphers as the SSLCiphersOption.
+func ValidateCiphers(ciphers []string) error {
+	parsed, err := parseCiphers(strings.Join(ciphers, ":"))
+	if err != nil {
+		return err
+	}
+
+	return validateParsedList(ciphers, parsed)
+}
+
+// annotationErr returns the error of an annotation parser without the path of the annotation.
+func annotationErr(err *field.Error) error {
+	if err == nil {
+		return nil
+	}
+
+	return errors.New(err.Detail)
+}
+
+// validateParsedList checks that the values are the same as the values that an annotation parser returned for them.
+// They differ if the values include the separators of the annotation or the whitespace that the parser removes.
+func validateParsedList(values, parsed []string) error {
+	if len(values) != len(parsed) {
+		return errors.New("values must not be empty or include separators")
+	}
+
+	for i := range values {
+		if values[i] != parsed[i] {
+			return fmt.Errorf("%q must not include whitespace", values[i])
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package graph
 
 import (
 	"sort"
+	"time"
 
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -19,12 +20,84 @@ type Gateway struct {
 	Source *v1beta1.Gateway
 	// Listeners include the listeners of the Gateway.
 	Listeners map[string]*Listener
+	// ClientMaxBodySize is the maximum allowed size of the client request body in bytes for the servers of the
+	// Gateway. It is set from the ClientMaxBodySizeAnnotation. If nil, the NGINX default applies.
+	ClientMaxBodySize *int64
+	// RequestIDHeader is the name of the header that carries the ID of the request to the backends and in the
+	// response. It is set from the RequestIDHeaderAnnotation. If empty, the header is not set.
+	RequestIDHeader string
+	// TrustedProxies holds the CIDRs of the trusted proxies in front of NGINX. It is set from the
+	// TrustedProxiesAnnotation. If empty, NGINX uses the address of the connecting client.
+	TrustedProxies []string
+	// DNSResolvers holds the IP addresses of the DNS servers that NGINX uses to resolve the DNS names of
+	// the backends. It is set from the DNSResolversAnnotation.
+	DNSResolvers []string
+	// ErrorPage is the custom error page of the servers of the Gateway. It is set from the
+	// ErrorPageStatusCodesAnnotation. If nil, NGINX responds with its default error pages.
+	ErrorPage *ErrorPage
+	// BackendErrorPage is the custom error page, which replaces the error responses of the backends for the routes
+	// attached to the Gateway. It is set from the BackendErrorStatusCodesAnnotation. If nil, the error responses of
+	// the backends pass through.
+	BackendErrorPage *ErrorPage
+	// Gzip holds the settings of the gzip compression of the responses. It is set from the GzipAnnotation.
+	// If nil, the responses are not compressed.
+	Gzip *Gzip
+	// OpenFileCache is the cache of the open file descriptors. It is set from the OpenFileCacheMaxFilesAnnotation.
+	// If nil, the cache is disabled.
+	OpenFileCache *OpenFileCache
+	// UpstreamZoneSize is the size in bytes of the shared memory zone of each upstream. It is set from
+	// the UpstreamZoneSizeAnnotation. If 0, the default size applies.
+	UpstreamZoneSize int64
+	// WorkerProcesses is the number of the NGINX worker processes: a positive integer or "auto". It is set from
+	// the WorkerProcessesAnnotation. If empty, the NGINX default applies.
+	WorkerProcesses string
 	// Conditions holds the conditions for the Gateway.
 	Conditions []conditions.Condition
+	// WorkerShutdownTimeout is the time, for which NGINX waits for the active connections to complete during
+	// a graceful shutdown. It is set from the WorkerShutdownTimeoutAnnotation. If 0, the default timeout applies.
+	WorkerShutdownTimeout time.Duration
+	// WorkerConnections is the maximum number of simultaneous connections of an NGINX worker process. It is set from
+	// the WorkerConnectionsAnnotation. If 0, the NGINX default applies.
+	WorkerConnections int32
+	// ShowServerVersion allows NGINX to show its version in the Server response header and on the error pages.
+	// It is set from the HideServerVersionAnnotation.
+	ShowServerVersion bool
 	// Valid indicates whether the Gateway Spec is valid.
 	Valid bool
 }
 
+// ErrorPage is a custom error page, which NGINX returns instead of its default error page for the responses with
+// the specified status codes.
+type ErrorPage struct {
+	// Body is the body of the error page.
+	Body string
+	// ContentType is the content type of the error page.
+	ContentType string
+	// StatusCodes are the status codes of the responses to replace with the error page.
+	StatusCodes []int
+}
+
+// Gzip holds the settings of the gzip compression of the responses.
+type Gzip struct {
+	// Types are the MIME types of the responses to compress in addition to text/html. "*" matches any type.
+	Types []string
+	// MinLength is the minimum length in bytes of the responses to compress.
+	MinLength int32
+	// Level is the compression level from 1 to 9.
+	Level int32
+}
+
+// OpenFileCache is the NGINX cache of the open file descriptors, which saves the system calls for the files that
+// NGINX opens for every request.
+type OpenFileCache struct {
+	// MaxFiles is the maximum number of the cached files.
+	MaxFiles int32
+	// Inactive is the time after which a file, which is not accessed, is removed from the cache.
+	Inactive time.Duration
+	// Valid is the time after which NGINX checks that a cached file is still valid.
+	Valid time.Duration
+}
+
 // processedGateways holds the resources that belong to NKG.
 type processedGateways struct {
 	Winner  *v1beta1.Gateway
@@ -78,7 +151,7 @@ func processGateways(
 		return nkgsort.LessObjectMeta(&referencedGws[i].ObjectMeta, &referencedGws[j].ObjectMeta)
 	})
 
-	ignoredGws := make(map[types.NamespacedName]*v1beta1.Gateway)
+	ignoredGws := make(map[types.NamespacedName]*v1beta1.Gateway, len(referencedGws)-1)
 
 	for _, gw := range referencedGws[1:] {
 		ignoredGws[client.ObjectKeyFromObject(gw)] = gw
@@ -95,6 +168,7 @@ func buildGateway(
 	secretResolver *secretResolver,
 	gc *GatewayClass,
 	refGrantResolver *referenceGrantResolver,
+	enableSnippets bool,
 ) *Gateway {
 	if gw == nil {
 		return nil
@@ -102,6 +176,55 @@ func buildGateway(
 
 	conds := validateGateway(gw, gc)
 
+	// addUnsupportedValue adds the condition for an invalid annotation.
+	addUnsupportedValue := func(valErr *field.Error) {
+		if valErr != nil {
+			conds = append(conds, staticConds.NewGatewayUnsupportedValue(valErr.Error())...)
+		}
+	}
+
+	clientMaxBodySize, valErr := getClientMaxBodySize(gw.Annotations)
+	addUnsupportedValue(valErr)
+
+	requestIDHeader, valErr := getRequestIDHeader(gw.Annotations)
+	addUnsupportedValue(valErr)
+
+	trustedProxies, valErr := getTrustedProxies(gw.Annotations)
+	addUnsupportedValue(valErr)
+
+	dnsResolvers, valErr := getDNSResolvers(gw.Annotations)
+	addUnsupportedValue(valErr)
+
+	errorPage, valErr := getErrorPage(gw.Annotations)
+	addUnsupportedValue(valErr)
+
+	backendErrorPage, valErr := getBackendErrorPage(gw.Annotations)
+	addUnsupportedValue(valErr)
+
+	serverSnippet, valErr := getServerSnippet(gw.Annotations, enableSnippets)
+	addUnsupportedValue(valErr)
+
+	showServerVersion, valErr := getShowServerVersion(gw.Annotations)
+	addUnsupportedValue(valErr)
+
+	gzip, valErr := getGzip(gw.Annotations)
+	addUnsupportedValue(valErr)
+
+	openFileCache, valErr := getOpenFileCache(gw.Annotations)
+	addUnsupportedValue(valErr)
+
+	upstreamZoneSize, valErr := getUpstreamZoneSize(gw.Annotations)
+	addUnsupportedValue(valErr)
+
+	workerProcesses, valErr := getWorkerProcesses(gw.Annotations)
+	addUnsupportedValue(valErr)
+
+	workerConnections, valErr := getWorkerConnections(gw.Annotations)
+	addUnsupportedValue(valErr)
+
+	workerShutdownTimeout, valErr := getWorkerShutdownTimeout(gw.Annotations)
+	addUnsupportedValue(valErr)
+
 	if len(conds) > 0 {
 		return &Gateway{
 			Source:     gw,
@@ -110,10 +233,28 @@ func buildGateway(
 		}
 	}
 
+	listeners := buildListeners(gw, secretResolver, refGrantResolver)
+	for _, l := range listeners {
+		l.ServerSnippet = serverSnippet
+	}
+
 	return &Gateway{
-		Source:    gw,
-		Listeners: buildListeners(gw, secretResolver, refGrantResolver),
-		Valid:     true,
+		Source:                gw,
+		Listeners:             listeners,
+		ClientMaxBodySize:     clientMaxBodySize,
+		RequestIDHeader:       requestIDHeader,
+		TrustedProxies:        trustedProxies,
+		DNSResolvers:          dnsResolvers,
+		ErrorPage:             errorPage,
+		BackendErrorPage:      backendErrorPage,
+		Gzip:                  gzip,
+		OpenFileCache:         openFileCache,
+		UpstreamZoneSize:      upstreamZoneSize,
+		WorkerProcesses:       workerProcesses,
+		WorkerConnections:     workerConnections,
+		WorkerShutdownTimeout: workerShutdownTimeout,
+		ShowServerVersion:     showServerVersion,
+		Valid:                 true,
 	}
 }
 
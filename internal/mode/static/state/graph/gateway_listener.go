@@ -27,10 +27,19 @@ type Listener struct {
 	// ResolvedSecret is the namespaced name of the Secret resolved for this listener.
 	// Only applicable for HTTPS listeners.
 	ResolvedSecret *types.NamespacedName
+	// ResolvedDHParamsSecret is the namespaced name of the Secret with the Diffie-Hellman parameters resolved for
+	// this listener. Only applicable for HTTPS listeners with the DH parameters Secret in the TLS options.
+	ResolvedDHParamsSecret *types.NamespacedName
 	// Conditions holds the conditions of the Listener.
 	Conditions []conditions.Condition
 	// SupportedKinds is the list of RouteGroupKinds allowed by the listener.
 	SupportedKinds []v1beta1.RouteGroupKind
+	// TLSSettings holds the NGINX TLS settings of the listener.
+	// Only applicable for HTTPS listeners.
+	TLSSettings TLSSettings
+	// ServerSnippet holds the NGINX directives that are appended to the server blocks of the listener.
+	// It is set from the ServerSnippetAnnotation of the Gateway.
+	ServerSnippet string
 	// Valid shows whether the Listener is valid.
 	// A Listener is considered valid if NKG can generate valid NGINX configuration for it.
 	Valid bool
@@ -111,6 +120,7 @@ func newListenerConfiguratorFactory(
 			},
 			externalReferenceResolvers: []listenerExternalReferenceResolver{
 				createExternalReferencesForTLSSecretsResolver(gw.Namespace, secretResolver, refGrantResolver),
+				createExternalReferencesForDHParamsSecretResolver(gw.Namespace, secretResolver),
 			},
 		},
 	}
@@ -180,6 +190,11 @@ func (c *listenerConfigurator) configure(listener v1beta1.Listener) *Listener {
 		SupportedKinds:            supportedKinds,
 	}
 
+	if listener.TLS != nil {
+		// the validators have already validated the options
+		l.TLSSettings, _ = getTLSSettings(listener.TLS.Options)
+	}
+
 	// resolvers might add different conditions to the listener, so we run them all.
 
 	for _, resolver := range c.conflictResolvers {
@@ -322,10 +337,8 @@ func createHTTPSListenerValidator() listenerValidator {
 			conds = append(conds, staticConds.NewListenerUnsupportedValue(valErr.Error())...)
 		}
 
-		if len(listener.TLS.Options) > 0 {
-			path := tlsPath.Child("options")
-			valErr := field.Forbidden(path, "options are not supported")
-			conds = append(conds, staticConds.NewListenerUnsupportedValue(valErr.Error())...)
+		if _, valErrs := getTLSSettings(listener.TLS.Options); len(valErrs) > 0 {
+			conds = append(conds, staticConds.NewListenerUnsupportedValue(valErrs.ToAggregate().Error())...)
 		}
 
 		if len(listener.TLS.CertificateRefs) == 0 {
@@ -438,12 +451,74 @@ func createExternalReferencesForTLSSecretsResolver(
 
 			l.Conditions = append(l.Conditions, staticConds.NewListenerInvalidCertificateRef(valErr.Error())...)
 			l.Valid = false
-		} else {
-			l.ResolvedSecret = &certRefNsName
+			return
+		}
+
+		l.ResolvedSecret = &certRefNsName
+
+		if l.TLSSettings.Stapling {
+			resolveCACertForStapling(l, secretResolver)
 		}
 	}
 }
 
+func createExternalReferencesForDHParamsSecretResolver(
+	gwNs string,
+	secretResolver *secretResolver,
+) listenerExternalReferenceResolver {
+	return func(l *Listener) {
+		if l.TLSSettings.DHParamSecret == "" {
+			return
+		}
+
+		nsname := types.NamespacedName{
+			Namespace: gwNs,
+			Name:      l.TLSSettings.DHParamSecret,
+		}
+
+		if err := secretResolver.resolveDHParams(nsname); err != nil {
+			path := field.NewPath("tls", "options").Key(string(SSLDHParamSecretOption))
+			valErr := field.Invalid(path, nsname, err.Error())
+
+			l.Conditions = append(l.Conditions, staticConds.NewListenerInvalidCertificateRef(valErr.Error())...)
+			l.Valid = false
+			return
+		}
+
+		l.ResolvedDHParamsSecret = &nsname
+	}
+}
+
+// resolveCACertForStapling ensures that the resolved Secret of the listener includes a valid CA certificate,
+// which NGINX needs to verify OCSP responses. If the Secret doesn't include the CA certificate, OCSP stapling is
+// disabled, but the listener stays valid. If the CA certificate is invalid, the listener is made invalid.
+func resolveCACertForStapling(l *Listener, secretResolver *secretResolver) {
+	path := field.NewPath("tls", "certificateRefs").Index(0)
+
+	caCert := secretResolver.getCACert(*l.ResolvedSecret)
+	if caCert == nil {
+		l.TLSSettings.Stapling = false
+
+		msg := fmt.Sprintf(
+			"%s: OCSP stapling is disabled because the Secret %s doesn't have the %s key",
+			path,
+			*l.ResolvedSecret,
+			CACertKey,
+		)
+		l.Conditions = append(l.Conditions, staticConds.NewListenerAcceptedWithWarning(msg))
+
+		return
+	}
+
+	if err := validateCACert(caCert); err != nil {
+		msg := fmt.Sprintf("the %s key of the Secret is invalid: %s", CACertKey, err)
+		valErr := field.Invalid(path, *l.ResolvedSecret, msg)
+
+		l.Conditions = append(l.Conditions, staticConds.NewListenerInvalidCertificateRef(valErr.Error())...)
+		l.Valid = false
+	}
+}
+
 // GetAllowedRouteLabelSelector returns a listener's AllowedRoutes label selector if it exists.
 func GetAllowedRouteLabelSelector(l v1beta1.Listener) *metav1.LabelSelector {
 	if l.AllowedRoutes != nil && l.AllowedRoutes.Namespaces != nil {
@@ -49,22 +49,22 @@ func TestValidateHTTPSListener(t *testing.T) {
 	secretNs := "secret-ns"
 
 	validSecretRef := v1beta1.SecretObjectReference{
-		Kind:      (*v1beta1.Kind)(helpers.GetStringPointer("Secret")),
+		Kind:      (*v1beta1.Kind)(helpers.GetPointer("Secret")),
 		Name:      "secret",
-		Namespace: (*v1beta1.Namespace)(helpers.GetStringPointer(secretNs)),
+		Namespace: (*v1beta1.Namespace)(helpers.GetPointer(secretNs)),
 	}
 
 	invalidSecretRefGroup := v1beta1.SecretObjectReference{
-		Group:     (*v1beta1.Group)(helpers.GetStringPointer("some-group")),
-		Kind:      (*v1beta1.Kind)(helpers.GetStringPointer("Secret")),
+		Group:     (*v1beta1.Group)(helpers.GetPointer("some-group")),
+		Kind:      (*v1beta1.Kind)(helpers.GetPointer("Secret")),
 		Name:      "secret",
-		Namespace: (*v1beta1.Namespace)(helpers.GetStringPointer(secretNs)),
+		Namespace: (*v1beta1.Namespace)(helpers.GetPointer(secretNs)),
 	}
 
 	invalidSecretRefKind := v1beta1.SecretObjectReference{
-		Kind:      (*v1beta1.Kind)(helpers.GetStringPointer("ConfigMap")),
+		Kind:      (*v1beta1.Kind)(helpers.GetPointer("ConfigMap")),
 		Name:      "secret",
-		Namespace: (*v1beta1.Namespace)(helpers.GetStringPointer(secretNs)),
+		Namespace: (*v1beta1.Namespace)(helpers.GetPointer(secretNs)),
 	}
 
 	tests := []struct {
@@ -76,7 +76,7 @@ func TestValidateHTTPSListener(t *testing.T) {
 			l: v1beta1.Listener{
 				Port: 443,
 				TLS: &v1beta1.GatewayTLSConfig{
-					Mode:            helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
+					Mode:            helpers.GetPointer[v1beta1.TLSModeType](v1beta1.TLSModeTerminate),
 					CertificateRefs: []v1beta1.SecretObjectReference{validSecretRef},
 				},
 			},
@@ -87,7 +87,7 @@ func TestValidateHTTPSListener(t *testing.T) {
 			l: v1beta1.Listener{
 				Port: 0,
 				TLS: &v1beta1.GatewayTLSConfig{
-					Mode:            helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
+					Mode:            helpers.GetPointer[v1beta1.TLSModeType](v1beta1.TLSModeTerminate),
 					CertificateRefs: []v1beta1.SecretObjectReference{validSecretRef},
 				},
 			},
@@ -98,19 +98,55 @@ func TestValidateHTTPSListener(t *testing.T) {
 			l: v1beta1.Listener{
 				Port: 443,
 				TLS: &v1beta1.GatewayTLSConfig{
-					Mode:            helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
+					Mode:            helpers.GetPointer[v1beta1.TLSModeType](v1beta1.TLSModeTerminate),
 					CertificateRefs: []v1beta1.SecretObjectReference{validSecretRef},
 					Options:         map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{"key": "val"},
 				},
 			},
-			expected: staticConds.NewListenerUnsupportedValue("tls.options: Forbidden: options are not supported"),
-			name:     "invalid options",
+			expected: staticConds.NewListenerUnsupportedValue(
+				`tls.options: Unsupported value: "key": supported values: ` +
+					`"gateway.nginx.org/min-tls-version", "gateway.nginx.org/ssl-ciphers", "gateway.nginx.org/ssl-dhparam-secret", ` +
+					`"gateway.nginx.org/ssl-session-cache-size", "gateway.nginx.org/ssl-session-tickets", ` +
+					`"gateway.nginx.org/ssl-stapling"`,
+			),
+			name: "unsupported option",
+		},
+		{
+			l: v1beta1.Listener{
+				Port: 443,
+				TLS: &v1beta1.GatewayTLSConfig{
+					Mode:            helpers.GetPointer[v1beta1.TLSModeType](v1beta1.TLSModeTerminate),
+					CertificateRefs: []v1beta1.SecretObjectReference{validSecretRef},
+					Options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+						SSLSessionCacheSizeOption: "10Mi",
+						SSLSessionTicketsOption:   "false",
+					},
+				},
+			},
+			expected: nil,
+			name:     "valid options",
+		},
+		{
+			l: v1beta1.Listener{
+				Port: 443,
+				TLS: &v1beta1.GatewayTLSConfig{
+					Mode:            helpers.GetPointer[v1beta1.TLSModeType](v1beta1.TLSModeTerminate),
+					CertificateRefs: []v1beta1.SecretObjectReference{validSecretRef},
+					Options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+						SSLSessionTicketsOption: "no",
+					},
+				},
+			},
+			expected: staticConds.NewListenerUnsupportedValue(
+				`tls.options[gateway.nginx.org/ssl-session-tickets]: Invalid value: "no": must be a boolean`,
+			),
+			name: "invalid option value",
 		},
 		{
 			l: v1beta1.Listener{
 				Port: 443,
 				TLS: &v1beta1.GatewayTLSConfig{
-					Mode:            helpers.GetTLSModePointer(v1beta1.TLSModePassthrough),
+					Mode:            helpers.GetPointer[v1beta1.TLSModeType](v1beta1.TLSModePassthrough),
 					CertificateRefs: []v1beta1.SecretObjectReference{validSecretRef},
 				},
 			},
@@ -123,7 +159,7 @@ func TestValidateHTTPSListener(t *testing.T) {
 			l: v1beta1.Listener{
 				Port: 443,
 				TLS: &v1beta1.GatewayTLSConfig{
-					Mode:            helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
+					Mode:            helpers.GetPointer[v1beta1.TLSModeType](v1beta1.TLSModeTerminate),
 					CertificateRefs: []v1beta1.SecretObjectReference{invalidSecretRefGroup},
 				},
 			},
@@ -136,7 +172,7 @@ func TestValidateHTTPSListener(t *testing.T) {
 			l: v1beta1.Listener{
 				Port: 443,
 				TLS: &v1beta1.GatewayTLSConfig{
-					Mode:            helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
+					Mode:            helpers.GetPointer[v1beta1.TLSModeType](v1beta1.TLSModeTerminate),
 					CertificateRefs: []v1beta1.SecretObjectReference{invalidSecretRefKind},
 				},
 			},
@@ -149,7 +185,7 @@ func TestValidateHTTPSListener(t *testing.T) {
 			l: v1beta1.Listener{
 				Port: 443,
 				TLS: &v1beta1.GatewayTLSConfig{
-					Mode:            helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
+					Mode:            helpers.GetPointer[v1beta1.TLSModeType](v1beta1.TLSModeTerminate),
 					CertificateRefs: []v1beta1.SecretObjectReference{validSecretRef, validSecretRef},
 				},
 			},
@@ -184,22 +220,22 @@ func TestValidateListenerHostname(t *testing.T) {
 			name:      "nil hostname",
 		},
 		{
-			hostname:  (*v1beta1.Hostname)(helpers.GetStringPointer("")),
+			hostname:  (*v1beta1.Hostname)(helpers.GetPointer("")),
 			expectErr: false,
 			name:      "empty hostname",
 		},
 		{
-			hostname:  (*v1beta1.Hostname)(helpers.GetStringPointer("foo.example.com")),
+			hostname:  (*v1beta1.Hostname)(helpers.GetPointer("foo.example.com")),
 			expectErr: false,
 			name:      "valid hostname",
 		},
 		{
-			hostname:  (*v1beta1.Hostname)(helpers.GetStringPointer("*.example.com")),
+			hostname:  (*v1beta1.Hostname)(helpers.GetPointer("*.example.com")),
 			expectErr: false,
 			name:      "wildcard hostname",
 		},
 		{
-			hostname:  (*v1beta1.Hostname)(helpers.GetStringPointer("example$com")),
+			hostname:  (*v1beta1.Hostname)(helpers.GetPointer("example$com")),
 			expectErr: true,
 			name:      "invalid hostname",
 		},
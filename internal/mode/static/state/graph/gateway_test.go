@@ -3,6 +3,7 @@ package graph
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	apiv1 "k8s.io/api/core/v1"
@@ -12,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/conditions"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
 	staticConds "github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/conditions"
 )
@@ -225,6 +227,78 @@ func TestBuildGateway(t *testing.T) {
 		},
 	}
 
+	secretWithCA := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "secret-with-ca",
+		},
+		Data: map[string][]byte{
+			apiv1.TLSCertKey:       cert,
+			apiv1.TLSPrivateKeyKey: key,
+			CACertKey:              cert,
+		},
+		Type: apiv1.SecretTypeTLS,
+	}
+
+	secretInvalidCA := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "secret-invalid-ca",
+		},
+		Data: map[string][]byte{
+			apiv1.TLSCertKey:       cert,
+			apiv1.TLSPrivateKeyKey: key,
+			CACertKey:              invalidCert,
+		},
+		Type: apiv1.SecretTypeTLS,
+	}
+
+	secretDHParams := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "dhparams",
+		},
+		Data: map[string][]byte{
+			DHParamsKey: dhParamsPEM,
+		},
+		Type: apiv1.SecretTypeOpaque,
+	}
+
+	secretInvalidDHParams := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "invalid-dhparams",
+		},
+		Data: map[string][]byte{
+			DHParamsKey: cert,
+		},
+		Type: apiv1.SecretTypeOpaque,
+	}
+
+	createDHParamsTLSConfig := func(name string) *v1beta1.GatewayTLSConfig {
+		tls := gatewayTLSConfigSameNs.DeepCopy()
+		tls.Options = map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+			SSLDHParamSecretOption: v1beta1.AnnotationValue(name),
+		}
+		return tls
+	}
+
+	createStaplingTLSConfig := func(secret *apiv1.Secret) *v1beta1.GatewayTLSConfig {
+		return &v1beta1.GatewayTLSConfig{
+			Mode: helpers.GetPointer(v1beta1.TLSModeTerminate),
+			CertificateRefs: []v1beta1.SecretObjectReference{
+				{
+					Kind:      helpers.GetPointer[v1beta1.Kind]("Secret"),
+					Name:      v1beta1.ObjectName(secret.Name),
+					Namespace: (*v1beta1.Namespace)(&secret.Namespace),
+				},
+			},
+			Options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				SSLStaplingOption: "true",
+			},
+		}
+	}
+
 	createListener := func(
 		name string,
 		hostname string,
@@ -276,6 +350,36 @@ func TestBuildGateway(t *testing.T) {
 		gatewayTLSConfigDiffNs,
 	)
 
+	// https listeners with OCSP stapling
+	staplingListener := createHTTPSListener("stapling", "foo.example.com", 443, createStaplingTLSConfig(secretWithCA))
+	staplingNoCAListener := createHTTPSListener(
+		"stapling-no-ca",
+		"foo.example.com",
+		8443,
+		createStaplingTLSConfig(secretSameNs),
+	)
+	staplingInvalidCAListener := createHTTPSListener(
+		"stapling-invalid-ca",
+		"foo.example.com",
+		9443,
+		createStaplingTLSConfig(secretInvalidCA),
+	)
+
+	// https listeners with DH parameters
+	dhParamsListener := createHTTPSListener("dhparams", "foo.example.com", 443, createDHParamsTLSConfig("dhparams"))
+	invalidDHParamsListener := createHTTPSListener(
+		"invalid-dhparams",
+		"foo.example.com",
+		8443,
+		createDHParamsTLSConfig("invalid-dhparams"),
+	)
+	missingDHParamsListener := createHTTPSListener(
+		"missing-dhparams",
+		"foo.example.com",
+		9443,
+		createDHParamsTLSConfig("does-not-exist"),
+	)
+
 	// invalid listeners
 	invalidProtocolListener := createTCPListener("invalid-protocol", "bar.example.com", 80)
 	invalidPortListener := createHTTPListener("invalid-port", "invalid-port", 0)
@@ -308,15 +412,17 @@ func TestBuildGateway(t *testing.T) {
 	)
 
 	type gatewayCfg struct {
-		listeners []v1beta1.Listener
-		addresses []v1beta1.GatewayAddress
+		annotations map[string]string
+		listeners   []v1beta1.Listener
+		addresses   []v1beta1.GatewayAddress
 	}
 
 	var lastCreatedGateway *v1beta1.Gateway
 	createGateway := func(cfg gatewayCfg) *v1beta1.Gateway {
 		lastCreatedGateway = &v1beta1.Gateway{
 			ObjectMeta: metav1.ObjectMeta{
-				Namespace: "test",
+				Namespace:   "test",
+				Annotations: cfg.annotations,
 			},
 			Spec: v1beta1.GatewaySpec{
 				GatewayClassName: gcName,
@@ -343,6 +449,8 @@ func TestBuildGateway(t *testing.T) {
 		refGrants    map[types.NamespacedName]*v1beta1.ReferenceGrant
 		expected     *Gateway
 		name         string
+		// disableSnippets disables the snippets, which are enabled in the other tests.
+		disableSnippets bool
 	}{
 		{
 			gateway:      createGateway(gatewayCfg{listeners: []v1beta1.Listener{foo80Listener1, foo8080Listener}}),
@@ -802,6 +910,714 @@ func TestBuildGateway(t *testing.T) {
 			},
 			name: "gateway addresses are not supported",
 		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{ClientMaxBodySizeAnnotation: "1G"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Listeners: map[string]*Listener{
+					"foo-80-1": {
+						Source: foo80Listener1,
+						Valid:  true,
+						Routes: map[types.NamespacedName]*Route{},
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+					},
+				},
+				ClientMaxBodySize: helpers.GetPointer[int64](1000000000),
+				Valid:             true,
+			},
+			name: "client max body size",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{ClientMaxBodySizeAnnotation: "invalid"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Valid:  false,
+				Conditions: staticConds.NewGatewayUnsupportedValue(
+					`metadata.annotations[gateway.nginx.org/client-max-body-size]: Invalid value: "invalid": ` +
+						"quantities must match the regular expression " +
+						"'^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'",
+				),
+			},
+			name: "invalid client max body size",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{RequestIDHeaderAnnotation: "X-Request-ID"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Listeners: map[string]*Listener{
+					"foo-80-1": {
+						Source: foo80Listener1,
+						Valid:  true,
+						Routes: map[types.NamespacedName]*Route{},
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+					},
+				},
+				RequestIDHeader: "X-Request-ID",
+				Valid:           true,
+			},
+			name: "request ID header",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{RequestIDHeaderAnnotation: "Host"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Valid:  false,
+				Conditions: staticConds.NewGatewayUnsupportedValue(
+					`metadata.annotations[gateway.nginx.org/request-id-header]: Invalid value: "Host": ` +
+						"must not be the Host header",
+				),
+			},
+			name: "invalid request ID header",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{TrustedProxiesAnnotation: "10.0.0.0/8,2001:db8::/32"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Listeners: map[string]*Listener{
+					"foo-80-1": {
+						Source: foo80Listener1,
+						Valid:  true,
+						Routes: map[types.NamespacedName]*Route{},
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+					},
+				},
+				TrustedProxies: []string{"10.0.0.0/8", "2001:db8::/32"},
+				Valid:          true,
+			},
+			name: "trusted proxies",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{TrustedProxiesAnnotation: "10.0.0.0/33"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Valid:  false,
+				Conditions: staticConds.NewGatewayUnsupportedValue(
+					`metadata.annotations[gateway.nginx.org/trusted-proxies]: Invalid value: "10.0.0.0/33": ` +
+						`"10.0.0.0/33" is not a valid CIDR`,
+				),
+			},
+			name: "invalid trusted proxies",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{DNSResolversAnnotation: "10.96.0.10"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Listeners: map[string]*Listener{
+					"foo-80-1": {
+						Source: foo80Listener1,
+						Valid:  true,
+						Routes: map[types.NamespacedName]*Route{},
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+					},
+				},
+				DNSResolvers: []string{"10.96.0.10"},
+				Valid:        true,
+			},
+			name: "DNS resolvers",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{DNSResolversAnnotation: "kube-dns"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Valid:  false,
+				Conditions: staticConds.NewGatewayUnsupportedValue(
+					`metadata.annotations[gateway.nginx.org/dns-resolvers]: Invalid value: "kube-dns": ` +
+						`"kube-dns" is not a valid IP address`,
+				),
+			},
+			name: "invalid DNS resolvers",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners: []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{
+						WorkerProcessesAnnotation:   "auto",
+						WorkerConnectionsAnnotation: "4096",
+					},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Listeners: map[string]*Listener{
+					"foo-80-1": {
+						Source: foo80Listener1,
+						Valid:  true,
+						Routes: map[types.NamespacedName]*Route{},
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+					},
+				},
+				WorkerProcesses:   "auto",
+				WorkerConnections: 4096,
+				Valid:             true,
+			},
+			name: "worker settings",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{WorkerConnectionsAnnotation: "1000"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Valid:  false,
+				Conditions: staticConds.NewGatewayUnsupportedValue(
+					`metadata.annotations[gateway.nginx.org/worker-connections]: Invalid value: "1000": ` +
+						"must be a power of two between 512 and 65536",
+				),
+			},
+			name: "invalid worker connections",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners: []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{
+						OpenFileCacheMaxFilesAnnotation: "1000",
+						OpenFileCacheValidAnnotation:    "30s",
+					},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Listeners: map[string]*Listener{
+					"foo-80-1": {
+						Source: foo80Listener1,
+						Valid:  true,
+						Routes: map[types.NamespacedName]*Route{},
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+					},
+				},
+				OpenFileCache: &OpenFileCache{
+					MaxFiles: 1000,
+					Inactive: 60 * time.Second,
+					Valid:    30 * time.Second,
+				},
+				Valid: true,
+			},
+			name: "open file cache",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{OpenFileCacheMaxFilesAnnotation: "many"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Valid:  false,
+				Conditions: staticConds.NewGatewayUnsupportedValue(
+					`metadata.annotations[gateway.nginx.org/open-file-cache-max-files]: Invalid value: "many": ` +
+						"must be a positive integer",
+				),
+			},
+			name: "invalid open file cache",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners: []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{
+						GzipAnnotation:      "true",
+						GzipTypesAnnotation: "application/json",
+					},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Listeners: map[string]*Listener{
+					"foo-80-1": {
+						Source: foo80Listener1,
+						Valid:  true,
+						Routes: map[types.NamespacedName]*Route{},
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+					},
+				},
+				Gzip: &Gzip{
+					Types:     []string{"application/json"},
+					MinLength: 20,
+					Level:     1,
+				},
+				Valid: true,
+			},
+			name: "gzip",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners: []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{
+						GzipAnnotation:      "true",
+						GzipLevelAnnotation: "0",
+					},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Valid:  false,
+				Conditions: staticConds.NewGatewayUnsupportedValue(
+					`metadata.annotations[gateway.nginx.org/gzip-level]: Invalid value: "0": ` +
+						"must be an integer between 1 and 9",
+				),
+			},
+			name: "invalid gzip compression level",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{HideServerVersionAnnotation: "false"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Listeners: map[string]*Listener{
+					"foo-80-1": {
+						Source: foo80Listener1,
+						Valid:  true,
+						Routes: map[types.NamespacedName]*Route{},
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+					},
+				},
+				ShowServerVersion: true,
+				Valid:             true,
+			},
+			name: "server version shown",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{HideServerVersionAnnotation: "no"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Valid:  false,
+				Conditions: staticConds.NewGatewayUnsupportedValue(
+					`metadata.annotations[gateway.nginx.org/hide-server-version]: Invalid value: "no": ` +
+						"must be a boolean",
+				),
+			},
+			name: "invalid hide server version",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{UpstreamZoneSizeAnnotation: "64Ki"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Listeners: map[string]*Listener{
+					"foo-80-1": {
+						Source: foo80Listener1,
+						Valid:  true,
+						Routes: map[types.NamespacedName]*Route{},
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+					},
+				},
+				UpstreamZoneSize: 64 * 1024,
+				Valid:            true,
+			},
+			name: "upstream zone size",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{UpstreamZoneSizeAnnotation: "16Ki"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Valid:  false,
+				Conditions: staticConds.NewGatewayUnsupportedValue(
+					`metadata.annotations[gateway.nginx.org/upstream-zone-size]: Invalid value: "16Ki": ` +
+						"must be between 32Ki and 512Mi",
+				),
+			},
+			name: "too small upstream zone size",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{WorkerShutdownTimeoutAnnotation: "20s"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Listeners: map[string]*Listener{
+					"foo-80-1": {
+						Source: foo80Listener1,
+						Valid:  true,
+						Routes: map[types.NamespacedName]*Route{},
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+					},
+				},
+				WorkerShutdownTimeout: 20 * time.Second,
+				Valid:                 true,
+			},
+			name: "worker shutdown timeout",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{WorkerShutdownTimeoutAnnotation: "-5s"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Valid:  false,
+				Conditions: staticConds.NewGatewayUnsupportedValue(
+					`metadata.annotations[gateway.nginx.org/worker-shutdown-timeout]: Invalid value: "-5s": ` +
+						"must be a positive duration in whole milliseconds, for example, 30s",
+				),
+			},
+			name: "negative worker shutdown timeout",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners: []v1beta1.Listener{foo80Listener1},
+					// add_header X-Gateway nginx;
+					annotations: map[string]string{ServerSnippetAnnotation: "YWRkX2hlYWRlciBYLUdhdGV3YXkgbmdpbng7"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Listeners: map[string]*Listener{
+					"foo-80-1": {
+						Source: foo80Listener1,
+						Valid:  true,
+						Routes: map[types.NamespacedName]*Route{},
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+						ServerSnippet: "add_header X-Gateway nginx;",
+					},
+				},
+				Valid: true,
+			},
+			name: "server snippet",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners: []v1beta1.Listener{foo80Listener1},
+					// location / {
+					annotations: map[string]string{ServerSnippetAnnotation: "bG9jYXRpb24gLyB7"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Valid:  false,
+				Conditions: staticConds.NewGatewayUnsupportedValue(
+					`metadata.annotations[gateway.nginx.org/server-snippet]: Invalid value: "bG9jYXRpb24gLyB7": ` +
+						"unclosed {",
+				),
+			},
+			name: "invalid server snippet",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners: []v1beta1.Listener{foo80Listener1},
+					// add_header X-Gateway nginx;
+					annotations: map[string]string{ServerSnippetAnnotation: "YWRkX2hlYWRlciBYLUdhdGV3YXkgbmdpbng7"},
+				},
+			),
+			gatewayClass:    validGC,
+			disableSnippets: true,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Valid:  false,
+				Conditions: staticConds.NewGatewayUnsupportedValue(
+					"metadata.annotations[gateway.nginx.org/server-snippet]: Forbidden: " +
+						"snippets are disabled; NKG must run with --enable-snippets to allow them",
+				),
+			},
+			name: "server snippet when snippets are disabled",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners: []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{
+						ErrorPageStatusCodesAnnotation: "404,503",
+						ErrorPageBodyAnnotation:        "Not available",
+					},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Listeners: map[string]*Listener{
+					"foo-80-1": {
+						Source: foo80Listener1,
+						Valid:  true,
+						Routes: map[types.NamespacedName]*Route{},
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+					},
+				},
+				ErrorPage: &ErrorPage{
+					Body:        "Not available",
+					ContentType: "text/html",
+					StatusCodes: []int{404, 503},
+				},
+				Valid: true,
+			},
+			name: "error page",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners:   []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{ErrorPageStatusCodesAnnotation: "404,200"},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Valid:  false,
+				Conditions: staticConds.NewGatewayUnsupportedValue(
+					`metadata.annotations[gateway.nginx.org/error-page-status-codes]: Invalid value: "404,200": ` +
+						`"200" is not a valid HTTP status code in range 400-599`,
+				),
+			},
+			name: "invalid error page status code",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners: []v1beta1.Listener{foo80Listener1},
+					annotations: map[string]string{
+						BackendErrorStatusCodesAnnotation: "503",
+						BackendErrorBodyAnnotation:        `{"error": "unavailable"}`,
+						BackendErrorContentTypeAnnotation: "application/json",
+					},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Listeners: map[string]*Listener{
+					"foo-80-1": {
+						Source: foo80Listener1,
+						Valid:  true,
+						Routes: map[types.NamespacedName]*Route{},
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+					},
+				},
+				BackendErrorPage: &ErrorPage{
+					Body:        `{"error": "unavailable"}`,
+					ContentType: "application/json",
+					StatusCodes: []int{503},
+				},
+				Valid: true,
+			},
+			name: "backend error page",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners: []v1beta1.Listener{staplingListener, staplingNoCAListener, staplingInvalidCAListener},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Listeners: map[string]*Listener{
+					"stapling": {
+						Source:         staplingListener,
+						Valid:          true,
+						Routes:         map[types.NamespacedName]*Route{},
+						ResolvedSecret: helpers.GetPointer(client.ObjectKeyFromObject(secretWithCA)),
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+						TLSSettings: TLSSettings{Stapling: true},
+					},
+					"stapling-no-ca": {
+						Source:         staplingNoCAListener,
+						Valid:          true,
+						Routes:         map[types.NamespacedName]*Route{},
+						ResolvedSecret: helpers.GetPointer(client.ObjectKeyFromObject(secretSameNs)),
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+						Conditions: []conditions.Condition{
+							staticConds.NewListenerAcceptedWithWarning(
+								"tls.certificateRefs[0]: OCSP stapling is disabled because the Secret test/secret " +
+									"doesn't have the ca.crt key",
+							),
+						},
+					},
+					"stapling-invalid-ca": {
+						Source:         staplingInvalidCAListener,
+						Valid:          false,
+						Routes:         map[types.NamespacedName]*Route{},
+						ResolvedSecret: helpers.GetPointer(client.ObjectKeyFromObject(secretInvalidCA)),
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+						TLSSettings: TLSSettings{Stapling: true},
+						Conditions: staticConds.NewListenerInvalidCertificateRef(
+							"tls.certificateRefs[0]: Invalid value: test/secret-invalid-ca: the ca.crt key of the " +
+								"Secret is invalid: x509: malformed certificate",
+						),
+					},
+				},
+				Valid: true,
+			},
+			name: "https listeners with OCSP stapling",
+		},
+		{
+			gateway: createGateway(
+				gatewayCfg{
+					listeners: []v1beta1.Listener{dhParamsListener, invalidDHParamsListener, missingDHParamsListener},
+				},
+			),
+			gatewayClass: validGC,
+			expected: &Gateway{
+				Source: getLastCreatedGetaway(),
+				Listeners: map[string]*Listener{
+					"dhparams": {
+						Source:                 dhParamsListener,
+						Valid:                  true,
+						Routes:                 map[types.NamespacedName]*Route{},
+						ResolvedSecret:         helpers.GetPointer(client.ObjectKeyFromObject(secretSameNs)),
+						ResolvedDHParamsSecret: helpers.GetPointer(client.ObjectKeyFromObject(secretDHParams)),
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+						TLSSettings: TLSSettings{DHParamSecret: "dhparams"},
+					},
+					"invalid-dhparams": {
+						Source:         invalidDHParamsListener,
+						Valid:          false,
+						Routes:         map[types.NamespacedName]*Route{},
+						ResolvedSecret: helpers.GetPointer(client.ObjectKeyFromObject(secretSameNs)),
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+						TLSSettings: TLSSettings{DHParamSecret: "invalid-dhparams"},
+						Conditions: staticConds.NewListenerInvalidCertificateRef(
+							"tls.options[gateway.nginx.org/ssl-dhparam-secret]: Invalid value: test/invalid-dhparams: " +
+								`DH parameters are invalid: unexpected PEM block type "CERTIFICATE"`,
+						),
+					},
+					"missing-dhparams": {
+						Source:         missingDHParamsListener,
+						Valid:          false,
+						Routes:         map[types.NamespacedName]*Route{},
+						ResolvedSecret: helpers.GetPointer(client.ObjectKeyFromObject(secretSameNs)),
+						SupportedKinds: []v1beta1.RouteGroupKind{
+							{Kind: "HTTPRoute"},
+						},
+						TLSSettings: TLSSettings{DHParamSecret: "does-not-exist"},
+						Conditions: staticConds.NewListenerInvalidCertificateRef(
+							"tls.options[gateway.nginx.org/ssl-dhparam-secret]: Invalid value: test/does-not-exist: " +
+								"secret does not exist",
+						),
+					},
+				},
+				Valid: true,
+			},
+			name: "https listeners with DH parameters",
+		},
 		{
 			gateway:  nil,
 			expected: nil,
@@ -835,15 +1651,19 @@ func TestBuildGateway(t *testing.T) {
 
 	secretResolver := newSecretResolver(
 		map[types.NamespacedName]*apiv1.Secret{
-			client.ObjectKeyFromObject(secretSameNs):        secretSameNs,
-			client.ObjectKeyFromObject(secretDiffNamespace): secretDiffNamespace,
+			client.ObjectKeyFromObject(secretSameNs):          secretSameNs,
+			client.ObjectKeyFromObject(secretDiffNamespace):   secretDiffNamespace,
+			client.ObjectKeyFromObject(secretWithCA):          secretWithCA,
+			client.ObjectKeyFromObject(secretInvalidCA):       secretInvalidCA,
+			client.ObjectKeyFromObject(secretDHParams):        secretDHParams,
+			client.ObjectKeyFromObject(secretInvalidDHParams): secretInvalidDHParams,
 		})
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			g := NewGomegaWithT(t)
 			resolver := newReferenceGrantResolver(test.refGrants)
-			result := buildGateway(test.gateway, secretResolver, test.gatewayClass, resolver)
+			result := buildGateway(test.gateway, secretResolver, test.gatewayClass, resolver, !test.disableSnippets)
 			g.Expect(helpers.Diff(test.expected, result)).To(BeEmpty())
 		})
 	}
@@ -1,6 +1,9 @@
 package graph
 
 import (
+	"errors"
+	"fmt"
+
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -10,6 +13,17 @@ import (
 	staticConds "github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/conditions"
 )
 
+const (
+	// nkgParametersGroup is the API group of the NKGParameters resource.
+	nkgParametersGroup = "gateway.nginx.org"
+	// nkgParametersKind is the kind of the NKGParameters resource.
+	nkgParametersKind = "NKGParameters"
+)
+
+// ErrParametersRefNotFound is returned when the parametersRef of a GatewayClass references an object of
+// a supported group and kind that doesn't exist.
+var ErrParametersRefNotFound = errors.New("referenced object not found")
+
 // GatewayClass represents the GatewayClass resource.
 type GatewayClass struct {
 	// Source is the source resource.
@@ -31,6 +45,7 @@ type processedGatewayClasses struct {
 // that reference this controller, but are not named in the command-line argument.
 // Also returns a boolean that says whether or not the GatewayClass defined
 // in the command-line argument exists, regardless of which controller it references.
+// The caller must ensure that gcs is not modified while the function runs.
 func processGatewayClasses(
 	gcs map[types.NamespacedName]*v1beta1.GatewayClass,
 	gcName string,
@@ -76,10 +91,28 @@ func buildGatewayClass(gc *v1beta1.GatewayClass) *GatewayClass {
 }
 
 func validateGatewayClass(gc *v1beta1.GatewayClass) error {
-	if gc.Spec.ParametersRef != nil {
-		path := field.NewPath("spec").Child("parametersRef")
+	ref := gc.Spec.ParametersRef
+	if ref == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec").Child("parametersRef")
+
+	if ref.Group != nkgParametersGroup || ref.Kind != nkgParametersKind {
 		return field.Forbidden(path, "parametersRef is not supported")
 	}
 
-	return nil
+	// NKG doesn't track NKGParameters resources yet, so the referenced object is never found.
+	return fmt.Errorf("%s: %w: %s", path, ErrParametersRefNotFound, parametersRefString(*ref))
+}
+
+// parametersRefString returns a string representation of the parametersRef in the format
+// group/kind namespace/name. The namespace is omitted for a cluster-scoped reference.
+func parametersRefString(ref v1beta1.ParametersReference) string {
+	name := ref.Name
+	if ref.Namespace != nil {
+		name = types.NamespacedName{Namespace: string(*ref.Namespace), Name: ref.Name}.String()
+	}
+
+	return fmt.Sprintf("%s/%s %s", ref.Group, ref.Kind, name)
 }
@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"errors"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -127,6 +128,17 @@ func TestBuildGatewayClass(t *testing.T) {
 		},
 	}
 
+	gcWithMissingParams := &v1beta1.GatewayClass{
+		Spec: v1beta1.GatewayClassSpec{
+			ParametersRef: &v1beta1.ParametersReference{
+				Group:     nkgParametersGroup,
+				Kind:      nkgParametersKind,
+				Name:      "params",
+				Namespace: helpers.GetPointer[v1beta1.Namespace]("test"),
+			},
+		},
+	}
+
 	tests := []struct {
 		gc       *v1beta1.GatewayClass
 		expected *GatewayClass
@@ -158,6 +170,19 @@ func TestBuildGatewayClass(t *testing.T) {
 			},
 			name: "invalid gatewayclass",
 		},
+		{
+			gc: gcWithMissingParams,
+			expected: &GatewayClass{
+				Source: gcWithMissingParams,
+				Valid:  false,
+				Conditions: []conditions.Condition{
+					staticConds.NewGatewayClassInvalidParameters(
+						"spec.parametersRef: referenced object not found: gateway.nginx.org/NKGParameters test/params",
+					),
+				},
+			},
+			name: "gatewayclass with missing parameters",
+		},
 	}
 
 	for _, test := range tests {
@@ -169,3 +194,76 @@ func TestBuildGatewayClass(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateGatewayClass(t *testing.T) {
+	tests := []struct {
+		ref            *v1beta1.ParametersReference
+		name           string
+		expErr         string
+		expErrNotFound bool
+	}{
+		{
+			ref:  nil,
+			name: "no parametersRef",
+		},
+		{
+			ref: &v1beta1.ParametersReference{
+				Group: "some.group",
+				Kind:  nkgParametersKind,
+				Name:  "params",
+			},
+			expErr: "spec.parametersRef: Forbidden: parametersRef is not supported",
+			name:   "unsupported group",
+		},
+		{
+			ref: &v1beta1.ParametersReference{
+				Group: nkgParametersGroup,
+				Kind:  "ConfigMap",
+				Name:  "params",
+			},
+			expErr: "spec.parametersRef: Forbidden: parametersRef is not supported",
+			name:   "unsupported kind",
+		},
+		{
+			ref: &v1beta1.ParametersReference{
+				Group:     nkgParametersGroup,
+				Kind:      nkgParametersKind,
+				Name:      "params",
+				Namespace: helpers.GetPointer[v1beta1.Namespace]("test"),
+			},
+			expErr:         "spec.parametersRef: referenced object not found: gateway.nginx.org/NKGParameters test/params",
+			expErrNotFound: true,
+			name:           "namespaced reference not found",
+		},
+		{
+			ref: &v1beta1.ParametersReference{
+				Group: nkgParametersGroup,
+				Kind:  nkgParametersKind,
+				Name:  "params",
+			},
+			expErr:         "spec.parametersRef: referenced object not found: gateway.nginx.org/NKGParameters params",
+			expErrNotFound: true,
+			name:           "cluster-scoped reference not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			err := validateGatewayClass(&v1beta1.GatewayClass{
+				Spec: v1beta1.GatewayClassSpec{
+					ParametersRef: test.ref,
+				},
+			})
+
+			if test.expErr == "" {
+				g.Expect(err).ToNot(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).To(MatchError(test.expErr))
+			g.Expect(errors.Is(err, ErrParametersRefNotFound)).To(Equal(test.expErrNotFound))
+		})
+	}
+}
@@ -18,6 +18,8 @@ type ClusterState struct {
 	Namespaces      map[types.NamespacedName]*v1.Namespace
 	ReferenceGrants map[types.NamespacedName]*v1beta1.ReferenceGrant
 	Secrets         map[types.NamespacedName]*v1.Secret
+	// ConfigMaps only includes the ConfigMap with the NGINX configuration snippets, if it is configured.
+	ConfigMaps map[types.NamespacedName]*v1.ConfigMap
 }
 
 // Graph is a Graph-like representation of Gateway API resources.
@@ -41,6 +43,8 @@ type Graph struct {
 	// in the cluster. We need such entries so that we can query the Graph to determine if a Secret is referenced
 	// by the Gateway, including the case when the Secret is newly created.
 	ReferencedSecrets map[types.NamespacedName]*Secret
+	// HTTPSnippet is the snippet of NGINX configuration for the http context from the NGINX ConfigMap.
+	HTTPSnippet string
 }
 
 // IsReferenced returns true if the Graph references the resource.
@@ -54,6 +58,10 @@ func (g *Graph) IsReferenced(resourceType client.Object, nsname types.Namespaced
 	case *v1.Secret:
 		_, exists := g.ReferencedSecrets[nsname]
 		return exists
+	case *v1.ConfigMap:
+		// NKG only watches the NGINX ConfigMap, which the Graph always references for the HTTPSnippet.
+		// ConfigMaps don't have a generation, so this makes every update of the ConfigMap change the Graph.
+		return true
 	default:
 		return false
 	}
@@ -65,6 +73,7 @@ func BuildGraph(
 	controllerName string,
 	gcName string,
 	validators validation.Validators,
+	enableSnippets bool,
 ) *Graph {
 	processedGwClasses, gcExists := processGatewayClasses(state.GatewayClasses, gcName, controllerName)
 	if gcExists && processedGwClasses.Winner == nil {
@@ -78,11 +87,22 @@ func BuildGraph(
 	processedGws := processGateways(state.Gateways, gcName)
 
 	refGrantResolver := newReferenceGrantResolver(state.ReferenceGrants)
-	gw := buildGateway(processedGws.Winner, secretResolver, gc, refGrantResolver)
+	gw := buildGateway(processedGws.Winner, secretResolver, gc, refGrantResolver, enableSnippets)
 
-	routes := buildRoutesForGateways(validators.HTTPFieldsValidator, state.HTTPRoutes, processedGws.GetAllNsNames())
+	routes := buildRoutesForGateways(
+		validators.HTTPFieldsValidator,
+		state.HTTPRoutes,
+		processedGws.GetAllNsNames(),
+		state.Gateways,
+		enableSnippets,
+	)
 	bindRoutesToListeners(routes, gw, state.Namespaces)
-	addBackendRefsToRouteRules(routes, refGrantResolver, state.Services)
+	var dnsResolvers []string
+	if gw != nil {
+		dnsResolvers = gw.DNSResolvers
+	}
+
+	addBackendRefsToRouteRules(routes, refGrantResolver, state.Services, dnsResolvers)
 
 	g := &Graph{
 		GatewayClass:          gc,
@@ -91,6 +111,7 @@ func BuildGraph(
 		IgnoredGatewayClasses: processedGwClasses.Ignored,
 		IgnoredGateways:       processedGws.Ignored,
 		ReferencedSecrets:     secretResolver.getResolvedSecrets(),
+		HTTPSnippet:           buildHTTPSnippet(state.ConfigMaps),
 	}
 
 	return g
@@ -2,7 +2,9 @@
 package graph
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
@@ -12,6 +14,7 @@ import (
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
+	staticConds "github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/conditions"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/validation"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/validation/validationfakes"
 )
@@ -40,9 +43,9 @@ func TestBuildGraph(t *testing.T) {
 				CommonRouteSpec: v1beta1.CommonRouteSpec{
 					ParentRefs: []v1beta1.ParentReference{
 						{
-							Namespace:   (*v1beta1.Namespace)(helpers.GetStringPointer("test")),
+							Namespace:   (*v1beta1.Namespace)(helpers.GetPointer("test")),
 							Name:        v1beta1.ObjectName(gatewayName),
-							SectionName: (*v1beta1.SectionName)(helpers.GetStringPointer(listenerName)),
+							SectionName: (*v1beta1.SectionName)(helpers.GetPointer(listenerName)),
 						},
 					},
 				},
@@ -55,7 +58,7 @@ func TestBuildGraph(t *testing.T) {
 							{
 								Path: &v1beta1.HTTPPathMatch{
 									Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
-									Value: helpers.GetStringPointer("/"),
+									Value: helpers.GetPointer("/"),
 								},
 							},
 						},
@@ -63,10 +66,10 @@ func TestBuildGraph(t *testing.T) {
 							{
 								BackendRef: v1beta1.BackendRef{
 									BackendObjectReference: v1beta1.BackendObjectReference{
-										Kind:      (*v1beta1.Kind)(helpers.GetStringPointer("Service")),
+										Kind:      (*v1beta1.Kind)(helpers.GetPointer("Service")),
 										Name:      "foo",
-										Namespace: (*v1beta1.Namespace)(helpers.GetStringPointer("service")),
-										Port:      (*v1beta1.PortNumber)(helpers.GetInt32Pointer(80)),
+										Namespace: (*v1beta1.Namespace)(helpers.GetPointer("service")),
+										Port:      (*v1beta1.PortNumber)(helpers.GetPointer[int32](80)),
 									},
 								},
 							},
@@ -134,7 +137,7 @@ func TestBuildGraph(t *testing.T) {
 						Hostname: nil,
 						Port:     443,
 						TLS: &v1beta1.GatewayTLSConfig{
-							Mode: helpers.GetTLSModePointer(v1beta1.TLSModeTerminate),
+							Mode: helpers.GetPointer[v1beta1.TLSModeType](v1beta1.TLSModeTerminate),
 							CertificateRefs: []v1beta1.SecretObjectReference{
 								{
 									Kind:      helpers.GetPointer[v1beta1.Kind]("Secret"),
@@ -240,6 +243,21 @@ func TestBuildGraph(t *testing.T) {
 		Rules: []Rule{createValidRuleWithBackendRefs(hr1Refs)},
 	}
 
+	routeHR2 := &Route{
+		Valid:  true,
+		Source: hr2,
+		ParentRefs: []ParentRef{
+			{
+				Idx:     0,
+				Gateway: types.NamespacedName{Namespace: "test", Name: "wrong-gateway"},
+				Attachment: &ParentRefAttachmentStatus{
+					FailedCondition: staticConds.NewRouteNoMatchingParent(),
+				},
+			},
+		},
+		Rules: []Rule{createValidRuleWithBackendRefs(hr1Refs)},
+	}
+
 	routeHR3 := &Route{
 		Valid:  true,
 		Source: hr3,
@@ -290,6 +308,7 @@ func TestBuildGraph(t *testing.T) {
 			},
 			Routes: map[types.NamespacedName]*Route{
 				{Namespace: "test", Name: "hr-1"}: routeHR1,
+				{Namespace: "test", Name: "hr-2"}: routeHR2,
 				{Namespace: "test", Name: "hr-3"}: routeHR3,
 			},
 			ReferencedSecrets: map[types.NamespacedName]*Secret{
@@ -343,9 +362,148 @@ func TestBuildGraph(t *testing.T) {
 				controllerName,
 				gcName,
 				validation.Validators{HTTPFieldsValidator: &validationfakes.FakeHTTPFieldsValidator{}},
+				false,
 			)
 
 			g.Expect(helpers.Diff(test.expected, result)).To(BeEmpty())
 		})
 	}
 }
+
+func BenchmarkBuildGraph(b *testing.B) {
+	const (
+		gcName         = "my-class"
+		controllerName = "my.controller"
+	)
+
+	counts := []int{
+		10,
+		100,
+		1000,
+	}
+
+	validators := validation.Validators{HTTPFieldsValidator: &validationfakes.FakeHTTPFieldsValidator{}}
+
+	for _, count := range counts {
+		state := generateClusterState(count, gcName, controllerName)
+
+		b.Run(fmt.Sprintf("%d GatewayClasses, Gateways and HTTPRoutes", count), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				g := BuildGraph(state, controllerName, gcName, validators, false)
+				if len(g.Routes) != count {
+					b.Fatalf("expected %d routes, got %d", count, len(g.Routes))
+				}
+			}
+		})
+	}
+}
+
+// generateClusterState generates a ClusterState with n GatewayClasses, n Gateways and n HTTPRoutes.
+// All HTTPRoutes reference the same Gateway, which becomes the winner. Every route references its own Service
+// and has a unique hostname, so that the routes don't conflict with each other.
+func generateClusterState(n int, gcName, controllerName string) ClusterState {
+	state := ClusterState{
+		GatewayClasses: make(map[types.NamespacedName]*v1beta1.GatewayClass, n),
+		Gateways:       make(map[types.NamespacedName]*v1beta1.Gateway, n),
+		HTTPRoutes:     make(map[types.NamespacedName]*v1beta1.HTTPRoute, n),
+		Services:       make(map[types.NamespacedName]*v1.Service, n),
+	}
+
+	for i := 0; i < n; i++ {
+		name := gcName
+		if i > 0 {
+			name = fmt.Sprintf("%s-%d", gcName, i)
+		}
+
+		state.GatewayClasses[types.NamespacedName{Name: name}] = &v1beta1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: v1beta1.GatewayClassSpec{
+				ControllerName: v1beta1.GatewayController(controllerName),
+			},
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		gw := &v1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "test",
+				Name:              fmt.Sprintf("gateway-%d", i),
+				CreationTimestamp: metav1.NewTime(time.Unix(int64(i), 0)),
+			},
+			Spec: v1beta1.GatewaySpec{
+				GatewayClassName: v1beta1.ObjectName(gcName),
+				Listeners: []v1beta1.Listener{
+					{
+						Name:     "listener-80-1",
+						Port:     80,
+						Protocol: v1beta1.HTTPProtocolType,
+					},
+				},
+			},
+		}
+
+		state.Gateways[client.ObjectKeyFromObject(gw)] = gw
+	}
+
+	for i := 0; i < n; i++ {
+		svc := &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      fmt.Sprintf("svc-%d", i),
+			},
+		}
+
+		state.Services[client.ObjectKeyFromObject(svc)] = svc
+
+		hr := &v1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test",
+				Name:      fmt.Sprintf("hr-%d", i),
+			},
+			Spec: v1beta1.HTTPRouteSpec{
+				CommonRouteSpec: v1beta1.CommonRouteSpec{
+					ParentRefs: []v1beta1.ParentReference{
+						{
+							Namespace: helpers.GetPointer[v1beta1.Namespace]("test"),
+							Name:      "gateway-0",
+						},
+					},
+				},
+				Hostnames: []v1beta1.Hostname{
+					v1beta1.Hostname(fmt.Sprintf("foo-%d.example.com", i)),
+				},
+				Rules: []v1beta1.HTTPRouteRule{
+					{
+						Matches: []v1beta1.HTTPRouteMatch{
+							{
+								Path: &v1beta1.HTTPPathMatch{
+									Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+									Value: helpers.GetPointer("/"),
+								},
+							},
+						},
+						BackendRefs: []v1beta1.HTTPBackendRef{
+							{
+								BackendRef: v1beta1.BackendRef{
+									BackendObjectReference: v1beta1.BackendObjectReference{
+										Kind: helpers.GetPointer[v1beta1.Kind]("Service"),
+										Name: v1beta1.ObjectName(svc.Name),
+										Port: helpers.GetPointer[v1beta1.PortNumber](80),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		state.HTTPRoutes[client.ObjectKeyFromObject(hr)] = hr
+	}
+
+	return state
+}
@@ -36,7 +36,8 @@ type ParentRef struct {
 	// Attachment is the attachment status of the ParentRef. It could be nil. In that case, NGK didn't attempt to
 	// attach because of problems with the Route.
 	Attachment *ParentRefAttachmentStatus
-	// Gateway is the NamespacedName of the referenced Gateway
+	// Gateway is the NamespacedName of the referenced Gateway. If the Gateway doesn't exist,
+	// the Attachment includes the NoMatchingParent condition.
 	Gateway types.NamespacedName
 	// Idx is the index of the corresponding ParentReference in the HTTPRoute.
 	Idx int
@@ -62,28 +63,59 @@ type Route struct {
 	ParentRefs []ParentRef
 	// Conditions include Conditions for the HTTPRoute.
 	Conditions []conditions.Condition
+	// ClientMaxBodySize is the maximum allowed size of the client request body in bytes for the routes of the
+	// HTTPRoute. It is set from the ClientMaxBodySizeAnnotation. If nil, the size of the Gateway applies.
+	ClientMaxBodySize *int64
 	// Rules include Rules for the HTTPRoute. Each Rule[i] corresponds to the ith HTTPRouteRule.
 	// If the Route is invalid, this field is nil
 	Rules []Rule
 	// Valid tells if the Route is valid.
 	// If it is invalid, NGK should not generate any configuration for it.
 	Valid bool
+	// BackendErrorPage is the custom error page, which replaces the error responses of the backends for the routes of
+	// the HTTPRoute. It is set from the BackendErrorStatusCodesAnnotation. If nil, the page of the Gateway applies.
+	BackendErrorPage *ErrorPage
+	// NextUpstream holds the settings of passing a request to the next backend server for the routes of the
+	// HTTPRoute. It is set from the ProxyNextUpstreamAnnotation and ProxyNextUpstreamTriesAnnotation. If nil,
+	// the NGINX defaults apply.
+	NextUpstream *NextUpstream
+	// LocationSnippet holds the NGINX directives that are appended to the locations of the routes of the HTTPRoute.
+	// It is set from the LocationSnippetAnnotation.
+	LocationSnippet string
+	// StreamingProxy tells if NGINX must not buffer the responses for the routes of the HTTPRoute.
+	// It is set from the StreamingProxyAnnotation.
+	StreamingProxy bool
+	// CaseInsensitiveHeaderValues tells if the header matches of the HTTPRoute compare the header values
+	// in a case-insensitive manner. It is set from the CaseInsensitiveHeaderValuesAnnotation.
+	CaseInsensitiveHeaderValues bool
 }
 
-// buildRoutesForGateways builds routes from HTTPRoutes that reference any of the specified Gateways.
+// NextUpstream holds the settings of passing a request to the next backend server when the current one fails.
+type NextUpstream struct {
+	// Conditions are the conditions in which a request is passed to the next server, for example, "error" or
+	// "http_502". If empty, the NGINX default conditions apply.
+	Conditions []string
+	// Tries is the maximum number of tries to pass a request to the next server. If 0, the number is not limited.
+	Tries int32
+}
+
+// buildRoutesForGateways builds routes from HTTPRoutes that reference any of the specified Gateways or
+// the Gateways that don't exist in the cluster (existingGws).
 func buildRoutesForGateways(
 	validator validation.HTTPFieldsValidator,
 	httpRoutes map[types.NamespacedName]*v1beta1.HTTPRoute,
 	gatewayNsNames []types.NamespacedName,
+	existingGws map[types.NamespacedName]*v1beta1.Gateway,
+	enableSnippets bool,
 ) map[types.NamespacedName]*Route {
 	if len(gatewayNsNames) == 0 {
 		return nil
 	}
 
-	routes := make(map[types.NamespacedName]*Route)
+	routes := make(map[types.NamespacedName]*Route, len(httpRoutes))
 
 	for _, ghr := range httpRoutes {
-		r := buildRoute(validator, ghr, gatewayNsNames)
+		r := buildRoute(validator, ghr, gatewayNsNames, existingGws, enableSnippets)
 		if r != nil {
 			routes[client.ObjectKeyFromObject(ghr)] = r
 		}
@@ -92,10 +124,14 @@ func buildRoutesForGateways(
 	return routes
 }
 
+// buildSectionNameRefs builds the ParentRefs for the parentRefs that reference any of the specified Gateways.
+// It also builds the ParentRefs for the parentRefs that reference the Gateways that don't exist in the cluster
+// (existingGws), so that the Route reports them as not attached with the NoMatchingParent condition.
 func buildSectionNameRefs(
 	parentRefs []v1beta1.ParentReference,
 	routeNamespace string,
 	gatewayNsNames []types.NamespacedName,
+	existingGws map[types.NamespacedName]*v1beta1.Gateway,
 ) []ParentRef {
 	sectionNameRefs := make([]ParentRef, 0, len(parentRefs))
 
@@ -106,9 +142,18 @@ func buildSectionNameRefs(
 	uniqueSectionsPerGateway := make(map[key]struct{})
 
 	for i, p := range parentRefs {
+		var attachment *ParentRefAttachmentStatus
+
 		gw, found := findGatewayForParentRef(p, routeNamespace, gatewayNsNames)
 		if !found {
-			continue
+			gw, found = findMissingGatewayForParentRef(p, routeNamespace, existingGws)
+			if !found {
+				continue
+			}
+
+			attachment = &ParentRefAttachmentStatus{
+				FailedCondition: staticConds.NewRouteNoMatchingParent(),
+			}
 		}
 
 		var sectionName string
@@ -129,8 +174,9 @@ func buildSectionNameRefs(
 		uniqueSectionsPerGateway[k] = struct{}{}
 
 		sectionNameRefs = append(sectionNameRefs, ParentRef{
-			Idx:     i,
-			Gateway: gw,
+			Idx:        i,
+			Gateway:    gw,
+			Attachment: attachment,
 		})
 	}
 
@@ -142,6 +188,44 @@ func findGatewayForParentRef(
 	routeNamespace string,
 	gatewayNsNames []types.NamespacedName,
 ) (gwNsName types.NamespacedName, found bool) {
+	refNsName, isGateway := getGatewayNsNameForParentRef(ref, routeNamespace)
+	if !isGateway {
+		return types.NamespacedName{}, false
+	}
+
+	for _, gw := range gatewayNsNames {
+		if gw == refNsName {
+			return gw, true
+		}
+	}
+
+	return types.NamespacedName{}, false
+}
+
+// findMissingGatewayForParentRef returns the NamespacedName of the Gateway referenced by the parentRef
+// if that Gateway doesn't exist in the cluster.
+func findMissingGatewayForParentRef(
+	ref v1beta1.ParentReference,
+	routeNamespace string,
+	existingGws map[types.NamespacedName]*v1beta1.Gateway,
+) (gwNsName types.NamespacedName, missing bool) {
+	refNsName, isGateway := getGatewayNsNameForParentRef(ref, routeNamespace)
+	if !isGateway {
+		return types.NamespacedName{}, false
+	}
+
+	if _, exists := existingGws[refNsName]; exists {
+		return types.NamespacedName{}, false
+	}
+
+	return refNsName, true
+}
+
+// getGatewayNsNameForParentRef returns the NamespacedName of the parentRef if it references a Gateway.
+func getGatewayNsNameForParentRef(
+	ref v1beta1.ParentReference,
+	routeNamespace string,
+) (gwNsName types.NamespacedName, isGateway bool) {
 	if ref.Kind != nil && *ref.Kind != "Gateway" {
 		return types.NamespacedName{}, false
 	}
@@ -155,21 +239,17 @@ func findGatewayForParentRef(
 		ns = string(*ref.Namespace)
 	}
 
-	for _, gw := range gatewayNsNames {
-		if gw.Namespace == ns && gw.Name == string(ref.Name) {
-			return gw, true
-		}
-	}
-
-	return types.NamespacedName{}, false
+	return types.NamespacedName{Namespace: ns, Name: string(ref.Name)}, true
 }
 
 func buildRoute(
 	validator validation.HTTPFieldsValidator,
 	ghr *v1beta1.HTTPRoute,
 	gatewayNsNames []types.NamespacedName,
+	existingGws map[types.NamespacedName]*v1beta1.Gateway,
+	enableSnippets bool,
 ) *Route {
-	sectionNameRefs := buildSectionNameRefs(ghr.Spec.ParentRefs, ghr.Namespace, gatewayNsNames)
+	sectionNameRefs := buildSectionNameRefs(ghr.Spec.ParentRefs, ghr.Namespace, gatewayNsNames, existingGws)
 	// route doesn't belong to any of the Gateways
 	if len(sectionNameRefs) == 0 {
 		return nil
@@ -188,6 +268,54 @@ func buildRoute(
 		return r
 	}
 
+	var annotationsErrs field.ErrorList
+
+	clientMaxBodySize, valErr := getClientMaxBodySize(ghr.Annotations)
+	if valErr != nil {
+		annotationsErrs = append(annotationsErrs, valErr)
+	}
+
+	streamingProxy, valErr := getStreamingProxy(ghr.Annotations)
+	if valErr != nil {
+		annotationsErrs = append(annotationsErrs, valErr)
+	}
+
+	caseInsensitiveHeaderValues, valErr := getCaseInsensitiveHeaderValues(ghr.Annotations)
+	if valErr != nil {
+		annotationsErrs = append(annotationsErrs, valErr)
+	}
+
+	nextUpstream, valErr := getNextUpstream(ghr.Annotations)
+	if valErr != nil {
+		annotationsErrs = append(annotationsErrs, valErr)
+	}
+
+	backendErrorPage, valErr := getBackendErrorPage(ghr.Annotations)
+	if valErr != nil {
+		annotationsErrs = append(annotationsErrs, valErr)
+	}
+
+	locationSnippet, valErr := getLocationSnippet(ghr.Annotations, enableSnippets)
+	if valErr != nil {
+		annotationsErrs = append(annotationsErrs, valErr)
+	}
+
+	if len(annotationsErrs) > 0 {
+		r.Valid = false
+		r.Conditions = append(
+			r.Conditions,
+			staticConds.NewRouteUnsupportedValue(annotationsErrs.ToAggregate().Error()),
+		)
+
+		return r
+	}
+
+	r.ClientMaxBodySize = clientMaxBodySize
+	r.StreamingProxy = streamingProxy
+	r.CaseInsensitiveHeaderValues = caseInsensitiveHeaderValues
+	r.NextUpstream = nextUpstream
+	r.BackendErrorPage = backendErrorPage
+	r.LocationSnippet = locationSnippet
 	r.Valid = true
 
 	r.Rules = make([]Rule, len(ghr.Spec.Rules))
@@ -266,25 +394,22 @@ func bindRouteToListeners(r *Route, gw *Gateway, namespaces map[types.Namespaced
 	}
 
 	for i := 0; i < len(r.ParentRefs); i++ {
+		ref := &r.ParentRefs[i]
+
+		// The parentRef references a Gateway that doesn't exist, so buildSectionNameRefs has already
+		// set the failed attachment.
+		if ref.Attachment != nil {
+			continue
+		}
+
 		attachment := &ParentRefAttachmentStatus{
 			AcceptedHostnames: make(map[string][]string),
 		}
-		ref := &r.ParentRefs[i]
 		ref.Attachment = attachment
 
 		routeRef := r.Source.Spec.ParentRefs[ref.Idx]
 
-		path := field.NewPath("spec").Child("parentRefs").Index(ref.Idx)
-
-		// Case 1: Attachment is not possible due to unsupported configuration
-
-		if routeRef.Port != nil {
-			valErr := field.Forbidden(path.Child("port"), "cannot be set")
-			attachment.FailedCondition = staticConds.NewRouteUnsupportedValue(valErr.Error())
-			continue
-		}
-
-		// Case 2: the parentRef references an ignored Gateway resource.
+		// Case 1: the parentRef references an ignored Gateway resource.
 
 		referencesWinningGw := ref.Gateway.Namespace == gw.Source.Namespace && ref.Gateway.Name == gw.Source.Name
 
@@ -293,17 +418,24 @@ func bindRouteToListeners(r *Route, gw *Gateway, namespaces map[types.Namespaced
 			continue
 		}
 
-		// Case 3: Attachment is not possible because Gateway is invalid
+		// Case 2: Attachment is not possible because Gateway is invalid
 
 		if !gw.Valid {
 			attachment.FailedCondition = staticConds.NewRouteInvalidGateway()
 			continue
 		}
 
-		// Case 4 - winning Gateway
+		// Case 3 - winning Gateway
 
 		// Try to attach Route to all matching listeners
-		cond, attached := tryToAttachRouteToListeners(ref.Attachment, routeRef.SectionName, r, gw, namespaces)
+		cond, attached := tryToAttachRouteToListeners(
+			ref.Attachment,
+			routeRef.SectionName,
+			routeRef.Port,
+			r,
+			gw,
+			namespaces,
+		)
 		if !attached {
 			attachment.FailedCondition = cond
 			continue
@@ -319,11 +451,12 @@ func bindRouteToListeners(r *Route, gw *Gateway, namespaces map[types.Namespaced
 func tryToAttachRouteToListeners(
 	refStatus *ParentRefAttachmentStatus,
 	sectionName *v1beta1.SectionName,
+	port *v1beta1.PortNumber,
 	route *Route,
 	gw *Gateway,
 	namespaces map[types.NamespacedName]*apiv1.Namespace,
 ) (conditions.Condition, bool) {
-	validListeners, listenerExists := findValidListeners(getSectionName(sectionName), gw.Listeners)
+	validListeners, listenerExists := findValidListeners(getSectionName(sectionName), getPort(port), gw.Listeners)
 
 	if !listenerExists {
 		return staticConds.NewRouteNoMatchingParent(), false
@@ -366,11 +499,16 @@ func tryToAttachRouteToListeners(
 	return conditions.Condition{}, true
 }
 
-// findValidListeners returns a list of valid listeners and whether the listener exists for a non-empty sectionName.
-func findValidListeners(sectionName string, listeners map[string]*Listener) ([]*Listener, bool) {
+// findValidListeners returns a list of valid listeners and whether the listener exists for a non-empty sectionName
+// or a non-zero port. If both are set, the listener must match both.
+func findValidListeners(
+	sectionName string,
+	port v1beta1.PortNumber,
+	listeners map[string]*Listener,
+) ([]*Listener, bool) {
 	if sectionName != "" {
 		l, exists := listeners[sectionName]
-		if !exists {
+		if !exists || !listenerPortMatches(l, port) {
 			return nil, false
 		}
 
@@ -381,8 +519,17 @@ func findValidListeners(sectionName string, listeners map[string]*Listener) ([]*
 		return nil, true
 	}
 
+	// If the port is not set, any listener matches.
+	listenerExists := port == 0
+
 	validListeners := make([]*Listener, 0, len(listeners))
 	for _, l := range listeners {
+		if !listenerPortMatches(l, port) {
+			continue
+		}
+
+		listenerExists = true
+
 		if !l.Valid {
 			continue
 		}
@@ -390,7 +537,12 @@ func findValidListeners(sectionName string, listeners map[string]*Listener) ([]*
 		validListeners = append(validListeners, l)
 	}
 
-	return validListeners, true
+	return validListeners, listenerExists
+}
+
+// listenerPortMatches returns true if the port is 0 (not set) or equal to the port of the listener.
+func listenerPortMatches(l *Listener, port v1beta1.PortNumber) bool {
+	return port == 0 || l.Source.Port == port
 }
 
 func findAcceptedHostnames(listenerHostname *v1beta1.Hostname, routeHostnames []v1beta1.Hostname) []string {
@@ -517,6 +669,13 @@ func getSectionName(s *v1beta1.SectionName) string {
 	return string(*s)
 }
 
+func getPort(p *v1beta1.PortNumber) v1beta1.PortNumber {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
 func validateHostnames(hostnames []v1beta1.Hostname, path *field.Path) error {
 	var allErrs field.ErrorList
 
@@ -580,19 +739,29 @@ func validateQueryParamMatch(
 ) field.ErrorList {
 	var allErrs field.ErrorList
 
+	validateName := validator.ValidateQueryParamNameInMatch
+	validateValue := validator.ValidateQueryParamValueInMatch
+
 	if q.Type == nil {
 		allErrs = append(allErrs, field.Required(queryParamPath.Child("type"), "cannot be empty"))
+	} else if *q.Type == v1beta1.QueryParamMatchRegularExpression {
+		validateName = validator.ValidateQueryParamNameInRegexMatch
+		validateValue = validator.ValidateQueryParamRegexInMatch
 	} else if *q.Type != v1beta1.QueryParamMatchExact {
-		valErr := field.NotSupported(queryParamPath.Child("type"), *q.Type, []string{string(v1beta1.QueryParamMatchExact)})
+		valErr := field.NotSupported(
+			queryParamPath.Child("type"),
+			*q.Type,
+			[]string{string(v1beta1.QueryParamMatchExact), string(v1beta1.QueryParamMatchRegularExpression)},
+		)
 		allErrs = append(allErrs, valErr)
 	}
 
-	if err := validator.ValidateQueryParamNameInMatch(string(q.Name)); err != nil {
+	if err := validateName(string(q.Name)); err != nil {
 		valErr := field.Invalid(queryParamPath.Child("name"), q.Name, err.Error())
 		allErrs = append(allErrs, valErr)
 	}
 
-	if err := validator.ValidateQueryParamValueInMatch(q.Value); err != nil {
+	if err := validateValue(q.Value); err != nil {
 		valErr := field.Invalid(queryParamPath.Child("value"), q.Value, err.Error())
 		allErrs = append(allErrs, valErr)
 	}
@@ -649,14 +818,24 @@ func validatePathMatch(
 		panicForBrokenWebhookAssumption(errors.New("path value cannot be nil"))
 	}
 
-	if *path.Type != v1beta1.PathMatchPathPrefix && *path.Type != v1beta1.PathMatchExact {
+	switch *path.Type {
+	case v1beta1.PathMatchPathPrefix, v1beta1.PathMatchExact:
+		if err := validator.ValidatePathInMatch(*path.Value); err != nil {
+			valErr := field.Invalid(fieldPath.Child("value"), *path.Value, err.Error())
+			allErrs = append(allErrs, valErr)
+		}
+	case v1beta1.PathMatchRegularExpression:
+		if err := validator.ValidatePathRegexInMatch(*path.Value); err != nil {
+			valErr := field.Invalid(fieldPath.Child("value"), *path.Value, err.Error())
+			allErrs = append(allErrs, valErr)
+		}
+	default:
 		valErr := field.NotSupported(fieldPath.Child("type"), *path.Type,
-			[]string{string(v1beta1.PathMatchExact), string(v1beta1.PathMatchPathPrefix)})
-		allErrs = append(allErrs, valErr)
-	}
-
-	if err := validator.ValidatePathInMatch(*path.Value); err != nil {
-		valErr := field.Invalid(fieldPath.Child("value"), *path.Value, err.Error())
+			[]string{
+				string(v1beta1.PathMatchExact),
+				string(v1beta1.PathMatchPathPrefix),
+				string(v1beta1.PathMatchRegularExpression),
+			})
 		allErrs = append(allErrs, valErr)
 	}
 
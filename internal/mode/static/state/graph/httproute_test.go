@@ -84,10 +84,17 @@ func TestBuildRoutes(t *testing.T) {
 
 	hr := createHTTPRoute("hr-1", gwNsName.Name, "example.com", "/")
 	hrWrongGateway := createHTTPRoute("hr-2", "some-gateway", "example.com", "/")
+	hrMissingGateway := createHTTPRoute("hr-3", "missing-gateway", "example.com", "/")
 
 	hrRoutes := map[types.NamespacedName]*v1beta1.HTTPRoute{
-		client.ObjectKeyFromObject(hr):             hr,
-		client.ObjectKeyFromObject(hrWrongGateway): hrWrongGateway,
+		client.ObjectKeyFromObject(hr):               hr,
+		client.ObjectKeyFromObject(hrWrongGateway):   hrWrongGateway,
+		client.ObjectKeyFromObject(hrMissingGateway): hrMissingGateway,
+	}
+
+	existingGws := map[types.NamespacedName]*v1beta1.Gateway{
+		gwNsName: {},
+		{Namespace: "test", Name: "some-gateway"}: {},
 	}
 
 	tests := []struct {
@@ -114,6 +121,25 @@ func TestBuildRoutes(t *testing.T) {
 						},
 					},
 				},
+				client.ObjectKeyFromObject(hrMissingGateway): {
+					Source: hrMissingGateway,
+					ParentRefs: []ParentRef{
+						{
+							Idx:     0,
+							Gateway: types.NamespacedName{Namespace: "test", Name: "missing-gateway"},
+							Attachment: &ParentRefAttachmentStatus{
+								FailedCondition: staticConds.NewRouteNoMatchingParent(),
+							},
+						},
+					},
+					Valid: true,
+					Rules: []Rule{
+						{
+							ValidMatches: true,
+							ValidFilters: true,
+						},
+					},
+				},
 			},
 			name: "normal case",
 		},
@@ -129,7 +155,7 @@ func TestBuildRoutes(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			g := NewGomegaWithT(t)
-			routes := buildRoutesForGateways(validator, hrRoutes, test.gwNsNames)
+			routes := buildRoutesForGateways(validator, hrRoutes, test.gwNsNames, existingGws, false)
 			g.Expect(helpers.Diff(test.expected, routes)).To(BeEmpty())
 		})
 	}
@@ -166,10 +192,24 @@ func TestBuildSectionNameRefs(t *testing.T) {
 			Name:        v1beta1.ObjectName("some-other-gateway"),
 			SectionName: helpers.GetPointer[v1beta1.SectionName]("same-name"),
 		},
+		{
+			Name:        v1beta1.ObjectName("missing-gateway"),
+			SectionName: helpers.GetPointer[v1beta1.SectionName]("same-name"),
+		},
+		{
+			Kind: helpers.GetPointer[v1beta1.Kind]("Service"),
+			Name: v1beta1.ObjectName("missing-service"),
+		},
 	}
 
 	gwNsNames := []types.NamespacedName{gwNsName1, gwNsName2}
 
+	existingGws := map[types.NamespacedName]*v1beta1.Gateway{
+		gwNsName1: {},
+		gwNsName2: {},
+		{Namespace: routeNamespace, Name: "some-other-gateway"}: {},
+	}
+
 	expected := []ParentRef{
 		{
 			Idx:     0,
@@ -187,11 +227,18 @@ func TestBuildSectionNameRefs(t *testing.T) {
 			Idx:     4,
 			Gateway: gwNsName2,
 		},
+		{
+			Idx:     6,
+			Gateway: types.NamespacedName{Namespace: routeNamespace, Name: "missing-gateway"},
+			Attachment: &ParentRefAttachmentStatus{
+				FailedCondition: staticConds.NewRouteNoMatchingParent(),
+			},
+		},
 	}
 
 	g := NewGomegaWithT(t)
 
-	result := buildSectionNameRefs(parentRefs, routeNamespace, gwNsNames)
+	result := buildSectionNameRefs(parentRefs, routeNamespace, gwNsNames, existingGws)
 	g.Expect(result).To(Equal(expected))
 }
 
@@ -235,7 +282,7 @@ func TestBuildSectionNameRefsPanicsForDuplicateParentRefs(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			g := NewGomegaWithT(t)
-			run := func() { buildSectionNameRefs(test.parentRefs, gwNsName.Namespace, gwNsNames) }
+			run := func() { buildSectionNameRefs(test.parentRefs, gwNsName.Namespace, gwNsNames, nil) }
 			g.Expect(run).To(Panic())
 		})
 	}
@@ -330,6 +377,7 @@ func TestBuildRoute(t *testing.T) {
 	const (
 		invalidPath             = "/invalid"
 		invalidRedirectHostname = "invalid.example.com"
+		invalidQueryParamRegex  = "v[0-9"
 	)
 
 	gatewayNsName := types.NamespacedName{Namespace: "test", Name: "gateway"}
@@ -350,14 +398,65 @@ func TestBuildRoute(t *testing.T) {
 
 	hrInvalidHostname := createHTTPRoute("hr", gatewayNsName.Name, "", "/")
 	hrNotNKG := createHTTPRoute("hr", "some-gateway", "example.com", "/")
+	hrMissingGateway := createHTTPRoute("hr", "missing-gateway", "example.com", "/")
 	hrInvalidMatches := createHTTPRoute("hr", gatewayNsName.Name, "example.com", invalidPath)
 
+	hrInvalidQueryParamRegex := createHTTPRoute("hr", gatewayNsName.Name, "example.com", "/")
+	hrInvalidQueryParamRegex.Spec.Rules[0].Matches[0].QueryParams = []v1beta1.HTTPQueryParamMatch{
+		{
+			Type:  helpers.GetPointer(v1beta1.QueryParamMatchRegularExpression),
+			Name:  "version",
+			Value: invalidQueryParamRegex,
+		},
+	}
+
 	hrInvalidFilters := createHTTPRoute("hr", gatewayNsName.Name, "example.com", "/filter")
 	addFilterToPath(hrInvalidFilters, "/filter", invalidFilter)
 
 	hrInvalidValidRules := createHTTPRoute("hr", gatewayNsName.Name, "example.com", invalidPath, "/filter", "/")
 	addFilterToPath(hrInvalidValidRules, "/filter", invalidFilter)
 
+	hrClientMaxBodySize := createHTTPRoute("hr", gatewayNsName.Name, "example.com", "/")
+	hrClientMaxBodySize.Annotations = map[string]string{ClientMaxBodySizeAnnotation: "10M"}
+
+	hrInvalidClientMaxBodySize := createHTTPRoute("hr", gatewayNsName.Name, "example.com", "/")
+	hrInvalidClientMaxBodySize.Annotations = map[string]string{ClientMaxBodySizeAnnotation: "0.5"}
+
+	hrStreamingProxy := createHTTPRoute("hr", gatewayNsName.Name, "example.com", "/")
+	hrStreamingProxy.Annotations = map[string]string{StreamingProxyAnnotation: "true"}
+
+	hrInvalidStreamingProxy := createHTTPRoute("hr", gatewayNsName.Name, "example.com", "/")
+	hrInvalidStreamingProxy.Annotations = map[string]string{StreamingProxyAnnotation: "on"}
+
+	hrCaseInsensitiveHeaderValues := createHTTPRoute("hr", gatewayNsName.Name, "example.com", "/")
+	hrCaseInsensitiveHeaderValues.Annotations = map[string]string{CaseInsensitiveHeaderValuesAnnotation: "true"}
+
+	hrLocationSnippet := createHTTPRoute("hr", gatewayNsName.Name, "example.com", "/")
+	// proxy_set_header X-Route hr;
+	hrLocationSnippet.Annotations = map[string]string{LocationSnippetAnnotation: "cHJveHlfc2V0X2hlYWRlciBYLVJvdXRlIGhyOw=="}
+
+	hrInvalidLocationSnippet := createHTTPRoute("hr", gatewayNsName.Name, "example.com", "/")
+	// proxy_pass http://backend;
+	hrInvalidLocationSnippet.Annotations = map[string]string{LocationSnippetAnnotation: "cHJveHlfcGFzcyBodHRwOi8vYmFja2VuZDs="}
+
+	hrNextUpstream := createHTTPRoute("hr", gatewayNsName.Name, "example.com", "/")
+	hrNextUpstream.Annotations = map[string]string{
+		ProxyNextUpstreamAnnotation:      "error,timeout,http_502",
+		ProxyNextUpstreamTriesAnnotation: "3",
+	}
+
+	hrInvalidNextUpstream := createHTTPRoute("hr", gatewayNsName.Name, "example.com", "/")
+	hrInvalidNextUpstream.Annotations = map[string]string{ProxyNextUpstreamAnnotation: "http_501"}
+
+	hrBackendErrorPage := createHTTPRoute("hr", gatewayNsName.Name, "example.com", "/")
+	hrBackendErrorPage.Annotations = map[string]string{
+		BackendErrorStatusCodesAnnotation: "502,503",
+		BackendErrorBodyAnnotation:        "Try again later",
+	}
+
+	hrInvalidBackendErrorPage := createHTTPRoute("hr", gatewayNsName.Name, "example.com", "/")
+	hrInvalidBackendErrorPage.Annotations = map[string]string{BackendErrorStatusCodesAnnotation: "200"}
+
 	validatorInvalidFieldsInRule := &validationfakes.FakeHTTPFieldsValidator{
 		ValidatePathInMatchStub: func(path string) error {
 			if path == invalidPath {
@@ -371,6 +470,12 @@ func TestBuildRoute(t *testing.T) {
 			}
 			return nil
 		},
+		ValidateQueryParamRegexInMatchStub: func(regex string) error {
+			if regex == invalidQueryParamRegex {
+				return errors.New("invalid regex")
+			}
+			return nil
+		},
 	}
 
 	tests := []struct {
@@ -378,6 +483,8 @@ func TestBuildRoute(t *testing.T) {
 		hr        *v1beta1.HTTPRoute
 		expected  *Route
 		name      string
+		// disableSnippets disables the snippets, which are enabled in the other tests.
+		disableSnippets bool
 	}{
 		{
 			validator: &validationfakes.FakeHTTPFieldsValidator{},
@@ -410,6 +517,30 @@ func TestBuildRoute(t *testing.T) {
 			expected:  nil,
 			name:      "not NKG route",
 		},
+		{
+			validator: &validationfakes.FakeHTTPFieldsValidator{},
+			hr:        hrMissingGateway,
+			expected: &Route{
+				Source: hrMissingGateway,
+				ParentRefs: []ParentRef{
+					{
+						Idx:     0,
+						Gateway: types.NamespacedName{Namespace: "test", Name: "missing-gateway"},
+						Attachment: &ParentRefAttachmentStatus{
+							FailedCondition: staticConds.NewRouteNoMatchingParent(),
+						},
+					},
+				},
+				Valid: true,
+				Rules: []Rule{
+					{
+						ValidMatches: true,
+						ValidFilters: true,
+					},
+				},
+			},
+			name: "route for missing gateway",
+		},
 		{
 			validator: &validationfakes.FakeHTTPFieldsValidator{},
 			hr:        hrInvalidHostname,
@@ -456,6 +587,33 @@ func TestBuildRoute(t *testing.T) {
 			},
 			name: "all rules invalid, with invalid matches",
 		},
+		{
+			validator: validatorInvalidFieldsInRule,
+			hr:        hrInvalidQueryParamRegex,
+			expected: &Route{
+				Source: hrInvalidQueryParamRegex,
+				Valid:  false,
+				ParentRefs: []ParentRef{
+					{
+						Idx:     0,
+						Gateway: gatewayNsName,
+					},
+				},
+				Conditions: []conditions.Condition{
+					staticConds.NewRouteUnsupportedValue(
+						`All rules are invalid: spec.rules[0].matches[0].queryParams[0].value: ` +
+							`Invalid value: "v[0-9": invalid regex`,
+					),
+				},
+				Rules: []Rule{
+					{
+						ValidMatches: false,
+						ValidFilters: true,
+					},
+				},
+			},
+			name: "all rules invalid, with invalid query param regex",
+		},
 		{
 			validator: validatorInvalidFieldsInRule,
 			hr:        hrInvalidFilters,
@@ -519,15 +677,286 @@ func TestBuildRoute(t *testing.T) {
 			},
 			name: "invalid with invalid and valid rules",
 		},
+		{
+			validator: &validationfakes.FakeHTTPFieldsValidator{},
+			hr:        hrClientMaxBodySize,
+			expected: &Route{
+				Source: hrClientMaxBodySize,
+				ParentRefs: []ParentRef{
+					{
+						Idx:     0,
+						Gateway: gatewayNsName,
+					},
+				},
+				ClientMaxBodySize: helpers.GetPointer[int64](10000000),
+				Valid:             true,
+				Rules: []Rule{
+					{
+						ValidMatches: true,
+						ValidFilters: true,
+					},
+				},
+			},
+			name: "client max body size",
+		},
+		{
+			validator: &validationfakes.FakeHTTPFieldsValidator{},
+			hr:        hrInvalidClientMaxBodySize,
+			expected: &Route{
+				Source: hrInvalidClientMaxBodySize,
+				Valid:  false,
+				ParentRefs: []ParentRef{
+					{
+						Idx:     0,
+						Gateway: gatewayNsName,
+					},
+				},
+				Conditions: []conditions.Condition{
+					staticConds.NewRouteUnsupportedValue(
+						`metadata.annotations[gateway.nginx.org/client-max-body-size]: Invalid value: "0.5": ` +
+							`must be a whole number of bytes`,
+					),
+				},
+			},
+			name: "invalid client max body size",
+		},
+		{
+			validator: &validationfakes.FakeHTTPFieldsValidator{},
+			hr:        hrStreamingProxy,
+			expected: &Route{
+				Source: hrStreamingProxy,
+				ParentRefs: []ParentRef{
+					{
+						Idx:     0,
+						Gateway: gatewayNsName,
+					},
+				},
+				StreamingProxy: true,
+				Valid:          true,
+				Rules: []Rule{
+					{
+						ValidMatches: true,
+						ValidFilters: true,
+					},
+				},
+			},
+			name: "streaming proxy",
+		},
+		{
+			validator: &validationfakes.FakeHTTPFieldsValidator{},
+			hr:        hrCaseInsensitiveHeaderValues,
+			expected: &Route{
+				Source: hrCaseInsensitiveHeaderValues,
+				ParentRefs: []ParentRef{
+					{
+						Idx:     0,
+						Gateway: gatewayNsName,
+					},
+				},
+				CaseInsensitiveHeaderValues: true,
+				Valid:                       true,
+				Rules: []Rule{
+					{
+						ValidMatches: true,
+						ValidFilters: true,
+					},
+				},
+			},
+			name: "case-insensitive header values",
+		},
+		{
+			validator: &validationfakes.FakeHTTPFieldsValidator{},
+			hr:        hrInvalidStreamingProxy,
+			expected: &Route{
+				Source: hrInvalidStreamingProxy,
+				Valid:  false,
+				ParentRefs: []ParentRef{
+					{
+						Idx:     0,
+						Gateway: gatewayNsName,
+					},
+				},
+				Conditions: []conditions.Condition{
+					staticConds.NewRouteUnsupportedValue(
+						`metadata.annotations[gateway.nginx.org/streaming-proxy]: Invalid value: "on": ` +
+							`must be a boolean`,
+					),
+				},
+			},
+			name: "invalid streaming proxy",
+		},
+		{
+			validator: &validationfakes.FakeHTTPFieldsValidator{},
+			hr:        hrLocationSnippet,
+			expected: &Route{
+				Source: hrLocationSnippet,
+				ParentRefs: []ParentRef{
+					{
+						Idx:     0,
+						Gateway: gatewayNsName,
+					},
+				},
+				LocationSnippet: "proxy_set_header X-Route hr;",
+				Valid:           true,
+				Rules: []Rule{
+					{
+						ValidMatches: true,
+						ValidFilters: true,
+					},
+				},
+			},
+			name: "location snippet",
+		},
+		{
+			validator:       &validationfakes.FakeHTTPFieldsValidator{},
+			hr:              hrLocationSnippet,
+			disableSnippets: true,
+			expected: &Route{
+				Source: hrLocationSnippet,
+				Valid:  false,
+				ParentRefs: []ParentRef{
+					{
+						Idx:     0,
+						Gateway: gatewayNsName,
+					},
+				},
+				Conditions: []conditions.Condition{
+					staticConds.NewRouteUnsupportedValue(
+						"metadata.annotations[gateway.nginx.org/location-snippet]: Forbidden: " +
+							"snippets are disabled; NKG must run with --enable-snippets to allow them",
+					),
+				},
+			},
+			name: "location snippet when snippets are disabled",
+		},
+		{
+			validator: &validationfakes.FakeHTTPFieldsValidator{},
+			hr:        hrInvalidLocationSnippet,
+			expected: &Route{
+				Source: hrInvalidLocationSnippet,
+				Valid:  false,
+				ParentRefs: []ParentRef{
+					{
+						Idx:     0,
+						Gateway: gatewayNsName,
+					},
+				},
+				Conditions: []conditions.Condition{
+					staticConds.NewRouteUnsupportedValue(
+						`metadata.annotations[gateway.nginx.org/location-snippet]: Invalid value: "cHJveHlfcGFzcyBodHRwOi8vYmFja2VuZDs=": ` +
+							`must not include the proxy_pass directive`,
+					),
+				},
+			},
+			name: "invalid location snippet",
+		},
+		{
+			validator: &validationfakes.FakeHTTPFieldsValidator{},
+			hr:        hrNextUpstream,
+			expected: &Route{
+				Source: hrNextUpstream,
+				ParentRefs: []ParentRef{
+					{
+						Idx:     0,
+						Gateway: gatewayNsName,
+					},
+				},
+				NextUpstream: &NextUpstream{
+					Conditions: []string{"error", "timeout", "http_502"},
+					Tries:      3,
+				},
+				Valid: true,
+				Rules: []Rule{
+					{
+						ValidMatches: true,
+						ValidFilters: true,
+					},
+				},
+			},
+			name: "next upstream",
+		},
+		{
+			validator: &validationfakes.FakeHTTPFieldsValidator{},
+			hr:        hrInvalidNextUpstream,
+			expected: &Route{
+				Source: hrInvalidNextUpstream,
+				Valid:  false,
+				ParentRefs: []ParentRef{
+					{
+						Idx:     0,
+						Gateway: gatewayNsName,
+					},
+				},
+				Conditions: []conditions.Condition{
+					staticConds.NewRouteUnsupportedValue(
+						`metadata.annotations[gateway.nginx.org/proxy-next-upstream]: Invalid value: "http_501": ` +
+							`"http_501" is not a supported condition`,
+					),
+				},
+			},
+			name: "invalid next upstream",
+		},
+		{
+			validator: &validationfakes.FakeHTTPFieldsValidator{},
+			hr:        hrBackendErrorPage,
+			expected: &Route{
+				Source: hrBackendErrorPage,
+				ParentRefs: []ParentRef{
+					{
+						Idx:     0,
+						Gateway: gatewayNsName,
+					},
+				},
+				BackendErrorPage: &ErrorPage{
+					Body:        "Try again later",
+					ContentType: "text/html",
+					StatusCodes: []int{502, 503},
+				},
+				Valid: true,
+				Rules: []Rule{
+					{
+						ValidMatches: true,
+						ValidFilters: true,
+					},
+				},
+			},
+			name: "backend error page",
+		},
+		{
+			validator: &validationfakes.FakeHTTPFieldsValidator{},
+			hr:        hrInvalidBackendErrorPage,
+			expected: &Route{
+				Source: hrInvalidBackendErrorPage,
+				Valid:  false,
+				ParentRefs: []ParentRef{
+					{
+						Idx:     0,
+						Gateway: gatewayNsName,
+					},
+				},
+				Conditions: []conditions.Condition{
+					staticConds.NewRouteUnsupportedValue(
+						`metadata.annotations[gateway.nginx.org/backend-error-status-codes]: Invalid value: "200": ` +
+							`"200" is not a valid HTTP status code in range 400-599`,
+					),
+				},
+			},
+			name: "invalid backend error page",
+		},
 	}
 
 	gatewayNsNames := []types.NamespacedName{gatewayNsName}
 
+	existingGws := map[types.NamespacedName]*v1beta1.Gateway{
+		gatewayNsName: {},
+		{Namespace: "test", Name: "some-gateway"}: {},
+	}
+
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			g := NewGomegaWithT(t)
 
-			route := buildRoute(test.validator, test.hr, gatewayNsNames)
+			route := buildRoute(test.validator, test.hr, gatewayNsNames, existingGws, !test.disableSnippets)
 			g.Expect(helpers.Diff(test.expected, route)).To(BeEmpty())
 		})
 	}
@@ -539,7 +968,8 @@ func TestBindRouteToListeners(t *testing.T) {
 		return &Listener{
 			Source: v1beta1.Listener{
 				Name:     v1beta1.SectionName(name),
-				Hostname: (*v1beta1.Hostname)(helpers.GetStringPointer("foo.example.com")),
+				Hostname: (*v1beta1.Hostname)(helpers.GetPointer("foo.example.com")),
+				Port:     80,
 			},
 			Valid:  true,
 			Routes: map[types.NamespacedName]*Route{},
@@ -597,6 +1027,14 @@ func TestBindRouteToListeners(t *testing.T) {
 		helpers.GetPointer[v1beta1.SectionName]("listener-80-1"),
 		helpers.GetPointer[v1beta1.PortNumber](80),
 	)
+	hrWithPortAndNilSectionName := createHTTPRouteWithSectionNameAndPort(
+		nil,
+		helpers.GetPointer[v1beta1.PortNumber](443),
+	)
+	hrWithNonMatchingPort := createHTTPRouteWithSectionNameAndPort(
+		nil,
+		helpers.GetPointer[v1beta1.PortNumber](8080),
+	)
 	hrWithNonExistingListener := createHTTPRouteWithSectionNameAndPort(
 		helpers.GetPointer[v1beta1.SectionName]("listener-80-2"),
 		nil,
@@ -630,8 +1068,22 @@ func TestBindRouteToListeners(t *testing.T) {
 			},
 		},
 	}
-	routeWithEmptySectionName := &Route{
-		Source: hrWithEmptySectionName,
+	// the function under test modifies the route, so the test cases can't share it
+	createRouteWithEmptySectionName := func() *Route {
+		return &Route{
+			Source: hrWithEmptySectionName,
+			Valid:  true,
+			ParentRefs: []ParentRef{
+				{
+					Idx:     0,
+					Gateway: client.ObjectKeyFromObject(gw),
+				},
+			},
+		}
+	}
+	routeWithEmptySectionName := createRouteWithEmptySectionName()
+	routeWithSectionNameAndMultipleListeners := &Route{
+		Source: hr,
 		Valid:  true,
 		ParentRefs: []ParentRef{
 			{
@@ -660,6 +1112,26 @@ func TestBindRouteToListeners(t *testing.T) {
 			},
 		},
 	}
+	routeWithPortAndNilSectionName := &Route{
+		Source: hrWithPortAndNilSectionName,
+		Valid:  true,
+		ParentRefs: []ParentRef{
+			{
+				Idx:     0,
+				Gateway: client.ObjectKeyFromObject(gw),
+			},
+		},
+	}
+	routeWithNonMatchingPort := &Route{
+		Source: hrWithNonMatchingPort,
+		Valid:  true,
+		ParentRefs: []ParentRef{
+			{
+				Idx:     0,
+				Gateway: client.ObjectKeyFromObject(gw),
+			},
+		},
+	}
 	ignoredGwNsName := types.NamespacedName{Namespace: "test", Name: "ignored-gateway"}
 	routeWithIgnoredGateway := &Route{
 		Source: hr,
@@ -671,6 +1143,20 @@ func TestBindRouteToListeners(t *testing.T) {
 			},
 		},
 	}
+	missingGwNsName := types.NamespacedName{Namespace: "test", Name: "missing-gateway"}
+	routeWithMissingGateway := &Route{
+		Source: hr,
+		Valid:  true,
+		ParentRefs: []ParentRef{
+			{
+				Idx:     0,
+				Gateway: missingGwNsName,
+				Attachment: &ParentRefAttachmentStatus{
+					FailedCondition: staticConds.NewRouteNoMatchingParent(),
+				},
+			},
+		},
+	}
 	notValidRoute := &Route{
 		Valid: false,
 		ParentRefs: []ParentRef{
@@ -793,7 +1279,39 @@ func TestBindRouteToListeners(t *testing.T) {
 			name: "section name is empty; bind to multiple listeners",
 		},
 		{
-			route: routeWithEmptySectionName,
+			route: routeWithSectionNameAndMultipleListeners,
+			gateway: &Gateway{
+				Source: gw,
+				Valid:  true,
+				Listeners: map[string]*Listener{
+					"listener-80-1": createListener("listener-80-1"),
+					"listener-443":  createListener("listener-443"),
+				},
+			},
+			expectedSectionNameRefs: []ParentRef{
+				{
+					Idx:     0,
+					Gateway: client.ObjectKeyFromObject(gw),
+					Attachment: &ParentRefAttachmentStatus{
+						Attached: true,
+						AcceptedHostnames: map[string][]string{
+							"listener-80-1": {"foo.example.com"},
+						},
+					},
+				},
+			},
+			expectedGatewayListeners: map[string]*Listener{
+				"listener-80-1": createModifiedListener("listener-80-1", func(l *Listener) {
+					l.Routes = map[types.NamespacedName]*Route{
+						client.ObjectKeyFromObject(hr): routeWithSectionNameAndMultipleListeners,
+					}
+				}),
+				"listener-443": createListener("listener-443"),
+			},
+			name: "section name is set; bind only to the named listener of multiple listeners",
+		},
+		{
+			route: createRouteWithEmptySectionName(),
 			gateway: &Gateway{
 				Source: gw,
 				Valid:  true,
@@ -831,18 +1349,87 @@ func TestBindRouteToListeners(t *testing.T) {
 					Idx:     0,
 					Gateway: client.ObjectKeyFromObject(gw),
 					Attachment: &ParentRefAttachmentStatus{
-						Attached: false,
-						FailedCondition: staticConds.NewRouteUnsupportedValue(
-							`spec.parentRefs[0].port: Forbidden: cannot be set`,
-						),
+						Attached: true,
+						AcceptedHostnames: map[string][]string{
+							"listener-80-1": {"foo.example.com"},
+						},
+					},
+				},
+			},
+			expectedGatewayListeners: map[string]*Listener{
+				"listener-80-1": createModifiedListener("listener-80-1", func(l *Listener) {
+					l.Routes = map[types.NamespacedName]*Route{
+						client.ObjectKeyFromObject(hr): routeWithPort,
+					}
+				}),
+			},
+			name: "port and section name match the listener",
+		},
+		{
+			route: routeWithPortAndNilSectionName,
+			gateway: &Gateway{
+				Source: gw,
+				Valid:  true,
+				Listeners: map[string]*Listener{
+					"listener-80-1": createListener("listener-80-1"),
+					"listener-443": createModifiedListener("listener-443", func(l *Listener) {
+						l.Source.Port = 443
+					}),
+				},
+			},
+			expectedSectionNameRefs: []ParentRef{
+				{
+					Idx:     0,
+					Gateway: client.ObjectKeyFromObject(gw),
+					Attachment: &ParentRefAttachmentStatus{
+						Attached: true,
+						AcceptedHostnames: map[string][]string{
+							"listener-443": {"foo.example.com"},
+						},
+					},
+				},
+			},
+			expectedGatewayListeners: map[string]*Listener{
+				"listener-80-1": createListener("listener-80-1"),
+				"listener-443": createModifiedListener("listener-443", func(l *Listener) {
+					l.Source.Port = 443
+					l.Routes = map[types.NamespacedName]*Route{
+						client.ObjectKeyFromObject(hr): routeWithPortAndNilSectionName,
+					}
+				}),
+			},
+			name: "port matches one of multiple listeners",
+		},
+		{
+			route: routeWithNonMatchingPort,
+			gateway: &Gateway{
+				Source: gw,
+				Valid:  true,
+				Listeners: map[string]*Listener{
+					"listener-80-1": createListener("listener-80-1"),
+					"listener-443": createModifiedListener("listener-443", func(l *Listener) {
+						l.Source.Port = 443
+					}),
+				},
+			},
+			expectedSectionNameRefs: []ParentRef{
+				{
+					Idx:     0,
+					Gateway: client.ObjectKeyFromObject(gw),
+					Attachment: &ParentRefAttachmentStatus{
+						Attached:          false,
+						FailedCondition:   staticConds.NewRouteNoMatchingParent(),
 						AcceptedHostnames: map[string][]string{},
 					},
 				},
 			},
 			expectedGatewayListeners: map[string]*Listener{
 				"listener-80-1": createListener("listener-80-1"),
+				"listener-443": createModifiedListener("listener-443", func(l *Listener) {
+					l.Source.Port = 443
+				}),
 			},
-			name: "port is configured",
+			name: "port matches no listener",
 		},
 		{
 			route: routeWithNonExistingListener,
@@ -944,6 +1531,29 @@ func TestBindRouteToListeners(t *testing.T) {
 			},
 			name: "gateway is ignored",
 		},
+		{
+			route: routeWithMissingGateway,
+			gateway: &Gateway{
+				Source: gw,
+				Valid:  true,
+				Listeners: map[string]*Listener{
+					"listener-80-1": createListener("listener-80-1"),
+				},
+			},
+			expectedSectionNameRefs: []ParentRef{
+				{
+					Idx:     0,
+					Gateway: missingGwNsName,
+					Attachment: &ParentRefAttachmentStatus{
+						FailedCondition: staticConds.NewRouteNoMatchingParent(),
+					},
+				},
+			},
+			expectedGatewayListeners: map[string]*Listener{
+				"listener-80-1": createListener("listener-80-1"),
+			},
+			name: "gateway doesn't exist",
+		},
 		{
 			route: notValidRoute,
 			gateway: &Gateway{
@@ -1432,6 +2042,32 @@ func TestValidateMatch(t *testing.T) {
 			match: v1beta1.HTTPRouteMatch{
 				Path: &v1beta1.HTTPPathMatch{
 					Type:  helpers.GetPointer(v1beta1.PathMatchRegularExpression),
+					Value: helpers.GetPointer("/path/[0-9]+"),
+				},
+			},
+			expectErrCount: 0,
+			name:           "valid regex match",
+		},
+		{
+			validator: func() *validationfakes.FakeHTTPFieldsValidator {
+				validator := createAllValidValidator()
+				validator.ValidatePathRegexInMatchReturns(errors.New("invalid path regex"))
+				return validator
+			}(),
+			match: v1beta1.HTTPRouteMatch{
+				Path: &v1beta1.HTTPPathMatch{
+					Type:  helpers.GetPointer(v1beta1.PathMatchRegularExpression),
+					Value: helpers.GetPointer("/path/[0-9"),
+				},
+			},
+			expectErrCount: 1,
+			name:           "wrong path regex",
+		},
+		{
+			validator: createAllValidValidator(),
+			match: v1beta1.HTTPRouteMatch{
+				Path: &v1beta1.HTTPPathMatch{
+					Type:  helpers.GetPointer[v1beta1.PathMatchType]("Unknown"),
 					Value: helpers.GetPointer("/"),
 				},
 			},
@@ -1536,7 +2172,7 @@ func TestValidateMatch(t *testing.T) {
 			match: v1beta1.HTTPRouteMatch{
 				QueryParams: []v1beta1.HTTPQueryParamMatch{
 					{
-						Type:  helpers.GetPointer(v1beta1.QueryParamMatchRegularExpression),
+						Type:  helpers.GetPointer[v1beta1.QueryParamMatchType]("Unknown"),
 						Name:  "param",
 						Value: "y",
 					},
@@ -1545,6 +2181,56 @@ func TestValidateMatch(t *testing.T) {
 			expectErrCount: 1,
 			name:           "query param match type is invalid",
 		},
+		{
+			validator: createAllValidValidator(),
+			match: v1beta1.HTTPRouteMatch{
+				QueryParams: []v1beta1.HTTPQueryParamMatch{
+					{
+						Type:  helpers.GetPointer(v1beta1.QueryParamMatchRegularExpression),
+						Name:  "param",
+						Value: "^(foo|bar)$",
+					},
+				},
+			},
+			expectErrCount: 0,
+			name:           "valid query param regex match",
+		},
+		{
+			validator: func() *validationfakes.FakeHTTPFieldsValidator {
+				validator := createAllValidValidator()
+				validator.ValidateQueryParamRegexInMatchReturns(errors.New("invalid regex"))
+				return validator
+			}(),
+			match: v1beta1.HTTPRouteMatch{
+				QueryParams: []v1beta1.HTTPQueryParamMatch{
+					{
+						Type:  helpers.GetPointer(v1beta1.QueryParamMatchRegularExpression),
+						Name:  "param",
+						Value: "[0-9",
+					},
+				},
+			},
+			expectErrCount: 1,
+			name:           "query param regex is invalid",
+		},
+		{
+			validator: func() *validationfakes.FakeHTTPFieldsValidator {
+				validator := createAllValidValidator()
+				validator.ValidateQueryParamNameInRegexMatchReturns(errors.New("invalid name"))
+				return validator
+			}(),
+			match: v1beta1.HTTPRouteMatch{
+				QueryParams: []v1beta1.HTTPQueryParamMatch{
+					{
+						Type:  helpers.GetPointer(v1beta1.QueryParamMatchRegularExpression),
+						Name:  "param-1",
+						Value: "y",
+					},
+				},
+			},
+			expectErrCount: 1,
+			name:           "query param name in regex match is invalid",
+		},
 		{
 			validator: func() *validationfakes.FakeHTTPFieldsValidator {
 				validator := createAllValidValidator()
@@ -1597,7 +2283,7 @@ func TestValidateMatch(t *testing.T) {
 			validator: createAllValidValidator(),
 			match: v1beta1.HTTPRouteMatch{
 				Path: &v1beta1.HTTPPathMatch{
-					Type:  helpers.GetPointer(v1beta1.PathMatchRegularExpression), // invalid
+					Type:  helpers.GetPointer[v1beta1.PathMatchType]("Unknown"), // invalid
 					Value: helpers.GetPointer("/"),
 				},
 				Headers: []v1beta1.HTTPHeaderMatch{
@@ -1609,7 +2295,7 @@ func TestValidateMatch(t *testing.T) {
 				},
 				QueryParams: []v1beta1.HTTPQueryParamMatch{
 					{
-						Type:  helpers.GetPointer(v1beta1.QueryParamMatchRegularExpression), // invalid
+						Type:  helpers.GetPointer[v1beta1.QueryParamMatchType]("Unknown"), // invalid
 						Name:  "param",
 						Value: "y",
 					},
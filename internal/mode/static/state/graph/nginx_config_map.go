@@ -0,0 +1,39 @@
+package graph
+
+import (
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// httpSnippetKey is the key of the snippet for the NGINX http context in the data of the NGINX ConfigMap.
+const httpSnippetKey = "http-snippet"
+
+// buildHTTPSnippet returns the snippet for the NGINX http context from the ConfigMaps.
+// NKG only watches the ConfigMap configured with the --nginx-config-map flag, so there is at most one ConfigMap.
+// If there are more, their snippets are joined in the order of their namespaced names, so that the result is stable.
+func buildHTTPSnippet(configMaps map[types.NamespacedName]*v1.ConfigMap) string {
+	if len(configMaps) == 0 {
+		return ""
+	}
+
+	nsNames := make([]types.NamespacedName, 0, len(configMaps))
+	for nsname := range configMaps {
+		nsNames = append(nsNames, nsname)
+	}
+
+	sort.Slice(nsNames, func(i, j int) bool {
+		return nsNames[i].String() < nsNames[j].String()
+	})
+
+	snippets := make([]string, 0, len(nsNames))
+	for _, nsname := range nsNames {
+		if snippet := configMaps[nsname].Data[httpSnippetKey]; snippet != "" {
+			snippets = append(snippets, snippet)
+		}
+	}
+
+	return strings.Join(snippets, "\n")
+}
@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestBuildHTTPSnippet(t *testing.T) {
+	createConfigMap := func(namespace, name string, data map[string]string) *v1.ConfigMap {
+		return &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+			Data: data,
+		}
+	}
+
+	tests := []struct {
+		configMaps map[types.NamespacedName]*v1.ConfigMap
+		name       string
+		expected   string
+	}{
+		{
+			configMaps: nil,
+			name:       "no ConfigMaps",
+			expected:   "",
+		},
+		{
+			configMaps: map[types.NamespacedName]*v1.ConfigMap{
+				{Namespace: "test", Name: "nginx"}: createConfigMap("test", "nginx", map[string]string{
+					"http-snippet": "gzip on;",
+				}),
+			},
+			name:     "ConfigMap with snippet",
+			expected: "gzip on;",
+		},
+		{
+			configMaps: map[types.NamespacedName]*v1.ConfigMap{
+				{Namespace: "test", Name: "nginx"}: createConfigMap("test", "nginx", map[string]string{
+					"other-key": "gzip on;",
+				}),
+			},
+			name:     "ConfigMap without snippet",
+			expected: "",
+		},
+		{
+			configMaps: map[types.NamespacedName]*v1.ConfigMap{
+				{Namespace: "test", Name: "nginx-b"}: createConfigMap("test", "nginx-b", map[string]string{
+					"http-snippet": "gzip_types text/plain;",
+				}),
+				{Namespace: "test", Name: "nginx-a"}: createConfigMap("test", "nginx-a", map[string]string{
+					"http-snippet": "gzip on;",
+				}),
+			},
+			name:     "multiple ConfigMaps",
+			expected: "gzip on;\ngzip_types text/plain;",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			g.Expect(buildHTTPSnippet(test.configMaps)).To(Equal(test.expected))
+		})
+	}
+}
@@ -1,14 +1,26 @@
 package graph
 
 import (
+	"bytes"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"math/big"
 
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
+const (
+	// CACertKey is the key of the CA certificate in a TLS Secret.
+	CACertKey = "ca.crt"
+	// DHParamsKey is the key of the Diffie-Hellman parameters in a Secret.
+	DHParamsKey = "dh.pem"
+)
+
 // Secret represents a Secret resource.
 type Secret struct {
 	// Source holds the actual Secret resource. Can be nil if the Secret does not exist.
@@ -26,12 +38,15 @@ type secretEntry struct {
 type secretResolver struct {
 	clusterSecrets  map[types.NamespacedName]*apiv1.Secret
 	resolvedSecrets map[types.NamespacedName]*secretEntry
+	// resolvedDHParamsSecrets are resolved separately from the TLS Secrets, because they are validated differently.
+	resolvedDHParamsSecrets map[types.NamespacedName]*secretEntry
 }
 
 func newSecretResolver(secrets map[types.NamespacedName]*apiv1.Secret) *secretResolver {
 	return &secretResolver{
-		clusterSecrets:  secrets,
-		resolvedSecrets: make(map[types.NamespacedName]*secretEntry),
+		clusterSecrets:          secrets,
+		resolvedSecrets:         make(map[types.NamespacedName]*secretEntry),
+		resolvedDHParamsSecrets: make(map[types.NamespacedName]*secretEntry),
 	}
 }
 
@@ -66,8 +81,47 @@ func (r *secretResolver) resolve(nsname types.NamespacedName) error {
 	return validationErr
 }
 
+// resolveDHParams resolves a Secret with the Diffie-Hellman parameters.
+func (r *secretResolver) resolveDHParams(nsname types.NamespacedName) error {
+	if s, resolved := r.resolvedDHParamsSecrets[nsname]; resolved {
+		return s.err
+	}
+
+	secret, exist := r.clusterSecrets[nsname]
+
+	var validationErr error
+
+	if !exist {
+		validationErr = errors.New("secret does not exist")
+	} else if data, ok := secret.Data[DHParamsKey]; !ok {
+		validationErr = fmt.Errorf("secret doesn't have the %s key", DHParamsKey)
+	} else if err := validateDHParams(data); err != nil {
+		validationErr = fmt.Errorf("DH parameters are invalid: %w", err)
+	}
+
+	r.resolvedDHParamsSecrets[nsname] = &secretEntry{
+		Secret: Secret{
+			Source: secret,
+		},
+		err: validationErr,
+	}
+
+	return validationErr
+}
+
+// getCACert returns the CA certificate of a resolved Secret.
+// It returns nil if the Secret doesn't include the CA certificate.
+func (r *secretResolver) getCACert(nsname types.NamespacedName) []byte {
+	s, resolved := r.resolvedSecrets[nsname]
+	if !resolved || s.Source == nil {
+		return nil
+	}
+
+	return s.Source.Data[CACertKey]
+}
+
 func (r *secretResolver) getResolvedSecrets() map[types.NamespacedName]*Secret {
-	if len(r.resolvedSecrets) == 0 {
+	if len(r.resolvedSecrets) == 0 && len(r.resolvedDHParamsSecrets) == 0 {
 		return nil
 	}
 
@@ -77,5 +131,82 @@ func (r *secretResolver) getResolvedSecrets() map[types.NamespacedName]*Secret {
 		resolved[nsname] = &entry.Secret
 	}
 
+	for nsname, entry := range r.resolvedDHParamsSecrets {
+		resolved[nsname] = &entry.Secret
+	}
+
 	return resolved
 }
+
+// validateCACert validates that the data includes PEM-encoded certificates and nothing else.
+func validateCACert(data []byte) error {
+	var count int
+
+	for rest := data; len(rest) > 0; {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			if len(bytes.TrimSpace(rest)) > 0 {
+				return errors.New("data is not PEM-encoded")
+			}
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("unexpected PEM block type %q", block.Type)
+		}
+
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return err
+		}
+
+		count++
+	}
+
+	if count == 0 {
+		return errors.New("no certificates found")
+	}
+
+	return nil
+}
+
+// dhParams is the ASN.1 structure of the Diffie-Hellman parameters (PKCS #3).
+type dhParams struct {
+	P                  *big.Int
+	G                  *big.Int
+	PrivateValueLength int `asn1:"optional"`
+}
+
+// validateDHParams validates that the data includes the PEM-encoded Diffie-Hellman parameters.
+func validateDHParams(data []byte) error {
+	block, rest := pem.Decode(data)
+	if block == nil {
+		return errors.New("data is not PEM-encoded")
+	}
+
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return errors.New("data must include a single PEM block")
+	}
+
+	if block.Type != "DH PARAMETERS" {
+		return fmt.Errorf("unexpected PEM block type %q", block.Type)
+	}
+
+	var params dhParams
+
+	rest, err := asn1.Unmarshal(block.Bytes, &params)
+	if err != nil {
+		return err
+	}
+
+	if len(rest) > 0 {
+		return errors.New("trailing data after the parameters")
+	}
+
+	if params.P == nil || params.P.Sign() <= 0 || params.G == nil || params.G.Sign() <= 0 {
+		return errors.New("prime and generator must be positive")
+	}
+
+	return nil
+}
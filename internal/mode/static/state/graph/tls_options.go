@@ -0,0 +1,174 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const (
+	// SSLSessionCacheSizeOption is the TLS option of HTTPS Listeners that enables the cache of TLS sessions shared
+	// between the NGINX worker processes and sets its size. The value is a quantity of at least 1Mi, for example,
+	// "10Mi". One megabyte of the cache can store about 4000 sessions.
+	SSLSessionCacheSizeOption v1beta1.AnnotationKey = "gateway.nginx.org/ssl-session-cache-size"
+	// SSLSessionTicketsOption is the TLS option of HTTPS Listeners that enables or disables resumption of TLS
+	// sessions through TLS session tickets. The value is a boolean, for example, "false".
+	SSLSessionTicketsOption v1beta1.AnnotationKey = "gateway.nginx.org/ssl-session-tickets"
+	// MinTLSVersionOption is the TLS option of HTTPS Listeners that sets the minimum TLS version accepted from
+	// clients. The value is either "TLSv1.2" or "TLSv1.3".
+	MinTLSVersionOption v1beta1.AnnotationKey = "gateway.nginx.org/min-tls-version"
+	// SSLCiphersOption is the TLS option of HTTPS Listeners that sets the enabled ciphers for TLSv1.2 as
+	// a colon-separated list in the OpenSSL format, for example,
+	// "ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256".
+	SSLCiphersOption v1beta1.AnnotationKey = "gateway.nginx.org/ssl-ciphers"
+	// SSLStaplingOption is the TLS option of HTTPS Listeners that enables OCSP stapling. The value is a boolean,
+	// for example, "true". NGINX verifies the OCSP responses with the CA certificate from the ca.crt key of the
+	// Secret of the Listener. If the Secret doesn't include the CA certificate, OCSP stapling is disabled.
+	SSLStaplingOption v1beta1.AnnotationKey = "gateway.nginx.org/ssl-stapling"
+	// SSLDHParamSecretOption is the TLS option of HTTPS Listeners that sets custom Diffie-Hellman parameters for
+	// the DHE ciphers. The value is the name of a Secret in the namespace of the Gateway. The Secret must include
+	// the PEM-encoded parameters in the dh.pem key.
+	SSLDHParamSecretOption v1beta1.AnnotationKey = "gateway.nginx.org/ssl-dhparam-secret"
+)
+
+const (
+	// TLSVersion12 is the TLSv1.2 version.
+	TLSVersion12 = "TLSv1.2"
+	// TLSVersion13 is the TLSv1.3 version.
+	TLSVersion13 = "TLSv1.3"
+)
+
+// cipherRegexp matches a cipher or a cipher string of the OpenSSL cipher list format, for example,
+// "ECDHE-RSA-AES128-GCM-SHA256", "HIGH", "!aNULL" or "@SECLEVEL=2".
+// The validation can't check that OpenSSL supports the cipher, because the control plane doesn't link OpenSSL.
+// OpenSSL ignores the unknown ciphers as long as at least one cipher of the list is supported.
+var cipherRegexp = regexp.MustCompile(`^[!+@-]?[A-Za-z0-9_.=-]+$`)
+
+// minSSLSessionCacheSize is the minimum size of the TLS session cache.
+// NGINX rejects shared memory zones that are smaller than 8 memory pages.
+const minSSLSessionCacheSize = 1024 * 1024
+
+var supportedTLSOptions = []string{
+	string(MinTLSVersionOption),
+	string(SSLCiphersOption),
+	string(SSLDHParamSecretOption),
+	string(SSLSessionCacheSizeOption),
+	string(SSLSessionTicketsOption),
+	string(SSLStaplingOption),
+}
+
+var supportedTLSVersions = []string{TLSVersion12, TLSVersion13}
+
+// TLSSettings holds the NGINX TLS settings of an HTTPS Listener, which are set from its TLS options.
+type TLSSettings struct {
+	// SessionCacheSize is the size in bytes of the shared cache of TLS sessions. If nil, the cache is not enabled.
+	SessionCacheSize *int64
+	// SessionTickets tells if the TLS session tickets are enabled. If nil, the NGINX default applies.
+	SessionTickets *bool
+	// MinTLSVersion is the minimum TLS version accepted from clients. If empty, the NGINX default applies.
+	MinTLSVersion string
+	// DHParamSecret is the name of the Secret with the Diffie-Hellman parameters in the namespace of the Gateway.
+	// If empty, the NGINX default applies.
+	DHParamSecret string
+	// Ciphers are the enabled ciphers for TLSv1.2. If empty, the NGINX default applies.
+	Ciphers []string
+	// Stapling tells if OCSP stapling is enabled.
+	Stapling bool
+}
+
+// getTLSSettings returns the TLSSettings from the TLS options of a Listener.
+// Because the errors are reported in the status of the Listener, their paths start from the tls field.
+func getTLSSettings(options map[v1beta1.AnnotationKey]v1beta1.AnnotationValue) (TLSSettings, field.ErrorList) {
+	var (
+		settings TLSSettings
+		allErrs  field.ErrorList
+	)
+
+	optionsPath := field.NewPath("tls", "options")
+
+	// sort the keys so that the errors are reported in the same order
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := string(options[v1beta1.AnnotationKey(key)])
+		path := optionsPath.Key(key)
+
+		switch v1beta1.AnnotationKey(key) {
+		case SSLSessionCacheSizeOption:
+			size, err := parseSize(value)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(path, value, err.Error()))
+				continue
+			}
+			if size < minSSLSessionCacheSize {
+				msg := fmt.Sprintf("must be at least %d bytes", minSSLSessionCacheSize)
+				allErrs = append(allErrs, field.Invalid(path, value, msg))
+				continue
+			}
+			settings.SessionCacheSize = &size
+		case SSLSessionTicketsOption:
+			tickets, err := strconv.ParseBool(value)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(path, value, "must be a boolean"))
+				continue
+			}
+			settings.SessionTickets = &tickets
+		case SSLStaplingOption:
+			stapling, err := strconv.ParseBool(value)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(path, value, "must be a boolean"))
+				continue
+			}
+			settings.Stapling = stapling
+		case MinTLSVersionOption:
+			if value != TLSVersion12 && value != TLSVersion13 {
+				allErrs = append(allErrs, field.NotSupported(path, value, supportedTLSVersions))
+				continue
+			}
+			settings.MinTLSVersion = value
+		case SSLCiphersOption:
+			ciphers, err := parseCiphers(value)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(path, value, err.Error()))
+				continue
+			}
+			settings.Ciphers = ciphers
+		case SSLDHParamSecretOption:
+			if msgs := validation.IsDNS1123Subdomain(value); len(msgs) > 0 {
+				allErrs = append(allErrs, field.Invalid(path, value, strings.Join(msgs, ", ")))
+				continue
+			}
+			settings.DHParamSecret = value
+		default:
+			allErrs = append(allErrs, field.NotSupported(optionsPath, key, supportedTLSOptions))
+		}
+	}
+
+	return settings, allErrs
+}
+
+func parseCiphers(value string) ([]string, error) {
+	if value == "" {
+		return nil, errors.New("must not be empty")
+	}
+
+	ciphers := strings.Split(value, ":")
+	for _, c := range ciphers {
+		if !cipherRegexp.MatchString(c) {
+			return nil, fmt.Errorf("%q is not a valid cipher", c)
+		}
+	}
+
+	return ciphers, nil
+}
@@ -0,0 +1,200 @@
+package graph
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
+)
+
+func TestGetTLSSettings(t *testing.T) {
+	optionsPath := field.NewPath("tls", "options")
+
+	tests := []struct {
+		options  map[v1beta1.AnnotationKey]v1beta1.AnnotationValue
+		name     string
+		expErrs  field.ErrorList
+		expected TLSSettings
+	}{
+		{
+			name:     "no options",
+			options:  nil,
+			expected: TLSSettings{},
+		},
+		{
+			name: "session cache and tickets disabled",
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				SSLSessionCacheSizeOption: "10Mi",
+				SSLSessionTicketsOption:   "false",
+			},
+			expected: TLSSettings{
+				SessionCacheSize: helpers.GetPointer[int64](10 * 1024 * 1024),
+				SessionTickets:   helpers.GetPointer(false),
+			},
+		},
+		{
+			name: "tickets enabled",
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				SSLSessionTicketsOption: "true",
+			},
+			expected: TLSSettings{
+				SessionTickets: helpers.GetPointer(true),
+			},
+		},
+		{
+			name: "invalid options",
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				SSLSessionCacheSizeOption: "512Ki",
+				SSLSessionTicketsOption:   "off",
+				"example.com/unknown":     "value",
+			},
+			expected: TLSSettings{},
+			expErrs: field.ErrorList{
+				field.NotSupported(optionsPath, "example.com/unknown", supportedTLSOptions),
+				field.Invalid(
+					optionsPath.Key(string(SSLSessionCacheSizeOption)),
+					"512Ki",
+					"must be at least 1048576 bytes",
+				),
+				field.Invalid(optionsPath.Key(string(SSLSessionTicketsOption)), "off", "must be a boolean"),
+			},
+		},
+		{
+			name: "TLSv1.3 only",
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				MinTLSVersionOption: "TLSv1.3",
+			},
+			expected: TLSSettings{
+				MinTLSVersion: TLSVersion13,
+			},
+		},
+		{
+			name: "TLSv1.2 and ciphers",
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				MinTLSVersionOption: "TLSv1.2",
+				SSLCiphersOption:    "ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256:HIGH:!aNULL",
+			},
+			expected: TLSSettings{
+				MinTLSVersion: TLSVersion12,
+				Ciphers: []string{
+					"ECDHE-ECDSA-AES128-GCM-SHA256",
+					"ECDHE-RSA-AES128-GCM-SHA256",
+					"HIGH",
+					"!aNULL",
+				},
+			},
+		},
+		{
+			name: "invalid TLS version and ciphers",
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				MinTLSVersionOption: "TLSv1.1",
+				SSLCiphersOption:    "HIGH;include /etc/passwd",
+			},
+			expected: TLSSettings{},
+			expErrs: field.ErrorList{
+				field.NotSupported(
+					optionsPath.Key(string(MinTLSVersionOption)),
+					"TLSv1.1",
+					[]string{TLSVersion12, TLSVersion13},
+				),
+				field.Invalid(
+					optionsPath.Key(string(SSLCiphersOption)),
+					"HIGH;include /etc/passwd",
+					`"HIGH;include /etc/passwd" is not a valid cipher`,
+				),
+			},
+		},
+		{
+			name: "empty ciphers",
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				SSLCiphersOption: "",
+			},
+			expected: TLSSettings{},
+			expErrs: field.ErrorList{
+				field.Invalid(optionsPath.Key(string(SSLCiphersOption)), "", "must not be empty"),
+			},
+		},
+		{
+			name: "empty cipher in the list",
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				SSLCiphersOption: "HIGH::!aNULL",
+			},
+			expected: TLSSettings{},
+			expErrs: field.ErrorList{
+				field.Invalid(optionsPath.Key(string(SSLCiphersOption)), "HIGH::!aNULL", `"" is not a valid cipher`),
+			},
+		},
+		{
+			name: "stapling enabled",
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				SSLStaplingOption: "true",
+			},
+			expected: TLSSettings{
+				Stapling: true,
+			},
+		},
+		{
+			name: "invalid stapling",
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				SSLStaplingOption: "on",
+			},
+			expected: TLSSettings{},
+			expErrs: field.ErrorList{
+				field.Invalid(optionsPath.Key(string(SSLStaplingOption)), "on", "must be a boolean"),
+			},
+		},
+		{
+			name: "DH parameters secret",
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				SSLDHParamSecretOption: "dhparams",
+			},
+			expected: TLSSettings{
+				DHParamSecret: "dhparams",
+			},
+		},
+		{
+			name: "invalid DH parameters secret name",
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				SSLDHParamSecretOption: "Invalid_Name",
+			},
+			expected: TLSSettings{},
+			expErrs: field.ErrorList{
+				field.Invalid(
+					optionsPath.Key(string(SSLDHParamSecretOption)),
+					"Invalid_Name",
+					"a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', "+
+						"and must start and end with an alphanumeric character (e.g. 'example.com', regex used for "+
+						`validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`,
+				),
+			},
+		},
+		{
+			name: "session cache size is not a quantity",
+			options: map[v1beta1.AnnotationKey]v1beta1.AnnotationValue{
+				SSLSessionCacheSizeOption: "10MB",
+			},
+			expected: TLSSettings{},
+			expErrs: field.ErrorList{
+				field.Invalid(
+					optionsPath.Key(string(SSLSessionCacheSizeOption)),
+					"10MB",
+					"quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'",
+				),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			settings, errs := getTLSSettings(test.options)
+
+			g.Expect(errs).To(Equal(test.expErrs))
+			g.Expect(settings).To(Equal(test.expected))
+		})
+	}
+}
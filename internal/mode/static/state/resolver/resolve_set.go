@@ -0,0 +1,18 @@
+//go:build !sorted_endpoints
+
+package resolver
+
+import (
+	v1 "k8s.io/api/core/v1"
+	discoveryV1 "k8s.io/api/discovery/v1"
+)
+
+// resolveServiceEndpoints resolves the endpoints of the Service port, deduplicating them with a set.
+// To use the sorted slice for deduplication instead, build with the sorted_endpoints build tag.
+func resolveServiceEndpoints(
+	svc *v1.Service,
+	port int32,
+	endpointSliceList discoveryV1.EndpointSliceList,
+) ([]Endpoint, error) {
+	return resolveEndpoints(svc, port, endpointSliceList, initEndpointSetWithCalculatedSize)
+}
@@ -0,0 +1,17 @@
+//go:build sorted_endpoints
+
+package resolver
+
+import (
+	v1 "k8s.io/api/core/v1"
+	discoveryV1 "k8s.io/api/discovery/v1"
+)
+
+// resolveServiceEndpoints resolves the endpoints of the Service port, deduplicating them with a sorted slice.
+func resolveServiceEndpoints(
+	svc *v1.Service,
+	port int32,
+	endpointSliceList discoveryV1.EndpointSliceList,
+) ([]Endpoint, error) {
+	return resolveEndpointsSorted(svc, port, endpointSliceList)
+}
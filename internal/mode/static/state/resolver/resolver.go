@@ -4,16 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
+	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
 	discoveryV1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/controller/index"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . ServiceResolver
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . Resolver
 
 // ServiceResolver resolves a Service and Service Port to a list of Endpoints.
 // Returns an error if the Service or Service Port cannot be resolved.
@@ -21,22 +25,64 @@ type ServiceResolver interface {
 	Resolve(ctx context.Context, svc *v1.Service, svcPort int32) ([]Endpoint, error)
 }
 
+// Resolver resolves a Service port to a list of Endpoints using the EndpointSlices of the Service.
+// Returns an error if the Service port cannot be resolved.
+type Resolver interface {
+	Resolve(svc *v1.Service, port int32, slices discoveryV1.EndpointSliceList) ([]Endpoint, error)
+}
+
 // Endpoint is the internal representation of a Kubernetes endpoint.
 type Endpoint struct {
 	// Address is the IP address of the endpoint.
 	Address string
+	// Zone is the zone of the node of the endpoint, which comes from the topology.kubernetes.io/zone label of
+	// the node. It is empty if the zone is unknown.
+	Zone string
 	// Port is the port of the endpoint.
 	Port int32
 }
 
+// ServiceResolverConfig holds configuration parameters for ServiceResolverImpl.
+type ServiceResolverConfig struct {
+	// Client is a Kubernetes API client.
+	Client client.Client
+	// Logger is the logger.
+	Logger logr.Logger
+	// NodeName is the name of the node of this Pod. If not empty, the endpoints of the Services with
+	// the Local external traffic policy are limited to the endpoints on this node.
+	NodeName string
+}
+
 // ServiceResolverImpl implements ServiceResolver.
 type ServiceResolverImpl struct {
-	client client.Client
+	resolver Resolver
+	cfg      ServiceResolverConfig
 }
 
 // NewServiceResolverImpl creates a new instance of a ServiceResolverImpl.
-func NewServiceResolverImpl(client client.Client) *ServiceResolverImpl {
-	return &ServiceResolverImpl{client: client}
+func NewServiceResolverImpl(cfg ServiceResolverConfig) *ServiceResolverImpl {
+	return &ServiceResolverImpl{
+		resolver: NewResolver(),
+		cfg:      cfg,
+	}
+}
+
+// resolverImpl implements Resolver.
+type resolverImpl struct{}
+
+// NewResolver creates a new Resolver.
+func NewResolver() Resolver {
+	return resolverImpl{}
+}
+
+// Resolve resolves a Service port to a list of Endpoints using the EndpointSlices of the Service.
+// Returns an error if the Service port cannot be resolved.
+func (resolverImpl) Resolve(
+	svc *v1.Service,
+	port int32,
+	slices discoveryV1.EndpointSliceList,
+) ([]Endpoint, error) {
+	return resolveServiceEndpoints(svc, port, slices)
 }
 
 // Resolve resolves a Service and Port to a list of Endpoints.
@@ -49,7 +95,7 @@ func (e *ServiceResolverImpl) Resolve(ctx context.Context, svc *v1.Service, port
 	// We list EndpointSlices using the Service Name Index Field we added as an index to the EndpointSlice cache.
 	// This allows us to perform a quick lookup of all EndpointSlices for a Service.
 	var endpointSliceList discoveryV1.EndpointSliceList
-	err := e.client.List(
+	err := e.cfg.Client.List(
 		ctx,
 		&endpointSliceList,
 		client.MatchingFields{index.KubernetesServiceNameIndexField: svc.Name},
@@ -60,7 +106,62 @@ func (e *ServiceResolverImpl) Resolve(ctx context.Context, svc *v1.Service, port
 		return nil, fmt.Errorf("no endpoints found for Service %s", client.ObjectKeyFromObject(svc))
 	}
 
-	return resolveEndpoints(svc, port, endpointSliceList, initEndpointSetWithCalculatedSize)
+	return e.selectEndpoints(svc, port, endpointSliceList)
+}
+
+// selectEndpoints resolves the endpoints of the Service port. If the Service has the Local external traffic policy,
+// it only selects the endpoints on the node of this Pod, so that the traffic doesn't take an extra hop to another
+// node. If there are no such endpoints, it falls back to the endpoints on all nodes.
+func (e *ServiceResolverImpl) selectEndpoints(
+	svc *v1.Service,
+	port int32,
+	endpointSliceList discoveryV1.EndpointSliceList,
+) ([]Endpoint, error) {
+	if e.cfg.NodeName == "" || svc.Spec.ExternalTrafficPolicy != v1.ServiceExternalTrafficPolicyLocal {
+		return e.resolver.Resolve(svc, port, endpointSliceList)
+	}
+
+	nodeEndpointSliceList := filterEndpointsForNode(endpointSliceList, e.cfg.NodeName)
+
+	endpoints, err := e.resolver.Resolve(svc, port, nodeEndpointSliceList)
+	if err == nil && len(endpoints) > 0 {
+		return endpoints, nil
+	}
+
+	e.cfg.Logger.Info(
+		"No ready endpoints on the node for the Service with the Local external traffic policy, "+
+			"using the endpoints on all nodes",
+		"service", client.ObjectKeyFromObject(svc),
+		"node", e.cfg.NodeName,
+	)
+
+	return e.resolver.Resolve(svc, port, endpointSliceList)
+}
+
+// filterEndpointsForNode returns a copy of the EndpointSliceList, in which the EndpointSlices only include
+// the endpoints on the node. The endpoints without a node name are excluded.
+func filterEndpointsForNode(
+	endpointSliceList discoveryV1.EndpointSliceList,
+	nodeName string,
+) discoveryV1.EndpointSliceList {
+	filtered := discoveryV1.EndpointSliceList{
+		Items: make([]discoveryV1.EndpointSlice, 0, len(endpointSliceList.Items)),
+	}
+
+	for _, endpointSlice := range endpointSliceList.Items {
+		endpoints := make([]discoveryV1.Endpoint, 0, len(endpointSlice.Endpoints))
+
+		for _, endpoint := range endpointSlice.Endpoints {
+			if endpoint.NodeName != nil && *endpoint.NodeName == nodeName {
+				endpoints = append(endpoints, endpoint)
+			}
+		}
+
+		endpointSlice.Endpoints = endpoints
+		filtered.Items = append(filtered.Items, endpointSlice)
+	}
+
+	return filtered
 }
 
 type initEndpointSetFunc func([]discoveryV1.EndpointSlice) map[Endpoint]struct{}
@@ -87,6 +188,11 @@ func calculateReadyEndpoints(endpointSlices []discoveryV1.EndpointSlice) int {
 	return total
 }
 
+// resolveEndpoints resolves the endpoints of the Service port, deduplicating them with a set.
+//
+// Thread-safety: resolveEndpoints is safe to call concurrently. Every call gets its own set from initEndpointsSet
+// and doesn't share it with other calls, so the set is not locked. initEndpointsSet must return a new set on every
+// call. The Service and the EndpointSliceList are only read, so the callers must not modify them during the call.
 func resolveEndpoints(
 	svc *v1.Service,
 	port int32,
@@ -120,8 +226,10 @@ func resolveEndpoints(
 			// that have a matching port.
 			endpointPort := findPort(eps.Ports, svcPort)
 
+			zone := getZone(endpoint)
+
 			for _, address := range endpoint.Addresses {
-				ep := Endpoint{Address: address, Port: endpointPort}
+				ep := Endpoint{Address: address, Port: endpointPort, Zone: zone}
 				endpointSet[ep] = struct{}{}
 			}
 		}
@@ -135,6 +243,68 @@ func resolveEndpoints(
 	return endpoints, nil
 }
 
+// resolveEndpointsSorted is an alternative to resolveEndpoints that deduplicates the endpoints using a sorted slice
+// instead of a set. It makes fewer heap allocations, which reduces GC pressure. Unlike resolveEndpoints,
+// it returns the endpoints sorted by address and port.
+func resolveEndpointsSorted(
+	svc *v1.Service,
+	port int32,
+	endpointSliceList discoveryV1.EndpointSliceList,
+) ([]Endpoint, error) {
+	svcPort, err := getServicePort(svc, port)
+	if err != nil {
+		return nil, err
+	}
+
+	filteredSlices := filterEndpointSliceList(endpointSliceList, svcPort)
+
+	if len(filteredSlices) == 0 {
+		svcNsName := client.ObjectKeyFromObject(svc)
+		return nil, fmt.Errorf("no valid endpoints found for Service %s and port %+v", svcNsName, svcPort)
+	}
+
+	endpoints := make([]Endpoint, 0, calculateReadyEndpoints(filteredSlices))
+
+	for _, eps := range filteredSlices {
+		for _, endpoint := range eps.Endpoints {
+
+			if !endpointReady(endpoint) {
+				continue
+			}
+
+			// We don't check for a zero port value here because we are only working with EndpointSlices
+			// that have a matching port.
+			endpointPort := findPort(eps.Ports, svcPort)
+
+			zone := getZone(endpoint)
+
+			for _, address := range endpoint.Addresses {
+				endpoints = append(endpoints, Endpoint{Address: address, Port: endpointPort, Zone: zone})
+			}
+		}
+	}
+
+	// Endpoints may be duplicated across multiple EndpointSlices.
+	// After sorting, the duplicates are adjacent, so we remove them in place.
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Address != endpoints[j].Address {
+			return endpoints[i].Address < endpoints[j].Address
+		}
+		return endpoints[i].Port < endpoints[j].Port
+	})
+
+	unique := 0
+	for i := range endpoints {
+		if i > 0 && endpoints[i] == endpoints[unique-1] {
+			continue
+		}
+		endpoints[unique] = endpoints[i]
+		unique++
+	}
+
+	return endpoints[:unique], nil
+}
+
 func getServicePort(svc *v1.Service, port int32) (v1.ServicePort, error) {
 	for _, p := range svc.Spec.Ports {
 		if p.Port == port {
@@ -169,15 +339,41 @@ func ignoreEndpointSlice(endpointSlice discoveryV1.EndpointSlice, port v1.Servic
 	return findPort(endpointSlice.Ports, port) == 0
 }
 
+// endpointReady returns true if the endpoint is ready to receive traffic.
+// Some controllers only set the Serving condition, for example, during rolling upgrades. So if the Ready condition
+// is unknown (nil), an endpoint is ready if it is serving and not terminating, which is how Kubernetes defines
+// readiness. If both Ready and Serving are unknown, the endpoint is not ready.
 func endpointReady(endpoint discoveryV1.Endpoint) bool {
-	ready := endpoint.Conditions.Ready
-	return ready != nil && *ready
+	conds := endpoint.Conditions
+
+	if conds.Ready != nil {
+		return *conds.Ready
+	}
+
+	serving := conds.Serving != nil && *conds.Serving
+	terminating := conds.Terminating != nil && *conds.Terminating
+
+	return serving && !terminating
+}
+
+// getZone returns the zone of the endpoint. The EndpointSlice controller sets it from the topology.kubernetes.io/zone
+// label of the node of the endpoint. It returns an empty string if the zone is not set or is not a valid label value.
+func getZone(endpoint discoveryV1.Endpoint) string {
+	if endpoint.Zone == nil || len(validation.IsValidLabelValue(*endpoint.Zone)) > 0 {
+		return ""
+	}
+
+	return *endpoint.Zone
 }
 
 func filterEndpointSliceList(
 	endpointSliceList discoveryV1.EndpointSliceList,
 	port v1.ServicePort,
 ) []discoveryV1.EndpointSlice {
+	if len(endpointSliceList.Items) == 0 {
+		return nil
+	}
+
 	filtered := make([]discoveryV1.EndpointSlice, 0, len(endpointSliceList.Items))
 
 	for _, endpointSlice := range endpointSliceList.Items {
@@ -189,18 +385,34 @@ func filterEndpointSliceList(
 	return filtered
 }
 
-// findPort locates the port in the slice of EndpointPort that matches the ServicePort name.
+// findPort locates the port in the slice of EndpointPort that matches the ServicePort name and protocol.
 // The Kubernetes EndpointSlice controller handles matching the TargetPort of a ServicePort to the container port of
 // an endpoint. All we have to do is find the port with the same name as the ServicePort.
 // If a ServicePort is unnamed, then the EndpointPort will also be unnamed (empty string).
+// EndpointPorts with a different protocol, for example, UDP for a TCP ServicePort, are skipped.
 //
 // If an EndpointPort port is nil -- indicating all ports are valid --
 // the default port for the ServicePort is returned.
+// If more than one EndpointPort has a nil name, the name is required to tell them apart, so 0 is returned.
 // If no matching port is found, 0 is returned.
 func findPort(ports []discoveryV1.EndpointPort, svcPort v1.ServicePort) int32 {
 	portName := svcPort.Name
 
+	nilNamedPorts := 0
 	for _, p := range ports {
+		if p.Name == nil && protocolMatches(p, svcPort) {
+			nilNamedPorts++
+		}
+	}
+
+	if nilNamedPorts > 1 {
+		return 0
+	}
+
+	for _, p := range ports {
+		if !protocolMatches(p, svcPort) {
+			continue
+		}
 
 		if p.Port == nil {
 			return getDefaultPort(svcPort)
@@ -213,3 +425,19 @@ func findPort(ports []discoveryV1.EndpointPort, svcPort v1.ServicePort) int32 {
 
 	return 0
 }
+
+// protocolMatches returns true if the EndpointPort has the same protocol as the ServicePort.
+// For both, an unset protocol means TCP.
+func protocolMatches(port discoveryV1.EndpointPort, svcPort v1.ServicePort) bool {
+	portProtocol := v1.ProtocolTCP
+	if port.Protocol != nil {
+		portProtocol = *port.Protocol
+	}
+
+	svcProtocol := svcPort.Protocol
+	if svcProtocol == "" {
+		svcProtocol = v1.ProtocolTCP
+	}
+
+	return portProtocol == svcProtocol
+}
@@ -2,8 +2,11 @@ package resolver
 
 import (
 	"fmt"
+	"net"
+	"sync"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
@@ -20,12 +23,12 @@ var (
 
 	readyEndpoint1 = discoveryV1.Endpoint{
 		Addresses:  addresses,
-		Conditions: discoveryV1.EndpointConditions{Ready: helpers.GetBoolPointer(true)},
+		Conditions: discoveryV1.EndpointConditions{Ready: helpers.GetPointer(true)},
 	}
 
 	notReadyEndpoint = discoveryV1.Endpoint{
 		Addresses:  addresses,
-		Conditions: discoveryV1.EndpointConditions{Ready: helpers.GetBoolPointer(false)},
+		Conditions: discoveryV1.EndpointConditions{Ready: helpers.GetPointer(false)},
 	}
 
 	mixedValidityEndpointSlice = discoveryV1.EndpointSlice{
@@ -34,7 +37,7 @@ var (
 		Ports: []discoveryV1.EndpointPort{
 			{
 				Name: &svcPortName,
-				Port: helpers.GetInt32Pointer(80),
+				Port: helpers.GetPointer[int32](80),
 			},
 		},
 	}
@@ -54,7 +57,7 @@ var (
 		Ports: []discoveryV1.EndpointPort{
 			{
 				Name: &svcPortName,
-				Port: helpers.GetInt32Pointer(80),
+				Port: helpers.GetPointer[int32](80),
 			},
 		},
 	}
@@ -65,7 +68,7 @@ var (
 		Ports: []discoveryV1.EndpointPort{
 			{
 				Name: &svcPortName,
-				Port: helpers.GetInt32Pointer(80),
+				Port: helpers.GetPointer[int32](80),
 			},
 		},
 	}
@@ -75,13 +78,45 @@ var (
 		Endpoints:   []discoveryV1.Endpoint{readyEndpoint1},
 		Ports: []discoveryV1.EndpointPort{
 			{
-				Name: helpers.GetStringPointer("other-svc-port"),
-				Port: helpers.GetInt32Pointer(8080),
+				Name: helpers.GetPointer("other-svc-port"),
+				Port: helpers.GetPointer[int32](8080),
 			},
 		},
 	}
 )
 
+func TestGetZone(t *testing.T) {
+	tests := []struct {
+		zone    *string
+		msg     string
+		expZone string
+	}{
+		{
+			msg:     "zone is set",
+			zone:    helpers.GetPointer("us-east-1a"),
+			expZone: "us-east-1a",
+		},
+		{
+			msg:     "zone is not set",
+			zone:    nil,
+			expZone: "",
+		},
+		{
+			msg:     "zone is not a valid label value",
+			zone:    helpers.GetPointer("us-east-1a\nserver 10.0.0.1"),
+			expZone: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.msg, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			g.Expect(getZone(discoveryV1.Endpoint{Zone: tc.zone})).To(Equal(tc.expZone))
+		})
+	}
+}
+
 func TestFilterEndpointSliceList(t *testing.T) {
 	sliceList := discoveryV1.EndpointSliceList{
 		Items: []discoveryV1.EndpointSlice{
@@ -107,6 +142,17 @@ func TestFilterEndpointSliceList(t *testing.T) {
 	}
 }
 
+func TestFilterEndpointSliceListEmpty(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	svcPort := v1.ServicePort{
+		Name: svcPortName,
+		Port: 80,
+	}
+
+	g.Expect(filterEndpointSliceList(discoveryV1.EndpointSliceList{}, svcPort)).To(BeNil())
+}
+
 func TestGetServicePort(t *testing.T) {
 	svc := &v1.Service{
 		Spec: v1.ServiceSpec{
@@ -186,6 +232,7 @@ func TestGetDefaultPort(t *testing.T) {
 
 func TestIgnoreEndpointSlice(t *testing.T) {
 	var (
+		port53   int32 = 53
 		port4000 int32 = 4000
 		port8080 int32 = 8080
 	)
@@ -238,7 +285,7 @@ func TestIgnoreEndpointSlice(t *testing.T) {
 				AddressType: discoveryV1.AddressTypeIPv4,
 				Ports: []discoveryV1.EndpointPort{
 					{
-						Name: helpers.GetStringPointer("other-svc-port"),
+						Name: helpers.GetPointer("other-svc-port"),
 						Port: &port4000,
 					},
 				},
@@ -285,6 +332,98 @@ func TestIgnoreEndpointSlice(t *testing.T) {
 			},
 			ignore: false,
 		},
+		{
+			msg: "TCP endpoint port for UDP service port",
+			slice: discoveryV1.EndpointSlice{
+				AddressType: discoveryV1.AddressTypeIPv4,
+				Ports: []discoveryV1.EndpointPort{
+					{
+						Name:     &svcPortName,
+						Port:     &port53,
+						Protocol: helpers.GetPointer(v1.ProtocolTCP),
+					},
+				},
+			},
+			servicePort: v1.ServicePort{
+				Name:     svcPortName,
+				Port:     53,
+				Protocol: v1.ProtocolUDP,
+			},
+			ignore: true,
+		},
+		{
+			msg: "UDP endpoint port for TCP service port",
+			slice: discoveryV1.EndpointSlice{
+				AddressType: discoveryV1.AddressTypeIPv4,
+				Ports: []discoveryV1.EndpointPort{
+					{
+						Name:     &svcPortName,
+						Port:     &port53,
+						Protocol: helpers.GetPointer(v1.ProtocolUDP),
+					},
+				},
+			},
+			servicePort: v1.ServicePort{
+				Name:     svcPortName,
+				Port:     53,
+				Protocol: v1.ProtocolTCP,
+			},
+			ignore: true,
+		},
+		{
+			msg: "UDP endpoint port for UDP service port",
+			slice: discoveryV1.EndpointSlice{
+				AddressType: discoveryV1.AddressTypeIPv4,
+				Ports: []discoveryV1.EndpointPort{
+					{
+						Name:     &svcPortName,
+						Port:     &port53,
+						Protocol: helpers.GetPointer(v1.ProtocolUDP),
+					},
+				},
+			},
+			servicePort: v1.ServicePort{
+				Name:     svcPortName,
+				Port:     53,
+				Protocol: v1.ProtocolUDP,
+			},
+			ignore: false,
+		},
+		{
+			msg: "unset endpoint port protocol for unset service port protocol",
+			slice: discoveryV1.EndpointSlice{
+				AddressType: discoveryV1.AddressTypeIPv4,
+				Ports: []discoveryV1.EndpointPort{
+					{
+						Name: &svcPortName,
+						Port: &port53,
+					},
+				},
+			},
+			servicePort: v1.ServicePort{
+				Name: svcPortName,
+				Port: 53,
+			},
+			ignore: false,
+		},
+		{
+			msg: "unset endpoint port protocol for UDP service port",
+			slice: discoveryV1.EndpointSlice{
+				AddressType: discoveryV1.AddressTypeIPv4,
+				Ports: []discoveryV1.EndpointPort{
+					{
+						Name: &svcPortName,
+						Port: &port53,
+					},
+				},
+			},
+			servicePort: v1.ServicePort{
+				Name:     svcPortName,
+				Port:     53,
+				Protocol: v1.ProtocolUDP,
+			},
+			ignore: true,
+		},
 	}
 	for _, tc := range testcases {
 		if ignoreEndpointSlice(tc.slice, tc.servicePort) != tc.ignore {
@@ -303,7 +442,7 @@ func TestEndpointReady(t *testing.T) {
 			msg: "endpoint ready",
 			endpoint: discoveryV1.Endpoint{
 				Conditions: discoveryV1.EndpointConditions{
-					Ready: helpers.GetBoolPointer(true),
+					Ready: helpers.GetPointer(true),
 				},
 			},
 			ready: true,
@@ -321,7 +460,48 @@ func TestEndpointReady(t *testing.T) {
 			msg: "endpoint not ready",
 			endpoint: discoveryV1.Endpoint{
 				Conditions: discoveryV1.EndpointConditions{
-					Ready: helpers.GetBoolPointer(false),
+					Ready: helpers.GetPointer(false),
+				},
+			},
+			ready: false,
+		},
+		{
+			msg: "nil ready and serving",
+			endpoint: discoveryV1.Endpoint{
+				Conditions: discoveryV1.EndpointConditions{
+					Ready:   nil,
+					Serving: helpers.GetPointer(true),
+				},
+			},
+			ready: true,
+		},
+		{
+			msg: "nil ready and not serving",
+			endpoint: discoveryV1.Endpoint{
+				Conditions: discoveryV1.EndpointConditions{
+					Ready:   nil,
+					Serving: helpers.GetPointer(false),
+				},
+			},
+			ready: false,
+		},
+		{
+			msg: "nil ready, serving and terminating",
+			endpoint: discoveryV1.Endpoint{
+				Conditions: discoveryV1.EndpointConditions{
+					Ready:       nil,
+					Serving:     helpers.GetPointer(true),
+					Terminating: helpers.GetPointer(true),
+				},
+			},
+			ready: false,
+		},
+		{
+			msg: "not ready but serving",
+			endpoint: discoveryV1.Endpoint{
+				Conditions: discoveryV1.EndpointConditions{
+					Ready:   helpers.GetPointer(false),
+					Serving: helpers.GetPointer(true),
 				},
 			},
 			ready: false,
@@ -387,7 +567,7 @@ func TestFindPort(t *testing.T) {
 			ports: []discoveryV1.EndpointPort{
 				{
 					Name: nil,
-					Port: helpers.GetInt32Pointer(8080),
+					Port: helpers.GetPointer[int32](8080),
 				},
 			},
 			svcPort: v1.ServicePort{
@@ -397,20 +577,39 @@ func TestFindPort(t *testing.T) {
 			},
 			expPort: 0,
 		},
+		{
+			msg: "multiple nil endpoint port names",
+			ports: []discoveryV1.EndpointPort{
+				{
+					Name: nil,
+					Port: nil,
+				},
+				{
+					Name: nil,
+					Port: helpers.GetPointer[int32](8081),
+				},
+			},
+			svcPort: v1.ServicePort{
+				Port:       80,
+				TargetPort: intstr.FromInt(8080),
+				Name:       svcPortName,
+			},
+			expPort: 0,
+		},
 		{
 			msg: "no matching endpoint name",
 			ports: []discoveryV1.EndpointPort{
 				{
-					Name: helpers.GetStringPointer("other-svc-port"),
-					Port: helpers.GetInt32Pointer(8080),
+					Name: helpers.GetPointer("other-svc-port"),
+					Port: helpers.GetPointer[int32](8080),
 				},
 				{
-					Name: helpers.GetStringPointer("other-svc-port2"),
-					Port: helpers.GetInt32Pointer(8081),
+					Name: helpers.GetPointer("other-svc-port2"),
+					Port: helpers.GetPointer[int32](8081),
 				},
 				{
-					Name: helpers.GetStringPointer("other-svc-port3"),
-					Port: helpers.GetInt32Pointer(8082),
+					Name: helpers.GetPointer("other-svc-port3"),
+					Port: helpers.GetPointer[int32](8082),
 				},
 			},
 			svcPort: v1.ServicePort{
@@ -424,16 +623,16 @@ func TestFindPort(t *testing.T) {
 			msg: "matching endpoint name",
 			ports: []discoveryV1.EndpointPort{
 				{
-					Name: helpers.GetStringPointer("other-svc-port"),
-					Port: helpers.GetInt32Pointer(8080),
+					Name: helpers.GetPointer("other-svc-port"),
+					Port: helpers.GetPointer[int32](8080),
 				},
 				{
-					Name: helpers.GetStringPointer("other-svc-port2"),
-					Port: helpers.GetInt32Pointer(8081),
+					Name: helpers.GetPointer("other-svc-port2"),
+					Port: helpers.GetPointer[int32](8081),
 				},
 				{
 					Name: &svcPortName, // match
-					Port: helpers.GetInt32Pointer(8082),
+					Port: helpers.GetPointer[int32](8082),
 				},
 			},
 			svcPort: v1.ServicePort{
@@ -448,8 +647,8 @@ func TestFindPort(t *testing.T) {
 			ports: []discoveryV1.EndpointPort{
 				{
 					// If a service port is unnamed (empty string), then the endpoint port will also be empty string.
-					Name: helpers.GetStringPointer(""),
-					Port: helpers.GetInt32Pointer(8080),
+					Name: helpers.GetPointer(""),
+					Port: helpers.GetPointer[int32](8080),
 				},
 			},
 			svcPort: v1.ServicePort{
@@ -482,7 +681,7 @@ func TestCalculateReadyEndpoints(t *testing.T) {
 				{
 					Addresses: []string{"1.0.0.1"},
 					Conditions: discoveryV1.EndpointConditions{
-						Ready: helpers.GetBoolPointer(true),
+						Ready: helpers.GetPointer(true),
 					},
 				},
 				{
@@ -498,7 +697,20 @@ func TestCalculateReadyEndpoints(t *testing.T) {
 				{
 					Addresses: []string{"2.0.0.1", "2.0.0.2", "2.0.0.3"},
 					Conditions: discoveryV1.EndpointConditions{
-						Ready: helpers.GetBoolPointer(true),
+						Ready: helpers.GetPointer(true),
+					},
+				},
+				{
+					Addresses: []string{"2.1.0.1", "2.1.0.2"},
+					Conditions: discoveryV1.EndpointConditions{
+						// the serving condition is used if the ready condition is unknown
+						Serving: helpers.GetPointer(true),
+					},
+				},
+				{
+					Addresses: []string{"2.2.0.1"},
+					Conditions: discoveryV1.EndpointConditions{
+						Serving: helpers.GetPointer(false),
 					},
 				},
 			},
@@ -507,14 +719,124 @@ func TestCalculateReadyEndpoints(t *testing.T) {
 
 	result := calculateReadyEndpoints(slices)
 
-	g.Expect(result).To(Equal(4))
+	g.Expect(result).To(Equal(6))
+}
+
+func TestResolveEndpointsSorted(t *testing.T) {
+	svc := &v1.Service{
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{
+				{
+					Name: svcPortName,
+					Port: 80,
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		msg          string
+		expEndpoints []Endpoint
+		list         discoveryV1.EndpointSliceList
+		port         int32
+		expErr       bool
+	}{
+		{
+			msg: "duplicate endpoints across slices",
+			list: discoveryV1.EndpointSliceList{
+				Items: []discoveryV1.EndpointSlice{
+					validEndpointSlice,
+					mixedValidityEndpointSlice,
+					invalidAddressTypeEndpointSlice,
+				},
+			},
+			port: 80,
+			expEndpoints: []Endpoint{
+				{Address: "10.0.0.1", Port: 80},
+				{Address: "10.0.0.2", Port: 80},
+				{Address: "10.0.0.3", Port: 80},
+			},
+		},
+		{
+			msg: "endpoints with and without zones",
+			list: discoveryV1.EndpointSliceList{
+				Items: []discoveryV1.EndpointSlice{
+					{
+						AddressType: discoveryV1.AddressTypeIPv4,
+						Endpoints: []discoveryV1.Endpoint{
+							{
+								Addresses:  []string{"10.0.0.2"},
+								Conditions: discoveryV1.EndpointConditions{Ready: helpers.GetPointer(true)},
+								Zone:       helpers.GetPointer("us-east-1b"),
+							},
+							{
+								Addresses:  []string{"10.0.0.1"},
+								Conditions: discoveryV1.EndpointConditions{Ready: helpers.GetPointer(true)},
+							},
+						},
+						Ports: []discoveryV1.EndpointPort{
+							{
+								Name: &svcPortName,
+								Port: helpers.GetPointer[int32](80),
+							},
+						},
+					},
+				},
+			},
+			port: 80,
+			expEndpoints: []Endpoint{
+				{Address: "10.0.0.1", Port: 80},
+				{Address: "10.0.0.2", Port: 80, Zone: "us-east-1b"},
+			},
+		},
+		{
+			msg: "no matching service port",
+			list: discoveryV1.EndpointSliceList{
+				Items: []discoveryV1.EndpointSlice{validEndpointSlice},
+			},
+			port:   8080,
+			expErr: true,
+		},
+		{
+			msg: "no valid endpoint slices",
+			list: discoveryV1.EndpointSliceList{
+				Items: []discoveryV1.EndpointSlice{invalidAddressTypeEndpointSlice, invalidPortEndpointSlice},
+			},
+			port:   80,
+			expErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.msg, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			endpoints, err := resolveEndpointsSorted(svc, tc.port, tc.list)
+			if tc.expErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(endpoints).To(Equal(tc.expEndpoints))
+		})
+	}
 }
 
+// generateEndpointSliceList generates n ready endpoints with unique addresses from the 10.0.0.0/8 range.
+// The address of the jth endpoint of the ith slice is 10.<i/256>.<i%256>.<j>.
 func generateEndpointSliceList(n int) discoveryV1.EndpointSliceList {
-	const maxEndpointsPerSlice = 100 // use the Kubernetes default max for endpoints in a slice.
+	const (
+		maxEndpointsPerSlice = 100 // use the Kubernetes default max for endpoints in a slice.
+		maxSlices            = 256 * 256
+	)
 
 	slicesCount := (n + maxEndpointsPerSlice - 1) / maxEndpointsPerSlice
 
+	if slicesCount > maxSlices {
+		panic(fmt.Sprintf("cannot generate %d endpoints with unique addresses in 10.0.0.0/8", n))
+	}
+
 	result := discoveryV1.EndpointSliceList{
 		Items: make([]discoveryV1.EndpointSlice, 0, slicesCount),
 	}
@@ -540,7 +862,7 @@ func generateEndpointSliceList(n int) discoveryV1.EndpointSliceList {
 
 		for j := 0; j < c; j++ {
 			slice.Endpoints[j] = discoveryV1.Endpoint{
-				Addresses: []string{fmt.Sprintf("10.0.%d.%d", i, j)},
+				Addresses: []string{fmt.Sprintf("10.%d.%d.%d", i/256, i%256, j)},
 				Conditions: discoveryV1.EndpointConditions{
 					Ready: &ready,
 				},
@@ -553,6 +875,225 @@ func generateEndpointSliceList(n int) discoveryV1.EndpointSliceList {
 	return result
 }
 
+func TestResolver(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	svc := &v1.Service{
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{
+				{
+					Name: svcPortName,
+					Port: 80,
+				},
+			},
+		},
+	}
+
+	list := discoveryV1.EndpointSliceList{
+		Items: []discoveryV1.EndpointSlice{validEndpointSlice},
+	}
+
+	r := NewResolver()
+
+	endpoints, err := r.Resolve(svc, 80, list)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(endpoints).To(ConsistOf(
+		Endpoint{Address: "10.0.0.1", Port: 80},
+		Endpoint{Address: "10.0.0.2", Port: 80},
+		Endpoint{Address: "10.0.0.3", Port: 80},
+	))
+
+	endpoints, err = r.Resolve(svc, 8080, list)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(endpoints).To(BeNil())
+}
+
+func TestSelectEndpoints(t *testing.T) {
+	createSvc := func(policy v1.ServiceExternalTrafficPolicy) *v1.Service {
+		return &v1.Service{
+			Spec: v1.ServiceSpec{
+				Ports: []v1.ServicePort{
+					{
+						Name: svcPortName,
+						Port: 80,
+					},
+				},
+				ExternalTrafficPolicy: policy,
+			},
+		}
+	}
+
+	list := discoveryV1.EndpointSliceList{
+		Items: []discoveryV1.EndpointSlice{
+			{
+				AddressType: discoveryV1.AddressTypeIPv4,
+				Endpoints: []discoveryV1.Endpoint{
+					{
+						Addresses:  []string{"10.0.0.1"},
+						Conditions: discoveryV1.EndpointConditions{Ready: helpers.GetPointer(true)},
+						NodeName:   helpers.GetPointer("node-1"),
+					},
+					{
+						Addresses:  []string{"10.0.0.2"},
+						Conditions: discoveryV1.EndpointConditions{Ready: helpers.GetPointer(true)},
+						NodeName:   helpers.GetPointer("node-2"),
+					},
+					{
+						Addresses:  []string{"10.0.0.3"},
+						Conditions: discoveryV1.EndpointConditions{Ready: helpers.GetPointer(true)},
+						NodeName:   nil,
+					},
+					{
+						Addresses:  []string{"10.0.0.4"},
+						Conditions: discoveryV1.EndpointConditions{Ready: helpers.GetPointer(false)},
+						NodeName:   helpers.GetPointer("node-3"),
+					},
+				},
+				Ports: []discoveryV1.EndpointPort{
+					{
+						Name: &svcPortName,
+						Port: helpers.GetPointer[int32](80),
+					},
+				},
+			},
+		},
+	}
+
+	allEndpoints := []Endpoint{
+		{Address: "10.0.0.1", Port: 80},
+		{Address: "10.0.0.2", Port: 80},
+		{Address: "10.0.0.3", Port: 80},
+	}
+
+	tests := []struct {
+		svc          *v1.Service
+		msg          string
+		nodeName     string
+		expEndpoints []Endpoint
+	}{
+		{
+			msg:          "no node filter",
+			svc:          createSvc(v1.ServiceExternalTrafficPolicyLocal),
+			nodeName:     "",
+			expEndpoints: allEndpoints,
+		},
+		{
+			msg:          "cluster external traffic policy",
+			svc:          createSvc(v1.ServiceExternalTrafficPolicyCluster),
+			nodeName:     "node-1",
+			expEndpoints: allEndpoints,
+		},
+		{
+			msg:      "matching node",
+			svc:      createSvc(v1.ServiceExternalTrafficPolicyLocal),
+			nodeName: "node-2",
+			expEndpoints: []Endpoint{
+				{Address: "10.0.0.2", Port: 80},
+			},
+		},
+		{
+			msg:          "non-matching node",
+			svc:          createSvc(v1.ServiceExternalTrafficPolicyLocal),
+			nodeName:     "node-4",
+			expEndpoints: allEndpoints,
+		},
+		{
+			msg:          "only not ready endpoints on the node",
+			svc:          createSvc(v1.ServiceExternalTrafficPolicyLocal),
+			nodeName:     "node-3",
+			expEndpoints: allEndpoints,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.msg, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+
+			serviceResolver := NewServiceResolverImpl(ServiceResolverConfig{
+				Logger:   logr.Discard(),
+				NodeName: tc.nodeName,
+			})
+
+			endpoints, err := serviceResolver.selectEndpoints(tc.svc, 80, list)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(endpoints).To(ConsistOf(tc.expEndpoints))
+		})
+	}
+}
+
+func TestFilterEndpointsForNode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	list := discoveryV1.EndpointSliceList{
+		Items: []discoveryV1.EndpointSlice{
+			{
+				Endpoints: []discoveryV1.Endpoint{
+					{
+						Addresses: []string{"10.0.0.1"},
+						NodeName:  helpers.GetPointer("node-1"),
+					},
+					{
+						Addresses: []string{"10.0.0.2"},
+						NodeName:  helpers.GetPointer("node-2"),
+					},
+					{
+						// endpoints without a node name are excluded
+						Addresses: []string{"10.0.0.3"},
+					},
+				},
+			},
+			{
+				Endpoints: []discoveryV1.Endpoint{
+					{
+						Addresses: []string{"10.0.1.1"},
+						NodeName:  helpers.GetPointer("node-2"),
+					},
+				},
+			},
+		},
+	}
+
+	expected := discoveryV1.EndpointSliceList{
+		Items: []discoveryV1.EndpointSlice{
+			{
+				Endpoints: []discoveryV1.Endpoint{
+					{
+						Addresses: []string{"10.0.0.1"},
+						NodeName:  helpers.GetPointer("node-1"),
+					},
+				},
+			},
+			{
+				Endpoints: []discoveryV1.Endpoint{},
+			},
+		},
+	}
+
+	g.Expect(filterEndpointsForNode(list, "node-1")).To(Equal(expected))
+	// the original list is not modified
+	g.Expect(list.Items[0].Endpoints).To(HaveLen(3))
+}
+
+func TestGenerateEndpointSliceList(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	const n = 10000
+
+	list := generateEndpointSliceList(n)
+
+	addresses := make(map[string]struct{}, n)
+	for _, slice := range list.Items {
+		for _, endpoint := range slice.Endpoints {
+			for _, address := range endpoint.Addresses {
+				g.Expect(net.ParseIP(address)).ToNot(BeNil(), address)
+				addresses[address] = struct{}{}
+			}
+		}
+	}
+
+	g.Expect(addresses).To(HaveLen(n))
+}
+
 func BenchmarkResolve(b *testing.B) {
 	counts := []int{
 		1,
@@ -564,6 +1105,8 @@ func BenchmarkResolve(b *testing.B) {
 		100,
 		500,
 		1000,
+		5000,
+		10000,
 	}
 
 	svc := &v1.Service{
@@ -589,6 +1132,33 @@ func BenchmarkResolve(b *testing.B) {
 		b.Run(fmt.Sprintf("%d endpoints with optimization", count), func(b *testing.B) {
 			bench(b, svc, list, initEndpointSetWithCalculatedSize, count)
 		})
+		b.Run(fmt.Sprintf("%d endpoints with sorted slice", count), func(b *testing.B) {
+			benchSorted(b, svc, list, count)
+		})
+	}
+}
+
+func BenchmarkFilterEndpointSliceList(b *testing.B) {
+	counts := []int{
+		0,
+		100,
+		1000,
+	}
+
+	svcPort := v1.ServicePort{
+		Port: 80,
+	}
+
+	for _, count := range counts {
+		list := generateEndpointSliceList(count)
+
+		b.Run(fmt.Sprintf("%d endpoints", count), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				filterEndpointSliceList(list, svcPort)
+			}
+		})
 	}
 }
 
@@ -603,3 +1173,73 @@ func bench(b *testing.B, svc *v1.Service, list discoveryV1.EndpointSliceList, in
 		}
 	}
 }
+
+func benchSorted(b *testing.B, svc *v1.Service, list discoveryV1.EndpointSliceList, n int) {
+	for i := 0; i < b.N; i++ {
+		res, err := resolveEndpointsSorted(svc, 80, list)
+		if len(res) != n {
+			b.Fatalf("expected %d endpoints, got %d", n, len(res))
+		}
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// lockedEndpointSet is a set of endpoints protected by a mutex, which can be shared between goroutines.
+// resolveEndpoints doesn't share its set, so it doesn't need the lock. lockedEndpointSet is only used to measure
+// the overhead of the lock.
+type lockedEndpointSet struct {
+	set map[Endpoint]struct{}
+	mu  sync.Mutex
+}
+
+func initLockedEndpointSetWithCalculatedSize(endpointSlices []discoveryV1.EndpointSlice) *lockedEndpointSet {
+	return &lockedEndpointSet{set: initEndpointSetWithCalculatedSize(endpointSlices)}
+}
+
+func (s *lockedEndpointSet) add(ep Endpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.set[ep] = struct{}{}
+}
+
+func BenchmarkEndpointSetAdd(b *testing.B) {
+	counts := []int{
+		10,
+		100,
+		1000,
+		10000,
+	}
+
+	for _, count := range counts {
+		list := generateEndpointSliceList(count)
+
+		endpoints := make([]Endpoint, 0, count)
+		for _, eps := range list.Items {
+			for _, endpoint := range eps.Endpoints {
+				for _, address := range endpoint.Addresses {
+					endpoints = append(endpoints, Endpoint{Address: address, Port: 80})
+				}
+			}
+		}
+
+		b.Run(fmt.Sprintf("%d endpoints", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				set := initEndpointSetWithCalculatedSize(list.Items)
+				for _, ep := range endpoints {
+					set[ep] = struct{}{}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("%d endpoints with lock", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				set := initLockedEndpointSetWithCalculatedSize(list.Items)
+				for _, ep := range endpoints {
+					set.add(ep)
+				}
+			}
+		})
+	}
+}
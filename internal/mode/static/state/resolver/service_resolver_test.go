@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/controller/index"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
@@ -38,7 +39,7 @@ func createSlice(
 			{
 				Addresses: addresses,
 				Conditions: discoveryV1.EndpointConditions{
-					Ready: helpers.GetBoolPointer(true),
+					Ready: helpers.GetPointer(true),
 				},
 			},
 			{
@@ -48,8 +49,8 @@ func createSlice(
 					"1.0.0.3",
 				}, // these endpoints should be ignored because they are not ready
 				Conditions: discoveryV1.EndpointConditions{
-					Serving:     helpers.GetBoolPointer(true),
-					Terminating: helpers.GetBoolPointer(true),
+					Serving:     helpers.GetPointer(true),
+					Terminating: helpers.GetPointer(true),
 				},
 			},
 			{
@@ -183,7 +184,10 @@ var _ = Describe("ServiceResolver", func() {
 			)
 			Expect(err).ToNot(HaveOccurred())
 
-			serviceResolver = resolver.NewServiceResolverImpl(fakeK8sClient)
+			serviceResolver = resolver.NewServiceResolverImpl(resolver.ServiceResolverConfig{
+				Client: fakeK8sClient,
+				Logger: zap.New(),
+			})
 		})
 		It("resolves a service for a given port", func() {
 			expectedEndpoints := []resolver.Endpoint{
@@ -9,9 +9,43 @@ import (
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/graph"
 	"github.com/nginxinc/nginx-kubernetes-gateway/internal/mode/static/state/relationship"
 )
 
+// configAnnotations are the annotations that affect the configuration of the data plane.
+// Changing an annotation doesn't change the generation of a resource, so they are compared separately.
+var configAnnotations = []string{
+	graph.ClientMaxBodySizeAnnotation,
+	graph.StreamingProxyAnnotation,
+	graph.CaseInsensitiveHeaderValuesAnnotation,
+	graph.RequestIDHeaderAnnotation,
+	graph.TrustedProxiesAnnotation,
+	graph.DNSResolversAnnotation,
+	graph.ErrorPageStatusCodesAnnotation,
+	graph.ErrorPageBodyAnnotation,
+	graph.ErrorPageContentTypeAnnotation,
+	graph.ProxyNextUpstreamAnnotation,
+	graph.ProxyNextUpstreamTriesAnnotation,
+	graph.BackendErrorStatusCodesAnnotation,
+	graph.BackendErrorBodyAnnotation,
+	graph.BackendErrorContentTypeAnnotation,
+	graph.ServerSnippetAnnotation,
+	graph.LocationSnippetAnnotation,
+	graph.WorkerProcessesAnnotation,
+	graph.WorkerConnectionsAnnotation,
+	graph.WorkerShutdownTimeoutAnnotation,
+	graph.OpenFileCacheMaxFilesAnnotation,
+	graph.OpenFileCacheInactiveAnnotation,
+	graph.OpenFileCacheValidAnnotation,
+	graph.GzipAnnotation,
+	graph.GzipMinLengthAnnotation,
+	graph.GzipTypesAnnotation,
+	graph.GzipLevelAnnotation,
+	graph.HideServerVersionAnnotation,
+	graph.UpstreamZoneSizeAnnotation,
+}
+
 // Updater updates the cluster state.
 type Updater interface {
 	Upsert(obj client.Object)
@@ -196,7 +230,23 @@ func (s *changeTrackingUpdater) upsert(obj client.Object) (changed bool) {
 		return false
 	}
 
-	return !exist || obj.GetGeneration() != oldObj.GetGeneration()
+	return !exist || obj.GetGeneration() != oldObj.GetGeneration() || configAnnotationsChanged(oldObj, obj)
+}
+
+func configAnnotationsChanged(oldObj, newObj client.Object) bool {
+	oldAnnotations := oldObj.GetAnnotations()
+	newAnnotations := newObj.GetAnnotations()
+
+	for _, key := range configAnnotations {
+		oldValue, oldExists := oldAnnotations[key]
+		newValue, newExists := newAnnotations[key]
+
+		if oldExists != newExists || oldValue != newValue {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (s *changeTrackingUpdater) Upsert(obj client.Object) {
@@ -54,6 +54,17 @@ type FakeHTTPFieldsValidator struct {
 	validatePathInMatchReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ValidatePathRegexInMatchStub        func(string) error
+	validatePathRegexInMatchMutex       sync.RWMutex
+	validatePathRegexInMatchArgsForCall []struct {
+		arg1 string
+	}
+	validatePathRegexInMatchReturns struct {
+		result1 error
+	}
+	validatePathRegexInMatchReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ValidateQueryParamNameInMatchStub        func(string) error
 	validateQueryParamNameInMatchMutex       sync.RWMutex
 	validateQueryParamNameInMatchArgsForCall []struct {
@@ -65,6 +76,28 @@ type FakeHTTPFieldsValidator struct {
 	validateQueryParamNameInMatchReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ValidateQueryParamNameInRegexMatchStub        func(string) error
+	validateQueryParamNameInRegexMatchMutex       sync.RWMutex
+	validateQueryParamNameInRegexMatchArgsForCall []struct {
+		arg1 string
+	}
+	validateQueryParamNameInRegexMatchReturns struct {
+		result1 error
+	}
+	validateQueryParamNameInRegexMatchReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ValidateQueryParamRegexInMatchStub        func(string) error
+	validateQueryParamRegexInMatchMutex       sync.RWMutex
+	validateQueryParamRegexInMatchArgsForCall []struct {
+		arg1 string
+	}
+	validateQueryParamRegexInMatchReturns struct {
+		result1 error
+	}
+	validateQueryParamRegexInMatchReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ValidateQueryParamValueInMatchStub        func(string) error
 	validateQueryParamValueInMatchMutex       sync.RWMutex
 	validateQueryParamValueInMatchArgsForCall []struct {
@@ -397,6 +430,67 @@ func (fake *FakeHTTPFieldsValidator) ValidatePathInMatchReturnsOnCall(i int, res
 	}{result1}
 }
 
+func (fake *FakeHTTPFieldsValidator) ValidatePathRegexInMatch(arg1 string) error {
+	fake.validatePathRegexInMatchMutex.Lock()
+	ret, specificReturn := fake.validatePathRegexInMatchReturnsOnCall[len(fake.validatePathRegexInMatchArgsForCall)]
+	fake.validatePathRegexInMatchArgsForCall = append(fake.validatePathRegexInMatchArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ValidatePathRegexInMatchStub
+	fakeReturns := fake.validatePathRegexInMatchReturns
+	fake.recordInvocation("ValidatePathRegexInMatch", []interface{}{arg1})
+	fake.validatePathRegexInMatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeHTTPFieldsValidator) ValidatePathRegexInMatchCallCount() int {
+	fake.validatePathRegexInMatchMutex.RLock()
+	defer fake.validatePathRegexInMatchMutex.RUnlock()
+	return len(fake.validatePathRegexInMatchArgsForCall)
+}
+
+func (fake *FakeHTTPFieldsValidator) ValidatePathRegexInMatchCalls(stub func(string) error) {
+	fake.validatePathRegexInMatchMutex.Lock()
+	defer fake.validatePathRegexInMatchMutex.Unlock()
+	fake.ValidatePathRegexInMatchStub = stub
+}
+
+func (fake *FakeHTTPFieldsValidator) ValidatePathRegexInMatchArgsForCall(i int) string {
+	fake.validatePathRegexInMatchMutex.RLock()
+	defer fake.validatePathRegexInMatchMutex.RUnlock()
+	argsForCall := fake.validatePathRegexInMatchArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeHTTPFieldsValidator) ValidatePathRegexInMatchReturns(result1 error) {
+	fake.validatePathRegexInMatchMutex.Lock()
+	defer fake.validatePathRegexInMatchMutex.Unlock()
+	fake.ValidatePathRegexInMatchStub = nil
+	fake.validatePathRegexInMatchReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHTTPFieldsValidator) ValidatePathRegexInMatchReturnsOnCall(i int, result1 error) {
+	fake.validatePathRegexInMatchMutex.Lock()
+	defer fake.validatePathRegexInMatchMutex.Unlock()
+	fake.ValidatePathRegexInMatchStub = nil
+	if fake.validatePathRegexInMatchReturnsOnCall == nil {
+		fake.validatePathRegexInMatchReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validatePathRegexInMatchReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeHTTPFieldsValidator) ValidateQueryParamNameInMatch(arg1 string) error {
 	fake.validateQueryParamNameInMatchMutex.Lock()
 	ret, specificReturn := fake.validateQueryParamNameInMatchReturnsOnCall[len(fake.validateQueryParamNameInMatchArgsForCall)]
@@ -458,6 +552,128 @@ func (fake *FakeHTTPFieldsValidator) ValidateQueryParamNameInMatchReturnsOnCall(
 	}{result1}
 }
 
+func (fake *FakeHTTPFieldsValidator) ValidateQueryParamNameInRegexMatch(arg1 string) error {
+	fake.validateQueryParamNameInRegexMatchMutex.Lock()
+	ret, specificReturn := fake.validateQueryParamNameInRegexMatchReturnsOnCall[len(fake.validateQueryParamNameInRegexMatchArgsForCall)]
+	fake.validateQueryParamNameInRegexMatchArgsForCall = append(fake.validateQueryParamNameInRegexMatchArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ValidateQueryParamNameInRegexMatchStub
+	fakeReturns := fake.validateQueryParamNameInRegexMatchReturns
+	fake.recordInvocation("ValidateQueryParamNameInRegexMatch", []interface{}{arg1})
+	fake.validateQueryParamNameInRegexMatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeHTTPFieldsValidator) ValidateQueryParamNameInRegexMatchCallCount() int {
+	fake.validateQueryParamNameInRegexMatchMutex.RLock()
+	defer fake.validateQueryParamNameInRegexMatchMutex.RUnlock()
+	return len(fake.validateQueryParamNameInRegexMatchArgsForCall)
+}
+
+func (fake *FakeHTTPFieldsValidator) ValidateQueryParamNameInRegexMatchCalls(stub func(string) error) {
+	fake.validateQueryParamNameInRegexMatchMutex.Lock()
+	defer fake.validateQueryParamNameInRegexMatchMutex.Unlock()
+	fake.ValidateQueryParamNameInRegexMatchStub = stub
+}
+
+func (fake *FakeHTTPFieldsValidator) ValidateQueryParamNameInRegexMatchArgsForCall(i int) string {
+	fake.validateQueryParamNameInRegexMatchMutex.RLock()
+	defer fake.validateQueryParamNameInRegexMatchMutex.RUnlock()
+	argsForCall := fake.validateQueryParamNameInRegexMatchArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeHTTPFieldsValidator) ValidateQueryParamNameInRegexMatchReturns(result1 error) {
+	fake.validateQueryParamNameInRegexMatchMutex.Lock()
+	defer fake.validateQueryParamNameInRegexMatchMutex.Unlock()
+	fake.ValidateQueryParamNameInRegexMatchStub = nil
+	fake.validateQueryParamNameInRegexMatchReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHTTPFieldsValidator) ValidateQueryParamNameInRegexMatchReturnsOnCall(i int, result1 error) {
+	fake.validateQueryParamNameInRegexMatchMutex.Lock()
+	defer fake.validateQueryParamNameInRegexMatchMutex.Unlock()
+	fake.ValidateQueryParamNameInRegexMatchStub = nil
+	if fake.validateQueryParamNameInRegexMatchReturnsOnCall == nil {
+		fake.validateQueryParamNameInRegexMatchReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateQueryParamNameInRegexMatchReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHTTPFieldsValidator) ValidateQueryParamRegexInMatch(arg1 string) error {
+	fake.validateQueryParamRegexInMatchMutex.Lock()
+	ret, specificReturn := fake.validateQueryParamRegexInMatchReturnsOnCall[len(fake.validateQueryParamRegexInMatchArgsForCall)]
+	fake.validateQueryParamRegexInMatchArgsForCall = append(fake.validateQueryParamRegexInMatchArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ValidateQueryParamRegexInMatchStub
+	fakeReturns := fake.validateQueryParamRegexInMatchReturns
+	fake.recordInvocation("ValidateQueryParamRegexInMatch", []interface{}{arg1})
+	fake.validateQueryParamRegexInMatchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeHTTPFieldsValidator) ValidateQueryParamRegexInMatchCallCount() int {
+	fake.validateQueryParamRegexInMatchMutex.RLock()
+	defer fake.validateQueryParamRegexInMatchMutex.RUnlock()
+	return len(fake.validateQueryParamRegexInMatchArgsForCall)
+}
+
+func (fake *FakeHTTPFieldsValidator) ValidateQueryParamRegexInMatchCalls(stub func(string) error) {
+	fake.validateQueryParamRegexInMatchMutex.Lock()
+	defer fake.validateQueryParamRegexInMatchMutex.Unlock()
+	fake.ValidateQueryParamRegexInMatchStub = stub
+}
+
+func (fake *FakeHTTPFieldsValidator) ValidateQueryParamRegexInMatchArgsForCall(i int) string {
+	fake.validateQueryParamRegexInMatchMutex.RLock()
+	defer fake.validateQueryParamRegexInMatchMutex.RUnlock()
+	argsForCall := fake.validateQueryParamRegexInMatchArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeHTTPFieldsValidator) ValidateQueryParamRegexInMatchReturns(result1 error) {
+	fake.validateQueryParamRegexInMatchMutex.Lock()
+	defer fake.validateQueryParamRegexInMatchMutex.Unlock()
+	fake.ValidateQueryParamRegexInMatchStub = nil
+	fake.validateQueryParamRegexInMatchReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHTTPFieldsValidator) ValidateQueryParamRegexInMatchReturnsOnCall(i int, result1 error) {
+	fake.validateQueryParamRegexInMatchMutex.Lock()
+	defer fake.validateQueryParamRegexInMatchMutex.Unlock()
+	fake.ValidateQueryParamRegexInMatchStub = nil
+	if fake.validateQueryParamRegexInMatchReturnsOnCall == nil {
+		fake.validateQueryParamRegexInMatchReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateQueryParamRegexInMatchReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeHTTPFieldsValidator) ValidateQueryParamValueInMatch(arg1 string) error {
 	fake.validateQueryParamValueInMatchMutex.Lock()
 	ret, specificReturn := fake.validateQueryParamValueInMatchReturnsOnCall[len(fake.validateQueryParamValueInMatchArgsForCall)]
@@ -902,8 +1118,14 @@ func (fake *FakeHTTPFieldsValidator) Invocations() map[string][][]interface{} {
 	defer fake.validateMethodInMatchMutex.RUnlock()
 	fake.validatePathInMatchMutex.RLock()
 	defer fake.validatePathInMatchMutex.RUnlock()
+	fake.validatePathRegexInMatchMutex.RLock()
+	defer fake.validatePathRegexInMatchMutex.RUnlock()
 	fake.validateQueryParamNameInMatchMutex.RLock()
 	defer fake.validateQueryParamNameInMatchMutex.RUnlock()
+	fake.validateQueryParamNameInRegexMatchMutex.RLock()
+	defer fake.validateQueryParamNameInRegexMatchMutex.RUnlock()
+	fake.validateQueryParamRegexInMatchMutex.RLock()
+	defer fake.validateQueryParamRegexInMatchMutex.RUnlock()
 	fake.validateQueryParamValueInMatchMutex.RLock()
 	defer fake.validateQueryParamValueInMatchMutex.RUnlock()
 	fake.validateRedirectHostnameMutex.RLock()
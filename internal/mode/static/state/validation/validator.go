@@ -14,10 +14,13 @@ type Validators struct {
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . HTTPFieldsValidator
 type HTTPFieldsValidator interface {
 	ValidatePathInMatch(path string) error
+	ValidatePathRegexInMatch(regex string) error
 	ValidateHeaderNameInMatch(name string) error
 	ValidateHeaderValueInMatch(value string) error
 	ValidateQueryParamNameInMatch(name string) error
 	ValidateQueryParamValueInMatch(name string) error
+	ValidateQueryParamNameInRegexMatch(name string) error
+	ValidateQueryParamRegexInMatch(regex string) error
 	ValidateMethodInMatch(method string) (valid bool, supportedValues []string)
 	ValidateRedirectScheme(scheme string) (valid bool, supportedValues []string)
 	ValidateRedirectHostname(hostname string) error
@@ -0,0 +1,376 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	embeddedfiles "github.com/nginxinc/nginx-kubernetes-gateway"
+)
+
+const (
+	// fieldOwner is the field manager used when applying the resources.
+	fieldOwner = client.FieldOwner("nkg-e2e")
+
+	// pollInterval is the interval between the checks of the expected state.
+	pollInterval = 500 * time.Millisecond
+)
+
+// E2ETestSuite sends requests to NGINX Kubernetes Gateway deployed in a cluster and checks the responses.
+// All namespaced resources are created in the namespace of the suite.
+type E2ETestSuite struct {
+	client     client.Client
+	httpClient *http.Client
+	namespace  string
+	timeout    time.Duration
+}
+
+// E2ETestSuiteConfig holds configuration parameters for E2ETestSuite.
+type E2ETestSuiteConfig struct {
+	// Client is the client for the cluster.
+	Client client.Client
+	// Namespace is the namespace where the test resources are created.
+	Namespace string
+	// HTTPAddress is the address of the HTTP port of the Gateway. For example, localhost:8080.
+	HTTPAddress string
+	// HTTPSAddress is the address of the HTTPS port of the Gateway. For example, localhost:8443.
+	HTTPSAddress string
+	// Timeout is the time to wait for the expected state.
+	Timeout time.Duration
+}
+
+// NewE2ETestSuite creates a new E2ETestSuite.
+//
+// The HTTP client of the suite sends all requests to the Gateway regardless of the host in the URL: the requests
+// to port 443 or with the https scheme are sent to the HTTPS address, all other requests are sent to the HTTP address.
+// This way the tests can use the hostnames of the routes in the URLs without configuring DNS.
+// The client doesn't follow redirects, so that the tests can check them.
+func NewE2ETestSuite(cfg E2ETestSuiteConfig) *E2ETestSuite {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			_, port, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, err
+			}
+
+			if port == "443" {
+				return dialer.DialContext(ctx, network, cfg.HTTPSAddress)
+			}
+
+			return dialer.DialContext(ctx, network, cfg.HTTPAddress)
+		},
+		// The Gateway uses a self-signed certificate.
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+	}
+
+	return &E2ETestSuite{
+		client:    cfg.Client,
+		namespace: cfg.Namespace,
+		timeout:   cfg.Timeout,
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   10 * time.Second,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Apply applies the objects from the YAML manifest file. The objects without a namespace are applied to
+// the namespace of the suite.
+func (s *E2ETestSuite) Apply(ctx context.Context, path string) error {
+	manifest, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the manifest %q: %w", path, err)
+	}
+
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), 4096)
+
+	for {
+		obj := &unstructured.Unstructured{}
+
+		if err := decoder.Decode(obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to decode the manifest %q: %w", path, err)
+		}
+
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		if err := s.applyObject(ctx, obj); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *E2ETestSuite) applyObject(ctx context.Context, obj client.Object) error {
+	namespaced, err := s.client.IsObjectNamespaced(obj)
+	if err != nil {
+		return fmt.Errorf("failed to determine the scope of %s: %w", obj.GetObjectKind().GroupVersionKind(), err)
+	}
+
+	if namespaced && obj.GetNamespace() == "" {
+		obj.SetNamespace(s.namespace)
+	}
+
+	if err := s.client.Patch(ctx, obj, client.Apply, fieldOwner, client.ForceOwnership); err != nil {
+		return fmt.Errorf(
+			"failed to apply %s %s: %w",
+			obj.GetObjectKind().GroupVersionKind().Kind,
+			client.ObjectKeyFromObject(obj),
+			err,
+		)
+	}
+
+	return nil
+}
+
+// DeployNKG deploys NGINX Kubernetes Gateway using the Deployment manifest embedded into the NKG binary.
+// If the image is not empty, it replaces the image of the NKG container.
+// It waits until the Deployment is available.
+// The other NKG resources, like the RBAC and the ConfigMaps, must already exist in the cluster.
+func (s *E2ETestSuite) DeployNKG(ctx context.Context, image string) error {
+	var deployment appsv1.Deployment
+
+	if err := yaml.Unmarshal(embeddedfiles.StaticModeDeploymentYAML, &deployment); err != nil {
+		return fmt.Errorf("failed to decode the NKG Deployment: %w", err)
+	}
+
+	if image != "" {
+		deployment.Spec.Template.Spec.Containers[0].Image = image
+		deployment.Spec.Template.Spec.Containers[0].ImagePullPolicy = apiv1.PullNever
+	}
+
+	deployment.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+
+	if err := s.applyObject(ctx, &deployment); err != nil {
+		return err
+	}
+
+	return s.WaitForDeploymentAvailable(ctx, client.ObjectKeyFromObject(&deployment))
+}
+
+// WaitForDeploymentAvailable waits until all replicas of the Deployment are available.
+func (s *E2ETestSuite) WaitForDeploymentAvailable(ctx context.Context, key client.ObjectKey) error {
+	return s.poll(ctx, func(ctx context.Context) (bool, error) {
+		var deployment appsv1.Deployment
+		if err := s.client.Get(ctx, key, &deployment); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+
+		return deployment.Status.ObservedGeneration == deployment.Generation &&
+			deployment.Status.AvailableReplicas == *deployment.Spec.Replicas &&
+			deployment.Status.UpdatedReplicas == *deployment.Spec.Replicas, nil
+	})
+}
+
+// ApplyHTTPRoute creates or updates the HTTPRoute.
+func (s *E2ETestSuite) ApplyHTTPRoute(ctx context.Context, hr *v1beta1.HTTPRoute) error {
+	hr = hr.DeepCopy()
+	hr.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("HTTPRoute"))
+
+	return s.applyObject(ctx, hr)
+}
+
+// DeleteHTTPRoute deletes the HTTPRoute from the namespace of the suite.
+// It is not an error if the HTTPRoute doesn't exist.
+func (s *E2ETestSuite) DeleteHTTPRoute(ctx context.Context, name string) error {
+	hr := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: s.namespace,
+			Name:      name,
+		},
+	}
+
+	if err := s.client.Delete(ctx, hr); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete HTTPRoute %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// WaitForProgrammed waits until the Gateway in the namespace of the suite is programmed and all of its listeners
+// are programmed.
+func (s *E2ETestSuite) WaitForProgrammed(ctx context.Context, name string) error {
+	return s.poll(ctx, func(ctx context.Context) (bool, error) {
+		var gw v1beta1.Gateway
+		if err := s.client.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: name}, &gw); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+
+		if !isConditionTrue(gw.Status.Conditions, string(v1beta1.GatewayConditionProgrammed), gw.Generation) {
+			return false, nil
+		}
+
+		if len(gw.Status.Listeners) != len(gw.Spec.Listeners) {
+			return false, nil
+		}
+
+		for _, l := range gw.Status.Listeners {
+			if !isConditionTrue(l.Conditions, string(v1beta1.ListenerConditionProgrammed), gw.Generation) {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+}
+
+// Get sends a GET request to the URL. The request is sent to the Gateway.
+func (s *E2ETestSuite) Get(ctx context.Context, url string) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp, body, nil
+}
+
+// ExpectStatus waits until the response to a GET request to the URL has the status code.
+// Waiting is necessary because NKG configures NGINX asynchronously.
+func (s *E2ETestSuite) ExpectStatus(ctx context.Context, url string, code int) error {
+	var lastResult string
+
+	err := s.poll(ctx, func(ctx context.Context) (bool, error) {
+		resp, _, err := s.Get(ctx, url)
+		if err != nil {
+			lastResult = err.Error()
+			return false, nil
+		}
+
+		lastResult = resp.Status
+		return resp.StatusCode == code, nil
+	})
+	if err != nil {
+		return fmt.Errorf("expected status %d for %s, last result: %s: %w", code, url, lastResult, err)
+	}
+
+	return nil
+}
+
+// CreateTLSSecret creates a TLS Secret with a self-signed certificate for the hostname in the namespace
+// of the suite.
+func (s *E2ETestSuite) CreateTLSSecret(ctx context.Context, name, hostname string) error {
+	cert, key, err := generateSelfSignedCert(hostname)
+	if err != nil {
+		return fmt.Errorf("failed to generate a certificate: %w", err)
+	}
+
+	secret := &apiv1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: s.namespace,
+			Name:      name,
+		},
+		Type: apiv1.SecretTypeTLS,
+		Data: map[string][]byte{
+			apiv1.TLSCertKey:       cert,
+			apiv1.TLSPrivateKeyKey: key,
+		},
+	}
+
+	return s.applyObject(ctx, secret)
+}
+
+func (s *E2ETestSuite) poll(ctx context.Context, condition wait.ConditionWithContextFunc) error {
+	return wait.PollUntilContextTimeout(ctx, pollInterval, s.timeout, true /* poll immediately */, condition)
+}
+
+func isConditionTrue(conditions []metav1.Condition, condType string, generation int64) bool {
+	for _, c := range conditions {
+		if c.Type == condType {
+			return c.Status == metav1.ConditionTrue && c.ObservedGeneration == generation
+		}
+	}
+
+	return false
+}
+
+func generateSelfSignedCert(hostname string) (cert, key []byte, err error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: hostname},
+		DNSNames:     []string{hostname},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	key = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return cert, key, nil
+}
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+
+	utilruntime.Must(apiv1.AddToScheme(scheme))
+	utilruntime.Must(appsv1.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+
+	return scheme
+}
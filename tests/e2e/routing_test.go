@@ -0,0 +1,229 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/nginxinc/nginx-kubernetes-gateway/internal/framework/helpers"
+)
+
+// serverNameRegexp extracts the name of the Pod from the response of the nginxdemos/nginx-hello:plain-text backend.
+var serverNameRegexp = regexp.MustCompile(`Server name: (\S+)`)
+
+func createHTTPRoute(name, sectionName string, rules ...v1beta1.HTTPRouteRule) *v1beta1.HTTPRoute {
+	return &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			CommonRouteSpec: v1beta1.CommonRouteSpec{
+				ParentRefs: []v1beta1.ParentReference{
+					{
+						Name:        "gateway",
+						SectionName: helpers.GetPointer(v1beta1.SectionName(sectionName)),
+					},
+				},
+			},
+			Hostnames: []v1beta1.Hostname{"cafe.example.com"},
+			Rules:     rules,
+		},
+	}
+}
+
+func createPrefixRule(path, svcName string) v1beta1.HTTPRouteRule {
+	return v1beta1.HTTPRouteRule{
+		Matches: []v1beta1.HTTPRouteMatch{
+			{
+				Path: &v1beta1.HTTPPathMatch{
+					Type:  helpers.GetPointer(v1beta1.PathMatchPathPrefix),
+					Value: helpers.GetPointer(path),
+				},
+			},
+		},
+		BackendRefs: []v1beta1.HTTPBackendRef{
+			{
+				BackendRef: v1beta1.BackendRef{
+					BackendObjectReference: v1beta1.BackendObjectReference{
+						Name: v1beta1.ObjectName(svcName),
+						Port: helpers.GetPointer[v1beta1.PortNumber](80),
+					},
+				},
+			},
+		},
+	}
+}
+
+// applyRoute applies the HTTPRoute and deletes it at the end of the test.
+func applyRoute(ctx context.Context, t *testing.T, suite *E2ETestSuite, hr *v1beta1.HTTPRoute) {
+	t.Helper()
+
+	if err := suite.ApplyHTTPRoute(ctx, hr); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := suite.DeleteHTTPRoute(context.Background(), hr.Name); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func testBasicRouting(ctx context.Context, t *testing.T, suite *E2ETestSuite) {
+	applyRoute(ctx, t, suite, createHTTPRoute("cafe", "http",
+		createPrefixRule("/coffee", "coffee"),
+		createPrefixRule("/tea", "tea"),
+	))
+
+	tests := []struct {
+		expServerNameP *regexp.Regexp
+		url            string
+	}{
+		{
+			expServerNameP: regexp.MustCompile(`^coffee-`),
+			url:            "http://cafe.example.com/coffee",
+		},
+		{
+			expServerNameP: regexp.MustCompile(`^coffee-`),
+			url:            "http://cafe.example.com/coffee/latte",
+		},
+		{
+			expServerNameP: regexp.MustCompile(`^tea-`),
+			url:            "http://cafe.example.com/tea",
+		},
+	}
+
+	for _, test := range tests {
+		if err := suite.ExpectStatus(ctx, test.url, http.StatusOK); err != nil {
+			t.Fatal(err)
+		}
+
+		_, body, err := suite.Get(ctx, test.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		matches := serverNameRegexp.FindSubmatch(body)
+		if matches == nil || !test.expServerNameP.Match(matches[1]) {
+			t.Errorf("expected the response for %s from a server matching %q, got:\n%s",
+				test.url, test.expServerNameP, body)
+		}
+	}
+
+	// After the route is deleted, the requests are no longer routed.
+	if err := suite.DeleteHTTPRoute(ctx, "cafe"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := suite.ExpectStatus(ctx, "http://cafe.example.com/coffee", http.StatusNotFound); err != nil {
+		t.Error(err)
+	}
+}
+
+func testNotFoundForUnmatchedPath(ctx context.Context, t *testing.T, suite *E2ETestSuite) {
+	applyRoute(ctx, t, suite, createHTTPRoute("coffee", "http", createPrefixRule("/coffee", "coffee")))
+
+	if err := suite.ExpectStatus(ctx, "http://cafe.example.com/coffee", http.StatusOK); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, url := range []string{
+		"http://cafe.example.com/",
+		"http://cafe.example.com/tea",
+		"http://cafe.example.com/coffeelatte",
+		"http://unknown.example.com/coffee",
+	} {
+		resp, _, err := suite.Get(ctx, url)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("expected status %d for %s, got %d", http.StatusNotFound, url, resp.StatusCode)
+		}
+	}
+}
+
+func testRoundRobinAcrossReplicas(ctx context.Context, t *testing.T, suite *E2ETestSuite) {
+	const (
+		// The coffee Deployment has three replicas. See testdata/cafe.yaml.
+		replicas = 3
+		requests = 30
+	)
+
+	applyRoute(ctx, t, suite, createHTTPRoute("coffee", "http", createPrefixRule("/coffee", "coffee")))
+
+	url := "http://cafe.example.com/coffee"
+
+	if err := suite.ExpectStatus(ctx, url, http.StatusOK); err != nil {
+		t.Fatal(err)
+	}
+
+	servers := make(map[string]int)
+
+	for i := 0; i < requests; i++ {
+		resp, body, err := suite.Get(ctx, url)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		matches := serverNameRegexp.FindSubmatch(body)
+		if matches == nil {
+			t.Fatalf("failed to find the server name in the response:\n%s", body)
+		}
+
+		servers[string(matches[1])]++
+	}
+
+	// NGINX uses the random two least_conn load balancing method, so the distribution is not exact.
+	// However, with sequential requests, every replica is expected to receive some of them.
+	if len(servers) != replicas {
+		t.Errorf("expected the requests to be distributed across %d replicas, got %v", replicas, servers)
+	}
+}
+
+func testHTTPSRedirect(ctx context.Context, t *testing.T, suite *E2ETestSuite) {
+	redirectRule := v1beta1.HTTPRouteRule{
+		Filters: []v1beta1.HTTPRouteFilter{
+			{
+				Type: v1beta1.HTTPRouteFilterRequestRedirect,
+				RequestRedirect: &v1beta1.HTTPRequestRedirectFilter{
+					Scheme:     helpers.GetPointer("https"),
+					Port:       helpers.GetPointer[v1beta1.PortNumber](443),
+					StatusCode: helpers.GetPointer(http.StatusMovedPermanently),
+				},
+			},
+		},
+	}
+
+	applyRoute(ctx, t, suite, createHTTPRoute("cafe-tls-redirect", "http", redirectRule))
+	applyRoute(ctx, t, suite, createHTTPRoute("coffee", "https", createPrefixRule("/coffee", "coffee")))
+
+	if err := suite.ExpectStatus(ctx, "http://cafe.example.com/coffee", http.StatusMovedPermanently); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, _, err := suite.Get(ctx, "http://cafe.example.com/coffee")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expLocation := "https://cafe.example.com/coffee"
+	if location := resp.Header.Get("Location"); location != expLocation {
+		t.Errorf("expected the Location header %q, got %q", expLocation, location)
+	}
+
+	if err := suite.ExpectStatus(ctx, "https://cafe.example.com/coffee", http.StatusOK); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,101 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"flag"
+	"os"
+	"testing"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+// The end-to-end tests run against NGINX Kubernetes Gateway deployed in a cluster.
+// The cluster must have the Gateway API CRDs and all NKG resources except for the NKG Deployment installed,
+// which the suite deploys from the manifest embedded into the NKG binary.
+// See the e2e-test target in the Makefile, which provisions a kind cluster for the tests.
+
+var (
+	nkgImage = flag.String("nkg-image", "", "The image of NGINX Kubernetes Gateway. "+
+		"If empty, the image from the embedded Deployment manifest is used")
+	httpAddress  = flag.String("http-address", "localhost:8080", "The address of the HTTP port of the Gateway")
+	httpsAddress = flag.String("https-address", "localhost:8443", "The address of the HTTPS port of the Gateway")
+	namespace    = flag.String("namespace", "nkg-e2e", "The namespace for the test resources")
+	timeout      = flag.Duration("timeout", 2*time.Minute, "The time to wait for the expected state")
+	cleanup      = flag.Bool("cleanup", true, "Delete the namespace with the test resources after the tests")
+)
+
+func TestE2E(t *testing.T) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		t.Fatalf("failed to get the cluster config: %v", err)
+	}
+
+	k8sClient, err := client.New(cfg, client.Options{Scheme: newScheme()})
+	if err != nil {
+		t.Fatalf("failed to create the client: %v", err)
+	}
+
+	suite := NewE2ETestSuite(E2ETestSuiteConfig{
+		Client:       k8sClient,
+		Namespace:    *namespace,
+		HTTPAddress:  *httpAddress,
+		HTTPSAddress: *httpsAddress,
+		Timeout:      *timeout,
+	})
+
+	ctx := context.Background()
+
+	if err := suite.DeployNKG(ctx, *nkgImage); err != nil {
+		t.Fatalf("failed to deploy NKG: %v", err)
+	}
+
+	ns := &apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: *namespace}}
+	if err := k8sClient.Create(ctx, ns); client.IgnoreAlreadyExists(err) != nil {
+		t.Fatalf("failed to create the namespace: %v", err)
+	}
+
+	if *cleanup {
+		t.Cleanup(func() {
+			if err := k8sClient.Delete(context.Background(), ns); err != nil {
+				t.Errorf("failed to delete the namespace: %v", err)
+			}
+		})
+	}
+
+	setup := []func() error{
+		func() error { return suite.Apply(ctx, "testdata/nginx-gateway-service.yaml") },
+		func() error { return suite.Apply(ctx, "testdata/cafe.yaml") },
+		func() error { return suite.CreateTLSSecret(ctx, "cafe-secret", "cafe.example.com") },
+		func() error { return suite.Apply(ctx, "testdata/gateway.yaml") },
+		func() error {
+			return suite.WaitForDeploymentAvailable(ctx, client.ObjectKey{Namespace: *namespace, Name: "coffee"})
+		},
+		func() error {
+			return suite.WaitForDeploymentAvailable(ctx, client.ObjectKey{Namespace: *namespace, Name: "tea"})
+		},
+		func() error { return suite.WaitForProgrammed(ctx, "gateway") },
+	}
+
+	for _, step := range setup {
+		if err := step(); err != nil {
+			t.Fatalf("failed to set up the test environment: %v", err)
+		}
+	}
+
+	// The test cases share the Gateway and the backends, so they run sequentially.
+	t.Run("BasicRouting", func(t *testing.T) { testBasicRouting(ctx, t, suite) })
+	t.Run("NotFoundForUnmatchedPath", func(t *testing.T) { testNotFoundForUnmatchedPath(ctx, t, suite) })
+	t.Run("RoundRobinAcrossReplicas", func(t *testing.T) { testRoundRobinAcrossReplicas(ctx, t, suite) })
+	t.Run("HTTPSRedirect", func(t *testing.T) { testHTTPSRedirect(ctx, t, suite) })
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	os.Exit(m.Run())
+}